@@ -3,6 +3,8 @@ package clay
 import (
 	"fmt"
 	"iter"
+	"math"
+	"time"
 	"unsafe"
 )
 
@@ -25,6 +27,25 @@ func toString(s string) String {
 	return String{Length: int32(len(s)), Chars: unsafe.StringData(s)}
 }
 
+// frameTime returns the time passed to SetDeltaTime for the current frame.
+func frameTime() time.Duration {
+	return time.Duration(float64(GetCurrentContext().deltaTime) * float64(time.Second))
+}
+
+// __ReleaseContextState is called by Initialize to discard the element state, text input and overlays kept for whichever
+// context used the memory before, so they don't carry over into the new context.
+func __ReleaseContextState(context *Context) {
+	delete(stateContexts, context)
+	delete(textInputContexts, context)
+	delete(overlayContexts, context)
+}
+
+// reportError passes an error to the current context's error handler.
+func reportError(errorType ErrorType, text string) {
+	context := GetCurrentContext()
+	context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: errorType, ErrorText: toString(text), UserData: context.errorHandler.UserData})
+}
+
 func ID(label string) ElementId {
 	return __HashString(toString(label), 0)
 }
@@ -119,6 +140,180 @@ func BorderAll(width uint16) BorderWidth {
 	}
 }
 
+// Opacity returns a config that fades an element and all of its descendants as a group to the given opacity, from 0 to 1.
+func Opacity(value float32) OpacityElementConfig {
+	return OpacityElementConfig{Enabled: true, Value: value}
+}
+
+// Rotate returns a config that rotates an element and all of its descendants clockwise around its center, by the given degrees.
+func Rotate(degrees float32) TransformElementConfig {
+	return TransformElementConfig{Enabled: true, Origin: Vector2{X: 0.5, Y: 0.5}, Rotation: degrees, Scale: Vector2{X: 1, Y: 1}}
+}
+
+// Scale returns a config that scales an element and all of its descendants around its center.
+func Scale(x, y float32) TransformElementConfig {
+	return TransformElementConfig{Enabled: true, Origin: Vector2{X: 0.5, Y: 0.5}, Scale: Vector2{X: x, Y: y}}
+}
+
+// Translate returns a config that moves an element and all of its descendants by the given number of pixels when they're drawn.
+func Translate(x, y float32) TransformElementConfig {
+	return TransformElementConfig{Enabled: true, Origin: Vector2{X: 0.5, Y: 0.5}, Scale: Vector2{X: 1, Y: 1}, Translate: Vector2{X: x, Y: y}}
+}
+
+// IsIdentity reports whether m leaves every point where it is.
+func (m Matrix2D) IsIdentity() bool {
+	return m == Matrix2D{A: 1, D: 1}
+}
+
+// Apply returns the point p transformed by m.
+func (m Matrix2D) Apply(p Vector2) Vector2 {
+	return Vector2{X: m.A*p.X + m.C*p.Y + m.Tx, Y: m.B*p.X + m.D*p.Y + m.Ty}
+}
+
+// Invert returns the transform that undoes m, and false if m collapses the plane and can't be undone.
+func (m Matrix2D) Invert() (Matrix2D, bool) {
+	determinant := m.A*m.D - m.B*m.C
+	if determinant == 0 {
+		return Matrix2D{}, false
+	}
+	inverse := Matrix2D{A: m.D / determinant, B: -m.B / determinant, C: -m.C / determinant, D: m.A / determinant}
+	inverse.Tx = -(inverse.A*m.Tx + inverse.C*m.Ty)
+	inverse.Ty = -(inverse.B*m.Tx + inverse.D*m.Ty)
+	return inverse, true
+}
+
+// Bounds returns the smallest axis aligned box that contains box once it has been transformed by m.
+func (m Matrix2D) Bounds(box BoundingBox) BoundingBox {
+	corners := [4]Vector2{
+		m.Apply(Vector2{X: box.X, Y: box.Y}),
+		m.Apply(Vector2{X: box.X + box.Width, Y: box.Y}),
+		m.Apply(Vector2{X: box.X, Y: box.Y + box.Height}),
+		m.Apply(Vector2{X: box.X + box.Width, Y: box.Y + box.Height}),
+	}
+	minX, minY, maxX, maxY := corners[0].X, corners[0].Y, corners[0].X, corners[0].Y
+	for _, c := range corners[1:] {
+		minX, minY, maxX, maxY = min(minX, c.X), min(minY, c.Y), max(maxX, c.X), max(maxY, c.Y)
+	}
+	return BoundingBox{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
+
+// PathCommands returns the commands as a PathCommandArray. The commands are copied when the element is declared, so the
+// slice can be reused afterwards.
+func PathCommands(commands ...PathCommand) PathCommandArray {
+	return PathCommandArray{Length: int32(len(commands)), InternalArray: unsafe.SliceData(commands)}
+}
+
+// Iter returns the commands in order.
+func (a PathCommandArray) Iter() iter.Seq[PathCommand] {
+	return func(yield func(PathCommand) bool) {
+		for _, v := range unsafe.Slice(a.InternalArray, a.Length) {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MoveTo returns a command that starts a new subpath at (x, y).
+func MoveTo(x, y float32) PathCommand {
+	return PathCommand{Type: PATH_COMMAND_MOVE_TO, Points: [3]Vector2{{X: x, Y: y}}}
+}
+
+// LineTo returns a command that draws a straight line to (x, y).
+func LineTo(x, y float32) PathCommand {
+	return PathCommand{Type: PATH_COMMAND_LINE_TO, Points: [3]Vector2{{X: x, Y: y}}}
+}
+
+// QuadTo returns a command that draws a quadratic curve to (x, y), with (cx, cy) as its control point.
+func QuadTo(cx, cy, x, y float32) PathCommand {
+	return PathCommand{Type: PATH_COMMAND_QUAD_TO, Points: [3]Vector2{{X: cx, Y: cy}, {X: x, Y: y}}}
+}
+
+// CubicTo returns a command that draws a cubic curve to (x, y), with (c1x, c1y) and (c2x, c2y) as its control points.
+func CubicTo(c1x, c1y, c2x, c2y, x, y float32) PathCommand {
+	return PathCommand{Type: PATH_COMMAND_CUBIC_TO, Points: [3]Vector2{{X: c1x, Y: c1y}, {X: c2x, Y: c2y}, {X: x, Y: y}}}
+}
+
+// Arc returns a command that draws a circular arc around (cx, cy) from startAngle to endAngle, in degrees clockwise from
+// the positive x axis.
+func Arc(cx, cy, radius, startAngle, endAngle float32) PathCommand {
+	return PathCommand{Type: PATH_COMMAND_ARC, Points: [3]Vector2{{X: cx, Y: cy}}, Radius: radius, StartAngle: startAngle, EndAngle: endAngle}
+}
+
+// ClosePath returns a command that closes the subpath with a straight line back to its start.
+func ClosePath() PathCommand {
+	return PathCommand{Type: PATH_COMMAND_CLOSE}
+}
+
+// LinearGradient returns a gradient running across the element in the direction of angle, in degrees clockwise from pointing up.
+// Only the first GRADIENT_MAX_STOPS stops are used.
+func LinearGradient(angle float32, stops ...GradientStop) Gradient {
+	g := Gradient{Type: GRADIENT_TYPE_LINEAR, Angle: angle}
+	g.StopCount = int32(copy(g.Stops[:], stops))
+	return g
+}
+
+// RadialGradient returns a gradient spreading out from center, given as a fraction of the element's size, and reaching its
+// last stop radius pixels away, or at the farthest corner if radius is 0. Only the first GRADIENT_MAX_STOPS stops are used.
+func RadialGradient(center Vector2, radius float32, stops ...GradientStop) Gradient {
+	g := Gradient{Type: GRADIENT_TYPE_RADIAL, Center: center, Radius: radius}
+	g.StopCount = int32(copy(g.Stops[:], stops))
+	return g
+}
+
+// Position returns where the point (x, y) lies along the gradient filling box, from 0 at its start to 1 at its end.
+// Points before the start or past the end return values outside of that range.
+func (g *Gradient) Position(box BoundingBox, x, y float32) float32 {
+	if g.Type == GRADIENT_TYPE_RADIAL {
+		cx, cy := box.X+box.Width*g.Center.X, box.Y+box.Height*g.Center.Y
+		radius := g.Radius
+		if radius <= 0 {
+			radius = float32(math.Hypot(float64(max(cx-box.X, box.X+box.Width-cx)), float64(max(cy-box.Y, box.Y+box.Height-cy))))
+		}
+		if radius <= 0 {
+			return 1
+		}
+		return float32(math.Hypot(float64(x-cx), float64(y-cy))) / radius
+	}
+	sin, cos := math.Sincos(float64(g.Angle) * math.Pi / 180)
+	dx, dy := float32(sin), float32(-cos)
+	length := abs32(box.Width*dx) + abs32(box.Height*dy)
+	if length <= 0 {
+		return 0
+	}
+	return ((x-(box.X+box.Width/2))*dx+(y-(box.Y+box.Height/2))*dy)/length + 0.5
+}
+
+// ColorAt returns the color of the gradient filling box at the point (x, y).
+func (g *Gradient) ColorAt(box BoundingBox, x, y float32) Color {
+	return g.ColorAtPosition(g.Position(box, x, y))
+}
+
+// ColorAtPosition returns the color of the gradient at position, blending each channel linearly between the closest stops.
+func (g *Gradient) ColorAtPosition(position float32) Color {
+	stops := g.Stops[:min(max(g.StopCount, 0), GRADIENT_MAX_STOPS)]
+	if len(stops) == 0 {
+		return Color{}
+	}
+	if position <= stops[0].Position {
+		return stops[0].Color
+	}
+	for i := 1; i < len(stops); i++ {
+		from, to := stops[i-1], stops[i]
+		if position >= to.Position {
+			continue
+		}
+		t := (position - from.Position) / (to.Position - from.Position)
+		return Color{
+			R: from.Color.R + (to.Color.R-from.Color.R)*t,
+			G: from.Color.G + (to.Color.G-from.Color.G)*t,
+			B: from.Color.B + (to.Color.B-from.Color.B)*t,
+			A: from.Color.A + (to.Color.A-from.Color.A)*t,
+		}
+	}
+	return stops[len(stops)-1].Color
+}
+
 // TODO: add generic iterator functions for types with [type]_GetValue functions that are converted into methods
 
 func UI(id ...ElementId) func(decl ElementDeclaration, children func()) {
@@ -168,3 +363,10 @@ func (r *RenderCommandArray) Iter() iter.Seq[RenderCommand] {
 func CreateArenaWithCapacityAndMemory(memory []byte) Arena {
 	return createArenaWithCapacityAndMemory(uint64(len(memory)), unsafe.Pointer(unsafe.SliceData(memory)))
 }
+
+// SetKeyboardState delivers the key presses that occurred since the last frame. CLAY_KEY_ESCAPE presses close the topmost open overlay
+// first, and are only delivered to the focused element when no overlay consumes them.
+func SetKeyboardState(keyEvents []KeyEvent) {
+	keyEvents = dismissWithKeys(keyEvents)
+	setKeyboardState(unsafe.SliceData(keyEvents), int32(len(keyEvents)))
+}
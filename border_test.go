@@ -0,0 +1,94 @@
+package clay_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+func TestBorderSideColors(t *testing.T) {
+	newTestContext(t)
+	clay.BeginLayout()
+	clay.UI(clay.ID("Field"))(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+		Border: clay.BorderElementConfig{
+			Color:      blue,
+			Colors:     clay.BorderColors{Bottom: red},
+			Width:      clay.BorderOutside(1),
+			Style:      clay.BORDER_STYLE_DASHED,
+			DashLength: 4,
+		},
+	}, nil)
+	cmds := clay.EndLayout()
+	for cmd := range cmds.Iter() {
+		if cmd.CommandType != clay.RENDER_COMMAND_TYPE_BORDER {
+			continue
+		}
+		border := cmd.RenderData.Border
+		// Sides without their own color fall back to the shared one
+		if want := (clay.BorderColors{Left: blue, Right: blue, Top: blue, Bottom: red}); border.Colors != want {
+			t.Errorf("expected side colors %v, got %v", want, border.Colors)
+		}
+		if border.Style != clay.BORDER_STYLE_DASHED || border.DashLength != 4 {
+			t.Errorf("expected a dashed border with dashes 4 long, got %v %v", border.Style, border.DashLength)
+		}
+		return
+	}
+	t.Fatal("expected a BORDER command")
+}
+
+func TestBorderSideColorsSoftwareRenderer(t *testing.T) {
+	img := renderElement(t, 4, 4, clay.ElementDeclaration{
+		Border: clay.BorderElementConfig{
+			Color:  blue,
+			Colors: clay.BorderColors{Top: red},
+			Width:  clay.BorderOutside(1),
+		},
+	})
+	redRGBA, blueRGBA := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	expectPixels(t, img, row(0, 1, 2), []color.RGBA{redRGBA, redRGBA})
+	expectPixels(t, img, row(1, 0, 1, 2, 3), []color.RGBA{blueRGBA, black, black, blueRGBA})
+	expectPixels(t, img, row(3, 1, 2), []color.RGBA{blueRGBA, blueRGBA})
+}
+
+func TestBorderDashedSoftwareRenderer(t *testing.T) {
+	img := renderElement(t, 12, 12, clay.ElementDeclaration{
+		Border: clay.BorderElementConfig{
+			Color:      white,
+			Width:      clay.BorderOutside(1),
+			Style:      clay.BORDER_STYLE_DASHED,
+			DashLength: 2,
+			GapLength:  2,
+		},
+	})
+	whiteRGBA := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	// Dashes start at the top left corner and repeat every 4 pixels along the center of the border
+	expectPixels(t, img, row(0, 1, 3, 5, 7, 9), []color.RGBA{whiteRGBA, black, whiteRGBA, black, whiteRGBA})
+	expectPixels(t, img, row(5, 5), []color.RGBA{black})
+}
+
+func TestBorderSideColorsFaded(t *testing.T) {
+	newTestContext(t)
+	clay.BeginLayout()
+	clay.UI(clay.ID("Field"))(clay.ElementDeclaration{
+		Layout:     clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+		Border:     clay.BorderElementConfig{Color: blue, Colors: clay.BorderColors{Bottom: red}, Width: clay.BorderOutside(1)},
+		Transition: clay.TransitionElementConfig{Properties: clay.TRANSITION_PROPERTY_OPACITY, Duration: 1, Opacity: 0.5},
+	}, nil)
+	cmds := clay.EndLayout()
+	var opacity float32
+	for cmd := range cmds.Iter() {
+		switch cmd.CommandType {
+		case clay.RENDER_COMMAND_TYPE_LAYER_START:
+			opacity = cmd.RenderData.Layer.Opacity
+		case clay.RENDER_COMMAND_TYPE_BORDER:
+			// Every side is faded along with the element by the layer around it, instead of through its colors
+			if colors := cmd.RenderData.Border.Colors; opacity != 0.5 || colors.Left != blue || colors.Bottom != red {
+				t.Errorf("expected side colors %v and %v in a layer with opacity 0.5, got %v in one with %v", blue, red, colors, opacity)
+			}
+			return
+		}
+	}
+	t.Fatal("expected a BORDER command")
+}
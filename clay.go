@@ -10,8 +10,9 @@ import (
 )
 
 const (
-	__NULL     = 0
-	__MAXFLOAT = 3.4028234663852886e+38
+	GRADIENT_MAX_STOPS = 8
+	__NULL             = 0
+	__MAXFLOAT         = 3.4028234663852886e+38
 )
 
 var __ELEMENT_DEFINITION_LATCH uint8
@@ -45,6 +46,8 @@ type Context struct {
 	disableCulling                     bool
 	externalScrollHandlingEnabled      bool
 	debugSelectedElementId             uint32
+	focusedElementId                   ElementId
+	focusVisible                       bool
 	generation                         uint32
 	arenaResetOffset                   uint64
 	measureTextUserData                any
@@ -57,6 +60,7 @@ type Context struct {
 	layoutElementChildrenBuffer        __int32_tArray
 	textElementData                    __TextElementDataArray
 	AspectRatioElementIndexes          __int32_tArray
+	focusableElementIndexes            __int32_tArray
 	reusableElementIndexBuffer         __int32_tArray
 	layoutElementClipElementIds        __int32_tArray
 	layoutConfigs                      __LayoutConfigArray
@@ -69,6 +73,15 @@ type Context struct {
 	customElementConfigs               __CustomElementConfigArray
 	borderElementConfigs               __BorderElementConfigArray
 	sharedElementConfigs               __SharedElementConfigArray
+	focusElementConfigs                __FocusElementConfigArray
+	stickyElementConfigs               __StickyElementConfigArray
+	zoomPanElementConfigs              __ZoomPanElementConfigArray
+	transitionElementConfigs           __TransitionElementConfigArray
+	shadowElementConfigs               __ShadowElementConfigArray
+	opacityElementConfigs              __OpacityElementConfigArray
+	transformElementConfigs            __TransformElementConfigArray
+	pathElementConfigs                 __PathElementConfigArray
+	pathCommands                       __PathCommandArray
 	layoutElementIdStrings             __StringArray
 	wrappedTextLines                   __WrappedTextLineArray
 	layoutElementTreeNodeArray1        __LayoutElementTreeNodeArray
@@ -83,6 +96,16 @@ type Context struct {
 	openClipElementStack               __int32_tArray
 	pointerOverIds                     ElementIdArray
 	scrollContainerDatas               __ScrollContainerDataInternalArray
+	zoomPanDatas                       __ZoomPanDataInternalArray
+	transitionDatas                    __TransitionDataInternalArray
+	transitionCommands                 RenderCommandArray
+	transitionCommandsNext             RenderCommandArray
+	transitionText                     __charArray
+	transitionTextNext                 __charArray
+	transitionPathCommands             __PathCommandArray
+	transitionPathCommandsNext         __PathCommandArray
+	exitingTransitionCount             int32
+	deltaTime                          float32
 	treeNodeVisited                    __boolArray
 	dynamicStringData                  __charArray
 	debugElementData                   __DebugElementDataArray
@@ -112,6 +135,14 @@ type BoundingBox struct {
 	Width  float32
 	Height float32
 }
+type Matrix2D struct {
+	A  float32
+	B  float32
+	C  float32
+	D  float32
+	Tx float32
+	Ty float32
+}
 type ElementId struct {
 	Id       uint32
 	Offset   uint32
@@ -129,6 +160,26 @@ type CornerRadius struct {
 	BottomLeft  float32
 	BottomRight float32
 }
+type GradientType int32
+
+const (
+	GRADIENT_TYPE_NONE = GradientType(iota)
+	GRADIENT_TYPE_LINEAR
+	GRADIENT_TYPE_RADIAL
+)
+
+type GradientStop struct {
+	Color    Color
+	Position float32
+}
+type Gradient struct {
+	Type      GradientType
+	Angle     float32
+	Center    Vector2
+	Radius    float32
+	StopCount int32
+	Stops     [8]GradientStop
+}
 type LayoutDirection int32
 
 const (
@@ -235,8 +286,30 @@ type AspectRatioElementConfig struct {
 type __AspectRatioElementConfigWrapper struct {
 	Wrapped AspectRatioElementConfig
 }
+type ImageFit int32
+
+const (
+	IMAGE_FIT_FILL = ImageFit(iota)
+	IMAGE_FIT_CONTAIN
+	IMAGE_FIT_COVER
+	IMAGE_FIT_NONE
+	IMAGE_FIT_SCALE_DOWN
+)
+
+type NineSlice struct {
+	Left   float32
+	Top    float32
+	Right  float32
+	Bottom float32
+	Tiled  bool
+}
 type ImageElementConfig struct {
-	ImageData any
+	ImageData     any
+	Fit           ImageFit
+	Alignment     ChildAlignment
+	SourceRect    BoundingBox
+	IntrinsicSize Dimensions
+	NineSlice     NineSlice
 }
 type __ImageElementConfigWrapper struct {
 	Wrapped ImageElementConfig
@@ -282,6 +355,14 @@ const (
 	CLIP_TO_ATTACHED_PARENT
 )
 
+type FloatingCollisionFlags int32
+
+const (
+	FLOATING_COLLISION_NONE  FloatingCollisionFlags = 0
+	FLOATING_COLLISION_FLIP  FloatingCollisionFlags = 1
+	FLOATING_COLLISION_SHIFT FloatingCollisionFlags = 2
+)
+
 type FloatingElementConfig struct {
 	Offset             Vector2
 	Expand             Dimensions
@@ -291,6 +372,8 @@ type FloatingElementConfig struct {
 	PointerCaptureMode PointerCaptureMode
 	AttachTo           FloatingAttachToElement
 	ClipTo             FloatingClipToElement
+	Modal              bool
+	Collision          FloatingCollisionFlags
 }
 type __FloatingElementConfigWrapper struct {
 	Wrapped FloatingElementConfig
@@ -301,14 +384,178 @@ type CustomElementConfig struct {
 type __CustomElementConfigWrapper struct {
 	Wrapped CustomElementConfig
 }
+type ScrollAlignment int32
+
+const (
+	SCROLL_ALIGN_NEAREST = ScrollAlignment(iota)
+	SCROLL_ALIGN_START
+	SCROLL_ALIGN_CENTER
+	SCROLL_ALIGN_END
+)
+
+type ScrollSnapType int32
+
+const (
+	SCROLL_SNAP_NONE = ScrollSnapType(iota)
+	SCROLL_SNAP_CHILDREN
+	SCROLL_SNAP_INTERVAL
+)
+
+type ScrollSnapConfig struct {
+	Type      ScrollSnapType
+	Alignment ScrollAlignment
+	Interval  float32
+}
+type ScrollPhysicsConfig struct {
+	Friction      float32
+	DragThreshold float32
+	Overscroll    float32
+}
+type ScrollbarConfig struct {
+	Width          float32
+	MinThumbLength float32
+	ThumbColor     Color
+	TrackColor     Color
+	CornerRadius   CornerRadius
+	AutoHideDelay  float32
+}
 type ClipElementConfig struct {
 	Horizontal  bool
 	Vertical    bool
 	ChildOffset Vector2
+	Scrollbar   ScrollbarConfig
+	Physics     ScrollPhysicsConfig
+	Snap        ScrollSnapConfig
 }
 type __ClipElementConfigWrapper struct {
 	Wrapped ClipElementConfig
 }
+type FocusElementConfig struct {
+	Focusable bool
+	TabIndex  int32
+}
+type __FocusElementConfigWrapper struct {
+	Wrapped FocusElementConfig
+}
+type StickyElementConfig struct {
+	Horizontal bool
+	Vertical   bool
+	Offset     Vector2
+}
+type __StickyElementConfigWrapper struct {
+	Wrapped StickyElementConfig
+}
+type ZoomPanElementConfig struct {
+	Enabled bool
+	MinZoom float32
+	MaxZoom float32
+}
+type __ZoomPanElementConfigWrapper struct {
+	Wrapped ZoomPanElementConfig
+}
+type TransitionProperty int32
+
+const (
+	TRANSITION_PROPERTY_NONE             TransitionProperty = 0
+	TRANSITION_PROPERTY_POSITION         TransitionProperty = 1
+	TRANSITION_PROPERTY_SIZE             TransitionProperty = 2
+	TRANSITION_PROPERTY_BACKGROUND_COLOR TransitionProperty = 4
+	TRANSITION_PROPERTY_OPACITY          TransitionProperty = 8
+	TRANSITION_PROPERTY_ALL              TransitionProperty = 15
+)
+
+type EasingCurve int32
+
+const (
+	EASING_LINEAR = EasingCurve(iota)
+	EASING_EASE_IN
+	EASING_EASE_OUT
+	EASING_EASE_IN_OUT
+)
+
+type TransitionEnterExitConfig struct {
+	Enabled bool
+	Opacity float32
+	Offset  Vector2
+}
+type TransitionElementConfig struct {
+	Properties TransitionProperty
+	Duration   float32
+	Easing     EasingCurve
+	Opacity    float32
+	Enter      TransitionEnterExitConfig
+	Exit       TransitionEnterExitConfig
+}
+type __TransitionElementConfigWrapper struct {
+	Wrapped TransitionElementConfig
+}
+type ShadowElementConfig struct {
+	Color      Color
+	Offset     Vector2
+	BlurRadius float32
+	Spread     float32
+	Inset      bool
+}
+type __ShadowElementConfigWrapper struct {
+	Wrapped ShadowElementConfig
+}
+type OpacityElementConfig struct {
+	Enabled bool
+	Value   float32
+}
+type __OpacityElementConfigWrapper struct {
+	Wrapped OpacityElementConfig
+}
+type TransformElementConfig struct {
+	Enabled   bool
+	Origin    Vector2
+	Rotation  float32
+	Scale     Vector2
+	Translate Vector2
+}
+type __TransformElementConfigWrapper struct {
+	Wrapped TransformElementConfig
+}
+type PathCommandType int32
+
+const (
+	PATH_COMMAND_MOVE_TO = PathCommandType(iota)
+	PATH_COMMAND_LINE_TO
+	PATH_COMMAND_QUAD_TO
+	PATH_COMMAND_CUBIC_TO
+	PATH_COMMAND_ARC
+	PATH_COMMAND_CLOSE
+)
+
+type PathCommand struct {
+	Type       PathCommandType
+	Points     [3]Vector2
+	Radius     float32
+	StartAngle float32
+	EndAngle   float32
+}
+type PathCommandArray struct {
+	Length        int32
+	InternalArray *PathCommand
+}
+type LineJoin int32
+
+const (
+	LINE_JOIN_MITER = LineJoin(iota)
+	LINE_JOIN_ROUND
+	LINE_JOIN_BEVEL
+)
+
+type PathElementConfig struct {
+	Commands    PathCommandArray
+	FillColor   Color
+	StrokeColor Color
+	StrokeWidth float32
+	Join        LineJoin
+}
+type __PathElementConfigWrapper struct {
+	Wrapped PathElementConfig
+}
 type BorderWidth struct {
 	Left            uint16
 	Right           uint16
@@ -316,9 +563,28 @@ type BorderWidth struct {
 	Bottom          uint16
 	BetweenChildren uint16
 }
+type BorderColors struct {
+	Left   Color
+	Right  Color
+	Top    Color
+	Bottom Color
+}
+type BorderStyle int32
+
+const (
+	BORDER_STYLE_SOLID = BorderStyle(iota)
+	BORDER_STYLE_DASHED
+	BORDER_STYLE_DOTTED
+)
+
 type BorderElementConfig struct {
-	Color Color
-	Width BorderWidth
+	Color      Color
+	Width      BorderWidth
+	Gradient   Gradient
+	Colors     BorderColors
+	Style      BorderStyle
+	DashLength float32
+	GapLength  float32
 }
 type __BorderElementConfigWrapper struct {
 	Wrapped BorderElementConfig
@@ -334,11 +600,18 @@ type TextRenderData struct {
 type RectangleRenderData struct {
 	BackgroundColor Color
 	CornerRadius    CornerRadius
+	Gradient        Gradient
 }
 type ImageRenderData struct {
 	BackgroundColor Color
 	CornerRadius    CornerRadius
 	ImageData       any
+	Fit             ImageFit
+	Alignment       ChildAlignment
+	SourceRect      BoundingBox
+	IntrinsicSize   Dimensions
+	NineSlice       NineSlice
+	NineSliceScale  float32
 }
 type CustomRenderData struct {
 	BackgroundColor Color
@@ -346,17 +619,42 @@ type CustomRenderData struct {
 	CustomData      any
 }
 type ScrollRenderData struct {
-	Horizontal bool
-	Vertical   bool
+	Horizontal   bool
+	Vertical     bool
+	CornerRadius CornerRadius
 }
 type (
 	ClipRenderData   ScrollRenderData
-	BorderRenderData struct {
+	ShadowRenderData struct {
 		Color        Color
 		CornerRadius CornerRadius
-		Width        BorderWidth
+		Offset       Vector2
+		BlurRadius   float32
+		Spread       float32
+		Inset        bool
 	}
 )
+type PathRenderData struct {
+	Commands    PathCommandArray
+	FillColor   Color
+	StrokeColor Color
+	StrokeWidth float32
+	Join        LineJoin
+	Scale       float32
+}
+type LayerRenderData struct {
+	Opacity float32
+}
+type BorderRenderData struct {
+	Color        Color
+	CornerRadius CornerRadius
+	Width        BorderWidth
+	Gradient     Gradient
+	Colors       BorderColors
+	Style        BorderStyle
+	DashLength   float32
+	GapLength    float32
+}
 type RenderData struct {
 	// union
 	Rectangle RectangleRenderData
@@ -365,6 +663,9 @@ type RenderData struct {
 	Custom    CustomRenderData
 	Border    BorderRenderData
 	Clip      ClipRenderData
+	Shadow    ShadowRenderData
+	Layer     LayerRenderData
+	Path      PathRenderData
 }
 type ScrollContainerData struct {
 	ScrollPosition            *Vector2
@@ -373,6 +674,26 @@ type ScrollContainerData struct {
 	Config                    ClipElementConfig
 	Found                     bool
 }
+type ZoomPanData struct {
+	Pan         *Vector2
+	Zoom        *float32
+	BoundingBox BoundingBox
+	Found       bool
+}
+type ElementVisibility struct {
+	VisibleBoundingBox BoundingBox
+	VisibleFraction    float32
+	Visible            bool
+	Found              bool
+}
+type LifecycleEvent int32
+
+const (
+	LIFECYCLE_EVENT_APPEARED = LifecycleEvent(iota)
+	LIFECYCLE_EVENT_DISAPPEARED
+	LIFECYCLE_EVENT_VISIBILITY_CHANGED
+)
+
 type ElementData struct {
 	BoundingBox BoundingBox
 	Found       bool
@@ -388,10 +709,15 @@ const (
 	RENDER_COMMAND_TYPE_SCISSOR_START
 	RENDER_COMMAND_TYPE_SCISSOR_END
 	RENDER_COMMAND_TYPE_CUSTOM
+	RENDER_COMMAND_TYPE_SHADOW
+	RENDER_COMMAND_TYPE_LAYER_START
+	RENDER_COMMAND_TYPE_LAYER_END
+	RENDER_COMMAND_TYPE_PATH
 )
 
 type RenderCommand struct {
 	BoundingBox BoundingBox
+	Transform   Matrix2D
 	RenderData  RenderData
 	UserData    any
 	Id          uint32
@@ -416,17 +742,99 @@ type PointerData struct {
 	Position Vector2
 	State    PointerDataInteractionState
 }
+type Key int32
+
+const (
+	KEY_NONE = Key(iota)
+	KEY_TAB
+	KEY_ENTER
+	KEY_ESCAPE
+	KEY_SPACE
+	KEY_BACKSPACE
+	KEY_DELETE
+	KEY_INSERT
+	KEY_LEFT
+	KEY_RIGHT
+	KEY_UP
+	KEY_DOWN
+	KEY_HOME
+	KEY_END
+	KEY_PAGE_UP
+	KEY_PAGE_DOWN
+	KEY_A
+	KEY_B
+	KEY_C
+	KEY_D
+	KEY_E
+	KEY_F
+	KEY_G
+	KEY_H
+	KEY_I
+	KEY_J
+	KEY_K
+	KEY_L
+	KEY_M
+	KEY_N
+	KEY_O
+	KEY_P
+	KEY_Q
+	KEY_R
+	KEY_S
+	KEY_T
+	KEY_U
+	KEY_V
+	KEY_W
+	KEY_X
+	KEY_Y
+	KEY_Z
+)
+
+type KeyModifiers int32
+
+const (
+	KEY_MODIFIER_NONE  KeyModifiers = 0
+	KEY_MODIFIER_SHIFT KeyModifiers = 1
+	KEY_MODIFIER_CTRL  KeyModifiers = 2
+	KEY_MODIFIER_ALT   KeyModifiers = 4
+	KEY_MODIFIER_SUPER KeyModifiers = 8
+)
+
+type KeyEvent struct {
+	Key       Key
+	Modifiers KeyModifiers
+	Repeat    bool
+}
+type FocusDirection int32
+
+const (
+	FOCUS_DIRECTION_NEXT = FocusDirection(iota)
+	FOCUS_DIRECTION_PREVIOUS
+	FOCUS_DIRECTION_UP
+	FOCUS_DIRECTION_DOWN
+	FOCUS_DIRECTION_LEFT
+	FOCUS_DIRECTION_RIGHT
+)
+
 type ElementDeclaration struct {
-	Layout          LayoutConfig
-	BackgroundColor Color
-	CornerRadius    CornerRadius
-	AspectRatio     AspectRatioElementConfig
-	Image           ImageElementConfig
-	Floating        FloatingElementConfig
-	Custom          CustomElementConfig
-	Clip            ClipElementConfig
-	Border          BorderElementConfig
-	UserData        any
+	Layout             LayoutConfig
+	BackgroundColor    Color
+	BackgroundGradient Gradient
+	CornerRadius       CornerRadius
+	AspectRatio        AspectRatioElementConfig
+	Image              ImageElementConfig
+	Floating           FloatingElementConfig
+	Custom             CustomElementConfig
+	Clip               ClipElementConfig
+	Border             BorderElementConfig
+	Focus              FocusElementConfig
+	Sticky             StickyElementConfig
+	ZoomPan            ZoomPanElementConfig
+	Transition         TransitionElementConfig
+	Shadow             ShadowElementConfig
+	Opacity            OpacityElementConfig
+	Transform          TransformElementConfig
+	Path               PathElementConfig
+	UserData           any
 }
 type __ElementDeclarationWrapper struct {
 	Wrapped ElementDeclaration
@@ -443,6 +851,7 @@ const (
 	ERROR_TYPE_PERCENTAGE_OVER_1
 	ERROR_TYPE_INTERNAL_ERROR
 	ERROR_TYPE_UNBALANCED_OPEN_CLOSE
+	ERROR_TYPE_INVALID_CONFIG
 )
 
 type ErrorData struct {
@@ -478,6 +887,7 @@ type BooleanWarnings struct {
 	MaxElementsExceeded           bool
 	MaxRenderCommandsExceeded     bool
 	MaxTextMeasureCacheExceeded   bool
+	MaxPathCommandsExceeded       bool
 	TextMeasurementFunctionNotSet bool
 }
 type __Warning struct {
@@ -493,9 +903,10 @@ type __WarningArray struct {
 	InternalArray *__Warning
 }
 type SharedElementConfig struct {
-	BackgroundColor Color
-	CornerRadius    CornerRadius
-	UserData        any
+	BackgroundColor    Color
+	BackgroundGradient Gradient
+	CornerRadius       CornerRadius
+	UserData           any
 }
 type __SharedElementConfigWrapper struct {
 	Wrapped SharedElementConfig
@@ -1489,64 +1900,69 @@ func __SharedElementConfigArray_Set(array *__SharedElementConfigArray, index int
 	}
 }
 
-type RenderCommandArraySlice struct {
+type __FocusElementConfigArray struct {
+	Capacity      int32
 	Length        int32
-	InternalArray *RenderCommand
+	InternalArray *FocusElementConfig
+}
+type __FocusElementConfigArraySlice struct {
+	Length        int32
+	InternalArray *FocusElementConfig
 }
 
-var RenderCommand_DEFAULT RenderCommand = RenderCommand{}
+var FocusElementConfig_DEFAULT FocusElementConfig = FocusElementConfig{Focusable: false}
 
-func RenderCommandArray_Allocate_Arena(capacity int32, arena *Arena) RenderCommandArray {
-	return RenderCommandArray{Capacity: capacity, Length: 0, InternalArray: (*RenderCommand)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(RenderCommand{})), arena))}
+func __FocusElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __FocusElementConfigArray {
+	return __FocusElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*FocusElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(FocusElementConfig{})), arena))}
 }
 
-func RenderCommandArray_Get(array *RenderCommandArray, index int32) *RenderCommand {
+func __FocusElementConfigArray_Get(array *__FocusElementConfigArray, index int32) *FocusElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index)))
+		return (*FocusElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(index)))
 	}
-	return &RenderCommand_DEFAULT
+	return &FocusElementConfig_DEFAULT
 }
 
-func RenderCommandArray_GetValue(array *RenderCommandArray, index int32) RenderCommand {
+func __FocusElementConfigArray_GetValue(array *__FocusElementConfigArray, index int32) FocusElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index)))
+		return *(*FocusElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(index)))
 	}
-	return RenderCommand_DEFAULT
+	return FocusElementConfig_DEFAULT
 }
 
-func RenderCommandArray_Add(array *RenderCommandArray, item RenderCommand) *RenderCommand {
+func __FocusElementConfigArray_Add(array *__FocusElementConfigArray, item FocusElementConfig) *FocusElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(func() int32 {
+		*(*FocusElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(array.Length-1)))
+		return (*FocusElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &RenderCommand_DEFAULT
+	return &FocusElementConfig_DEFAULT
 }
 
-func RenderCommandArraySlice_Get(slice *RenderCommandArraySlice, index int32) *RenderCommand {
+func __FocusElementConfigArraySlice_Get(slice *__FocusElementConfigArraySlice, index int32) *FocusElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*RenderCommand)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index)))
+		return (*FocusElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(index)))
 	}
-	return &RenderCommand_DEFAULT
+	return &FocusElementConfig_DEFAULT
 }
 
-func RenderCommandArray_RemoveSwapback(array *RenderCommandArray, index int32) RenderCommand {
+func __FocusElementConfigArray_RemoveSwapback(array *__FocusElementConfigArray, index int32) FocusElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed RenderCommand = *(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index)))
-		*(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index))) = *(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(array.Length)))
+		var removed FocusElementConfig = *(*FocusElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(index)))
+		*(*FocusElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(index))) = *(*FocusElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return RenderCommand_DEFAULT
+	return FocusElementConfig_DEFAULT
 }
 
-func RenderCommandArray_Set(array *RenderCommandArray, index int32, value RenderCommand) {
+func __FocusElementConfigArray_Set(array *__FocusElementConfigArray, index int32, value FocusElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index))) = value
+		*(*FocusElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(FocusElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -1555,98 +1971,69 @@ func RenderCommandArray_Set(array *RenderCommandArray, index int32, value Render
 	}
 }
 
-type __ElementConfigType int32
-
-const (
-	__ELEMENT_CONFIG_TYPE_NONE = __ElementConfigType(iota)
-	__ELEMENT_CONFIG_TYPE_BORDER
-	__ELEMENT_CONFIG_TYPE_FLOATING
-	__ELEMENT_CONFIG_TYPE_CLIP
-	__ELEMENT_CONFIG_TYPE_ASPECT
-	__ELEMENT_CONFIG_TYPE_IMAGE
-	__ELEMENT_CONFIG_TYPE_TEXT
-	__ELEMENT_CONFIG_TYPE_CUSTOM
-	__ELEMENT_CONFIG_TYPE_SHARED
-)
-
-type ElementConfigUnion struct {
-	// union
-	TextElementConfig        *TextElementConfig
-	AspectRatioElementConfig *AspectRatioElementConfig
-	ImageElementConfig       *ImageElementConfig
-	FloatingElementConfig    *FloatingElementConfig
-	CustomElementConfig      *CustomElementConfig
-	ClipElementConfig        *ClipElementConfig
-	BorderElementConfig      *BorderElementConfig
-	SharedElementConfig      *SharedElementConfig
-}
-type ElementConfig struct {
-	Type   __ElementConfigType
-	Config ElementConfigUnion
-}
-type __ElementConfigArray struct {
+type __StickyElementConfigArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *ElementConfig
+	InternalArray *StickyElementConfig
 }
-type __ElementConfigArraySlice struct {
+type __StickyElementConfigArraySlice struct {
 	Length        int32
-	InternalArray *ElementConfig
+	InternalArray *StickyElementConfig
 }
 
-var ElementConfig_DEFAULT ElementConfig = ElementConfig{}
+var StickyElementConfig_DEFAULT StickyElementConfig = StickyElementConfig{Horizontal: false}
 
-func __ElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __ElementConfigArray {
-	return __ElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*ElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(ElementConfig{})), arena))}
+func __StickyElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __StickyElementConfigArray {
+	return __StickyElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*StickyElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(StickyElementConfig{})), arena))}
 }
 
-func __ElementConfigArray_Get(array *__ElementConfigArray, index int32) *ElementConfig {
+func __StickyElementConfigArray_Get(array *__StickyElementConfigArray, index int32) *StickyElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index)))
+		return (*StickyElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(index)))
 	}
-	return &ElementConfig_DEFAULT
+	return &StickyElementConfig_DEFAULT
 }
 
-func __ElementConfigArray_GetValue(array *__ElementConfigArray, index int32) ElementConfig {
+func __StickyElementConfigArray_GetValue(array *__StickyElementConfigArray, index int32) StickyElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index)))
+		return *(*StickyElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(index)))
 	}
-	return ElementConfig_DEFAULT
+	return StickyElementConfig_DEFAULT
 }
 
-func __ElementConfigArray_Add(array *__ElementConfigArray, item ElementConfig) *ElementConfig {
+func __StickyElementConfigArray_Add(array *__StickyElementConfigArray, item StickyElementConfig) *StickyElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(func() int32 {
+		*(*StickyElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(array.Length-1)))
+		return (*StickyElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &ElementConfig_DEFAULT
+	return &StickyElementConfig_DEFAULT
 }
 
-func __ElementConfigArraySlice_Get(slice *__ElementConfigArraySlice, index int32) *ElementConfig {
+func __StickyElementConfigArraySlice_Get(slice *__StickyElementConfigArraySlice, index int32) *StickyElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*ElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index)))
+		return (*StickyElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(index)))
 	}
-	return &ElementConfig_DEFAULT
+	return &StickyElementConfig_DEFAULT
 }
 
-func __ElementConfigArray_RemoveSwapback(array *__ElementConfigArray, index int32) ElementConfig {
+func __StickyElementConfigArray_RemoveSwapback(array *__StickyElementConfigArray, index int32) StickyElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed ElementConfig = *(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index)))
-		*(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index))) = *(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(array.Length)))
+		var removed StickyElementConfig = *(*StickyElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(index)))
+		*(*StickyElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(index))) = *(*StickyElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return ElementConfig_DEFAULT
+	return StickyElementConfig_DEFAULT
 }
 
-func __ElementConfigArray_Set(array *__ElementConfigArray, index int32, value ElementConfig) {
+func __StickyElementConfigArray_Set(array *__StickyElementConfigArray, index int32, value StickyElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index))) = value
+		*(*StickyElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(StickyElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -1655,73 +2042,69 @@ func __ElementConfigArray_Set(array *__ElementConfigArray, index int32, value El
 	}
 }
 
-type __WrappedTextLine struct {
-	Dimensions Dimensions
-	Line       String
-}
-type __WrappedTextLineArray struct {
+type __ZoomPanElementConfigArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *__WrappedTextLine
+	InternalArray *ZoomPanElementConfig
 }
-type __WrappedTextLineArraySlice struct {
+type __ZoomPanElementConfigArraySlice struct {
 	Length        int32
-	InternalArray *__WrappedTextLine
+	InternalArray *ZoomPanElementConfig
 }
 
-var __WrappedTextLine_DEFAULT __WrappedTextLine = __WrappedTextLine{}
+var ZoomPanElementConfig_DEFAULT ZoomPanElementConfig = ZoomPanElementConfig{Enabled: false}
 
-func __WrappedTextLineArray_Allocate_Arena(capacity int32, arena *Arena) __WrappedTextLineArray {
-	return __WrappedTextLineArray{Capacity: capacity, Length: 0, InternalArray: (*__WrappedTextLine)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__WrappedTextLine{})), arena))}
+func __ZoomPanElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __ZoomPanElementConfigArray {
+	return __ZoomPanElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*ZoomPanElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(ZoomPanElementConfig{})), arena))}
 }
 
-func __WrappedTextLineArray_Get(array *__WrappedTextLineArray, index int32) *__WrappedTextLine {
+func __ZoomPanElementConfigArray_Get(array *__ZoomPanElementConfigArray, index int32) *ZoomPanElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index)))
+		return (*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(index)))
 	}
-	return &__WrappedTextLine_DEFAULT
+	return &ZoomPanElementConfig_DEFAULT
 }
 
-func __WrappedTextLineArray_GetValue(array *__WrappedTextLineArray, index int32) __WrappedTextLine {
+func __ZoomPanElementConfigArray_GetValue(array *__ZoomPanElementConfigArray, index int32) ZoomPanElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index)))
+		return *(*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(index)))
 	}
-	return __WrappedTextLine_DEFAULT
+	return ZoomPanElementConfig_DEFAULT
 }
 
-func __WrappedTextLineArray_Add(array *__WrappedTextLineArray, item __WrappedTextLine) *__WrappedTextLine {
+func __ZoomPanElementConfigArray_Add(array *__ZoomPanElementConfigArray, item ZoomPanElementConfig) *ZoomPanElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(func() int32 {
+		*(*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(array.Length-1)))
+		return (*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &__WrappedTextLine_DEFAULT
+	return &ZoomPanElementConfig_DEFAULT
 }
 
-func __WrappedTextLineArraySlice_Get(slice *__WrappedTextLineArraySlice, index int32) *__WrappedTextLine {
+func __ZoomPanElementConfigArraySlice_Get(slice *__ZoomPanElementConfigArraySlice, index int32) *ZoomPanElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index)))
+		return (*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(index)))
 	}
-	return &__WrappedTextLine_DEFAULT
+	return &ZoomPanElementConfig_DEFAULT
 }
 
-func __WrappedTextLineArray_RemoveSwapback(array *__WrappedTextLineArray, index int32) __WrappedTextLine {
+func __ZoomPanElementConfigArray_RemoveSwapback(array *__ZoomPanElementConfigArray, index int32) ZoomPanElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed __WrappedTextLine = *(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index)))
-		*(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index))) = *(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(array.Length)))
+		var removed ZoomPanElementConfig = *(*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(index)))
+		*(*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(index))) = *(*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return __WrappedTextLine_DEFAULT
+	return ZoomPanElementConfig_DEFAULT
 }
 
-func __WrappedTextLineArray_Set(array *__WrappedTextLineArray, index int32, value __WrappedTextLine) {
+func __ZoomPanElementConfigArray_Set(array *__ZoomPanElementConfigArray, index int32, value ZoomPanElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index))) = value
+		*(*ZoomPanElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ZoomPanElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -1730,75 +2113,69 @@ func __WrappedTextLineArray_Set(array *__WrappedTextLineArray, index int32, valu
 	}
 }
 
-type __TextElementData struct {
-	Text                String
-	PreferredDimensions Dimensions
-	ElementIndex        int32
-	WrappedLines        __WrappedTextLineArraySlice
-}
-type __TextElementDataArray struct {
+type __TransitionElementConfigArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *__TextElementData
+	InternalArray *TransitionElementConfig
 }
-type __TextElementDataArraySlice struct {
+type __TransitionElementConfigArraySlice struct {
 	Length        int32
-	InternalArray *__TextElementData
+	InternalArray *TransitionElementConfig
 }
 
-var __TextElementData_DEFAULT __TextElementData = __TextElementData{}
+var TransitionElementConfig_DEFAULT TransitionElementConfig = TransitionElementConfig{}
 
-func __TextElementDataArray_Allocate_Arena(capacity int32, arena *Arena) __TextElementDataArray {
-	return __TextElementDataArray{Capacity: capacity, Length: 0, InternalArray: (*__TextElementData)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__TextElementData{})), arena))}
+func __TransitionElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __TransitionElementConfigArray {
+	return __TransitionElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*TransitionElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(TransitionElementConfig{})), arena))}
 }
 
-func __TextElementDataArray_Get(array *__TextElementDataArray, index int32) *__TextElementData {
+func __TransitionElementConfigArray_Get(array *__TransitionElementConfigArray, index int32) *TransitionElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index)))
+		return (*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(index)))
 	}
-	return &__TextElementData_DEFAULT
+	return &TransitionElementConfig_DEFAULT
 }
 
-func __TextElementDataArray_GetValue(array *__TextElementDataArray, index int32) __TextElementData {
+func __TransitionElementConfigArray_GetValue(array *__TransitionElementConfigArray, index int32) TransitionElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index)))
+		return *(*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(index)))
 	}
-	return __TextElementData_DEFAULT
+	return TransitionElementConfig_DEFAULT
 }
 
-func __TextElementDataArray_Add(array *__TextElementDataArray, item __TextElementData) *__TextElementData {
+func __TransitionElementConfigArray_Add(array *__TransitionElementConfigArray, item TransitionElementConfig) *TransitionElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(func() int32 {
+		*(*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(array.Length-1)))
+		return (*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &__TextElementData_DEFAULT
+	return &TransitionElementConfig_DEFAULT
 }
 
-func __TextElementDataArraySlice_Get(slice *__TextElementDataArraySlice, index int32) *__TextElementData {
+func __TransitionElementConfigArraySlice_Get(slice *__TransitionElementConfigArraySlice, index int32) *TransitionElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*__TextElementData)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index)))
+		return (*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(index)))
 	}
-	return &__TextElementData_DEFAULT
+	return &TransitionElementConfig_DEFAULT
 }
 
-func __TextElementDataArray_RemoveSwapback(array *__TextElementDataArray, index int32) __TextElementData {
+func __TransitionElementConfigArray_RemoveSwapback(array *__TransitionElementConfigArray, index int32) TransitionElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed __TextElementData = *(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index)))
-		*(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index))) = *(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(array.Length)))
+		var removed TransitionElementConfig = *(*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(index)))
+		*(*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(index))) = *(*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return __TextElementData_DEFAULT
+	return TransitionElementConfig_DEFAULT
 }
 
-func __TextElementDataArray_Set(array *__TextElementDataArray, index int32, value __TextElementData) {
+func __TransitionElementConfigArray_Set(array *__TransitionElementConfigArray, index int32, value TransitionElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index))) = value
+		*(*TransitionElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransitionElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -1807,85 +2184,69 @@ func __TextElementDataArray_Set(array *__TextElementDataArray, index int32, valu
 	}
 }
 
-type __LayoutElementChildren struct {
-	Elements *int32
-	Length   uint16
+type __ShadowElementConfigArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *ShadowElementConfig
 }
-type LayoutElement struct {
-	ChildrenOrTextContent struct {
-		// union
-		Children        __LayoutElementChildren
-		TextElementData *__TextElementData
-	}
-	Dimensions     Dimensions
-	MinDimensions  Dimensions
-	LayoutConfig   *LayoutConfig
-	ElementConfigs __ElementConfigArraySlice
-	Id             uint32
-}
-type LayoutElementArray struct {
-	Capacity      int32
-	Length        int32
-	InternalArray *LayoutElement
-}
-type LayoutElementArraySlice struct {
+type __ShadowElementConfigArraySlice struct {
 	Length        int32
-	InternalArray *LayoutElement
+	InternalArray *ShadowElementConfig
 }
 
-var LayoutElement_DEFAULT LayoutElement = LayoutElement{}
+var ShadowElementConfig_DEFAULT ShadowElementConfig = ShadowElementConfig{}
 
-func LayoutElementArray_Allocate_Arena(capacity int32, arena *Arena) LayoutElementArray {
-	return LayoutElementArray{Capacity: capacity, Length: 0, InternalArray: (*LayoutElement)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(LayoutElement{})), arena))}
+func __ShadowElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __ShadowElementConfigArray {
+	return __ShadowElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*ShadowElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(ShadowElementConfig{})), arena))}
 }
 
-func LayoutElementArray_Get(array *LayoutElementArray, index int32) *LayoutElement {
+func __ShadowElementConfigArray_Get(array *__ShadowElementConfigArray, index int32) *ShadowElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index)))
+		return (*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(index)))
 	}
-	return &LayoutElement_DEFAULT
+	return &ShadowElementConfig_DEFAULT
 }
 
-func LayoutElementArray_GetValue(array *LayoutElementArray, index int32) LayoutElement {
+func __ShadowElementConfigArray_GetValue(array *__ShadowElementConfigArray, index int32) ShadowElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index)))
+		return *(*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(index)))
 	}
-	return LayoutElement_DEFAULT
+	return ShadowElementConfig_DEFAULT
 }
 
-func LayoutElementArray_Add(array *LayoutElementArray, item LayoutElement) *LayoutElement {
+func __ShadowElementConfigArray_Add(array *__ShadowElementConfigArray, item ShadowElementConfig) *ShadowElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(func() int32 {
+		*(*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(array.Length-1)))
+		return (*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &LayoutElement_DEFAULT
+	return &ShadowElementConfig_DEFAULT
 }
 
-func LayoutElementArraySlice_Get(slice *LayoutElementArraySlice, index int32) *LayoutElement {
+func __ShadowElementConfigArraySlice_Get(slice *__ShadowElementConfigArraySlice, index int32) *ShadowElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*LayoutElement)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index)))
+		return (*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(index)))
 	}
-	return &LayoutElement_DEFAULT
+	return &ShadowElementConfig_DEFAULT
 }
 
-func LayoutElementArray_RemoveSwapback(array *LayoutElementArray, index int32) LayoutElement {
+func __ShadowElementConfigArray_RemoveSwapback(array *__ShadowElementConfigArray, index int32) ShadowElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed LayoutElement = *(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index)))
-		*(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index))) = *(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(array.Length)))
+		var removed ShadowElementConfig = *(*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(index)))
+		*(*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(index))) = *(*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return LayoutElement_DEFAULT
+	return ShadowElementConfig_DEFAULT
 }
 
-func LayoutElementArray_Set(array *LayoutElementArray, index int32, value LayoutElement) {
+func __ShadowElementConfigArray_Set(array *__ShadowElementConfigArray, index int32, value ShadowElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index))) = value
+		*(*ShadowElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ShadowElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -1894,83 +2255,69 @@ func LayoutElementArray_Set(array *LayoutElementArray, index int32, value Layout
 	}
 }
 
-type __ScrollContainerDataInternal struct {
-	LayoutElement       *LayoutElement
-	BoundingBox         BoundingBox
-	ContentSize         Dimensions
-	ScrollOrigin        Vector2
-	PointerOrigin       Vector2
-	ScrollMomentum      Vector2
-	ScrollPosition      Vector2
-	PreviousDelta       Vector2
-	MomentumTime        float32
-	ElementId           uint32
-	OpenThisFrame       bool
-	PointerScrollActive bool
-}
-type __ScrollContainerDataInternalArray struct {
+type __OpacityElementConfigArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *__ScrollContainerDataInternal
+	InternalArray *OpacityElementConfig
 }
-type __ScrollContainerDataInternalArraySlice struct {
+type __OpacityElementConfigArraySlice struct {
 	Length        int32
-	InternalArray *__ScrollContainerDataInternal
+	InternalArray *OpacityElementConfig
 }
 
-var __ScrollContainerDataInternal_DEFAULT __ScrollContainerDataInternal = __ScrollContainerDataInternal{}
+var OpacityElementConfig_DEFAULT OpacityElementConfig = OpacityElementConfig{Enabled: false}
 
-func __ScrollContainerDataInternalArray_Allocate_Arena(capacity int32, arena *Arena) __ScrollContainerDataInternalArray {
-	return __ScrollContainerDataInternalArray{Capacity: capacity, Length: 0, InternalArray: (*__ScrollContainerDataInternal)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__ScrollContainerDataInternal{})), arena))}
+func __OpacityElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __OpacityElementConfigArray {
+	return __OpacityElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*OpacityElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(OpacityElementConfig{})), arena))}
 }
 
-func __ScrollContainerDataInternalArray_Get(array *__ScrollContainerDataInternalArray, index int32) *__ScrollContainerDataInternal {
+func __OpacityElementConfigArray_Get(array *__OpacityElementConfigArray, index int32) *OpacityElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index)))
+		return (*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(index)))
 	}
-	return &__ScrollContainerDataInternal_DEFAULT
+	return &OpacityElementConfig_DEFAULT
 }
 
-func __ScrollContainerDataInternalArray_GetValue(array *__ScrollContainerDataInternalArray, index int32) __ScrollContainerDataInternal {
+func __OpacityElementConfigArray_GetValue(array *__OpacityElementConfigArray, index int32) OpacityElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index)))
+		return *(*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(index)))
 	}
-	return __ScrollContainerDataInternal_DEFAULT
+	return OpacityElementConfig_DEFAULT
 }
 
-func __ScrollContainerDataInternalArray_Add(array *__ScrollContainerDataInternalArray, item __ScrollContainerDataInternal) *__ScrollContainerDataInternal {
+func __OpacityElementConfigArray_Add(array *__OpacityElementConfigArray, item OpacityElementConfig) *OpacityElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(func() int32 {
+		*(*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(array.Length-1)))
+		return (*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &__ScrollContainerDataInternal_DEFAULT
+	return &OpacityElementConfig_DEFAULT
 }
 
-func __ScrollContainerDataInternalArraySlice_Get(slice *__ScrollContainerDataInternalArraySlice, index int32) *__ScrollContainerDataInternal {
+func __OpacityElementConfigArraySlice_Get(slice *__OpacityElementConfigArraySlice, index int32) *OpacityElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index)))
+		return (*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(index)))
 	}
-	return &__ScrollContainerDataInternal_DEFAULT
+	return &OpacityElementConfig_DEFAULT
 }
 
-func __ScrollContainerDataInternalArray_RemoveSwapback(array *__ScrollContainerDataInternalArray, index int32) __ScrollContainerDataInternal {
+func __OpacityElementConfigArray_RemoveSwapback(array *__OpacityElementConfigArray, index int32) OpacityElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed __ScrollContainerDataInternal = *(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index)))
-		*(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index))) = *(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(array.Length)))
+		var removed OpacityElementConfig = *(*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(index)))
+		*(*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(index))) = *(*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return __ScrollContainerDataInternal_DEFAULT
+	return OpacityElementConfig_DEFAULT
 }
 
-func __ScrollContainerDataInternalArray_Set(array *__ScrollContainerDataInternalArray, index int32, value __ScrollContainerDataInternal) {
+func __OpacityElementConfigArray_Set(array *__OpacityElementConfigArray, index int32, value OpacityElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index))) = value
+		*(*OpacityElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(OpacityElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -1979,73 +2326,69 @@ func __ScrollContainerDataInternalArray_Set(array *__ScrollContainerDataInternal
 	}
 }
 
-type __DebugElementData struct {
-	Collision bool
-	Collapsed bool
-}
-type __DebugElementDataArray struct {
+type __TransformElementConfigArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *__DebugElementData
+	InternalArray *TransformElementConfig
 }
-type __DebugElementDataArraySlice struct {
+type __TransformElementConfigArraySlice struct {
 	Length        int32
-	InternalArray *__DebugElementData
+	InternalArray *TransformElementConfig
 }
 
-var __DebugElementData_DEFAULT __DebugElementData = __DebugElementData{Collision: false}
+var TransformElementConfig_DEFAULT TransformElementConfig = TransformElementConfig{Enabled: false}
 
-func __DebugElementDataArray_Allocate_Arena(capacity int32, arena *Arena) __DebugElementDataArray {
-	return __DebugElementDataArray{Capacity: capacity, Length: 0, InternalArray: (*__DebugElementData)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__DebugElementData{})), arena))}
+func __TransformElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __TransformElementConfigArray {
+	return __TransformElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*TransformElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(TransformElementConfig{})), arena))}
 }
 
-func __DebugElementDataArray_Get(array *__DebugElementDataArray, index int32) *__DebugElementData {
+func __TransformElementConfigArray_Get(array *__TransformElementConfigArray, index int32) *TransformElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index)))
+		return (*TransformElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(index)))
 	}
-	return &__DebugElementData_DEFAULT
+	return &TransformElementConfig_DEFAULT
 }
 
-func __DebugElementDataArray_GetValue(array *__DebugElementDataArray, index int32) __DebugElementData {
+func __TransformElementConfigArray_GetValue(array *__TransformElementConfigArray, index int32) TransformElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index)))
+		return *(*TransformElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(index)))
 	}
-	return __DebugElementData_DEFAULT
+	return TransformElementConfig_DEFAULT
 }
 
-func __DebugElementDataArray_Add(array *__DebugElementDataArray, item __DebugElementData) *__DebugElementData {
+func __TransformElementConfigArray_Add(array *__TransformElementConfigArray, item TransformElementConfig) *TransformElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(func() int32 {
+		*(*TransformElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(array.Length-1)))
+		return (*TransformElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &__DebugElementData_DEFAULT
+	return &TransformElementConfig_DEFAULT
 }
 
-func __DebugElementDataArraySlice_Get(slice *__DebugElementDataArraySlice, index int32) *__DebugElementData {
+func __TransformElementConfigArraySlice_Get(slice *__TransformElementConfigArraySlice, index int32) *TransformElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*__DebugElementData)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index)))
+		return (*TransformElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(index)))
 	}
-	return &__DebugElementData_DEFAULT
+	return &TransformElementConfig_DEFAULT
 }
 
-func __DebugElementDataArray_RemoveSwapback(array *__DebugElementDataArray, index int32) __DebugElementData {
+func __TransformElementConfigArray_RemoveSwapback(array *__TransformElementConfigArray, index int32) TransformElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed __DebugElementData = *(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index)))
-		*(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index))) = *(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(array.Length)))
+		var removed TransformElementConfig = *(*TransformElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(index)))
+		*(*TransformElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(index))) = *(*TransformElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return __DebugElementData_DEFAULT
+	return TransformElementConfig_DEFAULT
 }
 
-func __DebugElementDataArray_Set(array *__DebugElementDataArray, index int32, value __DebugElementData) {
+func __TransformElementConfigArray_Set(array *__TransformElementConfigArray, index int32, value TransformElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index))) = value
+		*(*TransformElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(TransformElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -2054,79 +2397,69 @@ func __DebugElementDataArray_Set(array *__DebugElementDataArray, index int32, va
 	}
 }
 
-type LayoutElementHashMapItem struct {
-	BoundingBox           BoundingBox
-	ElementId             ElementId
-	LayoutElement         *LayoutElement
-	OnHoverFunction       func(elementId ElementId, pointerInfo PointerData, userData int64)
-	HoverFunctionUserData any
-	NextIndex             int32
-	Generation            uint32
-	DebugData             *__DebugElementData
-}
-type __LayoutElementHashMapItemArray struct {
+type __PathElementConfigArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *LayoutElementHashMapItem
+	InternalArray *PathElementConfig
 }
-type __LayoutElementHashMapItemArraySlice struct {
+type __PathElementConfigArraySlice struct {
 	Length        int32
-	InternalArray *LayoutElementHashMapItem
+	InternalArray *PathElementConfig
 }
 
-var LayoutElementHashMapItem_DEFAULT LayoutElementHashMapItem = LayoutElementHashMapItem{}
+var PathElementConfig_DEFAULT PathElementConfig = PathElementConfig{}
 
-func __LayoutElementHashMapItemArray_Allocate_Arena(capacity int32, arena *Arena) __LayoutElementHashMapItemArray {
-	return __LayoutElementHashMapItemArray{Capacity: capacity, Length: 0, InternalArray: (*LayoutElementHashMapItem)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(LayoutElementHashMapItem{})), arena))}
+func __PathElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __PathElementConfigArray {
+	return __PathElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*PathElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(PathElementConfig{})), arena))}
 }
 
-func __LayoutElementHashMapItemArray_Get(array *__LayoutElementHashMapItemArray, index int32) *LayoutElementHashMapItem {
+func __PathElementConfigArray_Get(array *__PathElementConfigArray, index int32) *PathElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index)))
+		return (*PathElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(index)))
 	}
-	return &LayoutElementHashMapItem_DEFAULT
+	return &PathElementConfig_DEFAULT
 }
 
-func __LayoutElementHashMapItemArray_GetValue(array *__LayoutElementHashMapItemArray, index int32) LayoutElementHashMapItem {
+func __PathElementConfigArray_GetValue(array *__PathElementConfigArray, index int32) PathElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index)))
+		return *(*PathElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(index)))
 	}
-	return LayoutElementHashMapItem_DEFAULT
+	return PathElementConfig_DEFAULT
 }
 
-func __LayoutElementHashMapItemArray_Add(array *__LayoutElementHashMapItemArray, item LayoutElementHashMapItem) *LayoutElementHashMapItem {
+func __PathElementConfigArray_Add(array *__PathElementConfigArray, item PathElementConfig) *PathElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(func() int32 {
+		*(*PathElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(array.Length-1)))
+		return (*PathElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &LayoutElementHashMapItem_DEFAULT
+	return &PathElementConfig_DEFAULT
 }
 
-func __LayoutElementHashMapItemArraySlice_Get(slice *__LayoutElementHashMapItemArraySlice, index int32) *LayoutElementHashMapItem {
+func __PathElementConfigArraySlice_Get(slice *__PathElementConfigArraySlice, index int32) *PathElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index)))
+		return (*PathElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(index)))
 	}
-	return &LayoutElementHashMapItem_DEFAULT
+	return &PathElementConfig_DEFAULT
 }
 
-func __LayoutElementHashMapItemArray_RemoveSwapback(array *__LayoutElementHashMapItemArray, index int32) LayoutElementHashMapItem {
+func __PathElementConfigArray_RemoveSwapback(array *__PathElementConfigArray, index int32) PathElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed LayoutElementHashMapItem = *(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index)))
-		*(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index))) = *(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(array.Length)))
+		var removed PathElementConfig = *(*PathElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(index)))
+		*(*PathElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(index))) = *(*PathElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return LayoutElementHashMapItem_DEFAULT
+	return PathElementConfig_DEFAULT
 }
 
-func __LayoutElementHashMapItemArray_Set(array *__LayoutElementHashMapItemArray, index int32, value LayoutElementHashMapItem) {
+func __PathElementConfigArray_Set(array *__PathElementConfigArray, index int32, value PathElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index))) = value
+		*(*PathElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -2135,75 +2468,69 @@ func __LayoutElementHashMapItemArray_Set(array *__LayoutElementHashMapItemArray,
 	}
 }
 
-type __MeasuredWord struct {
-	StartOffset int32
-	Length      int32
-	Width       float32
-	Next        int32
-}
-type __MeasuredWordArray struct {
+type __PathCommandArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *__MeasuredWord
+	InternalArray *PathCommand
 }
-type __MeasuredWordArraySlice struct {
+type __PathCommandArraySlice struct {
 	Length        int32
-	InternalArray *__MeasuredWord
+	InternalArray *PathCommand
 }
 
-var __MeasuredWord_DEFAULT __MeasuredWord = __MeasuredWord{}
+var PathCommand_DEFAULT PathCommand = PathCommand{}
 
-func __MeasuredWordArray_Allocate_Arena(capacity int32, arena *Arena) __MeasuredWordArray {
-	return __MeasuredWordArray{Capacity: capacity, Length: 0, InternalArray: (*__MeasuredWord)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__MeasuredWord{})), arena))}
+func __PathCommandArray_Allocate_Arena(capacity int32, arena *Arena) __PathCommandArray {
+	return __PathCommandArray{Capacity: capacity, Length: 0, InternalArray: (*PathCommand)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(PathCommand{})), arena))}
 }
 
-func __MeasuredWordArray_Get(array *__MeasuredWordArray, index int32) *__MeasuredWord {
+func __PathCommandArray_Get(array *__PathCommandArray, index int32) *PathCommand {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index)))
+		return (*PathCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(index)))
 	}
-	return &__MeasuredWord_DEFAULT
+	return &PathCommand_DEFAULT
 }
 
-func __MeasuredWordArray_GetValue(array *__MeasuredWordArray, index int32) __MeasuredWord {
+func __PathCommandArray_GetValue(array *__PathCommandArray, index int32) PathCommand {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index)))
+		return *(*PathCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(index)))
 	}
-	return __MeasuredWord_DEFAULT
+	return PathCommand_DEFAULT
 }
 
-func __MeasuredWordArray_Add(array *__MeasuredWordArray, item __MeasuredWord) *__MeasuredWord {
+func __PathCommandArray_Add(array *__PathCommandArray, item PathCommand) *PathCommand {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(func() int32 {
+		*(*PathCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(array.Length-1)))
+		return (*PathCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(array.Length-1)))
 	}
-	return &__MeasuredWord_DEFAULT
+	return &PathCommand_DEFAULT
 }
 
-func __MeasuredWordArraySlice_Get(slice *__MeasuredWordArraySlice, index int32) *__MeasuredWord {
+func __PathCommandArraySlice_Get(slice *__PathCommandArraySlice, index int32) *PathCommand {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*__MeasuredWord)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index)))
+		return (*PathCommand)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(index)))
 	}
-	return &__MeasuredWord_DEFAULT
+	return &PathCommand_DEFAULT
 }
 
-func __MeasuredWordArray_RemoveSwapback(array *__MeasuredWordArray, index int32) __MeasuredWord {
+func __PathCommandArray_RemoveSwapback(array *__PathCommandArray, index int32) PathCommand {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed __MeasuredWord = *(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index)))
-		*(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index))) = *(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(array.Length)))
+		var removed PathCommand = *(*PathCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(index)))
+		*(*PathCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(index))) = *(*PathCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(array.Length)))
 		return removed
 	}
-	return __MeasuredWord_DEFAULT
+	return PathCommand_DEFAULT
 }
 
-func __MeasuredWordArray_Set(array *__MeasuredWordArray, index int32, value __MeasuredWord) {
+func __PathCommandArray_Set(array *__PathCommandArray, index int32, value PathCommand) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index))) = value
+		*(*PathCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -2212,78 +2539,64 @@ func __MeasuredWordArray_Set(array *__MeasuredWordArray, index int32, value __Me
 	}
 }
 
-type __MeasureTextCacheItem struct {
-	UnwrappedDimensions     Dimensions
-	MeasuredWordsStartIndex int32
-	MinWidth                float32
-	ContainsNewlines        bool
-	Id                      uint32
-	NextIndex               int32
-	Generation              uint32
-}
-type __MeasureTextCacheItemArray struct {
-	Capacity      int32
-	Length        int32
-	InternalArray *__MeasureTextCacheItem
-}
-type __MeasureTextCacheItemArraySlice struct {
+type RenderCommandArraySlice struct {
 	Length        int32
-	InternalArray *__MeasureTextCacheItem
+	InternalArray *RenderCommand
 }
 
-var __MeasureTextCacheItem_DEFAULT __MeasureTextCacheItem = __MeasureTextCacheItem{}
+var RenderCommand_DEFAULT RenderCommand = RenderCommand{}
 
-func __MeasureTextCacheItemArray_Allocate_Arena(capacity int32, arena *Arena) __MeasureTextCacheItemArray {
-	return __MeasureTextCacheItemArray{Capacity: capacity, Length: 0, InternalArray: (*__MeasureTextCacheItem)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__MeasureTextCacheItem{})), arena))}
+func RenderCommandArray_Allocate_Arena(capacity int32, arena *Arena) RenderCommandArray {
+	return RenderCommandArray{Capacity: capacity, Length: 0, InternalArray: (*RenderCommand)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(RenderCommand{})), arena))}
 }
 
-func __MeasureTextCacheItemArray_Get(array *__MeasureTextCacheItemArray, index int32) *__MeasureTextCacheItem {
+func RenderCommandArray_Get(array *RenderCommandArray, index int32) *RenderCommand {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index)))
+		return (*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index)))
 	}
-	return &__MeasureTextCacheItem_DEFAULT
+	return &RenderCommand_DEFAULT
 }
 
-func __MeasureTextCacheItemArray_GetValue(array *__MeasureTextCacheItemArray, index int32) __MeasureTextCacheItem {
+func RenderCommandArray_GetValue(array *RenderCommandArray, index int32) RenderCommand {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index)))
+		return *(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index)))
 	}
-	return __MeasureTextCacheItem_DEFAULT
+	return RenderCommand_DEFAULT
 }
 
-func __MeasureTextCacheItemArray_Add(array *__MeasureTextCacheItemArray, item __MeasureTextCacheItem) *__MeasureTextCacheItem {
+func RenderCommandArray_Add(array *RenderCommandArray, item RenderCommand) *RenderCommand {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(func() int32 {
+		*(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(array.Length-1)))
+		return (*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(array.Length-1)))
 	}
-	return &__MeasureTextCacheItem_DEFAULT
+	return &RenderCommand_DEFAULT
 }
 
-func __MeasureTextCacheItemArraySlice_Get(slice *__MeasureTextCacheItemArraySlice, index int32) *__MeasureTextCacheItem {
+func RenderCommandArraySlice_Get(slice *RenderCommandArraySlice, index int32) *RenderCommand {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index)))
+		return (*RenderCommand)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index)))
 	}
-	return &__MeasureTextCacheItem_DEFAULT
+	return &RenderCommand_DEFAULT
 }
 
-func __MeasureTextCacheItemArray_RemoveSwapback(array *__MeasureTextCacheItemArray, index int32) __MeasureTextCacheItem {
+func RenderCommandArray_RemoveSwapback(array *RenderCommandArray, index int32) RenderCommand {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed __MeasureTextCacheItem = *(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index)))
-		*(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index))) = *(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(array.Length)))
+		var removed RenderCommand = *(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index)))
+		*(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index))) = *(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(array.Length)))
 		return removed
 	}
-	return __MeasureTextCacheItem_DEFAULT
+	return RenderCommand_DEFAULT
 }
 
-func __MeasureTextCacheItemArray_Set(array *__MeasureTextCacheItemArray, index int32, value __MeasureTextCacheItem) {
+func RenderCommandArray_Set(array *RenderCommandArray, index int32, value RenderCommand) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index))) = value
+		*(*RenderCommand)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -2292,74 +2605,114 @@ func __MeasureTextCacheItemArray_Set(array *__MeasureTextCacheItemArray, index i
 	}
 }
 
-type __LayoutElementTreeNode struct {
-	LayoutElement   *LayoutElement
-	Position        Vector2
-	NextChildOffset Vector2
+type __ElementConfigType int32
+
+const (
+	__ELEMENT_CONFIG_TYPE_NONE = __ElementConfigType(iota)
+	__ELEMENT_CONFIG_TYPE_BORDER
+	__ELEMENT_CONFIG_TYPE_FLOATING
+	__ELEMENT_CONFIG_TYPE_CLIP
+	__ELEMENT_CONFIG_TYPE_ASPECT
+	__ELEMENT_CONFIG_TYPE_IMAGE
+	__ELEMENT_CONFIG_TYPE_TEXT
+	__ELEMENT_CONFIG_TYPE_CUSTOM
+	__ELEMENT_CONFIG_TYPE_SHARED
+	__ELEMENT_CONFIG_TYPE_FOCUS
+	__ELEMENT_CONFIG_TYPE_STICKY
+	__ELEMENT_CONFIG_TYPE_ZOOM_PAN
+	__ELEMENT_CONFIG_TYPE_TRANSITION
+	__ELEMENT_CONFIG_TYPE_SHADOW
+	__ELEMENT_CONFIG_TYPE_OPACITY
+	__ELEMENT_CONFIG_TYPE_TRANSFORM
+	__ELEMENT_CONFIG_TYPE_PATH
+)
+
+type ElementConfigUnion struct {
+	// union
+	TextElementConfig        *TextElementConfig
+	AspectRatioElementConfig *AspectRatioElementConfig
+	ImageElementConfig       *ImageElementConfig
+	FloatingElementConfig    *FloatingElementConfig
+	CustomElementConfig      *CustomElementConfig
+	ClipElementConfig        *ClipElementConfig
+	BorderElementConfig      *BorderElementConfig
+	SharedElementConfig      *SharedElementConfig
+	FocusElementConfig       *FocusElementConfig
+	StickyElementConfig      *StickyElementConfig
+	ZoomPanElementConfig     *ZoomPanElementConfig
+	TransitionElementConfig  *TransitionElementConfig
+	ShadowElementConfig      *ShadowElementConfig
+	OpacityElementConfig     *OpacityElementConfig
+	TransformElementConfig   *TransformElementConfig
+	PathElementConfig        *PathElementConfig
 }
-type __LayoutElementTreeNodeArray struct {
+type ElementConfig struct {
+	Type   __ElementConfigType
+	Config ElementConfigUnion
+}
+type __ElementConfigArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *__LayoutElementTreeNode
+	InternalArray *ElementConfig
 }
-type __LayoutElementTreeNodeArraySlice struct {
+type __ElementConfigArraySlice struct {
 	Length        int32
-	InternalArray *__LayoutElementTreeNode
+	InternalArray *ElementConfig
 }
 
-var __LayoutElementTreeNode_DEFAULT __LayoutElementTreeNode = __LayoutElementTreeNode{}
+var ElementConfig_DEFAULT ElementConfig = ElementConfig{}
 
-func __LayoutElementTreeNodeArray_Allocate_Arena(capacity int32, arena *Arena) __LayoutElementTreeNodeArray {
-	return __LayoutElementTreeNodeArray{Capacity: capacity, Length: 0, InternalArray: (*__LayoutElementTreeNode)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__LayoutElementTreeNode{})), arena))}
+func __ElementConfigArray_Allocate_Arena(capacity int32, arena *Arena) __ElementConfigArray {
+	return __ElementConfigArray{Capacity: capacity, Length: 0, InternalArray: (*ElementConfig)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(ElementConfig{})), arena))}
 }
 
-func __LayoutElementTreeNodeArray_Get(array *__LayoutElementTreeNodeArray, index int32) *__LayoutElementTreeNode {
+func __ElementConfigArray_Get(array *__ElementConfigArray, index int32) *ElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index)))
+		return (*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index)))
 	}
-	return &__LayoutElementTreeNode_DEFAULT
+	return &ElementConfig_DEFAULT
 }
 
-func __LayoutElementTreeNodeArray_GetValue(array *__LayoutElementTreeNodeArray, index int32) __LayoutElementTreeNode {
+func __ElementConfigArray_GetValue(array *__ElementConfigArray, index int32) ElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index)))
+		return *(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index)))
 	}
-	return __LayoutElementTreeNode_DEFAULT
+	return ElementConfig_DEFAULT
 }
 
-func __LayoutElementTreeNodeArray_Add(array *__LayoutElementTreeNodeArray, item __LayoutElementTreeNode) *__LayoutElementTreeNode {
+func __ElementConfigArray_Add(array *__ElementConfigArray, item ElementConfig) *ElementConfig {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(func() int32 {
+		*(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(array.Length-1)))
+		return (*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(array.Length-1)))
 	}
-	return &__LayoutElementTreeNode_DEFAULT
+	return &ElementConfig_DEFAULT
 }
 
-func __LayoutElementTreeNodeArraySlice_Get(slice *__LayoutElementTreeNodeArraySlice, index int32) *__LayoutElementTreeNode {
+func __ElementConfigArraySlice_Get(slice *__ElementConfigArraySlice, index int32) *ElementConfig {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index)))
+		return (*ElementConfig)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index)))
 	}
-	return &__LayoutElementTreeNode_DEFAULT
+	return &ElementConfig_DEFAULT
 }
 
-func __LayoutElementTreeNodeArray_RemoveSwapback(array *__LayoutElementTreeNodeArray, index int32) __LayoutElementTreeNode {
+func __ElementConfigArray_RemoveSwapback(array *__ElementConfigArray, index int32) ElementConfig {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed __LayoutElementTreeNode = *(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index)))
-		*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index))) = *(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(array.Length)))
+		var removed ElementConfig = *(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index)))
+		*(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index))) = *(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(array.Length)))
 		return removed
 	}
-	return __LayoutElementTreeNode_DEFAULT
+	return ElementConfig_DEFAULT
 }
 
-func __LayoutElementTreeNodeArray_Set(array *__LayoutElementTreeNodeArray, index int32, value __LayoutElementTreeNode) {
+func __ElementConfigArray_Set(array *__ElementConfigArray, index int32, value ElementConfig) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index))) = value
+		*(*ElementConfig)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -2368,76 +2721,73 @@ func __LayoutElementTreeNodeArray_Set(array *__LayoutElementTreeNodeArray, index
 	}
 }
 
-type __LayoutElementTreeRoot struct {
-	LayoutElementIndex int32
-	ParentId           uint32
-	ClipElementId      uint32
-	ZIndex             int16
-	PointerOffset      Vector2
+type __WrappedTextLine struct {
+	Dimensions Dimensions
+	Line       String
 }
-type __LayoutElementTreeRootArray struct {
+type __WrappedTextLineArray struct {
 	Capacity      int32
 	Length        int32
-	InternalArray *__LayoutElementTreeRoot
+	InternalArray *__WrappedTextLine
 }
-type __LayoutElementTreeRootArraySlice struct {
+type __WrappedTextLineArraySlice struct {
 	Length        int32
-	InternalArray *__LayoutElementTreeRoot
+	InternalArray *__WrappedTextLine
 }
 
-var __LayoutElementTreeRoot_DEFAULT __LayoutElementTreeRoot = __LayoutElementTreeRoot{}
+var __WrappedTextLine_DEFAULT __WrappedTextLine = __WrappedTextLine{}
 
-func __LayoutElementTreeRootArray_Allocate_Arena(capacity int32, arena *Arena) __LayoutElementTreeRootArray {
-	return __LayoutElementTreeRootArray{Capacity: capacity, Length: 0, InternalArray: (*__LayoutElementTreeRoot)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__LayoutElementTreeRoot{})), arena))}
+func __WrappedTextLineArray_Allocate_Arena(capacity int32, arena *Arena) __WrappedTextLineArray {
+	return __WrappedTextLineArray{Capacity: capacity, Length: 0, InternalArray: (*__WrappedTextLine)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__WrappedTextLine{})), arena))}
 }
 
-func __LayoutElementTreeRootArray_Get(array *__LayoutElementTreeRootArray, index int32) *__LayoutElementTreeRoot {
+func __WrappedTextLineArray_Get(array *__WrappedTextLineArray, index int32) *__WrappedTextLine {
 	if __Array_RangeCheck(index, array.Length) {
-		return (*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index)))
+		return (*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index)))
 	}
-	return &__LayoutElementTreeRoot_DEFAULT
+	return &__WrappedTextLine_DEFAULT
 }
 
-func __LayoutElementTreeRootArray_GetValue(array *__LayoutElementTreeRootArray, index int32) __LayoutElementTreeRoot {
+func __WrappedTextLineArray_GetValue(array *__WrappedTextLineArray, index int32) __WrappedTextLine {
 	if __Array_RangeCheck(index, array.Length) {
-		return *(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index)))
+		return *(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index)))
 	}
-	return __LayoutElementTreeRoot_DEFAULT
+	return __WrappedTextLine_DEFAULT
 }
 
-func __LayoutElementTreeRootArray_Add(array *__LayoutElementTreeRootArray, item __LayoutElementTreeRoot) *__LayoutElementTreeRoot {
+func __WrappedTextLineArray_Add(array *__WrappedTextLineArray, item __WrappedTextLine) *__WrappedTextLine {
 	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
-		*(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(func() int32 {
+		*(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(func() int32 {
 			p_ := &array.Length
 			x := *p_
 			*p_++
 			return x
 		}()))) = item
-		return (*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(array.Length-1)))
+		return (*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(array.Length-1)))
 	}
-	return &__LayoutElementTreeRoot_DEFAULT
+	return &__WrappedTextLine_DEFAULT
 }
 
-func __LayoutElementTreeRootArraySlice_Get(slice *__LayoutElementTreeRootArraySlice, index int32) *__LayoutElementTreeRoot {
+func __WrappedTextLineArraySlice_Get(slice *__WrappedTextLineArraySlice, index int32) *__WrappedTextLine {
 	if __Array_RangeCheck(index, slice.Length) {
-		return (*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index)))
+		return (*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index)))
 	}
-	return &__LayoutElementTreeRoot_DEFAULT
+	return &__WrappedTextLine_DEFAULT
 }
 
-func __LayoutElementTreeRootArray_RemoveSwapback(array *__LayoutElementTreeRootArray, index int32) __LayoutElementTreeRoot {
+func __WrappedTextLineArray_RemoveSwapback(array *__WrappedTextLineArray, index int32) __WrappedTextLine {
 	if __Array_RangeCheck(index, array.Length) {
 		array.Length--
-		var removed __LayoutElementTreeRoot = *(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index)))
-		*(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index))) = *(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(array.Length)))
+		var removed __WrappedTextLine = *(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index)))
+		*(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index))) = *(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(array.Length)))
 		return removed
 	}
-	return __LayoutElementTreeRoot_DEFAULT
+	return __WrappedTextLine_DEFAULT
 }
 
-func __LayoutElementTreeRootArray_Set(array *__LayoutElementTreeRootArray, index int32, value __LayoutElementTreeRoot) {
+func __WrappedTextLineArray_Set(array *__WrappedTextLineArray, index int32, value __WrappedTextLine) {
 	if __Array_RangeCheck(index, array.Capacity) {
-		*(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index))) = value
+		*(*__WrappedTextLine)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__WrappedTextLine{})*uintptr(index))) = value
 		if index < array.Length {
 			/* (001) */
 		} else {
@@ -2446,836 +2796,1919 @@ func __LayoutElementTreeRootArray_Set(array *__LayoutElementTreeRootArray, index
 	}
 }
 
-func __Context_Allocate_Arena(arena *Arena) *Context {
-	var totalSizeBytes uint64 = uint64(unsafe.Sizeof(Context{}))
-	if totalSizeBytes > arena.Capacity {
-		return nil
-	}
-	arena.NextAllocation += totalSizeBytes
-	return (*Context)(unsafe.Pointer(arena.Memory))
+type __TextElementData struct {
+	Text                String
+	PreferredDimensions Dimensions
+	ElementIndex        int32
+	WrappedLines        __WrappedTextLineArraySlice
 }
-
-func __WriteStringToCharBuffer(buffer *__charArray, string_ String) String {
-	for i := int32(0); i < string_.Length; i++ {
-		*(*byte)(unsafe.Add(unsafe.Pointer(buffer.InternalArray), buffer.Length+i)) = *(*byte)(unsafe.Add(unsafe.Pointer(string_.Chars), i))
-	}
-	buffer.Length += string_.Length
-	return String{Length: string_.Length, Chars: ((*byte)(unsafe.Add(unsafe.Pointer((*byte)(unsafe.Add(unsafe.Pointer(buffer.InternalArray), buffer.Length))), -string_.Length)))}
+type __TextElementDataArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__TextElementData
+}
+type __TextElementDataArraySlice struct {
+	Length        int32
+	InternalArray *__TextElementData
 }
 
-var (
-	__MeasureText       func(text StringSlice, config *TextElementConfig, userData unsafe.Pointer) Dimensions
-	__QueryScrollOffset func(elementId uint32, userData unsafe.Pointer) Vector2
-)
+var __TextElementData_DEFAULT __TextElementData = __TextElementData{}
 
-func __GetOpenLayoutElement() *LayoutElement {
-	var context *Context = GetCurrentContext()
-	return LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-1))
+func __TextElementDataArray_Allocate_Arena(capacity int32, arena *Arena) __TextElementDataArray {
+	return __TextElementDataArray{Capacity: capacity, Length: 0, InternalArray: (*__TextElementData)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__TextElementData{})), arena))}
 }
 
-func __GetParentElementId() uint32 {
-	var context *Context = GetCurrentContext()
-	return LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-2)).Id
+func __TextElementDataArray_Get(array *__TextElementDataArray, index int32) *__TextElementData {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index)))
+	}
+	return &__TextElementData_DEFAULT
 }
 
-func __StoreLayoutConfig(config LayoutConfig) *LayoutConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &LAYOUT_DEFAULT
+func __TextElementDataArray_GetValue(array *__TextElementDataArray, index int32) __TextElementData {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index)))
 	}
-	return __LayoutConfigArray_Add(&GetCurrentContext().layoutConfigs, config)
+	return __TextElementData_DEFAULT
 }
 
-func __StoreTextElementConfig(config TextElementConfig) *TextElementConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &TextElementConfig_DEFAULT
+func __TextElementDataArray_Add(array *__TextElementDataArray, item __TextElementData) *__TextElementData {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(array.Length-1)))
 	}
-	return __TextElementConfigArray_Add(&GetCurrentContext().textElementConfigs, config)
+	return &__TextElementData_DEFAULT
 }
 
-func __StoreAspectRatioElementConfig(config AspectRatioElementConfig) *AspectRatioElementConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &AspectRatioElementConfig_DEFAULT
+func __TextElementDataArraySlice_Get(slice *__TextElementDataArraySlice, index int32) *__TextElementData {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__TextElementData)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index)))
 	}
-	return __AspectRatioElementConfigArray_Add(&GetCurrentContext().AspectRatioElementConfigs, config)
+	return &__TextElementData_DEFAULT
 }
 
-func __StoreImageElementConfig(config ImageElementConfig) *ImageElementConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &ImageElementConfig_DEFAULT
+func __TextElementDataArray_RemoveSwapback(array *__TextElementDataArray, index int32) __TextElementData {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __TextElementData = *(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index)))
+		*(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index))) = *(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(array.Length)))
+		return removed
 	}
-	return __ImageElementConfigArray_Add(&GetCurrentContext().imageElementConfigs, config)
+	return __TextElementData_DEFAULT
 }
 
-func __StoreFloatingElementConfig(config FloatingElementConfig) *FloatingElementConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &FloatingElementConfig_DEFAULT
+func __TextElementDataArray_Set(array *__TextElementDataArray, index int32, value __TextElementData) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__TextElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TextElementData{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
+		} else {
+			array.Length = index + 1
+		}
 	}
-	return __FloatingElementConfigArray_Add(&GetCurrentContext().floatingElementConfigs, config)
 }
 
-func __StoreCustomElementConfig(config CustomElementConfig) *CustomElementConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &CustomElementConfig_DEFAULT
+type __LayoutElementChildren struct {
+	Elements *int32
+	Length   uint16
+}
+type LayoutElement struct {
+	ChildrenOrTextContent struct {
+		// union
+		Children        __LayoutElementChildren
+		TextElementData *__TextElementData
 	}
-	return __CustomElementConfigArray_Add(&GetCurrentContext().customElementConfigs, config)
+	Dimensions     Dimensions
+	MinDimensions  Dimensions
+	LayoutConfig   *LayoutConfig
+	ElementConfigs __ElementConfigArraySlice
+	Id             uint32
+}
+type LayoutElementArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *LayoutElement
+}
+type LayoutElementArraySlice struct {
+	Length        int32
+	InternalArray *LayoutElement
 }
 
-func __StoreClipElementConfig(config ClipElementConfig) *ClipElementConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &ClipElementConfig_DEFAULT
-	}
-	return __ClipElementConfigArray_Add(&GetCurrentContext().ClipElementConfigs, config)
+var LayoutElement_DEFAULT LayoutElement = LayoutElement{}
+
+func LayoutElementArray_Allocate_Arena(capacity int32, arena *Arena) LayoutElementArray {
+	return LayoutElementArray{Capacity: capacity, Length: 0, InternalArray: (*LayoutElement)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(LayoutElement{})), arena))}
 }
 
-func __StoreBorderElementConfig(config BorderElementConfig) *BorderElementConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &BorderElementConfig_DEFAULT
+func LayoutElementArray_Get(array *LayoutElementArray, index int32) *LayoutElement {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index)))
 	}
-	return __BorderElementConfigArray_Add(&GetCurrentContext().borderElementConfigs, config)
+	return &LayoutElement_DEFAULT
 }
 
-func __StoreSharedElementConfig(config SharedElementConfig) *SharedElementConfig {
-	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
-		return &SharedElementConfig_DEFAULT
+func LayoutElementArray_GetValue(array *LayoutElementArray, index int32) LayoutElement {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index)))
 	}
-	return __SharedElementConfigArray_Add(&GetCurrentContext().sharedElementConfigs, config)
+	return LayoutElement_DEFAULT
 }
 
-func __AttachElementConfig(config ElementConfigUnion, type_ __ElementConfigType) ElementConfig {
-	var context *Context = GetCurrentContext()
-	if context.booleanWarnings.MaxElementsExceeded {
-		return ElementConfig{}
-	}
-	var openLayoutElement *LayoutElement = __GetOpenLayoutElement()
-	openLayoutElement.ElementConfigs.Length++
-	return *__ElementConfigArray_Add(&context.elementConfigs, ElementConfig{Type: type_, Config: config})
+func LayoutElementArray_Add(array *LayoutElementArray, item LayoutElement) *LayoutElement {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(array.Length-1)))
+	}
+	return &LayoutElement_DEFAULT
 }
 
-func __FindElementConfigWithType(element *LayoutElement, type_ __ElementConfigType) ElementConfigUnion {
-	for i := int32(0); i < element.ElementConfigs.Length; i++ {
-		var config *ElementConfig = __ElementConfigArraySlice_Get(&element.ElementConfigs, i)
-		if config.Type == type_ {
-			return config.Config
+func LayoutElementArraySlice_Get(slice *LayoutElementArraySlice, index int32) *LayoutElement {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*LayoutElement)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index)))
+	}
+	return &LayoutElement_DEFAULT
+}
+
+func LayoutElementArray_RemoveSwapback(array *LayoutElementArray, index int32) LayoutElement {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed LayoutElement = *(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index)))
+		*(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index))) = *(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(array.Length)))
+		return removed
+	}
+	return LayoutElement_DEFAULT
+}
+
+func LayoutElementArray_Set(array *LayoutElementArray, index int32, value LayoutElement) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*LayoutElement)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElement{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
+		} else {
+			array.Length = index + 1
 		}
 	}
-	return ElementConfigUnion{}
 }
 
-func __HashNumber(offset uint32, seed uint32) ElementId {
-	var hash uint32 = seed
-	hash += offset + 48
-	hash += hash << 10
-	hash ^= hash >> 6
-	hash += hash << 3
-	hash ^= hash >> 11
-	hash += hash << 15
-	return ElementId{Id: hash + 1, Offset: offset, BaseId: seed, StringId: __STRING_DEFAULT}
+type __ScrollContainerDataInternal struct {
+	LayoutElement             *LayoutElement
+	BoundingBox               BoundingBox
+	ContentSize               Dimensions
+	ScrollOrigin              Vector2
+	PointerOrigin             Vector2
+	ScrollMomentum            Vector2
+	ScrollPosition            Vector2
+	PreviousDelta             Vector2
+	ScrollTarget              Vector2
+	ScrollbarPreviousPosition Vector2
+	MomentumTime              float32
+	ScrollbarIdleTime         float32
+	SnapIdleTime              float32
+	Scale                     float32
+	ElementId                 uint32
+	ScrollbarDragAxis         int32
+	OpenThisFrame             bool
+	PointerScrollActive       bool
+	ScrollAnimationActive     bool
+	ScrollbarPressed          bool
+	SnapPending               bool
+}
+type __ScrollContainerDataInternalArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__ScrollContainerDataInternal
+}
+type __ScrollContainerDataInternalArraySlice struct {
+	Length        int32
+	InternalArray *__ScrollContainerDataInternal
 }
 
-func __HashString(key String, seed uint32) ElementId {
-	var hash uint32 = seed
-	for i := int32(0); i < key.Length; i++ {
-		hash += uint32(*(*byte)(unsafe.Add(unsafe.Pointer(key.Chars), i)))
-		hash += hash << 10
-		hash ^= hash >> 6
+var __ScrollContainerDataInternal_DEFAULT __ScrollContainerDataInternal = __ScrollContainerDataInternal{}
+
+func __ScrollContainerDataInternalArray_Allocate_Arena(capacity int32, arena *Arena) __ScrollContainerDataInternalArray {
+	return __ScrollContainerDataInternalArray{Capacity: capacity, Length: 0, InternalArray: (*__ScrollContainerDataInternal)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__ScrollContainerDataInternal{})), arena))}
+}
+
+func __ScrollContainerDataInternalArray_Get(array *__ScrollContainerDataInternalArray, index int32) *__ScrollContainerDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index)))
 	}
-	hash += hash << 3
-	hash ^= hash >> 11
-	hash += hash << 15
-	return ElementId{Id: hash + 1, Offset: 0, BaseId: hash + 1, StringId: key}
+	return &__ScrollContainerDataInternal_DEFAULT
 }
 
-func __HashStringWithOffset(key String, offset uint32, seed uint32) ElementId {
-	var (
-		hash uint32 = 0
-		base uint32 = seed
-	)
-	for i := int32(0); i < key.Length; i++ {
-		base += uint32(*(*byte)(unsafe.Add(unsafe.Pointer(key.Chars), i)))
-		base += base << 10
-		base ^= base >> 6
+func __ScrollContainerDataInternalArray_GetValue(array *__ScrollContainerDataInternalArray, index int32) __ScrollContainerDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index)))
 	}
-	hash = base
-	hash += offset
-	hash += hash << 10
-	hash ^= hash >> 6
-	hash += hash << 3
-	base += base << 3
-	hash ^= hash >> 11
-	base ^= base >> 11
-	hash += hash << 15
-	base += base << 15
-	return ElementId{Id: hash + 1, Offset: offset, BaseId: base + 1, StringId: key}
+	return __ScrollContainerDataInternal_DEFAULT
 }
 
-func __HashData(data *uint8, length uint64) uint64 {
-	var hash uint64 = 0
-	for i := uint64(0); i < length; i++ {
-		hash += uint64(*(*uint8)(unsafe.Add(unsafe.Pointer(data), i)))
-		hash += hash << 10
-		hash ^= hash >> 6
+func __ScrollContainerDataInternalArray_Add(array *__ScrollContainerDataInternalArray, item __ScrollContainerDataInternal) *__ScrollContainerDataInternal {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(array.Length-1)))
 	}
-	return hash
+	return &__ScrollContainerDataInternal_DEFAULT
 }
 
-func __HashStringContentsWithConfig(text *String, config *TextElementConfig) uint32 {
-	var hash uint32 = 0
-	if text.IsStaticallyAllocated {
-		hash += uint32(uint64(uintptr(unsafe.Pointer(text.Chars))))
-		hash += hash << 10
-		hash ^= hash >> 6
-		hash += uint32(text.Length)
-		hash += hash << 10
-		hash ^= hash >> 6
-	} else {
-		hash = uint32(__HashData((*uint8)(unsafe.Pointer(text.Chars)), uint64(text.Length)) % math.MaxUint32)
+func __ScrollContainerDataInternalArraySlice_Get(slice *__ScrollContainerDataInternalArraySlice, index int32) *__ScrollContainerDataInternal {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index)))
 	}
-	hash += uint32(config.FontId)
-	hash += hash << 10
-	hash ^= hash >> 6
-	hash += uint32(config.FontSize)
-	hash += hash << 10
-	hash ^= hash >> 6
-	hash += uint32(config.LetterSpacing)
-	hash += hash << 10
-	hash ^= hash >> 6
-	hash += hash << 3
-	hash ^= hash >> 11
-	hash += hash << 15
-	return hash + 1
+	return &__ScrollContainerDataInternal_DEFAULT
 }
 
-func __AddMeasuredWord(word __MeasuredWord, previousWord *__MeasuredWord) *__MeasuredWord {
-	var context *Context = GetCurrentContext()
-	if context.measuredWordsFreeList.Length > 0 {
-		var newItemIndex uint32 = uint32(__int32_tArray_GetValue(&context.measuredWordsFreeList, context.measuredWordsFreeList.Length-1))
-		context.measuredWordsFreeList.Length--
-		__MeasuredWordArray_Set(&context.measuredWords, int32(newItemIndex), word)
-		previousWord.Next = int32(newItemIndex)
-		return __MeasuredWordArray_Get(&context.measuredWords, int32(newItemIndex))
-	} else {
-		previousWord.Next = context.measuredWords.Length
-		return __MeasuredWordArray_Add(&context.measuredWords, word)
+func __ScrollContainerDataInternalArray_RemoveSwapback(array *__ScrollContainerDataInternalArray, index int32) __ScrollContainerDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __ScrollContainerDataInternal = *(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index)))
+		*(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index))) = *(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(array.Length)))
+		return removed
 	}
+	return __ScrollContainerDataInternal_DEFAULT
 }
 
-func __MeasureTextCached(text *String, config *TextElementConfig) *__MeasureTextCacheItem {
-	var context *Context = GetCurrentContext()
-	if __MeasureText == nil {
-		if !context.booleanWarnings.TextMeasurementFunctionNotSet {
-			context.booleanWarnings.TextMeasurementFunctionNotSet = true
-			context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_TEXT_MEASUREMENT_FUNCTION_NOT_PROVIDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay's internal MeasureText function is null. You may have forgotten to call SetMeasureTextFunction(), or passed a NULL function pointer by mistake.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay's internal MeasureText function is null. You may have forgotten to call SetMeasureTextFunction(), or passed a NULL function pointer by mistake.")}, UserData: context.errorHandler.UserData})
+func __ScrollContainerDataInternalArray_Set(array *__ScrollContainerDataInternalArray, index int32, value __ScrollContainerDataInternal) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__ScrollContainerDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ScrollContainerDataInternal{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
+		} else {
+			array.Length = index + 1
 		}
-		return &__MeasureTextCacheItem_DEFAULT
 	}
-	var id uint32 = __HashStringContentsWithConfig(text, config)
-	var hashBucket uint32 = id % uint32(context.maxMeasureTextCacheWordCount/32)
-	var elementIndexPrevious int32 = 0
-	var elementIndex int32 = *(*int32)(unsafe.Add(unsafe.Pointer(context.measureTextHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket)))
-	for elementIndex != 0 {
-		var hashEntry *__MeasureTextCacheItem = __MeasureTextCacheItemArray_Get(&context.measureTextHashMapInternal, elementIndex)
-		if hashEntry.Id == id {
-			hashEntry.Generation = context.generation
-			return hashEntry
-		}
-		if context.generation-hashEntry.Generation > 2 {
-			var nextWordIndex int32 = hashEntry.MeasuredWordsStartIndex
-			for nextWordIndex != -1 {
-				var measuredWord *__MeasuredWord = __MeasuredWordArray_Get(&context.measuredWords, nextWordIndex)
-				__int32_tArray_Add(&context.measuredWordsFreeList, nextWordIndex)
-				nextWordIndex = measuredWord.Next
-			}
-			var nextIndex int32 = hashEntry.NextIndex
-			__MeasureTextCacheItemArray_Set(&context.measureTextHashMapInternal, elementIndex, __MeasureTextCacheItem{MeasuredWordsStartIndex: -1})
-			__int32_tArray_Add(&context.measureTextHashMapInternalFreeList, elementIndex)
-			if elementIndexPrevious == 0 {
-				*(*int32)(unsafe.Add(unsafe.Pointer(context.measureTextHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket))) = nextIndex
-			} else {
-				var previousHashEntry *__MeasureTextCacheItem = __MeasureTextCacheItemArray_Get(&context.measureTextHashMapInternal, elementIndexPrevious)
-				previousHashEntry.NextIndex = nextIndex
-			}
-			elementIndex = nextIndex
+}
+
+type __ZoomPanDataInternal struct {
+	LayoutElement *LayoutElement
+	BoundingBox   BoundingBox
+	Pan           Vector2
+	PanOrigin     Vector2
+	PointerOrigin Vector2
+	Zoom          float32
+	ParentScale   float32
+	ElementId     uint32
+	OpenThisFrame bool
+	DragActive    bool
+}
+type __ZoomPanDataInternalArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__ZoomPanDataInternal
+}
+type __ZoomPanDataInternalArraySlice struct {
+	Length        int32
+	InternalArray *__ZoomPanDataInternal
+}
+
+var __ZoomPanDataInternal_DEFAULT __ZoomPanDataInternal = __ZoomPanDataInternal{}
+
+func __ZoomPanDataInternalArray_Allocate_Arena(capacity int32, arena *Arena) __ZoomPanDataInternalArray {
+	return __ZoomPanDataInternalArray{Capacity: capacity, Length: 0, InternalArray: (*__ZoomPanDataInternal)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__ZoomPanDataInternal{})), arena))}
+}
+
+func __ZoomPanDataInternalArray_Get(array *__ZoomPanDataInternalArray, index int32) *__ZoomPanDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(index)))
+	}
+	return &__ZoomPanDataInternal_DEFAULT
+}
+
+func __ZoomPanDataInternalArray_GetValue(array *__ZoomPanDataInternalArray, index int32) __ZoomPanDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(index)))
+	}
+	return __ZoomPanDataInternal_DEFAULT
+}
+
+func __ZoomPanDataInternalArray_Add(array *__ZoomPanDataInternalArray, item __ZoomPanDataInternal) *__ZoomPanDataInternal {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(array.Length-1)))
+	}
+	return &__ZoomPanDataInternal_DEFAULT
+}
+
+func __ZoomPanDataInternalArraySlice_Get(slice *__ZoomPanDataInternalArraySlice, index int32) *__ZoomPanDataInternal {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(index)))
+	}
+	return &__ZoomPanDataInternal_DEFAULT
+}
+
+func __ZoomPanDataInternalArray_RemoveSwapback(array *__ZoomPanDataInternalArray, index int32) __ZoomPanDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __ZoomPanDataInternal = *(*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(index)))
+		*(*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(index))) = *(*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(array.Length)))
+		return removed
+	}
+	return __ZoomPanDataInternal_DEFAULT
+}
+
+func __ZoomPanDataInternalArray_Set(array *__ZoomPanDataInternalArray, index int32, value __ZoomPanDataInternal) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__ZoomPanDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__ZoomPanDataInternal{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
 		} else {
-			elementIndexPrevious = elementIndex
-			elementIndex = hashEntry.NextIndex
+			array.Length = index + 1
 		}
 	}
-	var newItemIndex int32 = 0
-	var newCacheItem __MeasureTextCacheItem = __MeasureTextCacheItem{MeasuredWordsStartIndex: -1, Id: id, Generation: context.generation}
-	var measured *__MeasureTextCacheItem = nil
-	if context.measureTextHashMapInternalFreeList.Length > 0 {
-		newItemIndex = __int32_tArray_GetValue(&context.measureTextHashMapInternalFreeList, context.measureTextHashMapInternalFreeList.Length-1)
-		context.measureTextHashMapInternalFreeList.Length--
-		__MeasureTextCacheItemArray_Set(&context.measureTextHashMapInternal, newItemIndex, newCacheItem)
-		measured = __MeasureTextCacheItemArray_Get(&context.measureTextHashMapInternal, newItemIndex)
-	} else {
-		if context.measureTextHashMapInternal.Length == context.measureTextHashMapInternal.Capacity-1 {
-			if !context.booleanWarnings.MaxTextMeasureCacheExceeded {
-				context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay ran out of capacity while attempting to measure text elements. Try using SetMaxElementCount() with a higher value.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay ran out of capacity while attempting to measure text elements. Try using SetMaxElementCount() with a higher value.")}, UserData: context.errorHandler.UserData})
-				context.booleanWarnings.MaxTextMeasureCacheExceeded = true
-			}
-			return &__MeasureTextCacheItem_DEFAULT
-		}
-		measured = __MeasureTextCacheItemArray_Add(&context.measureTextHashMapInternal, newCacheItem)
-		newItemIndex = context.measureTextHashMapInternal.Length - 1
-	}
-	var start int32 = 0
-	var end int32 = 0
-	var lineWidth float32 = 0
-	var measuredWidth float32 = 0
-	var measuredHeight float32 = 0
-	var spaceWidth float32 = __MeasureText(StringSlice{Length: 1, Chars: __SPACECHAR.Chars, BaseChars: __SPACECHAR.Chars}, config, context.measureTextUserData.(unsafe.Pointer)).Width
-	var tempWord __MeasuredWord = __MeasuredWord{Next: -1}
-	var previousWord *__MeasuredWord = &tempWord
-	for end < text.Length {
-		if context.measuredWords.Length == context.measuredWords.Capacity-1 {
-			if !context.booleanWarnings.MaxTextMeasureCacheExceeded {
-				context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_TEXT_MEASUREMENT_CAPACITY_EXCEEDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay has run out of space in it's internal text measurement cache. Try using SetMaxMeasureTextCacheWordCount() (default 16384, with 1 unit storing 1 measured word).") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay has run out of space in it's internal text measurement cache. Try using SetMaxMeasureTextCacheWordCount() (default 16384, with 1 unit storing 1 measured word).")}, UserData: context.errorHandler.UserData})
-				context.booleanWarnings.MaxTextMeasureCacheExceeded = true
-			}
-			return &__MeasureTextCacheItem_DEFAULT
-		}
-		var current int8 = int8(*(*byte)(unsafe.Add(unsafe.Pointer(text.Chars), end)))
-		if int32(current) == ' ' || int32(current) == '\n' {
-			var (
-				length     int32      = end - start
-				dimensions Dimensions = Dimensions{}
-			)
-			if length > 0 {
-				dimensions = __MeasureText(StringSlice{Length: length, Chars: (*byte)(unsafe.Add(unsafe.Pointer(text.Chars), start)), BaseChars: text.Chars}, config, context.measureTextUserData.(unsafe.Pointer))
-			}
-			if dimensions.Width > measured.MinWidth {
-				measured.MinWidth = dimensions.Width
-			} else {
-				/* (022) */
-			}
-			if measuredHeight > dimensions.Height {
-				/* (002) */
-			} else {
-				measuredHeight = dimensions.Height
-			}
-			if int32(current) == ' ' {
-				dimensions.Width += spaceWidth
-				previousWord = __AddMeasuredWord(__MeasuredWord{StartOffset: start, Length: length + 1, Width: dimensions.Width, Next: -1}, previousWord)
-				lineWidth += dimensions.Width
-			}
-			if int32(current) == '\n' {
-				if length > 0 {
-					previousWord = __AddMeasuredWord(__MeasuredWord{StartOffset: start, Length: length, Width: dimensions.Width, Next: -1}, previousWord)
-				}
-				previousWord = __AddMeasuredWord(__MeasuredWord{StartOffset: end + 1, Length: 0, Width: 0, Next: -1}, previousWord)
-				lineWidth += dimensions.Width
-				if lineWidth > measuredWidth {
-					measuredWidth = lineWidth
-				} else {
-					/* (003) */
-				}
-				measured.ContainsNewlines = true
-				lineWidth = 0
-			}
-			start = end + 1
-		}
-		end++
-	}
-	if end-start > 0 {
-		var dimensions Dimensions = __MeasureText(StringSlice{Length: end - start, Chars: (*byte)(unsafe.Add(unsafe.Pointer(text.Chars), start)), BaseChars: text.Chars}, config, context.measureTextUserData.(unsafe.Pointer))
-		__AddMeasuredWord(__MeasuredWord{StartOffset: start, Length: end - start, Width: dimensions.Width, Next: -1}, previousWord)
-		lineWidth += dimensions.Width
-		if measuredHeight > dimensions.Height {
-			/* (002) */
-		} else {
-			measuredHeight = dimensions.Height
-		}
-		if dimensions.Width > measured.MinWidth {
-			measured.MinWidth = dimensions.Width
-		} else {
-			/* (022) */
-		}
-	}
-	measuredWidth = (func() float32 {
-		if lineWidth > measuredWidth {
-			return lineWidth
-		}
-		return measuredWidth
-	}()) - float32(config.LetterSpacing)
-	measured.MeasuredWordsStartIndex = tempWord.Next
-	measured.UnwrappedDimensions.Width = measuredWidth
-	measured.UnwrappedDimensions.Height = measuredHeight
-	if elementIndexPrevious != 0 {
-		__MeasureTextCacheItemArray_Get(&context.measureTextHashMapInternal, elementIndexPrevious).NextIndex = newItemIndex
-	} else {
-		*(*int32)(unsafe.Add(unsafe.Pointer(context.measureTextHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket))) = newItemIndex
-	}
-	return measured
 }
 
-func __PointIsInsideRect(point Vector2, rect BoundingBox) bool {
-	return point.X >= rect.X && point.X <= rect.X+rect.Width && point.Y >= rect.Y && point.Y <= rect.Y+rect.Height
+type __TransitionDataInternal struct {
+	ElementId          uint32
+	ParentId           uint32
+	Config             TransitionElementConfig
+	PositionFrom       Vector2
+	PositionTo         Vector2
+	SizeFrom           Dimensions
+	SizeTo             Dimensions
+	ColorFrom          Color
+	ColorTo            Color
+	OpacityFrom        float32
+	OpacityTo          float32
+	PositionElapsed    float32
+	SizeElapsed        float32
+	ColorElapsed       float32
+	OpacityElapsed     float32
+	ExitElapsed        float32
+	ExitOpacity        float32
+	ExitCommandsStart  int32
+	ExitCommandsLength int32
+	OpenThisFrame      bool
+	Entering           bool
+	Exiting            bool
+}
+type __TransitionDataInternalArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__TransitionDataInternal
+}
+type __TransitionDataInternalArraySlice struct {
+	Length        int32
+	InternalArray *__TransitionDataInternal
 }
 
-func __AddHashMapItem(elementId ElementId, layoutElement *LayoutElement) *LayoutElementHashMapItem {
-	var context *Context = GetCurrentContext()
-	if context.layoutElementsHashMapInternal.Length == context.layoutElementsHashMapInternal.Capacity-1 {
-		return nil
-	}
-	var item LayoutElementHashMapItem = LayoutElementHashMapItem{ElementId: elementId, LayoutElement: layoutElement, NextIndex: -1, Generation: context.generation + 1}
-	var hashBucket uint32 = elementId.Id % uint32(context.layoutElementsHashMap.Capacity)
-	var hashItemPrevious int32 = -1
-	var hashItemIndex int32 = *(*int32)(unsafe.Add(unsafe.Pointer(context.layoutElementsHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket)))
-	for hashItemIndex != -1 {
-		var hashItem *LayoutElementHashMapItem = __LayoutElementHashMapItemArray_Get(&context.layoutElementsHashMapInternal, hashItemIndex)
-		if hashItem.ElementId.Id == elementId.Id {
-			item.NextIndex = hashItem.NextIndex
-			if hashItem.Generation <= context.generation {
-				hashItem.ElementId = elementId
-				hashItem.Generation = context.generation + 1
-				hashItem.LayoutElement = layoutElement
-				hashItem.DebugData.Collision = false
-				hashItem.OnHoverFunction = nil
-				hashItem.HoverFunctionUserData = 0
-			} else {
-				context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_DUPLICATE_ID, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("An element with this ID was already previously declared during this layout.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("An element with this ID was already previously declared during this layout.")}, UserData: context.errorHandler.UserData})
-				if context.debugModeEnabled {
-					hashItem.DebugData.Collision = true
-				}
-			}
-			return hashItem
-		}
-		hashItemPrevious = hashItemIndex
-		hashItemIndex = hashItem.NextIndex
+var __TransitionDataInternal_DEFAULT __TransitionDataInternal = __TransitionDataInternal{}
+
+func __TransitionDataInternalArray_Allocate_Arena(capacity int32, arena *Arena) __TransitionDataInternalArray {
+	return __TransitionDataInternalArray{Capacity: capacity, Length: 0, InternalArray: (*__TransitionDataInternal)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__TransitionDataInternal{})), arena))}
+}
+
+func __TransitionDataInternalArray_Get(array *__TransitionDataInternalArray, index int32) *__TransitionDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(index)))
 	}
-	var hashItem *LayoutElementHashMapItem = __LayoutElementHashMapItemArray_Add(&context.layoutElementsHashMapInternal, item)
-	hashItem.DebugData = __DebugElementDataArray_Add(&context.debugElementData, __DebugElementData{Collision: false})
-	if hashItemPrevious != -1 {
-		__LayoutElementHashMapItemArray_Get(&context.layoutElementsHashMapInternal, hashItemPrevious).NextIndex = context.layoutElementsHashMapInternal.Length - 1
-	} else {
-		*(*int32)(unsafe.Add(unsafe.Pointer(context.layoutElementsHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket))) = context.layoutElementsHashMapInternal.Length - 1
+	return &__TransitionDataInternal_DEFAULT
+}
+
+func __TransitionDataInternalArray_GetValue(array *__TransitionDataInternalArray, index int32) __TransitionDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(index)))
 	}
-	return hashItem
+	return __TransitionDataInternal_DEFAULT
 }
 
-func __GetHashMapItem(id uint32) *LayoutElementHashMapItem {
-	var (
-		context      *Context = GetCurrentContext()
-		hashBucket   uint32   = id % uint32(context.layoutElementsHashMap.Capacity)
-		elementIndex int32    = *(*int32)(unsafe.Add(unsafe.Pointer(context.layoutElementsHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket)))
-	)
-	for elementIndex != -1 {
-		var hashEntry *LayoutElementHashMapItem = __LayoutElementHashMapItemArray_Get(&context.layoutElementsHashMapInternal, elementIndex)
-		if hashEntry.ElementId.Id == id {
-			return hashEntry
-		}
-		elementIndex = hashEntry.NextIndex
+func __TransitionDataInternalArray_Add(array *__TransitionDataInternalArray, item __TransitionDataInternal) *__TransitionDataInternal {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(array.Length-1)))
 	}
-	return &LayoutElementHashMapItem_DEFAULT
+	return &__TransitionDataInternal_DEFAULT
 }
 
-func __GenerateIdForAnonymousElement(openLayoutElement *LayoutElement) ElementId {
-	var (
-		context       *Context       = GetCurrentContext()
-		parentElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-2))
-		elementId     ElementId      = __HashNumber(uint32(parentElement.ChildrenOrTextContent.Children.Length), parentElement.Id)
-	)
-	openLayoutElement.Id = elementId.Id
-	__AddHashMapItem(elementId, openLayoutElement)
-	__StringArray_Add(&context.layoutElementIdStrings, elementId.StringId)
-	return elementId
+func __TransitionDataInternalArraySlice_Get(slice *__TransitionDataInternalArraySlice, index int32) *__TransitionDataInternal {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(index)))
+	}
+	return &__TransitionDataInternal_DEFAULT
 }
 
-func __ElementHasConfig(layoutElement *LayoutElement, type_ __ElementConfigType) bool {
-	for i := int32(0); i < layoutElement.ElementConfigs.Length; i++ {
-		if __ElementConfigArraySlice_Get(&layoutElement.ElementConfigs, i).Type == type_ {
-			return true
-		}
+func __TransitionDataInternalArray_RemoveSwapback(array *__TransitionDataInternalArray, index int32) __TransitionDataInternal {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __TransitionDataInternal = *(*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(index)))
+		*(*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(index))) = *(*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(array.Length)))
+		return removed
 	}
-	return false
+	return __TransitionDataInternal_DEFAULT
 }
 
-func __UpdateAspectRatioBox(layoutElement *LayoutElement) {
-	for j := int32(0); j < layoutElement.ElementConfigs.Length; j++ {
-		var config *ElementConfig = __ElementConfigArraySlice_Get(&layoutElement.ElementConfigs, j)
-		if config.Type == __ELEMENT_CONFIG_TYPE_ASPECT {
-			var aspectConfig *AspectRatioElementConfig = config.Config.AspectRatioElementConfig
-			if aspectConfig.AspectRatio == 0 {
-				break
-			}
-			if layoutElement.Dimensions.Width == 0 && layoutElement.Dimensions.Height != 0 {
-				layoutElement.Dimensions.Width = layoutElement.Dimensions.Height * aspectConfig.AspectRatio
-			} else if layoutElement.Dimensions.Width != 0 && layoutElement.Dimensions.Height == 0 {
-				layoutElement.Dimensions.Height = layoutElement.Dimensions.Width * (1 / aspectConfig.AspectRatio)
-			}
-			break
+func __TransitionDataInternalArray_Set(array *__TransitionDataInternalArray, index int32, value __TransitionDataInternal) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__TransitionDataInternal)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__TransitionDataInternal{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
+		} else {
+			array.Length = index + 1
 		}
 	}
 }
 
-func __CloseElement() {
-	var context *Context = GetCurrentContext()
-	if context.booleanWarnings.MaxElementsExceeded {
-		return
+type __DebugElementData struct {
+	Collision bool
+	Collapsed bool
+}
+type __DebugElementDataArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__DebugElementData
+}
+type __DebugElementDataArraySlice struct {
+	Length        int32
+	InternalArray *__DebugElementData
+}
+
+var __DebugElementData_DEFAULT __DebugElementData = __DebugElementData{Collision: false}
+
+func __DebugElementDataArray_Allocate_Arena(capacity int32, arena *Arena) __DebugElementDataArray {
+	return __DebugElementDataArray{Capacity: capacity, Length: 0, InternalArray: (*__DebugElementData)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__DebugElementData{})), arena))}
+}
+
+func __DebugElementDataArray_Get(array *__DebugElementDataArray, index int32) *__DebugElementData {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index)))
 	}
-	var openLayoutElement *LayoutElement = __GetOpenLayoutElement()
-	var layoutConfig *LayoutConfig = openLayoutElement.LayoutConfig
-	if layoutConfig == nil {
-		openLayoutElement.LayoutConfig = &LayoutConfig_DEFAULT
-		layoutConfig = &LayoutConfig_DEFAULT
+	return &__DebugElementData_DEFAULT
+}
+
+func __DebugElementDataArray_GetValue(array *__DebugElementDataArray, index int32) __DebugElementData {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index)))
 	}
-	var elementHasClipHorizontal bool = false
-	var elementHasClipVertical bool = false
-	for i := int32(0); i < openLayoutElement.ElementConfigs.Length; i++ {
-		var config *ElementConfig = __ElementConfigArraySlice_Get(&openLayoutElement.ElementConfigs, i)
-		if config.Type == __ELEMENT_CONFIG_TYPE_CLIP {
-			elementHasClipHorizontal = config.Config.ClipElementConfig.Horizontal
-			elementHasClipVertical = config.Config.ClipElementConfig.Vertical
-			context.openClipElementStack.Length--
-			break
-		} else if config.Type == __ELEMENT_CONFIG_TYPE_FLOATING {
-			context.openClipElementStack.Length--
-		}
+	return __DebugElementData_DEFAULT
+}
+
+func __DebugElementDataArray_Add(array *__DebugElementDataArray, item __DebugElementData) *__DebugElementData {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(array.Length-1)))
 	}
-	var leftRightPadding float32 = float32(int32(layoutConfig.Padding.Left) + int32(layoutConfig.Padding.Right))
-	var topBottomPadding float32 = float32(int32(layoutConfig.Padding.Top) + int32(layoutConfig.Padding.Bottom))
-	openLayoutElement.ChildrenOrTextContent.Children.Elements = (*int32)(unsafe.Add(unsafe.Pointer(context.layoutElementChildren.InternalArray), unsafe.Sizeof(int32(0))*uintptr(context.layoutElementChildren.Length)))
-	if layoutConfig.LayoutDirection == LEFT_TO_RIGHT {
-		openLayoutElement.Dimensions.Width = leftRightPadding
-		openLayoutElement.MinDimensions.Width = leftRightPadding
-		for i := int32(0); i < int32(openLayoutElement.ChildrenOrTextContent.Children.Length); i++ {
-			var (
-				childIndex int32          = __int32_tArray_GetValue(&context.layoutElementChildrenBuffer, context.layoutElementChildrenBuffer.Length-int32(openLayoutElement.ChildrenOrTextContent.Children.Length)+i)
-				child      *LayoutElement = LayoutElementArray_Get(&context.layoutElements, childIndex)
-			)
-			openLayoutElement.Dimensions.Width += child.Dimensions.Width
-			if openLayoutElement.Dimensions.Height > (child.Dimensions.Height + topBottomPadding) {
-				/* (004) */
-			} else {
-				openLayoutElement.Dimensions.Height = child.Dimensions.Height + topBottomPadding
-			}
-			if !elementHasClipHorizontal {
-				openLayoutElement.MinDimensions.Width += child.MinDimensions.Width
-			}
-			if !elementHasClipVertical {
-				if openLayoutElement.MinDimensions.Height > (child.MinDimensions.Height + topBottomPadding) {
-					/* (005) */
-				} else {
-					openLayoutElement.MinDimensions.Height = child.MinDimensions.Height + topBottomPadding
-				}
-			}
-			__int32_tArray_Add(&context.layoutElementChildren, childIndex)
-		}
-		var childGap float32 = float32((func() int32 {
-			if (int32(openLayoutElement.ChildrenOrTextContent.Children.Length) - 1) > 0 {
-				return int32(openLayoutElement.ChildrenOrTextContent.Children.Length) - 1
-			}
-			return 0
-		}()) * int32(layoutConfig.ChildGap))
-		openLayoutElement.Dimensions.Width += childGap
-		if !elementHasClipHorizontal {
-			openLayoutElement.MinDimensions.Width += childGap
-		}
-	} else if layoutConfig.LayoutDirection == TOP_TO_BOTTOM {
-		openLayoutElement.Dimensions.Height = topBottomPadding
-		openLayoutElement.MinDimensions.Height = topBottomPadding
-		for i := int32(0); i < int32(openLayoutElement.ChildrenOrTextContent.Children.Length); i++ {
-			var (
-				childIndex int32          = __int32_tArray_GetValue(&context.layoutElementChildrenBuffer, context.layoutElementChildrenBuffer.Length-int32(openLayoutElement.ChildrenOrTextContent.Children.Length)+i)
-				child      *LayoutElement = LayoutElementArray_Get(&context.layoutElements, childIndex)
-			)
-			openLayoutElement.Dimensions.Height += child.Dimensions.Height
-			if openLayoutElement.Dimensions.Width > (child.Dimensions.Width + leftRightPadding) {
-				/* (006) */
-			} else {
-				openLayoutElement.Dimensions.Width = child.Dimensions.Width + leftRightPadding
-			}
-			if !elementHasClipVertical {
-				openLayoutElement.MinDimensions.Height += child.MinDimensions.Height
-			}
-			if !elementHasClipHorizontal {
-				if openLayoutElement.MinDimensions.Width > (child.MinDimensions.Width + leftRightPadding) {
-					/* (007) */
-				} else {
-					openLayoutElement.MinDimensions.Width = child.MinDimensions.Width + leftRightPadding
-				}
-			}
-			__int32_tArray_Add(&context.layoutElementChildren, childIndex)
-		}
-		var childGap float32 = float32((func() int32 {
-			if (int32(openLayoutElement.ChildrenOrTextContent.Children.Length) - 1) > 0 {
-				return int32(openLayoutElement.ChildrenOrTextContent.Children.Length) - 1
-			}
-			return 0
-		}()) * int32(layoutConfig.ChildGap))
-		openLayoutElement.Dimensions.Height += childGap
-		if !elementHasClipVertical {
-			openLayoutElement.MinDimensions.Height += childGap
-		}
+	return &__DebugElementData_DEFAULT
+}
+
+func __DebugElementDataArraySlice_Get(slice *__DebugElementDataArraySlice, index int32) *__DebugElementData {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__DebugElementData)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index)))
 	}
-	context.layoutElementChildrenBuffer.Length -= int32(openLayoutElement.ChildrenOrTextContent.Children.Length)
-	if layoutConfig.Sizing.Width.Type != __SIZING_TYPE_PERCENT {
-		if layoutConfig.Sizing.Width.Size.MinMax.Max <= 0 {
-			layoutConfig.Sizing.Width.Size.MinMax.Max = __MAXFLOAT
-		}
-		if (func() float32 {
-			if openLayoutElement.Dimensions.Width > layoutConfig.Sizing.Width.Size.MinMax.Min {
-				return openLayoutElement.Dimensions.Width
-			}
-			return layoutConfig.Sizing.Width.Size.MinMax.Min
-		}()) < layoutConfig.Sizing.Width.Size.MinMax.Max {
-			if openLayoutElement.Dimensions.Width > layoutConfig.Sizing.Width.Size.MinMax.Min {
-				/* (006) */
-			} else {
-				openLayoutElement.Dimensions.Width = layoutConfig.Sizing.Width.Size.MinMax.Min
-			}
-		} else {
-			openLayoutElement.Dimensions.Width = layoutConfig.Sizing.Width.Size.MinMax.Max
-		}
-		if (func() float32 {
-			if openLayoutElement.MinDimensions.Width > layoutConfig.Sizing.Width.Size.MinMax.Min {
-				return openLayoutElement.MinDimensions.Width
-			}
-			return layoutConfig.Sizing.Width.Size.MinMax.Min
-		}()) < layoutConfig.Sizing.Width.Size.MinMax.Max {
-			if openLayoutElement.MinDimensions.Width > layoutConfig.Sizing.Width.Size.MinMax.Min {
-				/* (007) */
-			} else {
-				openLayoutElement.MinDimensions.Width = layoutConfig.Sizing.Width.Size.MinMax.Min
-			}
-		} else {
-			openLayoutElement.MinDimensions.Width = layoutConfig.Sizing.Width.Size.MinMax.Max
-		}
-	} else {
-		openLayoutElement.Dimensions.Width = 0
+	return &__DebugElementData_DEFAULT
+}
+
+func __DebugElementDataArray_RemoveSwapback(array *__DebugElementDataArray, index int32) __DebugElementData {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __DebugElementData = *(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index)))
+		*(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index))) = *(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(array.Length)))
+		return removed
 	}
-	if layoutConfig.Sizing.Height.Type != __SIZING_TYPE_PERCENT {
-		if layoutConfig.Sizing.Height.Size.MinMax.Max <= 0 {
-			layoutConfig.Sizing.Height.Size.MinMax.Max = __MAXFLOAT
-		}
-		if (func() float32 {
-			if openLayoutElement.Dimensions.Height > layoutConfig.Sizing.Height.Size.MinMax.Min {
-				return openLayoutElement.Dimensions.Height
-			}
-			return layoutConfig.Sizing.Height.Size.MinMax.Min
-		}()) < layoutConfig.Sizing.Height.Size.MinMax.Max {
-			if openLayoutElement.Dimensions.Height > layoutConfig.Sizing.Height.Size.MinMax.Min {
-				/* (004) */
-			} else {
-				openLayoutElement.Dimensions.Height = layoutConfig.Sizing.Height.Size.MinMax.Min
-			}
-		} else {
-			openLayoutElement.Dimensions.Height = layoutConfig.Sizing.Height.Size.MinMax.Max
-		}
-		if (func() float32 {
-			if openLayoutElement.MinDimensions.Height > layoutConfig.Sizing.Height.Size.MinMax.Min {
-				return openLayoutElement.MinDimensions.Height
-			}
-			return layoutConfig.Sizing.Height.Size.MinMax.Min
-		}()) < layoutConfig.Sizing.Height.Size.MinMax.Max {
-			if openLayoutElement.MinDimensions.Height > layoutConfig.Sizing.Height.Size.MinMax.Min {
-				/* (005) */
-			} else {
-				openLayoutElement.MinDimensions.Height = layoutConfig.Sizing.Height.Size.MinMax.Min
-			}
+	return __DebugElementData_DEFAULT
+}
+
+func __DebugElementDataArray_Set(array *__DebugElementDataArray, index int32, value __DebugElementData) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__DebugElementData)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__DebugElementData{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
 		} else {
-			openLayoutElement.MinDimensions.Height = layoutConfig.Sizing.Height.Size.MinMax.Max
+			array.Length = index + 1
 		}
-	} else {
-		openLayoutElement.Dimensions.Height = 0
-	}
-	__UpdateAspectRatioBox(openLayoutElement)
-	var elementIsFloating bool = __ElementHasConfig(openLayoutElement, __ELEMENT_CONFIG_TYPE_FLOATING)
-	var closingElementIndex int32 = __int32_tArray_RemoveSwapback(&context.openLayoutElementStack, context.openLayoutElementStack.Length-1)
-	openLayoutElement = __GetOpenLayoutElement()
-	if !elementIsFloating && context.openLayoutElementStack.Length > 1 {
-		openLayoutElement.ChildrenOrTextContent.Children.Length++
-		__int32_tArray_Add(&context.layoutElementChildrenBuffer, closingElementIndex)
 	}
 }
 
-func __MemCmp(s1 *byte, s2 *byte, length int32) bool {
-	for i := int32(0); i < length; i++ {
-		if *(*byte)(unsafe.Add(unsafe.Pointer(s1), i)) != *(*byte)(unsafe.Add(unsafe.Pointer(s2), i)) {
-			return false
-		}
-	}
-	return true
+type LayoutElementHashMapItem struct {
+	BoundingBox               BoundingBox
+	ElementId                 ElementId
+	LayoutElement             *LayoutElement
+	OnHoverFunction           func(elementId ElementId, pointerInfo PointerData, userData int64)
+	HoverFunctionUserData     any
+	OnKeyFunction             func(elementId ElementId, keyEvent KeyEvent, userData any) bool
+	KeyFunctionUserData       any
+	OnLifecycleFunction       func(elementId ElementId, event LifecycleEvent, visibility ElementVisibility, userData any)
+	LifecycleFunctionUserData any
+	Visibility                ElementVisibility
+	PreviousVisibility        ElementVisibility
+	Appeared                  bool
+	NextIndex                 int32
+	Generation                uint32
+	DebugData                 *__DebugElementData
+	TransformOffset           Vector2
+	TransformScale            float32
+	Transform                 Matrix2D
+}
+type __LayoutElementHashMapItemArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *LayoutElementHashMapItem
+}
+type __LayoutElementHashMapItemArraySlice struct {
+	Length        int32
+	InternalArray *LayoutElementHashMapItem
 }
 
-func __OpenElement() {
-	var context *Context = GetCurrentContext()
-	if context.layoutElements.Length == context.layoutElements.Capacity-1 || context.booleanWarnings.MaxElementsExceeded {
-		context.booleanWarnings.MaxElementsExceeded = true
-		return
-	}
-	var layoutElement LayoutElement = LayoutElement{}
-	var openLayoutElement *LayoutElement = LayoutElementArray_Add(&context.layoutElements, layoutElement)
-	__int32_tArray_Add(&context.openLayoutElementStack, context.layoutElements.Length-1)
-	__GenerateIdForAnonymousElement(openLayoutElement)
-	if context.openClipElementStack.Length > 0 {
-		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, __int32_tArray_GetValue(&context.openClipElementStack, context.openClipElementStack.Length-1))
-	} else {
-		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, 0)
-	}
+var LayoutElementHashMapItem_DEFAULT LayoutElementHashMapItem = LayoutElementHashMapItem{}
+
+func __LayoutElementHashMapItemArray_Allocate_Arena(capacity int32, arena *Arena) __LayoutElementHashMapItemArray {
+	return __LayoutElementHashMapItemArray{Capacity: capacity, Length: 0, InternalArray: (*LayoutElementHashMapItem)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(LayoutElementHashMapItem{})), arena))}
 }
 
-func __OpenElementWithId(elementId ElementId) {
-	var context *Context = GetCurrentContext()
-	if context.layoutElements.Length == context.layoutElements.Capacity-1 || context.booleanWarnings.MaxElementsExceeded {
-		context.booleanWarnings.MaxElementsExceeded = true
-		return
+func __LayoutElementHashMapItemArray_Get(array *__LayoutElementHashMapItemArray, index int32) *LayoutElementHashMapItem {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index)))
 	}
-	var layoutElement LayoutElement = LayoutElement{}
-	layoutElement.Id = elementId.Id
-	var openLayoutElement *LayoutElement = LayoutElementArray_Add(&context.layoutElements, layoutElement)
-	__int32_tArray_Add(&context.openLayoutElementStack, context.layoutElements.Length-1)
-	__AddHashMapItem(elementId, openLayoutElement)
-	__StringArray_Add(&context.layoutElementIdStrings, elementId.StringId)
-	if context.openClipElementStack.Length > 0 {
-		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, __int32_tArray_GetValue(&context.openClipElementStack, context.openClipElementStack.Length-1))
-	} else {
-		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, 0)
+	return &LayoutElementHashMapItem_DEFAULT
+}
+
+func __LayoutElementHashMapItemArray_GetValue(array *__LayoutElementHashMapItemArray, index int32) LayoutElementHashMapItem {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index)))
 	}
+	return LayoutElementHashMapItem_DEFAULT
 }
 
-func __OpenTextElement(text String, textConfig *TextElementConfig) {
-	var context *Context = GetCurrentContext()
-	if context.layoutElements.Length == context.layoutElements.Capacity-1 || context.booleanWarnings.MaxElementsExceeded {
-		context.booleanWarnings.MaxElementsExceeded = true
-		return
-	}
-	var parentElement *LayoutElement = __GetOpenLayoutElement()
-	var layoutElement LayoutElement = LayoutElement{}
-	var textElement *LayoutElement = LayoutElementArray_Add(&context.layoutElements, layoutElement)
-	if context.openClipElementStack.Length > 0 {
-		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, __int32_tArray_GetValue(&context.openClipElementStack, context.openClipElementStack.Length-1))
-	} else {
-		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, 0)
+func __LayoutElementHashMapItemArray_Add(array *__LayoutElementHashMapItemArray, item LayoutElementHashMapItem) *LayoutElementHashMapItem {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(array.Length-1)))
 	}
-	__int32_tArray_Add(&context.layoutElementChildrenBuffer, context.layoutElements.Length-1)
-	var textMeasured *__MeasureTextCacheItem = __MeasureTextCached(&text, textConfig)
-	var elementId ElementId = __HashNumber(uint32(parentElement.ChildrenOrTextContent.Children.Length), parentElement.Id)
-	textElement.Id = elementId.Id
-	__AddHashMapItem(elementId, textElement)
-	__StringArray_Add(&context.layoutElementIdStrings, elementId.StringId)
-	var textDimensions Dimensions = Dimensions{Width: textMeasured.UnwrappedDimensions.Width, Height: func() float32 {
-		if int32(textConfig.LineHeight) > 0 {
-			return float32(textConfig.LineHeight)
-		}
-		return textMeasured.UnwrappedDimensions.Height
-	}()}
-	textElement.Dimensions = textDimensions
-	textElement.MinDimensions = Dimensions{Width: textMeasured.MinWidth, Height: textDimensions.Height}
-	textElement.ChildrenOrTextContent.TextElementData = __TextElementDataArray_Add(&context.textElementData, __TextElementData{Text: text, PreferredDimensions: textMeasured.UnwrappedDimensions, ElementIndex: context.layoutElements.Length - 1})
-	textElement.ElementConfigs = __ElementConfigArraySlice{Length: 1, InternalArray: __ElementConfigArray_Add(&context.elementConfigs, ElementConfig{Type: __ELEMENT_CONFIG_TYPE_TEXT, Config: ElementConfigUnion{TextElementConfig: textConfig}})}
-	textElement.LayoutConfig = &LAYOUT_DEFAULT
-	parentElement.ChildrenOrTextContent.Children.Length++
+	return &LayoutElementHashMapItem_DEFAULT
 }
 
-func __ConfigureOpenElementPtr(declaration *ElementDeclaration) {
-	var (
-		context           *Context       = GetCurrentContext()
-		openLayoutElement *LayoutElement = __GetOpenLayoutElement()
-	)
-	openLayoutElement.LayoutConfig = __StoreLayoutConfig(declaration.Layout)
-	if declaration.Layout.Sizing.Width.Type == __SIZING_TYPE_PERCENT && declaration.Layout.Sizing.Width.Size.Percent > 1 || declaration.Layout.Sizing.Height.Type == __SIZING_TYPE_PERCENT && declaration.Layout.Sizing.Height.Size.Percent > 1 {
-		context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_PERCENTAGE_OVER_1, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("An element was configured with SIZING_PERCENT, but the provided percentage value was over 1.0. Clay expects a value between 0 and 1, i.e. 20% is 0.2.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("An element was configured with SIZING_PERCENT, but the provided percentage value was over 1.0. Clay expects a value between 0 and 1, i.e. 20% is 0.2.")}, UserData: context.errorHandler.UserData})
+func __LayoutElementHashMapItemArraySlice_Get(slice *__LayoutElementHashMapItemArraySlice, index int32) *LayoutElementHashMapItem {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index)))
 	}
-	openLayoutElement.ElementConfigs.InternalArray = (*ElementConfig)(unsafe.Add(unsafe.Pointer(context.elementConfigs.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(context.elementConfigs.Length)))
-	var sharedConfig *SharedElementConfig = nil
-	if declaration.BackgroundColor.A > 0 {
-		sharedConfig = __StoreSharedElementConfig(SharedElementConfig{BackgroundColor: declaration.BackgroundColor})
-		__AttachElementConfig(ElementConfigUnion{SharedElementConfig: sharedConfig}, __ELEMENT_CONFIG_TYPE_SHARED)
+	return &LayoutElementHashMapItem_DEFAULT
+}
+
+func __LayoutElementHashMapItemArray_RemoveSwapback(array *__LayoutElementHashMapItemArray, index int32) LayoutElementHashMapItem {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed LayoutElementHashMapItem = *(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index)))
+		*(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index))) = *(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(array.Length)))
+		return removed
 	}
-	if !__MemCmp((*byte)(unsafe.Pointer(&declaration.CornerRadius)), (*byte)(unsafe.Pointer(&__CornerRadius_DEFAULT)), int32(uint32(unsafe.Sizeof(CornerRadius{})))) {
-		if sharedConfig != nil {
-			sharedConfig.CornerRadius = declaration.CornerRadius
+	return LayoutElementHashMapItem_DEFAULT
+}
+
+func __LayoutElementHashMapItemArray_Set(array *__LayoutElementHashMapItemArray, index int32, value LayoutElementHashMapItem) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*LayoutElementHashMapItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(LayoutElementHashMapItem{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
 		} else {
-			sharedConfig = __StoreSharedElementConfig(SharedElementConfig{CornerRadius: declaration.CornerRadius})
-			__AttachElementConfig(ElementConfigUnion{SharedElementConfig: sharedConfig}, __ELEMENT_CONFIG_TYPE_SHARED)
+			array.Length = index + 1
 		}
 	}
-	if declaration.UserData != nil {
-		if sharedConfig != nil {
-			sharedConfig.UserData = declaration.UserData
-		} else {
-			sharedConfig = __StoreSharedElementConfig(SharedElementConfig{UserData: declaration.UserData})
-			__AttachElementConfig(ElementConfigUnion{SharedElementConfig: sharedConfig}, __ELEMENT_CONFIG_TYPE_SHARED)
-		}
+}
+
+type __MeasuredWord struct {
+	StartOffset int32
+	Length      int32
+	Width       float32
+	Next        int32
+}
+type __MeasuredWordArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__MeasuredWord
+}
+type __MeasuredWordArraySlice struct {
+	Length        int32
+	InternalArray *__MeasuredWord
+}
+
+var __MeasuredWord_DEFAULT __MeasuredWord = __MeasuredWord{}
+
+func __MeasuredWordArray_Allocate_Arena(capacity int32, arena *Arena) __MeasuredWordArray {
+	return __MeasuredWordArray{Capacity: capacity, Length: 0, InternalArray: (*__MeasuredWord)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__MeasuredWord{})), arena))}
+}
+
+func __MeasuredWordArray_Get(array *__MeasuredWordArray, index int32) *__MeasuredWord {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index)))
 	}
-	if declaration.Image.ImageData != nil {
-		__AttachElementConfig(ElementConfigUnion{ImageElementConfig: __StoreImageElementConfig(declaration.Image)}, __ELEMENT_CONFIG_TYPE_IMAGE)
+	return &__MeasuredWord_DEFAULT
+}
+
+func __MeasuredWordArray_GetValue(array *__MeasuredWordArray, index int32) __MeasuredWord {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index)))
 	}
-	if declaration.AspectRatio.AspectRatio > 0 {
-		__AttachElementConfig(ElementConfigUnion{AspectRatioElementConfig: __StoreAspectRatioElementConfig(declaration.AspectRatio)}, __ELEMENT_CONFIG_TYPE_ASPECT)
-		__int32_tArray_Add(&context.AspectRatioElementIndexes, context.layoutElements.Length-1)
+	return __MeasuredWord_DEFAULT
+}
+
+func __MeasuredWordArray_Add(array *__MeasuredWordArray, item __MeasuredWord) *__MeasuredWord {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(array.Length-1)))
 	}
-	if declaration.Floating.AttachTo != ATTACH_TO_NONE {
-		var (
-			floatingConfig     FloatingElementConfig = declaration.Floating
-			hierarchicalParent *LayoutElement        = LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-2))
-		)
-		if hierarchicalParent != nil {
-			var clipElementId uint32 = 0
-			if declaration.Floating.AttachTo == ATTACH_TO_PARENT {
-				floatingConfig.ParentId = hierarchicalParent.Id
-				if context.openClipElementStack.Length > 0 {
-					clipElementId = uint32(__int32_tArray_GetValue(&context.openClipElementStack, context.openClipElementStack.Length-1))
-				}
-			} else if declaration.Floating.AttachTo == ATTACH_TO_ELEMENT_WITH_ID {
-				var parentItem *LayoutElementHashMapItem = __GetHashMapItem(floatingConfig.ParentId)
-				if parentItem == &LayoutElementHashMapItem_DEFAULT {
-					context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_FLOATING_CONTAINER_PARENT_NOT_FOUND, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("A floating element was declared with a parentId, but no element with that ID was found.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("A floating element was declared with a parentId, but no element with that ID was found.")}, UserData: context.errorHandler.UserData})
-				} else {
-					clipElementId = uint32(__int32_tArray_GetValue(&context.layoutElementClipElementIds, int32(int64((uintptr(unsafe.Pointer(parentItem.LayoutElement))-uintptr(unsafe.Pointer(context.layoutElements.InternalArray)))/unsafe.Sizeof(LayoutElement{})))))
-				}
-			} else if declaration.Floating.AttachTo == ATTACH_TO_ROOT {
-				floatingConfig.ParentId = __HashString(String{IsStaticallyAllocated: true, Length: int32(((len("__RootContainer") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("__RootContainer")}, 0).Id
-			}
-			if declaration.Floating.ClipTo == CLIP_TO_NONE {
-				clipElementId = 0
-			}
-			var currentElementIndex int32 = __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-1)
-			__int32_tArray_Set(&context.layoutElementClipElementIds, currentElementIndex, int32(clipElementId))
-			__int32_tArray_Add(&context.openClipElementStack, int32(clipElementId))
-			__LayoutElementTreeRootArray_Add(&context.layoutElementTreeRoots, __LayoutElementTreeRoot{LayoutElementIndex: __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-1), ParentId: floatingConfig.ParentId, ClipElementId: clipElementId, ZIndex: floatingConfig.ZIndex})
-			__AttachElementConfig(ElementConfigUnion{FloatingElementConfig: __StoreFloatingElementConfig(floatingConfig)}, __ELEMENT_CONFIG_TYPE_FLOATING)
-		}
+	return &__MeasuredWord_DEFAULT
+}
+
+func __MeasuredWordArraySlice_Get(slice *__MeasuredWordArraySlice, index int32) *__MeasuredWord {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__MeasuredWord)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index)))
 	}
-	if declaration.Custom.CustomData != nil {
-		__AttachElementConfig(ElementConfigUnion{CustomElementConfig: __StoreCustomElementConfig(declaration.Custom)}, __ELEMENT_CONFIG_TYPE_CUSTOM)
+	return &__MeasuredWord_DEFAULT
+}
+
+func __MeasuredWordArray_RemoveSwapback(array *__MeasuredWordArray, index int32) __MeasuredWord {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __MeasuredWord = *(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index)))
+		*(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index))) = *(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(array.Length)))
+		return removed
 	}
-	if declaration.Clip.Horizontal || declaration.Clip.Vertical {
-		__AttachElementConfig(ElementConfigUnion{ClipElementConfig: __StoreClipElementConfig(declaration.Clip)}, __ELEMENT_CONFIG_TYPE_CLIP)
-		__int32_tArray_Add(&context.openClipElementStack, int32(openLayoutElement.Id))
-		var scrollOffset *__ScrollContainerDataInternal = (*__ScrollContainerDataInternal)(nil)
-		for i := int32(0); i < context.scrollContainerDatas.Length; i++ {
-			var mapping *__ScrollContainerDataInternal = __ScrollContainerDataInternalArray_Get(&context.scrollContainerDatas, i)
-			if openLayoutElement.Id == mapping.ElementId {
-				scrollOffset = mapping
-				scrollOffset.LayoutElement = openLayoutElement
-				scrollOffset.OpenThisFrame = true
-			}
-		}
-		if scrollOffset == nil {
-			scrollOffset = __ScrollContainerDataInternalArray_Add(&context.scrollContainerDatas, __ScrollContainerDataInternal{LayoutElement: openLayoutElement, ScrollOrigin: Vector2{X: -1, Y: -1}, ElementId: openLayoutElement.Id, OpenThisFrame: true})
-		}
-		if context.externalScrollHandlingEnabled {
-			scrollOffset.ScrollPosition = __QueryScrollOffset(scrollOffset.ElementId, context.queryScrollOffsetUserData.(unsafe.Pointer))
+	return __MeasuredWord_DEFAULT
+}
+
+func __MeasuredWordArray_Set(array *__MeasuredWordArray, index int32, value __MeasuredWord) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__MeasuredWord)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasuredWord{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
+		} else {
+			array.Length = index + 1
 		}
 	}
-	if !__MemCmp((*byte)(unsafe.Pointer(&declaration.Border.Width)), (*byte)(unsafe.Pointer(&__BorderWidth_DEFAULT)), int32(uint32(unsafe.Sizeof(BorderWidth{})))) {
-		__AttachElementConfig(ElementConfigUnion{BorderElementConfig: __StoreBorderElementConfig(declaration.Border)}, __ELEMENT_CONFIG_TYPE_BORDER)
+}
+
+type __MeasureTextCacheItem struct {
+	UnwrappedDimensions     Dimensions
+	MeasuredWordsStartIndex int32
+	MinWidth                float32
+	ContainsNewlines        bool
+	Id                      uint32
+	NextIndex               int32
+	Generation              uint32
+}
+type __MeasureTextCacheItemArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__MeasureTextCacheItem
+}
+type __MeasureTextCacheItemArraySlice struct {
+	Length        int32
+	InternalArray *__MeasureTextCacheItem
+}
+
+var __MeasureTextCacheItem_DEFAULT __MeasureTextCacheItem = __MeasureTextCacheItem{}
+
+func __MeasureTextCacheItemArray_Allocate_Arena(capacity int32, arena *Arena) __MeasureTextCacheItemArray {
+	return __MeasureTextCacheItemArray{Capacity: capacity, Length: 0, InternalArray: (*__MeasureTextCacheItem)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__MeasureTextCacheItem{})), arena))}
+}
+
+func __MeasureTextCacheItemArray_Get(array *__MeasureTextCacheItemArray, index int32) *__MeasureTextCacheItem {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index)))
 	}
+	return &__MeasureTextCacheItem_DEFAULT
 }
 
-func __ConfigureOpenElement(declaration ElementDeclaration) {
-	__ConfigureOpenElementPtr(&declaration)
+func __MeasureTextCacheItemArray_GetValue(array *__MeasureTextCacheItemArray, index int32) __MeasureTextCacheItem {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index)))
+	}
+	return __MeasureTextCacheItem_DEFAULT
 }
 
-func __InitializeEphemeralMemory(context *Context) {
-	var (
-		maxElementCount int32  = context.maxElementCount
-		arena           *Arena = &context.internalArena
-	)
-	arena.NextAllocation = context.arenaResetOffset
+func __MeasureTextCacheItemArray_Add(array *__MeasureTextCacheItemArray, item __MeasureTextCacheItem) *__MeasureTextCacheItem {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(array.Length-1)))
+	}
+	return &__MeasureTextCacheItem_DEFAULT
+}
+
+func __MeasureTextCacheItemArraySlice_Get(slice *__MeasureTextCacheItemArraySlice, index int32) *__MeasureTextCacheItem {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index)))
+	}
+	return &__MeasureTextCacheItem_DEFAULT
+}
+
+func __MeasureTextCacheItemArray_RemoveSwapback(array *__MeasureTextCacheItemArray, index int32) __MeasureTextCacheItem {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __MeasureTextCacheItem = *(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index)))
+		*(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index))) = *(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(array.Length)))
+		return removed
+	}
+	return __MeasureTextCacheItem_DEFAULT
+}
+
+func __MeasureTextCacheItemArray_Set(array *__MeasureTextCacheItemArray, index int32, value __MeasureTextCacheItem) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__MeasureTextCacheItem)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__MeasureTextCacheItem{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
+		} else {
+			array.Length = index + 1
+		}
+	}
+}
+
+type __LayoutElementTreeNode struct {
+	LayoutElement         *LayoutElement
+	Position              Vector2
+	NextChildOffset       Vector2
+	TransformOffset       Vector2
+	TransformScale        float32
+	Transform             Matrix2D
+	ParentOrigin          Vector2
+	TransitionOffset      Vector2
+	ChildTransitionOffset Vector2
+	Opacity               float32
+	RenderCommandStart    int32
+	ClipBoundingBox       BoundingBox
+}
+type __LayoutElementTreeNodeArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__LayoutElementTreeNode
+}
+type __LayoutElementTreeNodeArraySlice struct {
+	Length        int32
+	InternalArray *__LayoutElementTreeNode
+}
+
+var __LayoutElementTreeNode_DEFAULT __LayoutElementTreeNode = __LayoutElementTreeNode{}
+
+func __LayoutElementTreeNodeArray_Allocate_Arena(capacity int32, arena *Arena) __LayoutElementTreeNodeArray {
+	return __LayoutElementTreeNodeArray{Capacity: capacity, Length: 0, InternalArray: (*__LayoutElementTreeNode)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__LayoutElementTreeNode{})), arena))}
+}
+
+func __LayoutElementTreeNodeArray_Get(array *__LayoutElementTreeNodeArray, index int32) *__LayoutElementTreeNode {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index)))
+	}
+	return &__LayoutElementTreeNode_DEFAULT
+}
+
+func __LayoutElementTreeNodeArray_GetValue(array *__LayoutElementTreeNodeArray, index int32) __LayoutElementTreeNode {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index)))
+	}
+	return __LayoutElementTreeNode_DEFAULT
+}
+
+func __LayoutElementTreeNodeArray_Add(array *__LayoutElementTreeNodeArray, item __LayoutElementTreeNode) *__LayoutElementTreeNode {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(array.Length-1)))
+	}
+	return &__LayoutElementTreeNode_DEFAULT
+}
+
+func __LayoutElementTreeNodeArraySlice_Get(slice *__LayoutElementTreeNodeArraySlice, index int32) *__LayoutElementTreeNode {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index)))
+	}
+	return &__LayoutElementTreeNode_DEFAULT
+}
+
+func __LayoutElementTreeNodeArray_RemoveSwapback(array *__LayoutElementTreeNodeArray, index int32) __LayoutElementTreeNode {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __LayoutElementTreeNode = *(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index)))
+		*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index))) = *(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(array.Length)))
+		return removed
+	}
+	return __LayoutElementTreeNode_DEFAULT
+}
+
+func __LayoutElementTreeNodeArray_Set(array *__LayoutElementTreeNodeArray, index int32, value __LayoutElementTreeNode) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
+		} else {
+			array.Length = index + 1
+		}
+	}
+}
+
+type __LayoutElementTreeRoot struct {
+	LayoutElementIndex int32
+	ParentId           uint32
+	ClipElementId      uint32
+	ZIndex             int16
+	PointerOffset      Vector2
+}
+type __LayoutElementTreeRootArray struct {
+	Capacity      int32
+	Length        int32
+	InternalArray *__LayoutElementTreeRoot
+}
+type __LayoutElementTreeRootArraySlice struct {
+	Length        int32
+	InternalArray *__LayoutElementTreeRoot
+}
+
+var __LayoutElementTreeRoot_DEFAULT __LayoutElementTreeRoot = __LayoutElementTreeRoot{}
+
+func __LayoutElementTreeRootArray_Allocate_Arena(capacity int32, arena *Arena) __LayoutElementTreeRootArray {
+	return __LayoutElementTreeRootArray{Capacity: capacity, Length: 0, InternalArray: (*__LayoutElementTreeRoot)(__Array_Allocate_Arena(capacity, uint32(unsafe.Sizeof(__LayoutElementTreeRoot{})), arena))}
+}
+
+func __LayoutElementTreeRootArray_Get(array *__LayoutElementTreeRootArray, index int32) *__LayoutElementTreeRoot {
+	if __Array_RangeCheck(index, array.Length) {
+		return (*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index)))
+	}
+	return &__LayoutElementTreeRoot_DEFAULT
+}
+
+func __LayoutElementTreeRootArray_GetValue(array *__LayoutElementTreeRootArray, index int32) __LayoutElementTreeRoot {
+	if __Array_RangeCheck(index, array.Length) {
+		return *(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index)))
+	}
+	return __LayoutElementTreeRoot_DEFAULT
+}
+
+func __LayoutElementTreeRootArray_Add(array *__LayoutElementTreeRootArray, item __LayoutElementTreeRoot) *__LayoutElementTreeRoot {
+	if __Array_AddCapacityCheck(array.Length, array.Capacity) {
+		*(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(func() int32 {
+			p_ := &array.Length
+			x := *p_
+			*p_++
+			return x
+		}()))) = item
+		return (*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(array.Length-1)))
+	}
+	return &__LayoutElementTreeRoot_DEFAULT
+}
+
+func __LayoutElementTreeRootArraySlice_Get(slice *__LayoutElementTreeRootArraySlice, index int32) *__LayoutElementTreeRoot {
+	if __Array_RangeCheck(index, slice.Length) {
+		return (*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(slice.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index)))
+	}
+	return &__LayoutElementTreeRoot_DEFAULT
+}
+
+func __LayoutElementTreeRootArray_RemoveSwapback(array *__LayoutElementTreeRootArray, index int32) __LayoutElementTreeRoot {
+	if __Array_RangeCheck(index, array.Length) {
+		array.Length--
+		var removed __LayoutElementTreeRoot = *(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index)))
+		*(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index))) = *(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(array.Length)))
+		return removed
+	}
+	return __LayoutElementTreeRoot_DEFAULT
+}
+
+func __LayoutElementTreeRootArray_Set(array *__LayoutElementTreeRootArray, index int32, value __LayoutElementTreeRoot) {
+	if __Array_RangeCheck(index, array.Capacity) {
+		*(*__LayoutElementTreeRoot)(unsafe.Add(unsafe.Pointer(array.InternalArray), unsafe.Sizeof(__LayoutElementTreeRoot{})*uintptr(index))) = value
+		if index < array.Length {
+			/* (001) */
+		} else {
+			array.Length = index + 1
+		}
+	}
+}
+
+func __Context_Allocate_Arena(arena *Arena) *Context {
+	var totalSizeBytes uint64 = uint64(unsafe.Sizeof(Context{}))
+	if totalSizeBytes > arena.Capacity {
+		return nil
+	}
+	arena.NextAllocation += totalSizeBytes
+	return (*Context)(unsafe.Pointer(arena.Memory))
+}
+
+func __WriteStringToCharBuffer(buffer *__charArray, string_ String) String {
+	for i := int32(0); i < string_.Length; i++ {
+		*(*byte)(unsafe.Add(unsafe.Pointer(buffer.InternalArray), buffer.Length+i)) = *(*byte)(unsafe.Add(unsafe.Pointer(string_.Chars), i))
+	}
+	buffer.Length += string_.Length
+	return String{Length: string_.Length, Chars: ((*byte)(unsafe.Add(unsafe.Pointer((*byte)(unsafe.Add(unsafe.Pointer(buffer.InternalArray), buffer.Length))), -string_.Length)))}
+}
+
+func __WritePathCommandsToBuffer(buffer *__PathCommandArray, commands PathCommandArray) PathCommandArray {
+	if buffer.Length+commands.Length > buffer.Capacity {
+		return PathCommandArray{}
+	}
+	for i := int32(0); i < commands.Length; i++ {
+		*(*PathCommand)(unsafe.Add(unsafe.Pointer(buffer.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(buffer.Length+i))) = *(*PathCommand)(unsafe.Add(unsafe.Pointer(commands.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(i)))
+	}
+	buffer.Length += commands.Length
+	return PathCommandArray{Length: commands.Length, InternalArray: (*PathCommand)(unsafe.Add(unsafe.Pointer((*PathCommand)(unsafe.Add(unsafe.Pointer(buffer.InternalArray), unsafe.Sizeof(PathCommand{})*uintptr(buffer.Length)))), -int(unsafe.Sizeof(PathCommand{})*uintptr(commands.Length))))}
+}
+
+var (
+	__MeasureText       func(text StringSlice, config *TextElementConfig, userData unsafe.Pointer) Dimensions
+	__QueryScrollOffset func(elementId uint32, userData unsafe.Pointer) Vector2
+)
+
+func __GetOpenLayoutElement() *LayoutElement {
+	var context *Context = GetCurrentContext()
+	return LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-1))
+}
+
+func __GetParentElementId() uint32 {
+	var context *Context = GetCurrentContext()
+	return LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-2)).Id
+}
+
+func __StoreLayoutConfig(config LayoutConfig) *LayoutConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &LAYOUT_DEFAULT
+	}
+	return __LayoutConfigArray_Add(&GetCurrentContext().layoutConfigs, config)
+}
+
+func __StoreTextElementConfig(config TextElementConfig) *TextElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &TextElementConfig_DEFAULT
+	}
+	return __TextElementConfigArray_Add(&GetCurrentContext().textElementConfigs, config)
+}
+
+func __StoreAspectRatioElementConfig(config AspectRatioElementConfig) *AspectRatioElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &AspectRatioElementConfig_DEFAULT
+	}
+	return __AspectRatioElementConfigArray_Add(&GetCurrentContext().AspectRatioElementConfigs, config)
+}
+
+func __StoreImageElementConfig(config ImageElementConfig) *ImageElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &ImageElementConfig_DEFAULT
+	}
+	return __ImageElementConfigArray_Add(&GetCurrentContext().imageElementConfigs, config)
+}
+
+func __StoreFloatingElementConfig(config FloatingElementConfig) *FloatingElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &FloatingElementConfig_DEFAULT
+	}
+	return __FloatingElementConfigArray_Add(&GetCurrentContext().floatingElementConfigs, config)
+}
+
+func __StoreCustomElementConfig(config CustomElementConfig) *CustomElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &CustomElementConfig_DEFAULT
+	}
+	return __CustomElementConfigArray_Add(&GetCurrentContext().customElementConfigs, config)
+}
+
+func __StoreClipElementConfig(config ClipElementConfig) *ClipElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &ClipElementConfig_DEFAULT
+	}
+	return __ClipElementConfigArray_Add(&GetCurrentContext().ClipElementConfigs, config)
+}
+
+func __StoreBorderElementConfig(config BorderElementConfig) *BorderElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &BorderElementConfig_DEFAULT
+	}
+	return __BorderElementConfigArray_Add(&GetCurrentContext().borderElementConfigs, config)
+}
+
+func __StoreSharedElementConfig(config SharedElementConfig) *SharedElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &SharedElementConfig_DEFAULT
+	}
+	return __SharedElementConfigArray_Add(&GetCurrentContext().sharedElementConfigs, config)
+}
+
+func __StoreFocusElementConfig(config FocusElementConfig) *FocusElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &FocusElementConfig_DEFAULT
+	}
+	return __FocusElementConfigArray_Add(&GetCurrentContext().focusElementConfigs, config)
+}
+
+func __GetTransitionData(elementId uint32) *__TransitionDataInternal {
+	var context *Context = GetCurrentContext()
+	for i := int32(0); i < context.transitionDatas.Length; i++ {
+		var transitionData *__TransitionDataInternal = __TransitionDataInternalArray_Get(&context.transitionDatas, i)
+		if transitionData.ElementId == elementId {
+			return transitionData
+		}
+	}
+	return (*__TransitionDataInternal)(nil)
+}
+
+func __StoreTransitionElementConfig(config TransitionElementConfig) *TransitionElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &TransitionElementConfig_DEFAULT
+	}
+	return __TransitionElementConfigArray_Add(&GetCurrentContext().transitionElementConfigs, config)
+}
+
+func __StoreShadowElementConfig(config ShadowElementConfig) *ShadowElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &ShadowElementConfig_DEFAULT
+	}
+	return __ShadowElementConfigArray_Add(&GetCurrentContext().shadowElementConfigs, config)
+}
+
+func __StoreOpacityElementConfig(config OpacityElementConfig) *OpacityElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &OpacityElementConfig_DEFAULT
+	}
+	return __OpacityElementConfigArray_Add(&GetCurrentContext().opacityElementConfigs, config)
+}
+
+func __StoreTransformElementConfig(config TransformElementConfig) *TransformElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &TransformElementConfig_DEFAULT
+	}
+	return __TransformElementConfigArray_Add(&GetCurrentContext().transformElementConfigs, config)
+}
+
+func __StorePathElementConfig(config PathElementConfig) *PathElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &PathElementConfig_DEFAULT
+	}
+	return __PathElementConfigArray_Add(&GetCurrentContext().pathElementConfigs, config)
+}
+
+func __StoreZoomPanElementConfig(config ZoomPanElementConfig) *ZoomPanElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &ZoomPanElementConfig_DEFAULT
+	}
+	return __ZoomPanElementConfigArray_Add(&GetCurrentContext().zoomPanElementConfigs, config)
+}
+
+func __StoreStickyElementConfig(config StickyElementConfig) *StickyElementConfig {
+	if GetCurrentContext().booleanWarnings.MaxElementsExceeded {
+		return &StickyElementConfig_DEFAULT
+	}
+	return __StickyElementConfigArray_Add(&GetCurrentContext().stickyElementConfigs, config)
+}
+
+func __AttachElementConfig(config ElementConfigUnion, type_ __ElementConfigType) ElementConfig {
+	var context *Context = GetCurrentContext()
+	if context.booleanWarnings.MaxElementsExceeded {
+		return ElementConfig{}
+	}
+	var openLayoutElement *LayoutElement = __GetOpenLayoutElement()
+	openLayoutElement.ElementConfigs.Length++
+	return *__ElementConfigArray_Add(&context.elementConfigs, ElementConfig{Type: type_, Config: config})
+}
+
+func __FindElementConfigWithType(element *LayoutElement, type_ __ElementConfigType) ElementConfigUnion {
+	for i := int32(0); i < element.ElementConfigs.Length; i++ {
+		var config *ElementConfig = __ElementConfigArraySlice_Get(&element.ElementConfigs, i)
+		if config.Type == type_ {
+			return config.Config
+		}
+	}
+	return ElementConfigUnion{}
+}
+
+func __HashNumber(offset uint32, seed uint32) ElementId {
+	var hash uint32 = seed
+	hash += offset + 48
+	hash += hash << 10
+	hash ^= hash >> 6
+	hash += hash << 3
+	hash ^= hash >> 11
+	hash += hash << 15
+	return ElementId{Id: hash + 1, Offset: offset, BaseId: seed, StringId: __STRING_DEFAULT}
+}
+
+func __HashString(key String, seed uint32) ElementId {
+	var hash uint32 = seed
+	for i := int32(0); i < key.Length; i++ {
+		hash += uint32(*(*byte)(unsafe.Add(unsafe.Pointer(key.Chars), i)))
+		hash += hash << 10
+		hash ^= hash >> 6
+	}
+	hash += hash << 3
+	hash ^= hash >> 11
+	hash += hash << 15
+	return ElementId{Id: hash + 1, Offset: 0, BaseId: hash + 1, StringId: key}
+}
+
+func __HashStringWithOffset(key String, offset uint32, seed uint32) ElementId {
+	var (
+		hash uint32 = 0
+		base uint32 = seed
+	)
+	for i := int32(0); i < key.Length; i++ {
+		base += uint32(*(*byte)(unsafe.Add(unsafe.Pointer(key.Chars), i)))
+		base += base << 10
+		base ^= base >> 6
+	}
+	hash = base
+	hash += offset
+	hash += hash << 10
+	hash ^= hash >> 6
+	hash += hash << 3
+	base += base << 3
+	hash ^= hash >> 11
+	base ^= base >> 11
+	hash += hash << 15
+	base += base << 15
+	return ElementId{Id: hash + 1, Offset: offset, BaseId: base + 1, StringId: key}
+}
+
+func __HashData(data *uint8, length uint64) uint64 {
+	var hash uint64 = 0
+	for i := uint64(0); i < length; i++ {
+		hash += uint64(*(*uint8)(unsafe.Add(unsafe.Pointer(data), i)))
+		hash += hash << 10
+		hash ^= hash >> 6
+	}
+	return hash
+}
+
+func __HashStringContentsWithConfig(text *String, config *TextElementConfig) uint32 {
+	var hash uint32 = 0
+	if text.IsStaticallyAllocated {
+		hash += uint32(uint64(uintptr(unsafe.Pointer(text.Chars))))
+		hash += hash << 10
+		hash ^= hash >> 6
+		hash += uint32(text.Length)
+		hash += hash << 10
+		hash ^= hash >> 6
+	} else {
+		hash = uint32(__HashData((*uint8)(unsafe.Pointer(text.Chars)), uint64(text.Length)) % math.MaxUint32)
+	}
+	hash += uint32(config.FontId)
+	hash += hash << 10
+	hash ^= hash >> 6
+	hash += uint32(config.FontSize)
+	hash += hash << 10
+	hash ^= hash >> 6
+	hash += uint32(config.LetterSpacing)
+	hash += hash << 10
+	hash ^= hash >> 6
+	hash += hash << 3
+	hash ^= hash >> 11
+	hash += hash << 15
+	return hash + 1
+}
+
+func __AddMeasuredWord(word __MeasuredWord, previousWord *__MeasuredWord) *__MeasuredWord {
+	var context *Context = GetCurrentContext()
+	if context.measuredWordsFreeList.Length > 0 {
+		var newItemIndex uint32 = uint32(__int32_tArray_GetValue(&context.measuredWordsFreeList, context.measuredWordsFreeList.Length-1))
+		context.measuredWordsFreeList.Length--
+		__MeasuredWordArray_Set(&context.measuredWords, int32(newItemIndex), word)
+		previousWord.Next = int32(newItemIndex)
+		return __MeasuredWordArray_Get(&context.measuredWords, int32(newItemIndex))
+	} else {
+		previousWord.Next = context.measuredWords.Length
+		return __MeasuredWordArray_Add(&context.measuredWords, word)
+	}
+}
+
+func __MeasureTextCached(text *String, config *TextElementConfig) *__MeasureTextCacheItem {
+	var context *Context = GetCurrentContext()
+	if __MeasureText == nil {
+		if !context.booleanWarnings.TextMeasurementFunctionNotSet {
+			context.booleanWarnings.TextMeasurementFunctionNotSet = true
+			context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_TEXT_MEASUREMENT_FUNCTION_NOT_PROVIDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay's internal MeasureText function is null. You may have forgotten to call SetMeasureTextFunction(), or passed a NULL function pointer by mistake.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay's internal MeasureText function is null. You may have forgotten to call SetMeasureTextFunction(), or passed a NULL function pointer by mistake.")}, UserData: context.errorHandler.UserData})
+		}
+		return &__MeasureTextCacheItem_DEFAULT
+	}
+	var id uint32 = __HashStringContentsWithConfig(text, config)
+	var hashBucket uint32 = id % uint32(context.maxMeasureTextCacheWordCount/32)
+	var elementIndexPrevious int32 = 0
+	var elementIndex int32 = *(*int32)(unsafe.Add(unsafe.Pointer(context.measureTextHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket)))
+	for elementIndex != 0 {
+		var hashEntry *__MeasureTextCacheItem = __MeasureTextCacheItemArray_Get(&context.measureTextHashMapInternal, elementIndex)
+		if hashEntry.Id == id {
+			hashEntry.Generation = context.generation
+			return hashEntry
+		}
+		if context.generation-hashEntry.Generation > 2 {
+			var nextWordIndex int32 = hashEntry.MeasuredWordsStartIndex
+			for nextWordIndex != -1 {
+				var measuredWord *__MeasuredWord = __MeasuredWordArray_Get(&context.measuredWords, nextWordIndex)
+				__int32_tArray_Add(&context.measuredWordsFreeList, nextWordIndex)
+				nextWordIndex = measuredWord.Next
+			}
+			var nextIndex int32 = hashEntry.NextIndex
+			__MeasureTextCacheItemArray_Set(&context.measureTextHashMapInternal, elementIndex, __MeasureTextCacheItem{MeasuredWordsStartIndex: -1})
+			__int32_tArray_Add(&context.measureTextHashMapInternalFreeList, elementIndex)
+			if elementIndexPrevious == 0 {
+				*(*int32)(unsafe.Add(unsafe.Pointer(context.measureTextHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket))) = nextIndex
+			} else {
+				var previousHashEntry *__MeasureTextCacheItem = __MeasureTextCacheItemArray_Get(&context.measureTextHashMapInternal, elementIndexPrevious)
+				previousHashEntry.NextIndex = nextIndex
+			}
+			elementIndex = nextIndex
+		} else {
+			elementIndexPrevious = elementIndex
+			elementIndex = hashEntry.NextIndex
+		}
+	}
+	var newItemIndex int32 = 0
+	var newCacheItem __MeasureTextCacheItem = __MeasureTextCacheItem{MeasuredWordsStartIndex: -1, Id: id, Generation: context.generation}
+	var measured *__MeasureTextCacheItem = nil
+	if context.measureTextHashMapInternalFreeList.Length > 0 {
+		newItemIndex = __int32_tArray_GetValue(&context.measureTextHashMapInternalFreeList, context.measureTextHashMapInternalFreeList.Length-1)
+		context.measureTextHashMapInternalFreeList.Length--
+		__MeasureTextCacheItemArray_Set(&context.measureTextHashMapInternal, newItemIndex, newCacheItem)
+		measured = __MeasureTextCacheItemArray_Get(&context.measureTextHashMapInternal, newItemIndex)
+	} else {
+		if context.measureTextHashMapInternal.Length == context.measureTextHashMapInternal.Capacity-1 {
+			if !context.booleanWarnings.MaxTextMeasureCacheExceeded {
+				context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay ran out of capacity while attempting to measure text elements. Try using SetMaxElementCount() with a higher value.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay ran out of capacity while attempting to measure text elements. Try using SetMaxElementCount() with a higher value.")}, UserData: context.errorHandler.UserData})
+				context.booleanWarnings.MaxTextMeasureCacheExceeded = true
+			}
+			return &__MeasureTextCacheItem_DEFAULT
+		}
+		measured = __MeasureTextCacheItemArray_Add(&context.measureTextHashMapInternal, newCacheItem)
+		newItemIndex = context.measureTextHashMapInternal.Length - 1
+	}
+	var start int32 = 0
+	var end int32 = 0
+	var lineWidth float32 = 0
+	var measuredWidth float32 = 0
+	var measuredHeight float32 = 0
+	var spaceWidth float32 = __MeasureText(StringSlice{Length: 1, Chars: __SPACECHAR.Chars, BaseChars: __SPACECHAR.Chars}, config, context.measureTextUserData.(unsafe.Pointer)).Width
+	var tempWord __MeasuredWord = __MeasuredWord{Next: -1}
+	var previousWord *__MeasuredWord = &tempWord
+	for end < text.Length {
+		if context.measuredWords.Length == context.measuredWords.Capacity-1 {
+			if !context.booleanWarnings.MaxTextMeasureCacheExceeded {
+				context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_TEXT_MEASUREMENT_CAPACITY_EXCEEDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay has run out of space in it's internal text measurement cache. Try using SetMaxMeasureTextCacheWordCount() (default 16384, with 1 unit storing 1 measured word).") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay has run out of space in it's internal text measurement cache. Try using SetMaxMeasureTextCacheWordCount() (default 16384, with 1 unit storing 1 measured word).")}, UserData: context.errorHandler.UserData})
+				context.booleanWarnings.MaxTextMeasureCacheExceeded = true
+			}
+			return &__MeasureTextCacheItem_DEFAULT
+		}
+		var current int8 = int8(*(*byte)(unsafe.Add(unsafe.Pointer(text.Chars), end)))
+		if int32(current) == ' ' || int32(current) == '\n' {
+			var (
+				length     int32      = end - start
+				dimensions Dimensions = Dimensions{}
+			)
+			if length > 0 {
+				dimensions = __MeasureText(StringSlice{Length: length, Chars: (*byte)(unsafe.Add(unsafe.Pointer(text.Chars), start)), BaseChars: text.Chars}, config, context.measureTextUserData.(unsafe.Pointer))
+			}
+			if dimensions.Width > measured.MinWidth {
+				measured.MinWidth = dimensions.Width
+			} else {
+				/* (022) */
+			}
+			if measuredHeight > dimensions.Height {
+				/* (002) */
+			} else {
+				measuredHeight = dimensions.Height
+			}
+			if int32(current) == ' ' {
+				dimensions.Width += spaceWidth
+				previousWord = __AddMeasuredWord(__MeasuredWord{StartOffset: start, Length: length + 1, Width: dimensions.Width, Next: -1}, previousWord)
+				lineWidth += dimensions.Width
+			}
+			if int32(current) == '\n' {
+				if length > 0 {
+					previousWord = __AddMeasuredWord(__MeasuredWord{StartOffset: start, Length: length, Width: dimensions.Width, Next: -1}, previousWord)
+				}
+				previousWord = __AddMeasuredWord(__MeasuredWord{StartOffset: end + 1, Length: 0, Width: 0, Next: -1}, previousWord)
+				lineWidth += dimensions.Width
+				if lineWidth > measuredWidth {
+					measuredWidth = lineWidth
+				} else {
+					/* (003) */
+				}
+				measured.ContainsNewlines = true
+				lineWidth = 0
+			}
+			start = end + 1
+		}
+		end++
+	}
+	if end-start > 0 {
+		var dimensions Dimensions = __MeasureText(StringSlice{Length: end - start, Chars: (*byte)(unsafe.Add(unsafe.Pointer(text.Chars), start)), BaseChars: text.Chars}, config, context.measureTextUserData.(unsafe.Pointer))
+		__AddMeasuredWord(__MeasuredWord{StartOffset: start, Length: end - start, Width: dimensions.Width, Next: -1}, previousWord)
+		lineWidth += dimensions.Width
+		if measuredHeight > dimensions.Height {
+			/* (002) */
+		} else {
+			measuredHeight = dimensions.Height
+		}
+		if dimensions.Width > measured.MinWidth {
+			measured.MinWidth = dimensions.Width
+		} else {
+			/* (022) */
+		}
+	}
+	measuredWidth = (func() float32 {
+		if lineWidth > measuredWidth {
+			return lineWidth
+		}
+		return measuredWidth
+	}()) - float32(config.LetterSpacing)
+	measured.MeasuredWordsStartIndex = tempWord.Next
+	measured.UnwrappedDimensions.Width = measuredWidth
+	measured.UnwrappedDimensions.Height = measuredHeight
+	if elementIndexPrevious != 0 {
+		__MeasureTextCacheItemArray_Get(&context.measureTextHashMapInternal, elementIndexPrevious).NextIndex = newItemIndex
+	} else {
+		*(*int32)(unsafe.Add(unsafe.Pointer(context.measureTextHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket))) = newItemIndex
+	}
+	return measured
+}
+
+func __PointIsInsideRect(point Vector2, rect BoundingBox) bool {
+	return point.X >= rect.X && point.X <= rect.X+rect.Width && point.Y >= rect.Y && point.Y <= rect.Y+rect.Height
+}
+
+func __AddHashMapItem(elementId ElementId, layoutElement *LayoutElement) *LayoutElementHashMapItem {
+	var context *Context = GetCurrentContext()
+	if context.layoutElementsHashMapInternal.Length == context.layoutElementsHashMapInternal.Capacity-1 {
+		return nil
+	}
+	var item LayoutElementHashMapItem = LayoutElementHashMapItem{ElementId: elementId, LayoutElement: layoutElement, NextIndex: -1, Generation: context.generation + 1, Appeared: true}
+	var hashBucket uint32 = elementId.Id % uint32(context.layoutElementsHashMap.Capacity)
+	var hashItemPrevious int32 = -1
+	var hashItemIndex int32 = *(*int32)(unsafe.Add(unsafe.Pointer(context.layoutElementsHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket)))
+	for hashItemIndex != -1 {
+		var hashItem *LayoutElementHashMapItem = __LayoutElementHashMapItemArray_Get(&context.layoutElementsHashMapInternal, hashItemIndex)
+		if hashItem.ElementId.Id == elementId.Id {
+			item.NextIndex = hashItem.NextIndex
+			if hashItem.Generation <= context.generation {
+				hashItem.ElementId = elementId
+				hashItem.Appeared = hashItem.Generation < context.generation
+				hashItem.Generation = context.generation + 1
+				hashItem.LayoutElement = layoutElement
+				hashItem.DebugData.Collision = false
+				hashItem.OnHoverFunction = nil
+				hashItem.HoverFunctionUserData = 0
+				hashItem.OnKeyFunction = nil
+				hashItem.KeyFunctionUserData = 0
+				hashItem.OnLifecycleFunction = nil
+				hashItem.LifecycleFunctionUserData = 0
+			} else {
+				context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_DUPLICATE_ID, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("An element with this ID was already previously declared during this layout.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("An element with this ID was already previously declared during this layout.")}, UserData: context.errorHandler.UserData})
+				if context.debugModeEnabled {
+					hashItem.DebugData.Collision = true
+				}
+			}
+			return hashItem
+		}
+		hashItemPrevious = hashItemIndex
+		hashItemIndex = hashItem.NextIndex
+	}
+	var hashItem *LayoutElementHashMapItem = __LayoutElementHashMapItemArray_Add(&context.layoutElementsHashMapInternal, item)
+	hashItem.DebugData = __DebugElementDataArray_Add(&context.debugElementData, __DebugElementData{Collision: false})
+	if hashItemPrevious != -1 {
+		__LayoutElementHashMapItemArray_Get(&context.layoutElementsHashMapInternal, hashItemPrevious).NextIndex = context.layoutElementsHashMapInternal.Length - 1
+	} else {
+		*(*int32)(unsafe.Add(unsafe.Pointer(context.layoutElementsHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket))) = context.layoutElementsHashMapInternal.Length - 1
+	}
+	return hashItem
+}
+
+func __GetHashMapItem(id uint32) *LayoutElementHashMapItem {
+	var (
+		context      *Context = GetCurrentContext()
+		hashBucket   uint32   = id % uint32(context.layoutElementsHashMap.Capacity)
+		elementIndex int32    = *(*int32)(unsafe.Add(unsafe.Pointer(context.layoutElementsHashMap.InternalArray), unsafe.Sizeof(int32(0))*uintptr(hashBucket)))
+	)
+	for elementIndex != -1 {
+		var hashEntry *LayoutElementHashMapItem = __LayoutElementHashMapItemArray_Get(&context.layoutElementsHashMapInternal, elementIndex)
+		if hashEntry.ElementId.Id == id {
+			return hashEntry
+		}
+		elementIndex = hashEntry.NextIndex
+	}
+	return &LayoutElementHashMapItem_DEFAULT
+}
+
+func __GenerateIdForAnonymousElement(openLayoutElement *LayoutElement) ElementId {
+	var (
+		context       *Context       = GetCurrentContext()
+		parentElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-2))
+		elementId     ElementId      = __HashNumber(uint32(parentElement.ChildrenOrTextContent.Children.Length), parentElement.Id)
+	)
+	openLayoutElement.Id = elementId.Id
+	__AddHashMapItem(elementId, openLayoutElement)
+	__StringArray_Add(&context.layoutElementIdStrings, elementId.StringId)
+	return elementId
+}
+
+func __ElementHasConfig(layoutElement *LayoutElement, type_ __ElementConfigType) bool {
+	for i := int32(0); i < layoutElement.ElementConfigs.Length; i++ {
+		if __ElementConfigArraySlice_Get(&layoutElement.ElementConfigs, i).Type == type_ {
+			return true
+		}
+	}
+	return false
+}
+
+func __UpdateAspectRatioBox(layoutElement *LayoutElement) {
+	for j := int32(0); j < layoutElement.ElementConfigs.Length; j++ {
+		var config *ElementConfig = __ElementConfigArraySlice_Get(&layoutElement.ElementConfigs, j)
+		if config.Type == __ELEMENT_CONFIG_TYPE_ASPECT {
+			var aspectConfig *AspectRatioElementConfig = config.Config.AspectRatioElementConfig
+			if aspectConfig.AspectRatio == 0 {
+				break
+			}
+			if layoutElement.Dimensions.Width == 0 && layoutElement.Dimensions.Height != 0 {
+				layoutElement.Dimensions.Width = layoutElement.Dimensions.Height * aspectConfig.AspectRatio
+			} else if layoutElement.Dimensions.Width != 0 && layoutElement.Dimensions.Height == 0 {
+				layoutElement.Dimensions.Height = layoutElement.Dimensions.Width * (1 / aspectConfig.AspectRatio)
+			}
+			break
+		}
+	}
+}
+
+func __CloseElement() {
+	var context *Context = GetCurrentContext()
+	if context.booleanWarnings.MaxElementsExceeded {
+		return
+	}
+	var openLayoutElement *LayoutElement = __GetOpenLayoutElement()
+	var layoutConfig *LayoutConfig = openLayoutElement.LayoutConfig
+	if layoutConfig == nil {
+		openLayoutElement.LayoutConfig = &LayoutConfig_DEFAULT
+		layoutConfig = &LayoutConfig_DEFAULT
+	}
+	var elementHasClipHorizontal bool = false
+	var elementHasClipVertical bool = false
+	for i := int32(0); i < openLayoutElement.ElementConfigs.Length; i++ {
+		var config *ElementConfig = __ElementConfigArraySlice_Get(&openLayoutElement.ElementConfigs, i)
+		if config.Type == __ELEMENT_CONFIG_TYPE_CLIP {
+			elementHasClipHorizontal = config.Config.ClipElementConfig.Horizontal
+			elementHasClipVertical = config.Config.ClipElementConfig.Vertical
+			context.openClipElementStack.Length--
+			break
+		} else if config.Type == __ELEMENT_CONFIG_TYPE_FLOATING {
+			context.openClipElementStack.Length--
+		}
+	}
+	var leftRightPadding float32 = float32(int32(layoutConfig.Padding.Left) + int32(layoutConfig.Padding.Right))
+	var topBottomPadding float32 = float32(int32(layoutConfig.Padding.Top) + int32(layoutConfig.Padding.Bottom))
+	openLayoutElement.ChildrenOrTextContent.Children.Elements = (*int32)(unsafe.Add(unsafe.Pointer(context.layoutElementChildren.InternalArray), unsafe.Sizeof(int32(0))*uintptr(context.layoutElementChildren.Length)))
+	if layoutConfig.LayoutDirection == LEFT_TO_RIGHT {
+		openLayoutElement.Dimensions.Width = leftRightPadding
+		openLayoutElement.MinDimensions.Width = leftRightPadding
+		for i := int32(0); i < int32(openLayoutElement.ChildrenOrTextContent.Children.Length); i++ {
+			var (
+				childIndex int32          = __int32_tArray_GetValue(&context.layoutElementChildrenBuffer, context.layoutElementChildrenBuffer.Length-int32(openLayoutElement.ChildrenOrTextContent.Children.Length)+i)
+				child      *LayoutElement = LayoutElementArray_Get(&context.layoutElements, childIndex)
+			)
+			openLayoutElement.Dimensions.Width += child.Dimensions.Width
+			if openLayoutElement.Dimensions.Height > (child.Dimensions.Height + topBottomPadding) {
+				/* (004) */
+			} else {
+				openLayoutElement.Dimensions.Height = child.Dimensions.Height + topBottomPadding
+			}
+			if !elementHasClipHorizontal {
+				openLayoutElement.MinDimensions.Width += child.MinDimensions.Width
+			}
+			if !elementHasClipVertical {
+				if openLayoutElement.MinDimensions.Height > (child.MinDimensions.Height + topBottomPadding) {
+					/* (005) */
+				} else {
+					openLayoutElement.MinDimensions.Height = child.MinDimensions.Height + topBottomPadding
+				}
+			}
+			__int32_tArray_Add(&context.layoutElementChildren, childIndex)
+		}
+		var childGap float32 = float32((func() int32 {
+			if (int32(openLayoutElement.ChildrenOrTextContent.Children.Length) - 1) > 0 {
+				return int32(openLayoutElement.ChildrenOrTextContent.Children.Length) - 1
+			}
+			return 0
+		}()) * int32(layoutConfig.ChildGap))
+		openLayoutElement.Dimensions.Width += childGap
+		if !elementHasClipHorizontal {
+			openLayoutElement.MinDimensions.Width += childGap
+		}
+	} else if layoutConfig.LayoutDirection == TOP_TO_BOTTOM {
+		openLayoutElement.Dimensions.Height = topBottomPadding
+		openLayoutElement.MinDimensions.Height = topBottomPadding
+		for i := int32(0); i < int32(openLayoutElement.ChildrenOrTextContent.Children.Length); i++ {
+			var (
+				childIndex int32          = __int32_tArray_GetValue(&context.layoutElementChildrenBuffer, context.layoutElementChildrenBuffer.Length-int32(openLayoutElement.ChildrenOrTextContent.Children.Length)+i)
+				child      *LayoutElement = LayoutElementArray_Get(&context.layoutElements, childIndex)
+			)
+			openLayoutElement.Dimensions.Height += child.Dimensions.Height
+			if openLayoutElement.Dimensions.Width > (child.Dimensions.Width + leftRightPadding) {
+				/* (006) */
+			} else {
+				openLayoutElement.Dimensions.Width = child.Dimensions.Width + leftRightPadding
+			}
+			if !elementHasClipVertical {
+				openLayoutElement.MinDimensions.Height += child.MinDimensions.Height
+			}
+			if !elementHasClipHorizontal {
+				if openLayoutElement.MinDimensions.Width > (child.MinDimensions.Width + leftRightPadding) {
+					/* (007) */
+				} else {
+					openLayoutElement.MinDimensions.Width = child.MinDimensions.Width + leftRightPadding
+				}
+			}
+			__int32_tArray_Add(&context.layoutElementChildren, childIndex)
+		}
+		var childGap float32 = float32((func() int32 {
+			if (int32(openLayoutElement.ChildrenOrTextContent.Children.Length) - 1) > 0 {
+				return int32(openLayoutElement.ChildrenOrTextContent.Children.Length) - 1
+			}
+			return 0
+		}()) * int32(layoutConfig.ChildGap))
+		openLayoutElement.Dimensions.Height += childGap
+		if !elementHasClipVertical {
+			openLayoutElement.MinDimensions.Height += childGap
+		}
+	}
+	context.layoutElementChildrenBuffer.Length -= int32(openLayoutElement.ChildrenOrTextContent.Children.Length)
+	var imageConfig *ImageElementConfig = __FindElementConfigWithType(openLayoutElement, __ELEMENT_CONFIG_TYPE_IMAGE).ImageElementConfig
+	if imageConfig != nil && imageConfig.IntrinsicSize.Width > 0 && imageConfig.IntrinsicSize.Height > 0 {
+		if openLayoutElement.Dimensions.Width < imageConfig.IntrinsicSize.Width+leftRightPadding {
+			openLayoutElement.Dimensions.Width = imageConfig.IntrinsicSize.Width + leftRightPadding
+		}
+		if openLayoutElement.Dimensions.Height < imageConfig.IntrinsicSize.Height+topBottomPadding {
+			openLayoutElement.Dimensions.Height = imageConfig.IntrinsicSize.Height + topBottomPadding
+		}
+	}
+	if layoutConfig.Sizing.Width.Type != __SIZING_TYPE_PERCENT {
+		if layoutConfig.Sizing.Width.Size.MinMax.Max <= 0 {
+			layoutConfig.Sizing.Width.Size.MinMax.Max = __MAXFLOAT
+		}
+		if (func() float32 {
+			if openLayoutElement.Dimensions.Width > layoutConfig.Sizing.Width.Size.MinMax.Min {
+				return openLayoutElement.Dimensions.Width
+			}
+			return layoutConfig.Sizing.Width.Size.MinMax.Min
+		}()) < layoutConfig.Sizing.Width.Size.MinMax.Max {
+			if openLayoutElement.Dimensions.Width > layoutConfig.Sizing.Width.Size.MinMax.Min {
+				/* (006) */
+			} else {
+				openLayoutElement.Dimensions.Width = layoutConfig.Sizing.Width.Size.MinMax.Min
+			}
+		} else {
+			openLayoutElement.Dimensions.Width = layoutConfig.Sizing.Width.Size.MinMax.Max
+		}
+		if (func() float32 {
+			if openLayoutElement.MinDimensions.Width > layoutConfig.Sizing.Width.Size.MinMax.Min {
+				return openLayoutElement.MinDimensions.Width
+			}
+			return layoutConfig.Sizing.Width.Size.MinMax.Min
+		}()) < layoutConfig.Sizing.Width.Size.MinMax.Max {
+			if openLayoutElement.MinDimensions.Width > layoutConfig.Sizing.Width.Size.MinMax.Min {
+				/* (007) */
+			} else {
+				openLayoutElement.MinDimensions.Width = layoutConfig.Sizing.Width.Size.MinMax.Min
+			}
+		} else {
+			openLayoutElement.MinDimensions.Width = layoutConfig.Sizing.Width.Size.MinMax.Max
+		}
+	} else {
+		openLayoutElement.Dimensions.Width = 0
+	}
+	if layoutConfig.Sizing.Height.Type != __SIZING_TYPE_PERCENT {
+		if layoutConfig.Sizing.Height.Size.MinMax.Max <= 0 {
+			layoutConfig.Sizing.Height.Size.MinMax.Max = __MAXFLOAT
+		}
+		if (func() float32 {
+			if openLayoutElement.Dimensions.Height > layoutConfig.Sizing.Height.Size.MinMax.Min {
+				return openLayoutElement.Dimensions.Height
+			}
+			return layoutConfig.Sizing.Height.Size.MinMax.Min
+		}()) < layoutConfig.Sizing.Height.Size.MinMax.Max {
+			if openLayoutElement.Dimensions.Height > layoutConfig.Sizing.Height.Size.MinMax.Min {
+				/* (004) */
+			} else {
+				openLayoutElement.Dimensions.Height = layoutConfig.Sizing.Height.Size.MinMax.Min
+			}
+		} else {
+			openLayoutElement.Dimensions.Height = layoutConfig.Sizing.Height.Size.MinMax.Max
+		}
+		if (func() float32 {
+			if openLayoutElement.MinDimensions.Height > layoutConfig.Sizing.Height.Size.MinMax.Min {
+				return openLayoutElement.MinDimensions.Height
+			}
+			return layoutConfig.Sizing.Height.Size.MinMax.Min
+		}()) < layoutConfig.Sizing.Height.Size.MinMax.Max {
+			if openLayoutElement.MinDimensions.Height > layoutConfig.Sizing.Height.Size.MinMax.Min {
+				/* (005) */
+			} else {
+				openLayoutElement.MinDimensions.Height = layoutConfig.Sizing.Height.Size.MinMax.Min
+			}
+		} else {
+			openLayoutElement.MinDimensions.Height = layoutConfig.Sizing.Height.Size.MinMax.Max
+		}
+	} else {
+		openLayoutElement.Dimensions.Height = 0
+	}
+	__UpdateAspectRatioBox(openLayoutElement)
+	var elementIsFloating bool = __ElementHasConfig(openLayoutElement, __ELEMENT_CONFIG_TYPE_FLOATING)
+	var closingElementIndex int32 = __int32_tArray_RemoveSwapback(&context.openLayoutElementStack, context.openLayoutElementStack.Length-1)
+	openLayoutElement = __GetOpenLayoutElement()
+	if !elementIsFloating && context.openLayoutElementStack.Length > 1 {
+		openLayoutElement.ChildrenOrTextContent.Children.Length++
+		__int32_tArray_Add(&context.layoutElementChildrenBuffer, closingElementIndex)
+	}
+}
+
+func __MemCmp(s1 *byte, s2 *byte, length int32) bool {
+	for i := int32(0); i < length; i++ {
+		if *(*byte)(unsafe.Add(unsafe.Pointer(s1), i)) != *(*byte)(unsafe.Add(unsafe.Pointer(s2), i)) {
+			return false
+		}
+	}
+	return true
+}
+
+func __OpenElement() {
+	var context *Context = GetCurrentContext()
+	if context.layoutElements.Length == context.layoutElements.Capacity-1 || context.booleanWarnings.MaxElementsExceeded {
+		context.booleanWarnings.MaxElementsExceeded = true
+		return
+	}
+	var layoutElement LayoutElement = LayoutElement{}
+	var openLayoutElement *LayoutElement = LayoutElementArray_Add(&context.layoutElements, layoutElement)
+	__int32_tArray_Add(&context.openLayoutElementStack, context.layoutElements.Length-1)
+	__GenerateIdForAnonymousElement(openLayoutElement)
+	if context.openClipElementStack.Length > 0 {
+		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, __int32_tArray_GetValue(&context.openClipElementStack, context.openClipElementStack.Length-1))
+	} else {
+		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, 0)
+	}
+}
+
+func __OpenElementWithId(elementId ElementId) {
+	var context *Context = GetCurrentContext()
+	if context.layoutElements.Length == context.layoutElements.Capacity-1 || context.booleanWarnings.MaxElementsExceeded {
+		context.booleanWarnings.MaxElementsExceeded = true
+		return
+	}
+	var layoutElement LayoutElement = LayoutElement{}
+	layoutElement.Id = elementId.Id
+	var openLayoutElement *LayoutElement = LayoutElementArray_Add(&context.layoutElements, layoutElement)
+	__int32_tArray_Add(&context.openLayoutElementStack, context.layoutElements.Length-1)
+	__AddHashMapItem(elementId, openLayoutElement)
+	__StringArray_Add(&context.layoutElementIdStrings, elementId.StringId)
+	if context.openClipElementStack.Length > 0 {
+		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, __int32_tArray_GetValue(&context.openClipElementStack, context.openClipElementStack.Length-1))
+	} else {
+		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, 0)
+	}
+}
+
+func __OpenTextElement(text String, textConfig *TextElementConfig) {
+	var context *Context = GetCurrentContext()
+	if context.layoutElements.Length == context.layoutElements.Capacity-1 || context.booleanWarnings.MaxElementsExceeded {
+		context.booleanWarnings.MaxElementsExceeded = true
+		return
+	}
+	var parentElement *LayoutElement = __GetOpenLayoutElement()
+	var layoutElement LayoutElement = LayoutElement{}
+	var textElement *LayoutElement = LayoutElementArray_Add(&context.layoutElements, layoutElement)
+	if context.openClipElementStack.Length > 0 {
+		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, __int32_tArray_GetValue(&context.openClipElementStack, context.openClipElementStack.Length-1))
+	} else {
+		__int32_tArray_Set(&context.layoutElementClipElementIds, context.layoutElements.Length-1, 0)
+	}
+	__int32_tArray_Add(&context.layoutElementChildrenBuffer, context.layoutElements.Length-1)
+	var textMeasured *__MeasureTextCacheItem = __MeasureTextCached(&text, textConfig)
+	var elementId ElementId = __HashNumber(uint32(parentElement.ChildrenOrTextContent.Children.Length), parentElement.Id)
+	textElement.Id = elementId.Id
+	__AddHashMapItem(elementId, textElement)
+	__StringArray_Add(&context.layoutElementIdStrings, elementId.StringId)
+	var textDimensions Dimensions = Dimensions{Width: textMeasured.UnwrappedDimensions.Width, Height: func() float32 {
+		if int32(textConfig.LineHeight) > 0 {
+			return float32(textConfig.LineHeight)
+		}
+		return textMeasured.UnwrappedDimensions.Height
+	}()}
+	textElement.Dimensions = textDimensions
+	textElement.MinDimensions = Dimensions{Width: textMeasured.MinWidth, Height: textDimensions.Height}
+	textElement.ChildrenOrTextContent.TextElementData = __TextElementDataArray_Add(&context.textElementData, __TextElementData{Text: text, PreferredDimensions: textMeasured.UnwrappedDimensions, ElementIndex: context.layoutElements.Length - 1})
+	textElement.ElementConfigs = __ElementConfigArraySlice{Length: 1, InternalArray: __ElementConfigArray_Add(&context.elementConfigs, ElementConfig{Type: __ELEMENT_CONFIG_TYPE_TEXT, Config: ElementConfigUnion{TextElementConfig: textConfig}})}
+	textElement.LayoutConfig = &LAYOUT_DEFAULT
+	parentElement.ChildrenOrTextContent.Children.Length++
+}
+
+func __ConfigureOpenElementPtr(declaration *ElementDeclaration) {
+	var (
+		context           *Context       = GetCurrentContext()
+		openLayoutElement *LayoutElement = __GetOpenLayoutElement()
+	)
+	openLayoutElement.LayoutConfig = __StoreLayoutConfig(declaration.Layout)
+	if declaration.Layout.Sizing.Width.Type == __SIZING_TYPE_PERCENT && declaration.Layout.Sizing.Width.Size.Percent > 1 || declaration.Layout.Sizing.Height.Type == __SIZING_TYPE_PERCENT && declaration.Layout.Sizing.Height.Size.Percent > 1 {
+		context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_PERCENTAGE_OVER_1, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("An element was configured with SIZING_PERCENT, but the provided percentage value was over 1.0. Clay expects a value between 0 and 1, i.e. 20% is 0.2.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("An element was configured with SIZING_PERCENT, but the provided percentage value was over 1.0. Clay expects a value between 0 and 1, i.e. 20% is 0.2.")}, UserData: context.errorHandler.UserData})
+	}
+	openLayoutElement.ElementConfigs.InternalArray = (*ElementConfig)(unsafe.Add(unsafe.Pointer(context.elementConfigs.InternalArray), unsafe.Sizeof(ElementConfig{})*uintptr(context.elementConfigs.Length)))
+	var sharedConfig *SharedElementConfig = nil
+	if declaration.BackgroundColor.A > 0 {
+		sharedConfig = __StoreSharedElementConfig(SharedElementConfig{BackgroundColor: declaration.BackgroundColor})
+		__AttachElementConfig(ElementConfigUnion{SharedElementConfig: sharedConfig}, __ELEMENT_CONFIG_TYPE_SHARED)
+	}
+	if declaration.BackgroundGradient.Type != GRADIENT_TYPE_NONE {
+		if sharedConfig != nil {
+			sharedConfig.BackgroundGradient = declaration.BackgroundGradient
+		} else {
+			sharedConfig = __StoreSharedElementConfig(SharedElementConfig{BackgroundGradient: declaration.BackgroundGradient})
+			__AttachElementConfig(ElementConfigUnion{SharedElementConfig: sharedConfig}, __ELEMENT_CONFIG_TYPE_SHARED)
+		}
+	}
+	if !__MemCmp((*byte)(unsafe.Pointer(&declaration.CornerRadius)), (*byte)(unsafe.Pointer(&__CornerRadius_DEFAULT)), int32(uint32(unsafe.Sizeof(CornerRadius{})))) {
+		if sharedConfig != nil {
+			sharedConfig.CornerRadius = declaration.CornerRadius
+		} else {
+			sharedConfig = __StoreSharedElementConfig(SharedElementConfig{CornerRadius: declaration.CornerRadius})
+			__AttachElementConfig(ElementConfigUnion{SharedElementConfig: sharedConfig}, __ELEMENT_CONFIG_TYPE_SHARED)
+		}
+	}
+	if declaration.UserData != nil {
+		if sharedConfig != nil {
+			sharedConfig.UserData = declaration.UserData
+		} else {
+			sharedConfig = __StoreSharedElementConfig(SharedElementConfig{UserData: declaration.UserData})
+			__AttachElementConfig(ElementConfigUnion{SharedElementConfig: sharedConfig}, __ELEMENT_CONFIG_TYPE_SHARED)
+		}
+	}
+	if declaration.Image.ImageData != nil {
+		__AttachElementConfig(ElementConfigUnion{ImageElementConfig: __StoreImageElementConfig(declaration.Image)}, __ELEMENT_CONFIG_TYPE_IMAGE)
+	}
+	if declaration.AspectRatio.AspectRatio > 0 {
+		__AttachElementConfig(ElementConfigUnion{AspectRatioElementConfig: __StoreAspectRatioElementConfig(declaration.AspectRatio)}, __ELEMENT_CONFIG_TYPE_ASPECT)
+		__int32_tArray_Add(&context.AspectRatioElementIndexes, context.layoutElements.Length-1)
+	} else if declaration.Image.ImageData != nil && declaration.Image.IntrinsicSize.Width > 0 && declaration.Image.IntrinsicSize.Height > 0 && declaration.Layout.Sizing.Width.Type != __SIZING_TYPE_FIT && declaration.Layout.Sizing.Height.Type != __SIZING_TYPE_FIXED && declaration.Layout.Sizing.Height.Type != __SIZING_TYPE_PERCENT {
+		var aspectConfig AspectRatioElementConfig = AspectRatioElementConfig{AspectRatio: declaration.Image.IntrinsicSize.Width / declaration.Image.IntrinsicSize.Height}
+		__AttachElementConfig(ElementConfigUnion{AspectRatioElementConfig: __StoreAspectRatioElementConfig(aspectConfig)}, __ELEMENT_CONFIG_TYPE_ASPECT)
+		__int32_tArray_Add(&context.AspectRatioElementIndexes, context.layoutElements.Length-1)
+	}
+	if declaration.Floating.AttachTo != ATTACH_TO_NONE {
+		var (
+			floatingConfig     FloatingElementConfig = declaration.Floating
+			hierarchicalParent *LayoutElement        = LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-2))
+		)
+		if hierarchicalParent != nil {
+			var clipElementId uint32 = 0
+			if declaration.Floating.AttachTo == ATTACH_TO_PARENT {
+				floatingConfig.ParentId = hierarchicalParent.Id
+				if context.openClipElementStack.Length > 0 {
+					clipElementId = uint32(__int32_tArray_GetValue(&context.openClipElementStack, context.openClipElementStack.Length-1))
+				}
+			} else if declaration.Floating.AttachTo == ATTACH_TO_ELEMENT_WITH_ID {
+				var parentItem *LayoutElementHashMapItem = __GetHashMapItem(floatingConfig.ParentId)
+				if parentItem == &LayoutElementHashMapItem_DEFAULT {
+					context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_FLOATING_CONTAINER_PARENT_NOT_FOUND, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("A floating element was declared with a parentId, but no element with that ID was found.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("A floating element was declared with a parentId, but no element with that ID was found.")}, UserData: context.errorHandler.UserData})
+				} else {
+					clipElementId = uint32(__int32_tArray_GetValue(&context.layoutElementClipElementIds, int32(int64((uintptr(unsafe.Pointer(parentItem.LayoutElement))-uintptr(unsafe.Pointer(context.layoutElements.InternalArray)))/unsafe.Sizeof(LayoutElement{})))))
+				}
+			} else if declaration.Floating.AttachTo == ATTACH_TO_ROOT {
+				floatingConfig.ParentId = __HashString(String{IsStaticallyAllocated: true, Length: int32(((len("__RootContainer") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("__RootContainer")}, 0).Id
+			}
+			if declaration.Floating.ClipTo == CLIP_TO_NONE {
+				clipElementId = 0
+			}
+			var currentElementIndex int32 = __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-1)
+			__int32_tArray_Set(&context.layoutElementClipElementIds, currentElementIndex, int32(clipElementId))
+			__int32_tArray_Add(&context.openClipElementStack, int32(clipElementId))
+			__LayoutElementTreeRootArray_Add(&context.layoutElementTreeRoots, __LayoutElementTreeRoot{LayoutElementIndex: __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-1), ParentId: floatingConfig.ParentId, ClipElementId: clipElementId, ZIndex: floatingConfig.ZIndex})
+			__AttachElementConfig(ElementConfigUnion{FloatingElementConfig: __StoreFloatingElementConfig(floatingConfig)}, __ELEMENT_CONFIG_TYPE_FLOATING)
+		}
+	}
+	if declaration.Custom.CustomData != nil {
+		__AttachElementConfig(ElementConfigUnion{CustomElementConfig: __StoreCustomElementConfig(declaration.Custom)}, __ELEMENT_CONFIG_TYPE_CUSTOM)
+	}
+	if declaration.Clip.Horizontal || declaration.Clip.Vertical {
+		__AttachElementConfig(ElementConfigUnion{ClipElementConfig: __StoreClipElementConfig(declaration.Clip)}, __ELEMENT_CONFIG_TYPE_CLIP)
+		__int32_tArray_Add(&context.openClipElementStack, int32(openLayoutElement.Id))
+		var scrollOffset *__ScrollContainerDataInternal = (*__ScrollContainerDataInternal)(nil)
+		for i := int32(0); i < context.scrollContainerDatas.Length; i++ {
+			var mapping *__ScrollContainerDataInternal = __ScrollContainerDataInternalArray_Get(&context.scrollContainerDatas, i)
+			if openLayoutElement.Id == mapping.ElementId {
+				scrollOffset = mapping
+				scrollOffset.LayoutElement = openLayoutElement
+				scrollOffset.OpenThisFrame = true
+			}
+		}
+		if scrollOffset == nil {
+			scrollOffset = __ScrollContainerDataInternalArray_Add(&context.scrollContainerDatas, __ScrollContainerDataInternal{LayoutElement: openLayoutElement, ScrollOrigin: Vector2{X: -1, Y: -1}, Scale: 1, ElementId: openLayoutElement.Id, OpenThisFrame: true})
+		}
+		if context.externalScrollHandlingEnabled {
+			scrollOffset.ScrollPosition = __QueryScrollOffset(scrollOffset.ElementId, context.queryScrollOffsetUserData.(unsafe.Pointer))
+		}
+	}
+	if !__MemCmp((*byte)(unsafe.Pointer(&declaration.Border.Width)), (*byte)(unsafe.Pointer(&__BorderWidth_DEFAULT)), int32(uint32(unsafe.Sizeof(BorderWidth{})))) {
+		__AttachElementConfig(ElementConfigUnion{BorderElementConfig: __StoreBorderElementConfig(declaration.Border)}, __ELEMENT_CONFIG_TYPE_BORDER)
+	}
+	if declaration.Focus.Focusable {
+		if openLayoutElement.Id == 0 {
+			__GenerateIdForAnonymousElement(openLayoutElement)
+		}
+		__AttachElementConfig(ElementConfigUnion{FocusElementConfig: __StoreFocusElementConfig(declaration.Focus)}, __ELEMENT_CONFIG_TYPE_FOCUS)
+		__int32_tArray_Add(&context.focusableElementIndexes, context.layoutElements.Length-1)
+	}
+	if declaration.Sticky.Horizontal || declaration.Sticky.Vertical {
+		__AttachElementConfig(ElementConfigUnion{StickyElementConfig: __StoreStickyElementConfig(declaration.Sticky)}, __ELEMENT_CONFIG_TYPE_STICKY)
+	}
+	if declaration.ZoomPan.Enabled {
+		__AttachElementConfig(ElementConfigUnion{ZoomPanElementConfig: __StoreZoomPanElementConfig(declaration.ZoomPan)}, __ELEMENT_CONFIG_TYPE_ZOOM_PAN)
+		var zoomPanData *__ZoomPanDataInternal = (*__ZoomPanDataInternal)(nil)
+		for i := int32(0); i < context.zoomPanDatas.Length; i++ {
+			var mapping *__ZoomPanDataInternal = __ZoomPanDataInternalArray_Get(&context.zoomPanDatas, i)
+			if openLayoutElement.Id == mapping.ElementId {
+				zoomPanData = mapping
+				zoomPanData.LayoutElement = openLayoutElement
+				zoomPanData.OpenThisFrame = true
+			}
+		}
+		if zoomPanData == nil {
+			__ZoomPanDataInternalArray_Add(&context.zoomPanDatas, __ZoomPanDataInternal{LayoutElement: openLayoutElement, Zoom: 1, ParentScale: 1, ElementId: openLayoutElement.Id, OpenThisFrame: true})
+		}
+	}
+	if declaration.Shadow.Color.A > 0 {
+		__AttachElementConfig(ElementConfigUnion{ShadowElementConfig: __StoreShadowElementConfig(declaration.Shadow)}, __ELEMENT_CONFIG_TYPE_SHADOW)
+	}
+	if declaration.Opacity.Enabled && declaration.Opacity.Value < 1 {
+		__AttachElementConfig(ElementConfigUnion{OpacityElementConfig: __StoreOpacityElementConfig(declaration.Opacity)}, __ELEMENT_CONFIG_TYPE_OPACITY)
+	}
+	if declaration.Transform.Enabled {
+		__AttachElementConfig(ElementConfigUnion{TransformElementConfig: __StoreTransformElementConfig(declaration.Transform)}, __ELEMENT_CONFIG_TYPE_TRANSFORM)
+	}
+	if declaration.Path.Commands.Length > 0 && (declaration.Path.FillColor.A > 0 || declaration.Path.StrokeColor.A > 0 && declaration.Path.StrokeWidth > 0) {
+		var pathConfig PathElementConfig = declaration.Path
+		pathConfig.Commands = __WritePathCommandsToBuffer(&context.pathCommands, declaration.Path.Commands)
+		if pathConfig.Commands.Length > 0 {
+			__AttachElementConfig(ElementConfigUnion{PathElementConfig: __StorePathElementConfig(pathConfig)}, __ELEMENT_CONFIG_TYPE_PATH)
+		} else if !context.booleanWarnings.MaxPathCommandsExceeded {
+			context.booleanWarnings.MaxPathCommandsExceeded = true
+			context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay ran out of capacity while attempting to store path commands. Try using SetMaxElementCount() with a higher value.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay ran out of capacity while attempting to store path commands. Try using SetMaxElementCount() with a higher value.")}, UserData: context.errorHandler.UserData})
+		}
+	}
+	if declaration.Transition.Properties != TRANSITION_PROPERTY_NONE || declaration.Transition.Enter.Enabled || declaration.Transition.Exit.Enabled {
+		__AttachElementConfig(ElementConfigUnion{TransitionElementConfig: __StoreTransitionElementConfig(declaration.Transition)}, __ELEMENT_CONFIG_TYPE_TRANSITION)
+		var parentId uint32 = LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.openLayoutElementStack, context.openLayoutElementStack.Length-2)).Id
+		var transitionData *__TransitionDataInternal = __GetTransitionData(openLayoutElement.Id)
+		if transitionData == nil && context.transitionDatas.Length < context.transitionDatas.Capacity {
+			transitionData = __TransitionDataInternalArray_Add(&context.transitionDatas, __TransitionDataInternal{ElementId: openLayoutElement.Id, Entering: true})
+		}
+		if transitionData != nil {
+			transitionData.Config = declaration.Transition
+			transitionData.ParentId = parentId
+			transitionData.OpenThisFrame = true
+		}
+	}
+}
+
+func __ConfigureOpenElement(declaration ElementDeclaration) {
+	__ConfigureOpenElementPtr(&declaration)
+}
+
+func __InitializeEphemeralMemory(context *Context) {
+	var (
+		maxElementCount int32  = context.maxElementCount
+		arena           *Arena = &context.internalArena
+	)
+	arena.NextAllocation = context.arenaResetOffset
 	context.layoutElementChildrenBuffer = __int32_tArray_Allocate_Arena(maxElementCount, arena)
 	context.layoutElements = LayoutElementArray_Allocate_Arena(maxElementCount, arena)
 	context.warnings = __WarningArray_Allocate_Arena(100, arena)
@@ -3289,6 +4722,15 @@ func __InitializeEphemeralMemory(context *Context) {
 	context.customElementConfigs = __CustomElementConfigArray_Allocate_Arena(maxElementCount, arena)
 	context.borderElementConfigs = __BorderElementConfigArray_Allocate_Arena(maxElementCount, arena)
 	context.sharedElementConfigs = __SharedElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.focusElementConfigs = __FocusElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.stickyElementConfigs = __StickyElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.zoomPanElementConfigs = __ZoomPanElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.transitionElementConfigs = __TransitionElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.shadowElementConfigs = __ShadowElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.opacityElementConfigs = __OpacityElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.transformElementConfigs = __TransformElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.pathElementConfigs = __PathElementConfigArray_Allocate_Arena(maxElementCount, arena)
+	context.pathCommands = __PathCommandArray_Allocate_Arena(maxElementCount*4, arena)
 	context.layoutElementIdStrings = __StringArray_Allocate_Arena(maxElementCount, arena)
 	context.wrappedTextLines = __WrappedTextLineArray_Allocate_Arena(maxElementCount, arena)
 	context.layoutElementTreeNodeArray1 = __LayoutElementTreeNodeArray_Allocate_Arena(maxElementCount, arena)
@@ -3297,6 +4739,7 @@ func __InitializeEphemeralMemory(context *Context) {
 	context.openLayoutElementStack = __int32_tArray_Allocate_Arena(maxElementCount, arena)
 	context.textElementData = __TextElementDataArray_Allocate_Arena(maxElementCount, arena)
 	context.AspectRatioElementIndexes = __int32_tArray_Allocate_Arena(maxElementCount, arena)
+	context.focusableElementIndexes = __int32_tArray_Allocate_Arena(maxElementCount, arena)
 	context.renderCommands = RenderCommandArray_Allocate_Arena(maxElementCount, arena)
 	context.treeNodeVisited = __boolArray_Allocate_Arena(maxElementCount, arena)
 	context.treeNodeVisited.Length = context.treeNodeVisited.Capacity
@@ -3313,6 +4756,14 @@ func __InitializePersistentMemory(context *Context) {
 		arena                        *Arena = &context.internalArena
 	)
 	context.scrollContainerDatas = __ScrollContainerDataInternalArray_Allocate_Arena(100, arena)
+	context.zoomPanDatas = __ZoomPanDataInternalArray_Allocate_Arena(100, arena)
+	context.transitionDatas = __TransitionDataInternalArray_Allocate_Arena(maxElementCount/4, arena)
+	context.transitionCommands = RenderCommandArray_Allocate_Arena(maxElementCount/4, arena)
+	context.transitionCommandsNext = RenderCommandArray_Allocate_Arena(maxElementCount/4, arena)
+	context.transitionText = __charArray_Allocate_Arena(maxElementCount*4, arena)
+	context.transitionTextNext = __charArray_Allocate_Arena(maxElementCount*4, arena)
+	context.transitionPathCommands = __PathCommandArray_Allocate_Arena(maxElementCount, arena)
+	context.transitionPathCommandsNext = __PathCommandArray_Allocate_Arena(maxElementCount, arena)
 	context.layoutElementsHashMapInternal = __LayoutElementHashMapItemArray_Allocate_Arena(maxElementCount, arena)
 	context.layoutElementsHashMap = __int32_tArray_Allocate_Arena(maxElementCount, arena)
 	context.measureTextHashMapInternal = __MeasureTextCacheItemArray_Allocate_Arena(maxElementCount, arena)
@@ -3718,73 +5169,1055 @@ func __SizeContainersAlongAxis(xAxis bool) {
 	}
 }
 
-func __IntToString(integer int32) String {
-	if integer == 0 {
-		return String{Length: 1, Chars: libc.CString("0")}
+func __IntToString(integer int32) String {
+	if integer == 0 {
+		return String{Length: 1, Chars: libc.CString("0")}
+	}
+	var context *Context = GetCurrentContext()
+	var chars *byte = ((*byte)(unsafe.Add(unsafe.Pointer(context.dynamicStringData.InternalArray), context.dynamicStringData.Length)))
+	var length int32 = 0
+	var sign int32 = integer
+	if integer < 0 {
+		integer = -integer
+	}
+	for integer > 0 {
+		*(*byte)(unsafe.Add(unsafe.Pointer(chars), func() int32 {
+			p_ := &length
+			x := *p_
+			*p_++
+			return x
+		}())) = byte(int8(integer%10 + '0'))
+		integer /= 10
+	}
+	if sign < 0 {
+		*(*byte)(unsafe.Add(unsafe.Pointer(chars), func() int32 {
+			p_ := &length
+			x := *p_
+			*p_++
+			return x
+		}())) = '-'
+	}
+	for j, k := int32(0), int32(length-1); j < k; func() int32 {
+		j++
+		return func() int32 {
+			p_ := &k
+			x := *p_
+			*p_--
+			return x
+		}()
+	}() {
+		var temp int8 = int8(*(*byte)(unsafe.Add(unsafe.Pointer(chars), j)))
+		*(*byte)(unsafe.Add(unsafe.Pointer(chars), j)) = *(*byte)(unsafe.Add(unsafe.Pointer(chars), k))
+		*(*byte)(unsafe.Add(unsafe.Pointer(chars), k)) = byte(temp)
+	}
+	context.dynamicStringData.Length += length
+	return String{Length: length, Chars: chars}
+}
+
+var __Matrix2D_IDENTITY Matrix2D = Matrix2D{A: 1, B: 0, C: 0, D: 1, Tx: 0, Ty: 0}
+
+func __MatrixIsIdentity(m Matrix2D) bool {
+	return m.A == 1 && m.B == 0 && m.C == 0 && m.D == 1 && m.Tx == 0 && m.Ty == 0
+}
+
+func __MultiplyMatrices(m Matrix2D, n Matrix2D) Matrix2D {
+	return Matrix2D{A: m.A*n.A + m.C*n.B, B: m.B*n.A + m.D*n.B, C: m.A*n.C + m.C*n.D, D: m.B*n.C + m.D*n.D, Tx: m.A*n.Tx + m.C*n.Ty + m.Tx, Ty: m.B*n.Tx + m.D*n.Ty + m.Ty}
+}
+
+func __TransformPoint(m Matrix2D, point Vector2) Vector2 {
+	return Vector2{X: m.A*point.X + m.C*point.Y + m.Tx, Y: m.B*point.X + m.D*point.Y + m.Ty}
+}
+
+func __InverseTransformPoint(m Matrix2D, point Vector2) Vector2 {
+	var determinant float32 = m.A*m.D - m.B*m.C
+	if determinant == 0 {
+		return Vector2{X: __MAXFLOAT, Y: __MAXFLOAT}
+	}
+	var x float32 = point.X - m.Tx
+	var y float32 = point.Y - m.Ty
+	return Vector2{X: (m.D*x - m.C*y) / determinant, Y: (m.A*y - m.B*x) / determinant}
+}
+
+func __TransformBoundingBox(m Matrix2D, box BoundingBox) BoundingBox {
+	if __MatrixIsIdentity(m) {
+		return box
+	}
+	var corners [4]Vector2 = [4]Vector2{__TransformPoint(m, Vector2{X: box.X, Y: box.Y}), __TransformPoint(m, Vector2{X: box.X + box.Width, Y: box.Y}), __TransformPoint(m, Vector2{X: box.X, Y: box.Y + box.Height}), __TransformPoint(m, Vector2{X: box.X + box.Width, Y: box.Y + box.Height})}
+	var minCorner Vector2 = corners[0]
+	var maxCorner Vector2 = corners[0]
+	for i := int32(1); i < 4; i++ {
+		if corners[i].X < minCorner.X {
+			minCorner.X = corners[i].X
+		}
+		if corners[i].Y < minCorner.Y {
+			minCorner.Y = corners[i].Y
+		}
+		if corners[i].X > maxCorner.X {
+			maxCorner.X = corners[i].X
+		}
+		if corners[i].Y > maxCorner.Y {
+			maxCorner.Y = corners[i].Y
+		}
+	}
+	return BoundingBox{X: minCorner.X, Y: minCorner.Y, Width: maxCorner.X - minCorner.X, Height: maxCorner.Y - minCorner.Y}
+}
+
+func __SinCosDegrees(degrees float32) Vector2 {
+	var (
+		quarterTurns float32 = degrees / 90
+		quadrant     int32   = int32(quarterTurns + 0.5)
+	)
+	if quarterTurns < 0 {
+		quadrant = int32(quarterTurns - 0.5)
+	}
+	var x float32 = (degrees - float32(quadrant)*90) * 0.017453292519943295
+	var x2 float32 = x * x
+	var sine float32 = x * (1 - x2/6*(1-x2/20*(1-x2/42*(1-x2/72))))
+	var cosine float32 = 1 - x2/2*(1-x2/12*(1-x2/30*(1-x2/56)))
+	switch quadrant & 3 {
+	case 0:
+		return Vector2{X: sine, Y: cosine}
+	case 1:
+		return Vector2{X: cosine, Y: -sine}
+	case 2:
+		return Vector2{X: -sine, Y: -cosine}
+	default:
+		return Vector2{X: -cosine, Y: sine}
+	}
+}
+
+func __ElementTransform(config *TransformElementConfig, boundingBox BoundingBox, transformScale float32) Matrix2D {
+	var (
+		sinCos Vector2  = __SinCosDegrees(config.Rotation)
+		origin Vector2  = Vector2{X: boundingBox.X + boundingBox.Width*config.Origin.X, Y: boundingBox.Y + boundingBox.Height*config.Origin.Y}
+		m      Matrix2D = Matrix2D{A: sinCos.Y * config.Scale.X, B: sinCos.X * config.Scale.X, C: -sinCos.X * config.Scale.Y, D: sinCos.Y * config.Scale.Y, Tx: 0, Ty: 0}
+	)
+	m.Tx = origin.X + config.Translate.X*transformScale - (m.A*origin.X + m.C*origin.Y)
+	m.Ty = origin.Y + config.Translate.Y*transformScale - (m.B*origin.X + m.D*origin.Y)
+	return m
+}
+
+func __AddRenderCommand(renderCommand RenderCommand) {
+	var (
+		context   *Context = GetCurrentContext()
+		transform Matrix2D = renderCommand.Transform
+	)
+	if transform.A == 0 && transform.B == 0 && transform.C == 0 && transform.D == 0 && transform.Tx == 0 && transform.Ty == 0 {
+		renderCommand.Transform = __Matrix2D_IDENTITY
+	}
+	if context.renderCommands.Length < context.renderCommands.Capacity-1 {
+		RenderCommandArray_Add(&context.renderCommands, renderCommand)
+	} else {
+		if !context.booleanWarnings.MaxRenderCommandsExceeded {
+			context.booleanWarnings.MaxRenderCommandsExceeded = true
+			context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay ran out of capacity while attempting to create render commands. This is usually caused by a large amount of wrapping text elements while close to the max element capacity. Try using SetMaxElementCount() with a higher value.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay ran out of capacity while attempting to create render commands. This is usually caused by a large amount of wrapping text elements while close to the max element capacity. Try using SetMaxElementCount() with a higher value.")}, UserData: context.errorHandler.UserData})
+		}
+	}
+}
+
+func __ElementIsOffscreen(boundingBox *BoundingBox) bool {
+	var context *Context = GetCurrentContext()
+	if context.disableCulling {
+		return false
+	}
+	return boundingBox.X > context.layoutDimensions.Width || boundingBox.Y > context.layoutDimensions.Height || boundingBox.X+boundingBox.Width < 0 || boundingBox.Y+boundingBox.Height < 0
+}
+
+func __FloatingAttachPosition(attachPoints FloatingAttachPoints, offset Vector2, parentBoundingBox BoundingBox, rootDimensions Dimensions) Vector2 {
+	var targetAttachPosition Vector2 = Vector2{}
+	switch attachPoints.Parent {
+	case ATTACH_POINT_LEFT_TOP:
+		fallthrough
+	case ATTACH_POINT_LEFT_CENTER:
+		fallthrough
+	case ATTACH_POINT_LEFT_BOTTOM:
+		targetAttachPosition.X = parentBoundingBox.X
+	case ATTACH_POINT_CENTER_TOP:
+		fallthrough
+	case ATTACH_POINT_CENTER_CENTER:
+		fallthrough
+	case ATTACH_POINT_CENTER_BOTTOM:
+		targetAttachPosition.X = parentBoundingBox.X + parentBoundingBox.Width/2
+	case ATTACH_POINT_RIGHT_TOP:
+		fallthrough
+	case ATTACH_POINT_RIGHT_CENTER:
+		fallthrough
+	case ATTACH_POINT_RIGHT_BOTTOM:
+		targetAttachPosition.X = parentBoundingBox.X + parentBoundingBox.Width
+	}
+	switch attachPoints.Element {
+	case ATTACH_POINT_LEFT_TOP:
+		fallthrough
+	case ATTACH_POINT_LEFT_CENTER:
+		fallthrough
+	case ATTACH_POINT_LEFT_BOTTOM:
+	case ATTACH_POINT_CENTER_TOP:
+		fallthrough
+	case ATTACH_POINT_CENTER_CENTER:
+		fallthrough
+	case ATTACH_POINT_CENTER_BOTTOM:
+		targetAttachPosition.X -= rootDimensions.Width / 2
+	case ATTACH_POINT_RIGHT_TOP:
+		fallthrough
+	case ATTACH_POINT_RIGHT_CENTER:
+		fallthrough
+	case ATTACH_POINT_RIGHT_BOTTOM:
+		targetAttachPosition.X -= rootDimensions.Width
+	}
+	switch attachPoints.Parent {
+	case ATTACH_POINT_LEFT_TOP:
+		fallthrough
+	case ATTACH_POINT_RIGHT_TOP:
+		fallthrough
+	case ATTACH_POINT_CENTER_TOP:
+		targetAttachPosition.Y = parentBoundingBox.Y
+	case ATTACH_POINT_LEFT_CENTER:
+		fallthrough
+	case ATTACH_POINT_CENTER_CENTER:
+		fallthrough
+	case ATTACH_POINT_RIGHT_CENTER:
+		targetAttachPosition.Y = parentBoundingBox.Y + parentBoundingBox.Height/2
+	case ATTACH_POINT_LEFT_BOTTOM:
+		fallthrough
+	case ATTACH_POINT_CENTER_BOTTOM:
+		fallthrough
+	case ATTACH_POINT_RIGHT_BOTTOM:
+		targetAttachPosition.Y = parentBoundingBox.Y + parentBoundingBox.Height
+	}
+	switch attachPoints.Element {
+	case ATTACH_POINT_LEFT_TOP:
+		fallthrough
+	case ATTACH_POINT_RIGHT_TOP:
+		fallthrough
+	case ATTACH_POINT_CENTER_TOP:
+	case ATTACH_POINT_LEFT_CENTER:
+		fallthrough
+	case ATTACH_POINT_CENTER_CENTER:
+		fallthrough
+	case ATTACH_POINT_RIGHT_CENTER:
+		targetAttachPosition.Y -= rootDimensions.Height / 2
+	case ATTACH_POINT_LEFT_BOTTOM:
+		fallthrough
+	case ATTACH_POINT_CENTER_BOTTOM:
+		fallthrough
+	case ATTACH_POINT_RIGHT_BOTTOM:
+		targetAttachPosition.Y -= rootDimensions.Height
+	}
+	targetAttachPosition.X += offset.X
+	targetAttachPosition.Y += offset.Y
+	return targetAttachPosition
+}
+
+func __FlipAttachPoint(attachPoint FloatingAttachPointType, xAxis bool) FloatingAttachPointType {
+	var (
+		column int32 = int32(attachPoint / 3)
+		row    int32 = int32(attachPoint % 3)
+	)
+	if xAxis {
+		column = 2 - column
+	} else {
+		row = 2 - row
+	}
+	return FloatingAttachPointType(column*3 + row)
+}
+
+func __AxisOverflow(position float32, size float32, boundaryStart float32, boundaryEnd float32) float32 {
+	return (func() float32 {
+		if (boundaryStart - position) > 0 {
+			return boundaryStart - position
+		}
+		return 0
+	}()) + (func() float32 {
+		if (position + size - boundaryEnd) > 0 {
+			return position + size - boundaryEnd
+		}
+		return 0
+	}())
+}
+
+func __ResolveFloatingCollision(config *FloatingElementConfig, parentItem *LayoutElementHashMapItem, parentBoundingBox BoundingBox, rootDimensions Dimensions, position Vector2, transformOffset Vector2, transformScale float32) Vector2 {
+	var (
+		context  *Context    = GetCurrentContext()
+		boundary BoundingBox = BoundingBox{X: 0, Y: 0, Width: context.layoutDimensions.Width, Height: context.layoutDimensions.Height}
+	)
+	if parentItem.LayoutElement != nil {
+		var clipElementId int32 = __int32_tArray_GetValue(&context.layoutElementClipElementIds, int32(int64((uintptr(unsafe.Pointer(parentItem.LayoutElement))-uintptr(unsafe.Pointer(context.layoutElements.InternalArray)))/unsafe.Sizeof(LayoutElement{}))))
+		if __ElementHasConfig(parentItem.LayoutElement, __ELEMENT_CONFIG_TYPE_CLIP) {
+			clipElementId = int32(parentItem.LayoutElement.Id)
+		}
+		var clipHashMapItem *LayoutElementHashMapItem = __GetHashMapItem(uint32(clipElementId))
+		if clipElementId != 0 && clipHashMapItem != &LayoutElementHashMapItem_DEFAULT {
+			var (
+				clipBox BoundingBox = clipHashMapItem.BoundingBox
+				left    float32     = (func() float32 {
+					if boundary.X > clipBox.X {
+						return boundary.X
+					}
+					return clipBox.X
+				}())
+				top float32 = (func() float32 {
+					if boundary.Y > clipBox.Y {
+						return boundary.Y
+					}
+					return clipBox.Y
+				}())
+				right float32 = (func() float32 {
+					if (boundary.X + boundary.Width) < (clipBox.X + clipBox.Width) {
+						return boundary.X + boundary.Width
+					}
+					return clipBox.X + clipBox.Width
+				}())
+				bottom float32 = (func() float32 {
+					if (boundary.Y + boundary.Height) < (clipBox.Y + clipBox.Height) {
+						return boundary.Y + boundary.Height
+					}
+					return clipBox.Y + clipBox.Height
+				}())
+			)
+			boundary = BoundingBox{X: left, Y: top, Width: (func() float32 {
+				if (right - left) > 0 {
+					return right - left
+				}
+				return 0
+			}()), Height: (func() float32 {
+				if (bottom - top) > 0 {
+					return bottom - top
+				}
+				return 0
+			}())}
+		}
+	}
+	boundary = BoundingBox{X: (boundary.X - transformOffset.X) / transformScale, Y: (boundary.Y - transformOffset.Y) / transformScale, Width: boundary.Width / transformScale, Height: boundary.Height / transformScale}
+	if config.Collision&FLOATING_COLLISION_FLIP != 0 {
+		for axis := int32(0); axis < 2; axis++ {
+			var (
+				xAxis    bool = axis == 0
+				overflow float32
+			)
+			if xAxis {
+				overflow = __AxisOverflow(position.X, rootDimensions.Width, boundary.X, boundary.X+boundary.Width)
+			} else {
+				overflow = __AxisOverflow(position.Y, rootDimensions.Height, boundary.Y, boundary.Y+boundary.Height)
+			}
+			if overflow <= 0 {
+				continue
+			}
+			var flippedAttachPoints FloatingAttachPoints = FloatingAttachPoints{Element: __FlipAttachPoint(config.AttachPoints.Element, xAxis), Parent: __FlipAttachPoint(config.AttachPoints.Parent, xAxis)}
+			var flippedOffset Vector2 = Vector2{X: func() float32 {
+				if xAxis {
+					return -config.Offset.X
+				}
+				return config.Offset.X
+			}(), Y: func() float32 {
+				if xAxis {
+					return config.Offset.Y
+				}
+				return -config.Offset.Y
+			}()}
+			var flippedPosition Vector2 = __FloatingAttachPosition(flippedAttachPoints, flippedOffset, parentBoundingBox, rootDimensions)
+			if xAxis && __AxisOverflow(flippedPosition.X, rootDimensions.Width, boundary.X, boundary.X+boundary.Width) < overflow {
+				position.X = flippedPosition.X
+			} else if !xAxis && __AxisOverflow(flippedPosition.Y, rootDimensions.Height, boundary.Y, boundary.Y+boundary.Height) < overflow {
+				position.Y = flippedPosition.Y
+			}
+		}
+	}
+	if config.Collision&FLOATING_COLLISION_SHIFT != 0 {
+		if position.X+rootDimensions.Width > boundary.X+boundary.Width {
+			position.X = boundary.X + boundary.Width - rootDimensions.Width
+		}
+		if position.X < boundary.X {
+			position.X = boundary.X
+		}
+		if position.Y+rootDimensions.Height > boundary.Y+boundary.Height {
+			position.Y = boundary.Y + boundary.Height - rootDimensions.Height
+		}
+		if position.Y < boundary.Y {
+			position.Y = boundary.Y
+		}
+	}
+	return position
+}
+
+func __Ease(easing EasingCurve, t float32) float32 {
+	switch easing {
+	case EASING_EASE_IN:
+		return t * t * t
+	case EASING_EASE_OUT:
+		return 1 - (1-t)*(1-t)*(1-t)
+	case EASING_EASE_IN_OUT:
+		if t < 0.5 {
+			return t * 4 * t * t
+		}
+		return 1 - (2-t*2)*(2-t*2)*(2-t*2)/2
+	default:
+		return t
+	}
+}
+
+func __TransitionProgress(config *TransitionElementConfig, elapsed float32) float32 {
+	if config.Duration <= 0 || elapsed >= config.Duration {
+		return 1
+	}
+	return __Ease(config.Easing, elapsed/config.Duration)
+}
+
+func __Lerp(from float32, to float32, t float32) float32 {
+	return from + (to-from)*t
+}
+
+func __TransitionPosition(data *__TransitionDataInternal) Vector2 {
+	var t float32 = __TransitionProgress(&data.Config, data.PositionElapsed)
+	return Vector2{X: __Lerp(data.PositionFrom.X, data.PositionTo.X, t), Y: __Lerp(data.PositionFrom.Y, data.PositionTo.Y, t)}
+}
+
+func __TransitionSize(data *__TransitionDataInternal) Dimensions {
+	var t float32 = __TransitionProgress(&data.Config, data.SizeElapsed)
+	return Dimensions{Width: __Lerp(data.SizeFrom.Width, data.SizeTo.Width, t), Height: __Lerp(data.SizeFrom.Height, data.SizeTo.Height, t)}
+}
+
+func __TransitionColor(data *__TransitionDataInternal) Color {
+	var t float32 = __TransitionProgress(&data.Config, data.ColorElapsed)
+	return Color{R: __Lerp(data.ColorFrom.R, data.ColorTo.R, t), G: __Lerp(data.ColorFrom.G, data.ColorTo.G, t), B: __Lerp(data.ColorFrom.B, data.ColorTo.B, t), A: __Lerp(data.ColorFrom.A, data.ColorTo.A, t)}
+}
+
+func __TransitionOpacity(data *__TransitionDataInternal) float32 {
+	return __Lerp(data.OpacityFrom, data.OpacityTo, __TransitionProgress(&data.Config, data.OpacityElapsed))
+}
+
+func __UpdateTransition(data *__TransitionDataInternal, target BoundingBox, targetColor Color, targetOpacity float32) {
+	var (
+		config         *TransitionElementConfig = &data.Config
+		targetPosition Vector2                  = Vector2{X: target.X, Y: target.Y}
+		targetSize     Dimensions               = Dimensions{Width: target.Width, Height: target.Height}
+	)
+	if data.Entering {
+		data.Entering = false
+		data.PositionFrom = targetPosition
+		data.PositionTo = targetPosition
+		data.SizeFrom = targetSize
+		data.SizeTo = targetSize
+		data.ColorFrom = targetColor
+		data.ColorTo = targetColor
+		data.OpacityFrom = targetOpacity
+		data.OpacityTo = targetOpacity
+		data.PositionElapsed = config.Duration
+		data.SizeElapsed = config.Duration
+		data.ColorElapsed = config.Duration
+		data.OpacityElapsed = config.Duration
+		if config.Enter.Enabled {
+			data.PositionFrom = Vector2{X: targetPosition.X + config.Enter.Offset.X, Y: targetPosition.Y + config.Enter.Offset.Y}
+			data.OpacityFrom = config.Enter.Opacity
+			data.PositionElapsed = 0
+			data.OpacityElapsed = 0
+		}
+		return
+	}
+	var deltaTime float32 = GetCurrentContext().deltaTime
+	if data.Exiting {
+		var t float32 = __TransitionProgress(config, data.ExitElapsed)
+		data.Exiting = false
+		data.PositionFrom = Vector2{X: data.PositionTo.X + config.Exit.Offset.X*t, Y: data.PositionTo.Y + config.Exit.Offset.Y*t}
+		data.OpacityFrom = __Lerp(data.ExitOpacity, config.Exit.Opacity, t)
+		data.PositionElapsed = 0
+		data.OpacityElapsed = 0
+		deltaTime = 0
+	}
+	data.PositionElapsed += deltaTime
+	data.SizeElapsed += deltaTime
+	data.ColorElapsed += deltaTime
+	data.OpacityElapsed += deltaTime
+	if targetPosition.X != data.PositionTo.X || targetPosition.Y != data.PositionTo.Y {
+		if (config.Properties & TRANSITION_PROPERTY_POSITION) != 0 {
+			data.PositionFrom = __TransitionPosition(data)
+		} else {
+			data.PositionFrom = targetPosition
+		}
+		data.PositionTo = targetPosition
+		if (config.Properties & TRANSITION_PROPERTY_POSITION) != 0 {
+			data.PositionElapsed = 0
+		} else {
+			data.PositionElapsed = config.Duration
+		}
+	}
+	if targetSize.Width != data.SizeTo.Width || targetSize.Height != data.SizeTo.Height {
+		if (config.Properties & TRANSITION_PROPERTY_SIZE) != 0 {
+			data.SizeFrom = __TransitionSize(data)
+		} else {
+			data.SizeFrom = targetSize
+		}
+		data.SizeTo = targetSize
+		if (config.Properties & TRANSITION_PROPERTY_SIZE) != 0 {
+			data.SizeElapsed = 0
+		} else {
+			data.SizeElapsed = config.Duration
+		}
+	}
+	if !__MemCmp((*byte)(unsafe.Pointer(&targetColor)), (*byte)(unsafe.Pointer(&data.ColorTo)), int32(uint32(unsafe.Sizeof(Color{})))) {
+		if (config.Properties & TRANSITION_PROPERTY_BACKGROUND_COLOR) != 0 {
+			data.ColorFrom = __TransitionColor(data)
+		} else {
+			data.ColorFrom = targetColor
+		}
+		data.ColorTo = targetColor
+		if (config.Properties & TRANSITION_PROPERTY_BACKGROUND_COLOR) != 0 {
+			data.ColorElapsed = 0
+		} else {
+			data.ColorElapsed = config.Duration
+		}
+	}
+	if targetOpacity != data.OpacityTo {
+		if (config.Properties & TRANSITION_PROPERTY_OPACITY) != 0 {
+			data.OpacityFrom = __TransitionOpacity(data)
+		} else {
+			data.OpacityFrom = targetOpacity
+		}
+		data.OpacityTo = targetOpacity
+		if (config.Properties & TRANSITION_PROPERTY_OPACITY) != 0 {
+			data.OpacityElapsed = 0
+		} else {
+			data.OpacityElapsed = config.Duration
+		}
+	}
+}
+
+func __SnapshotTransitionCommands(renderCommands *RenderCommand, count int32) int32 {
+	var (
+		context    *Context = GetCurrentContext()
+		textLength int32    = 0
+		pathLength int32    = 0
+	)
+	for i := int32(0); i < count; i++ {
+		if (*(*RenderCommand)(unsafe.Add(unsafe.Pointer(renderCommands), unsafe.Sizeof(RenderCommand{})*uintptr(i)))).CommandType == RENDER_COMMAND_TYPE_TEXT {
+			textLength += (*(*RenderCommand)(unsafe.Add(unsafe.Pointer(renderCommands), unsafe.Sizeof(RenderCommand{})*uintptr(i)))).RenderData.Text.StringContents.Length
+		} else if (*(*RenderCommand)(unsafe.Add(unsafe.Pointer(renderCommands), unsafe.Sizeof(RenderCommand{})*uintptr(i)))).CommandType == RENDER_COMMAND_TYPE_PATH {
+			pathLength += (*(*RenderCommand)(unsafe.Add(unsafe.Pointer(renderCommands), unsafe.Sizeof(RenderCommand{})*uintptr(i)))).RenderData.Path.Commands.Length
+		}
+	}
+	if context.transitionCommandsNext.Length+count > context.transitionCommandsNext.Capacity || context.transitionTextNext.Length+textLength > context.transitionTextNext.Capacity || context.transitionPathCommandsNext.Length+pathLength > context.transitionPathCommandsNext.Capacity {
+		return -1
+	}
+	var start int32 = context.transitionCommandsNext.Length
+	for i := int32(0); i < count; i++ {
+		var copy_ *RenderCommand = RenderCommandArray_Add(&context.transitionCommandsNext, *(*RenderCommand)(unsafe.Add(unsafe.Pointer(renderCommands), unsafe.Sizeof(RenderCommand{})*uintptr(i))))
+		if copy_.CommandType == RENDER_COMMAND_TYPE_TEXT {
+			var (
+				text    StringSlice = copy_.RenderData.Text.StringContents
+				written String      = __WriteStringToCharBuffer(&context.transitionTextNext, String{Length: text.Length, Chars: text.Chars})
+			)
+			copy_.RenderData.Text.StringContents = StringSlice{Length: written.Length, Chars: written.Chars, BaseChars: written.Chars}
+		} else if copy_.CommandType == RENDER_COMMAND_TYPE_PATH {
+			copy_.RenderData.Path.Commands = __WritePathCommandsToBuffer(&context.transitionPathCommandsNext, copy_.RenderData.Path.Commands)
+		}
 	}
+	return start
+}
+
+func __AddExitingTransitionCommands(parentId uint32, zIndex int16) {
 	var context *Context = GetCurrentContext()
-	var chars *byte = ((*byte)(unsafe.Add(unsafe.Pointer(context.dynamicStringData.InternalArray), context.dynamicStringData.Length)))
-	var length int32 = 0
-	var sign int32 = integer
-	if integer < 0 {
-		integer = -integer
+	for i := int32(0); i < context.transitionDatas.Length; i++ {
+		var transitionData *__TransitionDataInternal = __TransitionDataInternalArray_Get(&context.transitionDatas, i)
+		if !transitionData.Exiting || transitionData.ExitCommandsLength <= 0 || parentId != 0 && transitionData.ParentId != parentId {
+			continue
+		}
+		var config *TransitionElementConfig = &transitionData.Config
+		var t float32 = __TransitionProgress(config, transitionData.ExitElapsed)
+		var opacity float32 = __Lerp(transitionData.ExitOpacity, config.Exit.Opacity, t)
+		if opacity < 1 {
+			var first RenderCommand = *RenderCommandArray_Get(&context.transitionCommandsNext, transitionData.ExitCommandsStart)
+			__AddRenderCommand(RenderCommand{BoundingBox: BoundingBox{X: first.BoundingBox.X + config.Exit.Offset.X*t, Y: first.BoundingBox.Y + config.Exit.Offset.Y*t, Width: first.BoundingBox.Width, Height: first.BoundingBox.Height}, RenderData: RenderData{Layer: LayerRenderData{Opacity: (func() float32 {
+				if opacity > 0 {
+					return opacity
+				}
+				return 0
+			}())}}, Id: first.Id, ZIndex: zIndex, CommandType: RENDER_COMMAND_TYPE_LAYER_START})
+		}
+		for j := int32(0); j < transitionData.ExitCommandsLength; j++ {
+			var renderCommand RenderCommand = *RenderCommandArray_Get(&context.transitionCommandsNext, transitionData.ExitCommandsStart+j)
+			renderCommand.BoundingBox.X += config.Exit.Offset.X * t
+			renderCommand.BoundingBox.Y += config.Exit.Offset.Y * t
+			renderCommand.ZIndex = zIndex
+			__AddRenderCommand(renderCommand)
+		}
+		if opacity < 1 {
+			__AddRenderCommand(RenderCommand{RenderData: RenderData{Layer: LayerRenderData{Opacity: (func() float32 {
+				if opacity > 0 {
+					return opacity
+				}
+				return 0
+			}())}}, Id: RenderCommandArray_Get(&context.transitionCommandsNext, transitionData.ExitCommandsStart).Id, ZIndex: zIndex, CommandType: RENDER_COMMAND_TYPE_LAYER_END})
+		}
+		transitionData.ExitCommandsLength = -transitionData.ExitCommandsLength
 	}
-	for integer > 0 {
-		*(*byte)(unsafe.Add(unsafe.Pointer(chars), func() int32 {
-			p_ := &length
-			x := *p_
-			*p_++
-			return x
-		}())) = byte(int8(integer%10 + '0'))
-		integer /= 10
+}
+
+func __BeginTransitions() {
+	var context *Context = GetCurrentContext()
+	context.transitionCommandsNext.Length = 0
+	context.transitionTextNext.Length = 0
+	context.transitionPathCommandsNext.Length = 0
+	context.exitingTransitionCount = 0
+	for i := int32(0); i < context.transitionDatas.Length; i++ {
+		var transitionData *__TransitionDataInternal = __TransitionDataInternalArray_Get(&context.transitionDatas, i)
+		if transitionData.OpenThisFrame {
+			continue
+		}
+		if !transitionData.Exiting {
+			transitionData.Exiting = true
+			transitionData.ExitElapsed = 0
+			transitionData.ExitOpacity = __TransitionOpacity(transitionData)
+		} else {
+			transitionData.ExitElapsed += context.deltaTime
+		}
+		var finished bool = !transitionData.Config.Exit.Enabled || transitionData.ExitCommandsLength <= 0 || transitionData.ExitElapsed >= transitionData.Config.Duration
+		if !finished {
+			transitionData.ExitCommandsStart = __SnapshotTransitionCommands((*RenderCommand)(unsafe.Add(unsafe.Pointer(context.transitionCommands.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(transitionData.ExitCommandsStart))), transitionData.ExitCommandsLength)
+			finished = transitionData.ExitCommandsStart < 0
+		}
+		if finished {
+			__TransitionDataInternalArray_RemoveSwapback(&context.transitionDatas, i)
+			i--
+			continue
+		}
+		context.exitingTransitionCount++
 	}
-	if sign < 0 {
-		*(*byte)(unsafe.Add(unsafe.Pointer(chars), func() int32 {
-			p_ := &length
-			x := *p_
-			*p_++
-			return x
-		}())) = '-'
+}
+
+func __EndTransitions() {
+	var context *Context = GetCurrentContext()
+	if context.exitingTransitionCount > 0 {
+		__AddExitingTransitionCommands(0, 0)
+	}
+	for i := int32(0); i < context.transitionDatas.Length; i++ {
+		var transitionData *__TransitionDataInternal = __TransitionDataInternalArray_Get(&context.transitionDatas, i)
+		transitionData.OpenThisFrame = false
+		if transitionData.ExitCommandsLength < 0 {
+			transitionData.ExitCommandsLength = -transitionData.ExitCommandsLength
+		}
 	}
-	for j, k := int32(0), int32(length-1); j < k; func() int32 {
-		j++
-		return func() int32 {
-			p_ := &k
-			x := *p_
-			*p_--
-			return x
-		}()
-	}() {
-		var temp int8 = int8(*(*byte)(unsafe.Add(unsafe.Pointer(chars), j)))
-		*(*byte)(unsafe.Add(unsafe.Pointer(chars), j)) = *(*byte)(unsafe.Add(unsafe.Pointer(chars), k))
-		*(*byte)(unsafe.Add(unsafe.Pointer(chars), k)) = byte(temp)
+	var renderCommands RenderCommandArray = context.transitionCommands
+	context.transitionCommands = context.transitionCommandsNext
+	context.transitionCommandsNext = renderCommands
+	var text __charArray = context.transitionText
+	context.transitionText = context.transitionTextNext
+	context.transitionTextNext = text
+	var pathCommands __PathCommandArray = context.transitionPathCommands
+	context.transitionPathCommands = context.transitionPathCommandsNext
+	context.transitionPathCommandsNext = pathCommands
+}
+
+func __GetZoomPanDataForElement(layoutElement *LayoutElement) *__ZoomPanDataInternal {
+	var context *Context = GetCurrentContext()
+	for i := int32(0); i < context.zoomPanDatas.Length; i++ {
+		var zoomPanData *__ZoomPanDataInternal = __ZoomPanDataInternalArray_Get(&context.zoomPanDatas, i)
+		if zoomPanData.LayoutElement == layoutElement {
+			return zoomPanData
+		}
 	}
-	context.dynamicStringData.Length += length
-	return String{Length: length, Chars: chars}
+	return (*__ZoomPanDataInternal)(nil)
+}
+
+func __ScaleUInt16(value uint16, scale float32) uint16 {
+	return uint16(float32(value)*scale + 0.5)
+}
+
+func __BorderSideColor(sideColor Color, sharedColor Color) Color {
+	if sideColor.A > 0 {
+		return sideColor
+	}
+	return sharedColor
+}
+
+func __ScaleCornerRadius(cornerRadius CornerRadius, scale float32) CornerRadius {
+	return CornerRadius{TopLeft: cornerRadius.TopLeft * scale, TopRight: cornerRadius.TopRight * scale, BottomLeft: cornerRadius.BottomLeft * scale, BottomRight: cornerRadius.BottomRight * scale}
+}
+
+func __CreateShadowRenderCommand(config *ShadowElementConfig, boundingBox BoundingBox, cornerRadius CornerRadius, transformScale float32, transform Matrix2D, id uint32, zIndex int16) RenderCommand {
+	var (
+		shadow ShadowRenderData = ShadowRenderData{Color: config.Color, CornerRadius: __ScaleCornerRadius(cornerRadius, transformScale), Offset: Vector2{X: config.Offset.X * transformScale, Y: config.Offset.Y * transformScale}, BlurRadius: config.BlurRadius * transformScale, Spread: config.Spread * transformScale, Inset: config.Inset}
+		extent float32          = (func() float32 {
+			if (shadow.BlurRadius + shadow.Spread) > 0 {
+				return shadow.BlurRadius + shadow.Spread
+			}
+			return 0
+		}())
+		shadowBoundingBox BoundingBox = BoundingBox{X: boundingBox.X + shadow.Offset.X - extent, Y: boundingBox.Y + shadow.Offset.Y - extent, Width: boundingBox.Width + extent*2, Height: boundingBox.Height + extent*2}
+	)
+	_ = shadowBoundingBox
+	var cullingBoundingBox BoundingBox = __TransformBoundingBox(transform, func() BoundingBox {
+		if config.Inset {
+			return boundingBox
+		}
+		return shadowBoundingBox
+	}())
+	var offscreen bool = __ElementIsOffscreen(&cullingBoundingBox)
+	return RenderCommand{BoundingBox: boundingBox, RenderData: RenderData{Shadow: shadow}, Id: __HashNumber(id, 13).Id, ZIndex: zIndex, CommandType: func() RenderCommandType {
+		if offscreen {
+			return RENDER_COMMAND_TYPE_NONE
+		}
+		return RENDER_COMMAND_TYPE_SHADOW
+	}()}
+}
+
+func __ResolveStickyChildren(dfsBuffer *__LayoutElementTreeNodeArray, parentNode *__LayoutElementTreeNode) {
+	var (
+		context        *Context       = GetCurrentContext()
+		parent         *LayoutElement = parentNode.LayoutElement
+		childCount     int32          = int32(parent.ChildrenOrTextContent.Children.Length)
+		firstNodeIndex int32          = dfsBuffer.Length - childCount
+		contentEnd     Vector2        = Vector2{X: parentNode.Position.X + parent.Dimensions.Width - float32(parent.LayoutConfig.Padding.Right), Y: parentNode.Position.Y + parent.Dimensions.Height - float32(parent.LayoutConfig.Padding.Bottom)}
+	)
+	for i := int32(0); i < childCount; i++ {
+		var node *__LayoutElementTreeNode = (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(dfsBuffer.Length-1-i)))
+		if node.Position.X+node.LayoutElement.Dimensions.Width > contentEnd.X {
+			contentEnd.X = node.Position.X + node.LayoutElement.Dimensions.Width
+		}
+		if node.Position.Y+node.LayoutElement.Dimensions.Height > contentEnd.Y {
+			contentEnd.Y = node.Position.Y + node.LayoutElement.Dimensions.Height
+		}
+	}
+	for i := int32(0); i < childCount; i++ {
+		var (
+			node         *__LayoutElementTreeNode = (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(dfsBuffer.Length-1-i)))
+			childElement *LayoutElement           = node.LayoutElement
+			stickyConfig *StickyElementConfig     = __FindElementConfigWithType(childElement, __ELEMENT_CONFIG_TYPE_STICKY).StickyElementConfig
+		)
+		if stickyConfig == nil {
+			continue
+		}
+		var viewport BoundingBox = BoundingBox{X: 0, Y: 0, Width: context.layoutDimensions.Width, Height: context.layoutDimensions.Height}
+		var clipElementId uint32 = uint32(__int32_tArray_GetValue(&context.layoutElementClipElementIds, *(*int32)(unsafe.Add(unsafe.Pointer(parent.ChildrenOrTextContent.Children.Elements), unsafe.Sizeof(int32(0))*uintptr(i)))))
+		if clipElementId != 0 {
+			viewport = __GetHashMapItem(clipElementId).BoundingBox
+		}
+		var limit Vector2 = Vector2{X: contentEnd.X - childElement.Dimensions.Width, Y: contentEnd.Y - childElement.Dimensions.Height}
+		var pushedHorizontally bool = false
+		var pushedVertically bool = false
+		for j := int32(i + 1); j < childCount && (!pushedHorizontally || !pushedVertically); j++ {
+			var (
+				nextNode   *__LayoutElementTreeNode = (*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(dfsBuffer.Length-1-j)))
+				nextConfig *StickyElementConfig     = __FindElementConfigWithType(nextNode.LayoutElement, __ELEMENT_CONFIG_TYPE_STICKY).StickyElementConfig
+			)
+			if nextConfig == nil {
+				continue
+			}
+			if nextConfig.Horizontal && !pushedHorizontally {
+				if nextNode.Position.X-childElement.Dimensions.Width < limit.X {
+					limit.X = nextNode.Position.X - childElement.Dimensions.Width
+				}
+				pushedHorizontally = true
+			}
+			if nextConfig.Vertical && !pushedVertically {
+				if nextNode.Position.Y-childElement.Dimensions.Height < limit.Y {
+					limit.Y = nextNode.Position.Y - childElement.Dimensions.Height
+				}
+				pushedVertically = true
+			}
+		}
+		var stuckX float32 = (func() float32 {
+			if (viewport.X + stickyConfig.Offset.X) < limit.X {
+				return viewport.X + stickyConfig.Offset.X
+			}
+			return limit.X
+		}())
+		if stickyConfig.Horizontal && stuckX > node.Position.X {
+			node.Position.X = stuckX
+		}
+		var stuckY float32 = (func() float32 {
+			if (viewport.Y + stickyConfig.Offset.Y) < limit.Y {
+				return viewport.Y + stickyConfig.Offset.Y
+			}
+			return limit.Y
+		}())
+		if stickyConfig.Vertical && stuckY > node.Position.Y {
+			node.Position.Y = stuckY
+		}
+	}
+	var writeIndex int32 = firstNodeIndex
+	for nodeIndex := int32(firstNodeIndex); nodeIndex < dfsBuffer.Length; nodeIndex++ {
+		var node __LayoutElementTreeNode = *(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(nodeIndex)))
+		if !__ElementHasConfig(node.LayoutElement, __ELEMENT_CONFIG_TYPE_STICKY) {
+			continue
+		}
+		for k := int32(nodeIndex); k > writeIndex; k-- {
+			*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(k))) = *(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(k-1)))
+		}
+		*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(writeIndex))) = node
+		writeIndex++
+	}
+}
+
+func __ScrollViewportSize(scrollData *__ScrollContainerDataInternal) Dimensions {
+	return Dimensions{Width: scrollData.BoundingBox.Width / scrollData.Scale, Height: scrollData.BoundingBox.Height / scrollData.Scale}
+}
+
+func __GetScrollbarGeometry(scrollData *__ScrollContainerDataInternal, clipConfig *ClipElementConfig, vertical bool, track *BoundingBox, thumb *BoundingBox) bool {
+	var (
+		config         *ScrollbarConfig = &clipConfig.Scrollbar
+		box            BoundingBox      = scrollData.BoundingBox
+		viewport       Dimensions       = __ScrollViewportSize(scrollData)
+		showHorizontal bool             = clipConfig.Horizontal && scrollData.ContentSize.Width > viewport.Width
+		showVertical   bool             = clipConfig.Vertical && scrollData.ContentSize.Height > viewport.Height
+	)
+	if config.Width <= 0 || (func() bool {
+		if vertical {
+			return !showVertical
+		}
+		return !showHorizontal
+	}()) {
+		return false
+	}
+	var width float32 = config.Width * scrollData.Scale
+	var minThumbLength float32 = (func() float32 {
+		if config.MinThumbLength > config.Width {
+			return config.MinThumbLength
+		}
+		return config.Width
+	}()) * scrollData.Scale
+	if vertical {
+		*track = BoundingBox{X: box.X + box.Width - width, Y: box.Y, Width: width, Height: box.Height - (func() float32 {
+			if showHorizontal {
+				return width
+			}
+			return 0
+		}())}
+		var thumbLength float32 = (func() float32 {
+			if (func() float32 {
+				if (track.Height * viewport.Height / scrollData.ContentSize.Height) > minThumbLength {
+					return track.Height * viewport.Height / scrollData.ContentSize.Height
+				}
+				return minThumbLength
+			}()) < track.Height {
+				if (track.Height * viewport.Height / scrollData.ContentSize.Height) > minThumbLength {
+					return track.Height * viewport.Height / scrollData.ContentSize.Height
+				}
+				return minThumbLength
+			}
+			return track.Height
+		}())
+		var progress float32 = (func() float32 {
+			if (func() float32 {
+				if (-scrollData.ScrollPosition.Y / (scrollData.ContentSize.Height - viewport.Height)) > 0 {
+					return -scrollData.ScrollPosition.Y / (scrollData.ContentSize.Height - viewport.Height)
+				}
+				return 0
+			}()) < 1 {
+				if (-scrollData.ScrollPosition.Y / (scrollData.ContentSize.Height - viewport.Height)) > 0 {
+					return -scrollData.ScrollPosition.Y / (scrollData.ContentSize.Height - viewport.Height)
+				}
+				return 0
+			}
+			return 1
+		}())
+		*thumb = BoundingBox{X: track.X, Y: track.Y + (track.Height-thumbLength)*progress, Width: width, Height: thumbLength}
+	} else {
+		*track = BoundingBox{X: box.X, Y: box.Y + box.Height - width, Width: box.Width - (func() float32 {
+			if showVertical {
+				return width
+			}
+			return 0
+		}()), Height: width}
+		var thumbLength float32 = (func() float32 {
+			if (func() float32 {
+				if (track.Width * viewport.Width / scrollData.ContentSize.Width) > minThumbLength {
+					return track.Width * viewport.Width / scrollData.ContentSize.Width
+				}
+				return minThumbLength
+			}()) < track.Width {
+				if (track.Width * viewport.Width / scrollData.ContentSize.Width) > minThumbLength {
+					return track.Width * viewport.Width / scrollData.ContentSize.Width
+				}
+				return minThumbLength
+			}
+			return track.Width
+		}())
+		var progress float32 = (func() float32 {
+			if (func() float32 {
+				if (-scrollData.ScrollPosition.X / (scrollData.ContentSize.Width - viewport.Width)) > 0 {
+					return -scrollData.ScrollPosition.X / (scrollData.ContentSize.Width - viewport.Width)
+				}
+				return 0
+			}()) < 1 {
+				if (-scrollData.ScrollPosition.X / (scrollData.ContentSize.Width - viewport.Width)) > 0 {
+					return -scrollData.ScrollPosition.X / (scrollData.ContentSize.Width - viewport.Width)
+				}
+				return 0
+			}
+			return 1
+		}())
+		*thumb = BoundingBox{X: track.X + (track.Width-thumbLength)*progress, Y: track.Y, Width: thumbLength, Height: width}
+	}
+	return true
+}
+
+func __AddScrollbarRenderCommands(scrollData *__ScrollContainerDataInternal, clipConfig *ClipElementConfig, zIndex int16) {
+	var config *ScrollbarConfig = &clipConfig.Scrollbar
+	if config.AutoHideDelay > 0 && scrollData.ScrollbarIdleTime > config.AutoHideDelay {
+		return
+	}
+	for axis := int32(0); axis < 2; axis++ {
+		var (
+			track BoundingBox
+			thumb BoundingBox
+		)
+		if !__GetScrollbarGeometry(scrollData, clipConfig, axis == 1, &track, &thumb) {
+			continue
+		}
+		if config.TrackColor.A > 0 {
+			__AddRenderCommand(RenderCommand{BoundingBox: track, RenderData: RenderData{Rectangle: RectangleRenderData{BackgroundColor: config.TrackColor, CornerRadius: config.CornerRadius}}, Id: __HashStringWithOffset(String{IsStaticallyAllocated: true, Length: int32(((len("__ScrollbarTrack") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("__ScrollbarTrack")}, uint32(axis), scrollData.ElementId).Id, ZIndex: zIndex, CommandType: RENDER_COMMAND_TYPE_RECTANGLE})
+		}
+		__AddRenderCommand(RenderCommand{BoundingBox: thumb, RenderData: RenderData{Rectangle: RectangleRenderData{BackgroundColor: config.ThumbColor, CornerRadius: config.CornerRadius}}, Id: __HashStringWithOffset(String{IsStaticallyAllocated: true, Length: int32(((len("__ScrollbarThumb") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("__ScrollbarThumb")}, uint32(axis), scrollData.ElementId).Id, ZIndex: zIndex, CommandType: RENDER_COMMAND_TYPE_RECTANGLE})
+	}
+}
+
+func __UpdateScrollbars(scrollData *__ScrollContainerDataInternal, clipConfig *ClipElementConfig, deltaTime float32) bool {
+	var (
+		context *Context    = GetCurrentContext()
+		pointer PointerData = context.pointerInfo
+	)
+	if pointer.State != POINTER_DATA_PRESSED && pointer.State != POINTER_DATA_PRESSED_THIS_FRAME {
+		scrollData.ScrollbarPressed = false
+		scrollData.ScrollbarDragAxis = 0
+	}
+	var hovered bool = false
+	for axis := int32(0); axis < 2; axis++ {
+		var (
+			vertical bool = axis == 1
+			track    BoundingBox
+			thumb    BoundingBox
+		)
+		if !__GetScrollbarGeometry(scrollData, clipConfig, vertical, &track, &thumb) {
+			if scrollData.ScrollbarDragAxis == axis+1 {
+				scrollData.ScrollbarDragAxis = 0
+			}
+			continue
+		}
+		var overTrack bool = __PointIsInsideRect(pointer.Position, track)
+		hovered = hovered || overTrack
+		if pointer.State == POINTER_DATA_PRESSED_THIS_FRAME && overTrack {
+			scrollData.ScrollbarPressed = true
+			scrollData.ScrollMomentum = Vector2{}
+			if __PointIsInsideRect(pointer.Position, thumb) {
+				scrollData.ScrollbarDragAxis = axis + 1
+				scrollData.PointerOrigin = pointer.Position
+				scrollData.ScrollOrigin = scrollData.ScrollPosition
+				scrollData.ScrollAnimationActive = false
+			} else {
+				var target Vector2
+				if scrollData.ScrollAnimationActive {
+					target = scrollData.ScrollTarget
+				} else {
+					target = scrollData.ScrollPosition
+				}
+				var viewport Dimensions = __ScrollViewportSize(scrollData)
+				if vertical {
+					if pointer.Position.Y < thumb.Y {
+						target.Y += viewport.Height
+					} else {
+						target.Y += -viewport.Height
+					}
+				} else {
+					if pointer.Position.X < thumb.X {
+						target.X += viewport.Width
+					} else {
+						target.X += -viewport.Width
+					}
+				}
+				scrollData.ScrollTarget = __ClampScrollPosition(scrollData, target)
+				scrollData.ScrollAnimationActive = true
+			}
+		} else if scrollData.ScrollbarDragAxis == axis+1 {
+			var viewport Dimensions = __ScrollViewportSize(scrollData)
+			if vertical && track.Height > thumb.Height {
+				scrollData.ScrollPosition.Y = scrollData.ScrollOrigin.Y - (pointer.Position.Y-scrollData.PointerOrigin.Y)*(scrollData.ContentSize.Height-viewport.Height)/(track.Height-thumb.Height)
+			} else if !vertical && track.Width > thumb.Width {
+				scrollData.ScrollPosition.X = scrollData.ScrollOrigin.X - (pointer.Position.X-scrollData.PointerOrigin.X)*(scrollData.ContentSize.Width-viewport.Width)/(track.Width-thumb.Width)
+			}
+			scrollData.ScrollPosition = __ClampScrollPosition(scrollData, scrollData.ScrollPosition)
+		}
+	}
+	var moved bool = scrollData.ScrollPosition.X != scrollData.ScrollbarPreviousPosition.X || scrollData.ScrollPosition.Y != scrollData.ScrollbarPreviousPosition.Y
+	scrollData.ScrollbarPreviousPosition = scrollData.ScrollPosition
+	if moved || hovered || scrollData.ScrollbarPressed {
+		scrollData.ScrollbarIdleTime = 0
+	} else {
+		scrollData.ScrollbarIdleTime = scrollData.ScrollbarIdleTime + deltaTime
+	}
+	return scrollData.ScrollbarPressed
+}
+
+func __IntersectBoundingBoxes(a BoundingBox, b BoundingBox) BoundingBox {
+	var (
+		x float32 = (func() float32 {
+			if a.X > b.X {
+				return a.X
+			}
+			return b.X
+		}())
+		y float32 = (func() float32 {
+			if a.Y > b.Y {
+				return a.Y
+			}
+			return b.Y
+		}())
+	)
+	return BoundingBox{X: x, Y: y, Width: (func() float32 {
+		if (a.X + a.Width) < (b.X + b.Width) {
+			return a.X + a.Width
+		}
+		return b.X + b.Width
+	}()) - x, Height: (func() float32 {
+		if (a.Y + a.Height) < (b.Y + b.Height) {
+			return a.Y + a.Height
+		}
+		return b.Y + b.Height
+	}()) - y}
 }
 
-func __AddRenderCommand(renderCommand RenderCommand) {
-	var context *Context = GetCurrentContext()
-	if context.renderCommands.Length < context.renderCommands.Capacity-1 {
-		RenderCommandArray_Add(&context.renderCommands, renderCommand)
+func __CalculateVisibility(boundingBox BoundingBox, clipBoundingBox BoundingBox) ElementVisibility {
+	var (
+		visibleBoundingBox BoundingBox = __IntersectBoundingBoxes(boundingBox, clipBoundingBox)
+		area               float32     = boundingBox.Width * boundingBox.Height
+		visibleFraction    float32
+	)
+	if area > 0 {
+		visibleFraction = (visibleBoundingBox.Width * visibleBoundingBox.Height) / area
 	} else {
-		if !context.booleanWarnings.MaxRenderCommandsExceeded {
-			context.booleanWarnings.MaxRenderCommandsExceeded = true
-			context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay ran out of capacity while attempting to create render commands. This is usually caused by a large amount of wrapping text elements while close to the max element capacity. Try using SetMaxElementCount() with a higher value.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay ran out of capacity while attempting to create render commands. This is usually caused by a large amount of wrapping text elements while close to the max element capacity. Try using SetMaxElementCount() with a higher value.")}, UserData: context.errorHandler.UserData})
-		}
+		visibleFraction = 1
+	}
+	if visibleBoundingBox.Width < 0 || visibleBoundingBox.Height < 0 || visibleFraction <= 0 {
+		return ElementVisibility{Found: true}
 	}
+	return ElementVisibility{VisibleBoundingBox: visibleBoundingBox, VisibleFraction: visibleFraction, Visible: visibleFraction > 0, Found: true}
 }
 
-func __ElementIsOffscreen(boundingBox *BoundingBox) bool {
+func __DispatchLifecycleEvents() {
 	var context *Context = GetCurrentContext()
-	if context.disableCulling {
-		return false
+	for i := int32(0); i < context.layoutElementsHashMapInternal.Length; i++ {
+		var hashItem *LayoutElementHashMapItem = __LayoutElementHashMapItemArray_Get(&context.layoutElementsHashMapInternal, i)
+		if hashItem.Generation <= context.generation {
+			hashItem.Visibility = ElementVisibility{}
+		}
+		if hashItem.OnLifecycleFunction == nil {
+			continue
+		}
+		if hashItem.Generation == context.generation {
+			hashItem.OnLifecycleFunction(hashItem.ElementId, LIFECYCLE_EVENT_DISAPPEARED, hashItem.Visibility, hashItem.LifecycleFunctionUserData)
+			hashItem.OnLifecycleFunction = nil
+		} else if hashItem.Generation == context.generation+1 {
+			var visibility ElementVisibility = hashItem.Visibility
+			if hashItem.Appeared {
+				hashItem.OnLifecycleFunction(hashItem.ElementId, LIFECYCLE_EVENT_APPEARED, visibility, hashItem.LifecycleFunctionUserData)
+			} else if visibility.Visible != hashItem.PreviousVisibility.Visible || visibility.VisibleFraction != hashItem.PreviousVisibility.VisibleFraction {
+				hashItem.OnLifecycleFunction(hashItem.ElementId, LIFECYCLE_EVENT_VISIBILITY_CHANGED, visibility, hashItem.LifecycleFunctionUserData)
+			}
+		}
 	}
-	return boundingBox.X > context.layoutDimensions.Width || boundingBox.Y > context.layoutDimensions.Height || boundingBox.X+boundingBox.Width < 0 || boundingBox.Y+boundingBox.Height < 0
 }
 
 func __CalculateFinalLayout() {
 	var context *Context = GetCurrentContext()
+	__BeginTransitions()
 	__SizeContainersAlongAxis(true)
 	for textElementIndex := int32(0); textElementIndex < context.textElementData.Length; textElementIndex++ {
 		var textElementData *__TextElementData = __TextElementDataArray_Get(&context.textElementData, textElementIndex)
@@ -3973,95 +6406,27 @@ func __CalculateFinalLayout() {
 		var root *__LayoutElementTreeRoot = __LayoutElementTreeRootArray_Get(&context.layoutElementTreeRoots, rootIndex)
 		var rootElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, root.LayoutElementIndex)
 		var rootPosition Vector2 = Vector2{}
+		var rootTransformOffset Vector2 = Vector2{}
+		var rootTransformScale float32 = 1
+		var rootTransform Matrix2D = __Matrix2D_IDENTITY
+		var rootClipBoundingBox BoundingBox = BoundingBox{X: 0, Y: 0, Width: context.layoutDimensions.Width, Height: context.layoutDimensions.Height}
 		var parentHashMapItem *LayoutElementHashMapItem = __GetHashMapItem(root.ParentId)
 		if __ElementHasConfig(rootElement, __ELEMENT_CONFIG_TYPE_FLOATING) && parentHashMapItem != nil {
 			var (
-				config               *FloatingElementConfig = __FindElementConfigWithType(rootElement, __ELEMENT_CONFIG_TYPE_FLOATING).FloatingElementConfig
-				rootDimensions       Dimensions             = rootElement.Dimensions
-				parentBoundingBox    BoundingBox            = parentHashMapItem.BoundingBox
-				targetAttachPosition Vector2                = Vector2{}
+				config            *FloatingElementConfig = __FindElementConfigWithType(rootElement, __ELEMENT_CONFIG_TYPE_FLOATING).FloatingElementConfig
+				rootDimensions    Dimensions             = rootElement.Dimensions
+				parentBoundingBox BoundingBox            = parentHashMapItem.BoundingBox
 			)
-			switch config.AttachPoints.Parent {
-			case ATTACH_POINT_LEFT_TOP:
-				fallthrough
-			case ATTACH_POINT_LEFT_CENTER:
-				fallthrough
-			case ATTACH_POINT_LEFT_BOTTOM:
-				targetAttachPosition.X = parentBoundingBox.X
-			case ATTACH_POINT_CENTER_TOP:
-				fallthrough
-			case ATTACH_POINT_CENTER_CENTER:
-				fallthrough
-			case ATTACH_POINT_CENTER_BOTTOM:
-				targetAttachPosition.X = parentBoundingBox.X + parentBoundingBox.Width/2
-			case ATTACH_POINT_RIGHT_TOP:
-				fallthrough
-			case ATTACH_POINT_RIGHT_CENTER:
-				fallthrough
-			case ATTACH_POINT_RIGHT_BOTTOM:
-				targetAttachPosition.X = parentBoundingBox.X + parentBoundingBox.Width
-			}
-			switch config.AttachPoints.Element {
-			case ATTACH_POINT_LEFT_TOP:
-				fallthrough
-			case ATTACH_POINT_LEFT_CENTER:
-				fallthrough
-			case ATTACH_POINT_LEFT_BOTTOM:
-			case ATTACH_POINT_CENTER_TOP:
-				fallthrough
-			case ATTACH_POINT_CENTER_CENTER:
-				fallthrough
-			case ATTACH_POINT_CENTER_BOTTOM:
-				targetAttachPosition.X -= rootDimensions.Width / 2
-			case ATTACH_POINT_RIGHT_TOP:
-				fallthrough
-			case ATTACH_POINT_RIGHT_CENTER:
-				fallthrough
-			case ATTACH_POINT_RIGHT_BOTTOM:
-				targetAttachPosition.X -= rootDimensions.Width
+			if parentHashMapItem.TransformScale > 0 {
+				rootTransformOffset = parentHashMapItem.TransformOffset
+				rootTransformScale = parentHashMapItem.TransformScale
+				parentBoundingBox = BoundingBox{X: (parentBoundingBox.X - rootTransformOffset.X) / rootTransformScale, Y: (parentBoundingBox.Y - rootTransformOffset.Y) / rootTransformScale, Width: parentBoundingBox.Width / rootTransformScale, Height: parentBoundingBox.Height / rootTransformScale}
 			}
-			switch config.AttachPoints.Parent {
-			case ATTACH_POINT_LEFT_TOP:
-				fallthrough
-			case ATTACH_POINT_RIGHT_TOP:
-				fallthrough
-			case ATTACH_POINT_CENTER_TOP:
-				targetAttachPosition.Y = parentBoundingBox.Y
-			case ATTACH_POINT_LEFT_CENTER:
-				fallthrough
-			case ATTACH_POINT_CENTER_CENTER:
-				fallthrough
-			case ATTACH_POINT_RIGHT_CENTER:
-				targetAttachPosition.Y = parentBoundingBox.Y + parentBoundingBox.Height/2
-			case ATTACH_POINT_LEFT_BOTTOM:
-				fallthrough
-			case ATTACH_POINT_CENTER_BOTTOM:
-				fallthrough
-			case ATTACH_POINT_RIGHT_BOTTOM:
-				targetAttachPosition.Y = parentBoundingBox.Y + parentBoundingBox.Height
+			rootTransform = parentHashMapItem.Transform
+			rootPosition = __FloatingAttachPosition(config.AttachPoints, config.Offset, parentBoundingBox, rootDimensions)
+			if config.Collision != FLOATING_COLLISION_NONE {
+				rootPosition = __ResolveFloatingCollision(config, parentHashMapItem, parentBoundingBox, rootDimensions, rootPosition, rootTransformOffset, rootTransformScale)
 			}
-			switch config.AttachPoints.Element {
-			case ATTACH_POINT_LEFT_TOP:
-				fallthrough
-			case ATTACH_POINT_RIGHT_TOP:
-				fallthrough
-			case ATTACH_POINT_CENTER_TOP:
-			case ATTACH_POINT_LEFT_CENTER:
-				fallthrough
-			case ATTACH_POINT_CENTER_CENTER:
-				fallthrough
-			case ATTACH_POINT_RIGHT_CENTER:
-				targetAttachPosition.Y -= rootDimensions.Height / 2
-			case ATTACH_POINT_LEFT_BOTTOM:
-				fallthrough
-			case ATTACH_POINT_CENTER_BOTTOM:
-				fallthrough
-			case ATTACH_POINT_RIGHT_BOTTOM:
-				targetAttachPosition.Y -= rootDimensions.Height
-			}
-			targetAttachPosition.X += config.Offset.X
-			targetAttachPosition.Y += config.Offset.Y
-			rootPosition = targetAttachPosition
 		}
 		if root.ClipElementId != 0 {
 			var clipHashMapItem *LayoutElementHashMapItem = __GetHashMapItem(root.ClipElementId)
@@ -4075,10 +6440,26 @@ func __CalculateFinalLayout() {
 						rootPosition.Y += clipConfig.ChildOffset.Y
 					}
 				}
-				__AddRenderCommand(RenderCommand{BoundingBox: clipHashMapItem.BoundingBox, UserData: 0, Id: __HashNumber(rootElement.Id, uint32(int32(rootElement.ChildrenOrTextContent.Children.Length)+10)).Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_SCISSOR_START})
+				if clipHashMapItem.Visibility.Visible {
+					rootClipBoundingBox = clipHashMapItem.Visibility.VisibleBoundingBox
+				} else {
+					rootClipBoundingBox.Width = -1
+				}
+				var clipCornerRadius CornerRadius = CornerRadius{}
+				if __ElementHasConfig(clipHashMapItem.LayoutElement, __ELEMENT_CONFIG_TYPE_SHARED) {
+					var clipTransformScale float32
+					if clipHashMapItem.TransformScale > 0 {
+						clipTransformScale = clipHashMapItem.TransformScale
+					} else {
+						clipTransformScale = 1
+					}
+					clipCornerRadius = __ScaleCornerRadius(__FindElementConfigWithType(clipHashMapItem.LayoutElement, __ELEMENT_CONFIG_TYPE_SHARED).SharedElementConfig.CornerRadius, clipTransformScale)
+				}
+				__AddRenderCommand(RenderCommand{BoundingBox: clipHashMapItem.BoundingBox, RenderData: RenderData{Clip: ClipRenderData{CornerRadius: clipCornerRadius}}, Transform: clipHashMapItem.Transform, UserData: 0, Id: __HashNumber(rootElement.Id, uint32(int32(rootElement.ChildrenOrTextContent.Children.Length)+10)).Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_SCISSOR_START})
 			}
 		}
-		__LayoutElementTreeNodeArray_Add(&dfsBuffer, __LayoutElementTreeNode{LayoutElement: rootElement, Position: rootPosition, NextChildOffset: Vector2{X: float32(rootElement.LayoutConfig.Padding.Left), Y: float32(rootElement.LayoutConfig.Padding.Top)}})
+		__LayoutElementTreeNodeArray_Add(&dfsBuffer, __LayoutElementTreeNode{LayoutElement: rootElement, Position: rootPosition, NextChildOffset: Vector2{X: float32(rootElement.LayoutConfig.Padding.Left), Y: float32(rootElement.LayoutConfig.Padding.Top)}, TransformOffset: rootTransformOffset, TransformScale: rootTransformScale, Transform: rootTransform, ParentOrigin: rootPosition, ClipBoundingBox: rootClipBoundingBox})
+		var rootRenderCommandStart int32 = context.renderCommands.Length
 		*context.treeNodeVisited.InternalArray = false
 		for dfsBuffer.Length > 0 {
 			var (
@@ -4100,6 +6481,63 @@ func __CalculateFinalLayout() {
 					currentElementBoundingBox.Y -= expand.Height
 					currentElementBoundingBox.Height += expand.Height * 2
 				}
+				currentElementTreeNode.RenderCommandStart = context.renderCommands.Length
+				currentElementTreeNode.Opacity = 1
+				currentElementBoundingBox.X += currentElementTreeNode.TransitionOffset.X
+				currentElementBoundingBox.Y += currentElementTreeNode.TransitionOffset.Y
+				currentElementTreeNode.ChildTransitionOffset = currentElementTreeNode.TransitionOffset
+				var transitionData *__TransitionDataInternal
+				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_TRANSITION) {
+					transitionData = __GetTransitionData(currentElement.Id)
+				} else {
+					transitionData = (*__TransitionDataInternal)(nil)
+				}
+				if transitionData != nil {
+					var (
+						targetSharedConfig *SharedElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_SHARED).SharedElementConfig
+						origin             Vector2              = Vector2{X: currentElementTreeNode.ParentOrigin.X + currentElementTreeNode.TransitionOffset.X, Y: currentElementTreeNode.ParentOrigin.Y + currentElementTreeNode.TransitionOffset.Y}
+					)
+					__UpdateTransition(transitionData, BoundingBox{X: currentElementBoundingBox.X - origin.X, Y: currentElementBoundingBox.Y - origin.Y, Width: currentElementBoundingBox.Width, Height: currentElementBoundingBox.Height}, func() Color {
+						if targetSharedConfig != nil {
+							return targetSharedConfig.BackgroundColor
+						}
+						return Color{}
+					}(), func() float32 {
+						if (transitionData.Config.Properties&TRANSITION_PROPERTY_OPACITY) != 0 && transitionData.Config.Opacity > 0 {
+							return transitionData.Config.Opacity
+						}
+						return 1
+					}())
+					var position Vector2 = __TransitionPosition(transitionData)
+					var size Dimensions = __TransitionSize(transitionData)
+					currentElementTreeNode.ChildTransitionOffset.X += origin.X + position.X - currentElementBoundingBox.X
+					currentElementTreeNode.ChildTransitionOffset.Y += origin.Y + position.Y - currentElementBoundingBox.Y
+					currentElementBoundingBox = BoundingBox{X: origin.X + position.X, Y: origin.Y + position.Y, Width: size.Width, Height: size.Height}
+					currentElementTreeNode.Opacity = __TransitionOpacity(transitionData)
+				}
+				var transformScale float32 = currentElementTreeNode.TransformScale
+				currentElementBoundingBox = BoundingBox{X: currentElementBoundingBox.X*transformScale + currentElementTreeNode.TransformOffset.X, Y: currentElementBoundingBox.Y*transformScale + currentElementTreeNode.TransformOffset.Y, Width: currentElementBoundingBox.Width * transformScale, Height: currentElementBoundingBox.Height * transformScale}
+				if currentElementTreeNode.Opacity < 1 {
+					__AddRenderCommand(RenderCommand{BoundingBox: currentElementBoundingBox, RenderData: RenderData{Layer: LayerRenderData{Opacity: (func() float32 {
+						if currentElementTreeNode.Opacity > 0 {
+							return currentElementTreeNode.Opacity
+						}
+						return 0
+					}())}}, Id: __HashNumber(currentElement.Id, 16).Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_LAYER_START})
+				}
+				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_ZOOM_PAN) {
+					var zoomPanData *__ZoomPanDataInternal = __GetZoomPanDataForElement(currentElement)
+					if zoomPanData != nil {
+						zoomPanData.BoundingBox = currentElementBoundingBox
+						zoomPanData.ParentScale = transformScale
+					}
+				}
+				var transformConfig *TransformElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_TRANSFORM).TransformElementConfig
+				if transformConfig != nil {
+					currentElementTreeNode.Transform = __MultiplyMatrices(currentElementTreeNode.Transform, __ElementTransform(transformConfig, currentElementBoundingBox, transformScale))
+				}
+				var transformed bool = !__MatrixIsIdentity(currentElementTreeNode.Transform)
+				var cullingBoundingBox BoundingBox = __TransformBoundingBox(currentElementTreeNode.Transform, currentElementBoundingBox)
 				var scrollContainerData *__ScrollContainerDataInternal = (*__ScrollContainerDataInternal)(nil)
 				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_CLIP) {
 					var clipConfig *ClipElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_CLIP).ClipElementConfig
@@ -4108,6 +6546,7 @@ func __CalculateFinalLayout() {
 						if mapping.LayoutElement == currentElement {
 							scrollContainerData = mapping
 							mapping.BoundingBox = currentElementBoundingBox
+							mapping.Scale = transformScale
 							scrollOffset = clipConfig.ChildOffset
 							if context.externalScrollHandlingEnabled {
 								scrollOffset = Vector2{}
@@ -4119,6 +6558,14 @@ func __CalculateFinalLayout() {
 				var hashMapItem *LayoutElementHashMapItem = __GetHashMapItem(currentElement.Id)
 				if hashMapItem != nil {
 					hashMapItem.BoundingBox = currentElementBoundingBox
+					hashMapItem.TransformOffset = currentElementTreeNode.TransformOffset
+					hashMapItem.TransformScale = transformScale
+					hashMapItem.Transform = currentElementTreeNode.Transform
+					hashMapItem.PreviousVisibility = hashMapItem.Visibility
+					hashMapItem.Visibility = __CalculateVisibility(currentElementBoundingBox, currentElementTreeNode.ClipBoundingBox)
+				}
+				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_CLIP) {
+					currentElementTreeNode.ClipBoundingBox = __IntersectBoundingBoxes(currentElementBoundingBox, currentElementTreeNode.ClipBoundingBox)
 				}
 				var sortedConfigIndexes [20]int32
 				for elementConfigIndex := int32(0); elementConfigIndex < currentElement.ElementConfigs.Length; elementConfigIndex++ {
@@ -4142,17 +6589,42 @@ func __CalculateFinalLayout() {
 				}
 				var emitRectangle bool = false
 				var sharedConfig *SharedElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_SHARED).SharedElementConfig
-				if sharedConfig != nil && sharedConfig.BackgroundColor.A > 0 {
+				if sharedConfig != nil && (sharedConfig.BackgroundColor.A > 0 || sharedConfig.BackgroundGradient.Type != GRADIENT_TYPE_NONE) {
 					emitRectangle = true
 				} else if sharedConfig == nil {
 					emitRectangle = false
 					sharedConfig = &SharedElementConfig_DEFAULT
 				}
+				var transitionSharedConfig SharedElementConfig
+				if transitionData != nil {
+					transitionSharedConfig = *sharedConfig
+					transitionSharedConfig.BackgroundColor = __TransitionColor(transitionData)
+					sharedConfig = &transitionSharedConfig
+					emitRectangle = transitionSharedConfig.BackgroundColor.A > 0 || transitionSharedConfig.BackgroundGradient.Type != GRADIENT_TYPE_NONE
+				}
+				var opacityConfig *OpacityElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_OPACITY).OpacityElementConfig
+				if opacityConfig != nil {
+					__AddRenderCommand(RenderCommand{BoundingBox: currentElementBoundingBox, RenderData: RenderData{Layer: LayerRenderData{Opacity: (func() float32 {
+						if opacityConfig.Value > 0 {
+							return opacityConfig.Value
+						}
+						return 0
+					}())}}, Id: __HashNumber(currentElement.Id, 14).Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_LAYER_START})
+				}
+				var shadowConfig *ShadowElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_SHADOW).ShadowElementConfig
+				var shadowRenderCommand RenderCommand = RenderCommand{}
+				if shadowConfig != nil {
+					shadowRenderCommand = __CreateShadowRenderCommand(shadowConfig, currentElementBoundingBox, sharedConfig.CornerRadius, transformScale, currentElementTreeNode.Transform, currentElement.Id, root.ZIndex)
+					shadowRenderCommand.UserData = sharedConfig.UserData
+				}
+				if shadowRenderCommand.CommandType == RENDER_COMMAND_TYPE_SHADOW && !shadowConfig.Inset {
+					__AddRenderCommand(shadowRenderCommand)
+				}
 				for elementConfigIndex := int32(0); elementConfigIndex < currentElement.ElementConfigs.Length; elementConfigIndex++ {
 					var (
 						elementConfig *ElementConfig = __ElementConfigArraySlice_Get(&currentElement.ElementConfigs, sortedConfigIndexes[elementConfigIndex])
 						renderCommand RenderCommand  = RenderCommand{BoundingBox: currentElementBoundingBox, UserData: sharedConfig.UserData, Id: currentElement.Id}
-						offscreen     bool           = __ElementIsOffscreen(&currentElementBoundingBox)
+						offscreen     bool           = __ElementIsOffscreen(&cullingBoundingBox)
 						shouldRender  bool           = !offscreen
 					)
 					switch elementConfig.Type {
@@ -4162,14 +6634,30 @@ func __CalculateFinalLayout() {
 						fallthrough
 					case __ELEMENT_CONFIG_TYPE_SHARED:
 						fallthrough
+					case __ELEMENT_CONFIG_TYPE_FOCUS:
+						fallthrough
+					case __ELEMENT_CONFIG_TYPE_STICKY:
+						fallthrough
+					case __ELEMENT_CONFIG_TYPE_ZOOM_PAN:
+						fallthrough
+					case __ELEMENT_CONFIG_TYPE_TRANSITION:
+						fallthrough
+					case __ELEMENT_CONFIG_TYPE_SHADOW:
+						fallthrough
+					case __ELEMENT_CONFIG_TYPE_OPACITY:
+						fallthrough
+					case __ELEMENT_CONFIG_TYPE_TRANSFORM:
+						fallthrough
+					case __ELEMENT_CONFIG_TYPE_PATH:
+						fallthrough
 					case __ELEMENT_CONFIG_TYPE_BORDER:
 						shouldRender = false
 					case __ELEMENT_CONFIG_TYPE_CLIP:
 						renderCommand.CommandType = RENDER_COMMAND_TYPE_SCISSOR_START
-						renderCommand.RenderData = RenderData{Clip: ClipRenderData{Horizontal: elementConfig.Config.ClipElementConfig.Horizontal, Vertical: elementConfig.Config.ClipElementConfig.Vertical}}
+						renderCommand.RenderData = RenderData{Clip: ClipRenderData{Horizontal: elementConfig.Config.ClipElementConfig.Horizontal, Vertical: elementConfig.Config.ClipElementConfig.Vertical, CornerRadius: __ScaleCornerRadius(sharedConfig.CornerRadius, transformScale)}}
 					case __ELEMENT_CONFIG_TYPE_IMAGE:
 						renderCommand.CommandType = RENDER_COMMAND_TYPE_IMAGE
-						renderCommand.RenderData = RenderData{Image: ImageRenderData{BackgroundColor: sharedConfig.BackgroundColor, CornerRadius: sharedConfig.CornerRadius, ImageData: elementConfig.Config.ImageElementConfig.ImageData}}
+						renderCommand.RenderData = RenderData{Image: ImageRenderData{BackgroundColor: sharedConfig.BackgroundColor, CornerRadius: __ScaleCornerRadius(sharedConfig.CornerRadius, transformScale), ImageData: elementConfig.Config.ImageElementConfig.ImageData, Fit: elementConfig.Config.ImageElementConfig.Fit, Alignment: elementConfig.Config.ImageElementConfig.Alignment, SourceRect: elementConfig.Config.ImageElementConfig.SourceRect, IntrinsicSize: Dimensions{Width: elementConfig.Config.ImageElementConfig.IntrinsicSize.Width * transformScale, Height: elementConfig.Config.ImageElementConfig.IntrinsicSize.Height * transformScale}, NineSlice: elementConfig.Config.ImageElementConfig.NineSlice, NineSliceScale: transformScale}}
 						emitRectangle = false
 					case __ELEMENT_CONFIG_TYPE_TEXT:
 						if !shouldRender {
@@ -4193,22 +6681,22 @@ func __CalculateFinalLayout() {
 								yPosition += finalLineHeight
 								continue
 							}
-							var offset float32 = (currentElementBoundingBox.Width - wrappedLine.Dimensions.Width)
+							var offset float32 = (currentElement.Dimensions.Width - wrappedLine.Dimensions.Width)
 							if textElementConfig.TextAlignment == TEXT_ALIGN_LEFT {
 								offset = 0
 							}
 							if textElementConfig.TextAlignment == TEXT_ALIGN_CENTER {
 								offset /= 2
 							}
-							__AddRenderCommand(RenderCommand{BoundingBox: BoundingBox{X: currentElementBoundingBox.X + offset, Y: currentElementBoundingBox.Y + yPosition, Width: wrappedLine.Dimensions.Width, Height: wrappedLine.Dimensions.Height}, RenderData: RenderData{Text: TextRenderData{StringContents: StringSlice{Length: wrappedLine.Line.Length, Chars: wrappedLine.Line.Chars, BaseChars: currentElement.ChildrenOrTextContent.TextElementData.Text.Chars}, TextColor: textElementConfig.TextColor, FontId: textElementConfig.FontId, FontSize: textElementConfig.FontSize, LetterSpacing: textElementConfig.LetterSpacing, LineHeight: textElementConfig.LineHeight}}, UserData: textElementConfig.UserData, Id: __HashNumber(uint32(lineIndex), currentElement.Id).Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_TEXT})
+							__AddRenderCommand(RenderCommand{BoundingBox: BoundingBox{X: currentElementBoundingBox.X + offset*transformScale, Y: currentElementBoundingBox.Y + yPosition*transformScale, Width: wrappedLine.Dimensions.Width * transformScale, Height: wrappedLine.Dimensions.Height * transformScale}, RenderData: RenderData{Text: TextRenderData{StringContents: StringSlice{Length: wrappedLine.Line.Length, Chars: wrappedLine.Line.Chars, BaseChars: currentElement.ChildrenOrTextContent.TextElementData.Text.Chars}, TextColor: textElementConfig.TextColor, FontId: textElementConfig.FontId, FontSize: __ScaleUInt16(textElementConfig.FontSize, transformScale), LetterSpacing: __ScaleUInt16(textElementConfig.LetterSpacing, transformScale), LineHeight: __ScaleUInt16(textElementConfig.LineHeight, transformScale)}}, UserData: textElementConfig.UserData, Id: __HashNumber(uint32(lineIndex), currentElement.Id).Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_TEXT})
 							yPosition += finalLineHeight
-							if !context.disableCulling && currentElementBoundingBox.Y+yPosition > context.layoutDimensions.Height {
+							if !context.disableCulling && !transformed && currentElementBoundingBox.Y+yPosition*transformScale > context.layoutDimensions.Height {
 								break
 							}
 						}
 					case __ELEMENT_CONFIG_TYPE_CUSTOM:
 						renderCommand.CommandType = RENDER_COMMAND_TYPE_CUSTOM
-						renderCommand.RenderData = RenderData{Custom: CustomRenderData{BackgroundColor: sharedConfig.BackgroundColor, CornerRadius: sharedConfig.CornerRadius, CustomData: elementConfig.Config.CustomElementConfig.CustomData}}
+						renderCommand.RenderData = RenderData{Custom: CustomRenderData{BackgroundColor: sharedConfig.BackgroundColor, CornerRadius: __ScaleCornerRadius(sharedConfig.CornerRadius, transformScale), CustomData: elementConfig.Config.CustomElementConfig.CustomData}}
 						emitRectangle = false
 					default:
 					}
@@ -4219,7 +6707,14 @@ func __CalculateFinalLayout() {
 					}
 				}
 				if emitRectangle {
-					__AddRenderCommand(RenderCommand{BoundingBox: currentElementBoundingBox, RenderData: RenderData{Rectangle: RectangleRenderData{BackgroundColor: sharedConfig.BackgroundColor, CornerRadius: sharedConfig.CornerRadius}}, UserData: sharedConfig.UserData, Id: currentElement.Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_RECTANGLE})
+					__AddRenderCommand(RenderCommand{BoundingBox: currentElementBoundingBox, RenderData: RenderData{Rectangle: RectangleRenderData{BackgroundColor: sharedConfig.BackgroundColor, CornerRadius: __ScaleCornerRadius(sharedConfig.CornerRadius, transformScale), Gradient: sharedConfig.BackgroundGradient}}, UserData: sharedConfig.UserData, Id: currentElement.Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_RECTANGLE})
+				}
+				if shadowRenderCommand.CommandType == RENDER_COMMAND_TYPE_SHADOW && shadowConfig.Inset {
+					__AddRenderCommand(shadowRenderCommand)
+				}
+				var pathConfig *PathElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_PATH).PathElementConfig
+				if pathConfig != nil && !__ElementIsOffscreen(&cullingBoundingBox) {
+					__AddRenderCommand(RenderCommand{BoundingBox: currentElementBoundingBox, RenderData: RenderData{Path: PathRenderData{Commands: pathConfig.Commands, FillColor: pathConfig.FillColor, StrokeColor: pathConfig.StrokeColor, StrokeWidth: pathConfig.StrokeWidth * transformScale, Join: pathConfig.Join, Scale: transformScale}}, UserData: sharedConfig.UserData, Id: currentElement.Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_PATH})
 				}
 				if !__ElementHasConfig(currentElementTreeNode.LayoutElement, __ELEMENT_CONFIG_TYPE_TEXT) {
 					var contentSize Dimensions = Dimensions{}
@@ -4290,14 +6785,16 @@ func __CalculateFinalLayout() {
 				}
 			} else {
 				var (
-					closeClipElement bool               = false
-					clipConfig       *ClipElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_CLIP).ClipElementConfig
+					closeClipElement  bool                           = false
+					clipConfig        *ClipElementConfig             = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_CLIP).ClipElementConfig
+					closingScrollData *__ScrollContainerDataInternal = (*__ScrollContainerDataInternal)(nil)
 				)
 				if clipConfig != nil {
 					closeClipElement = true
 					for i := int32(0); i < context.scrollContainerDatas.Length; i++ {
 						var mapping *__ScrollContainerDataInternal = __ScrollContainerDataInternalArray_Get(&context.scrollContainerDatas, i)
 						if mapping.LayoutElement == currentElement {
+							closingScrollData = mapping
 							scrollOffset = clipConfig.ChildOffset
 							if context.externalScrollHandlingEnabled {
 								scrollOffset = Vector2{}
@@ -4306,12 +6803,16 @@ func __CalculateFinalLayout() {
 						}
 					}
 				}
+				if context.exitingTransitionCount > 0 {
+					__AddExitingTransitionCommands(currentElement.Id, root.ZIndex)
+				}
 				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_BORDER) {
 					var (
 						currentElementData        *LayoutElementHashMapItem = __GetHashMapItem(currentElement.Id)
 						currentElementBoundingBox BoundingBox               = currentElementData.BoundingBox
+						cullingBoundingBox        BoundingBox               = __TransformBoundingBox(currentElementData.Transform, currentElementBoundingBox)
 					)
-					if !__ElementIsOffscreen(&currentElementBoundingBox) {
+					if !__ElementIsOffscreen(&cullingBoundingBox) {
 						var sharedConfig *SharedElementConfig
 						if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_SHARED) {
 							sharedConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_SHARED).SharedElementConfig
@@ -4319,7 +6820,8 @@ func __CalculateFinalLayout() {
 							sharedConfig = &SharedElementConfig_DEFAULT
 						}
 						var borderConfig *BorderElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_BORDER).BorderElementConfig
-						var renderCommand RenderCommand = RenderCommand{BoundingBox: currentElementBoundingBox, RenderData: RenderData{Border: BorderRenderData{Color: borderConfig.Color, CornerRadius: sharedConfig.CornerRadius, Width: borderConfig.Width}}, UserData: sharedConfig.UserData, Id: __HashNumber(currentElement.Id, uint32(currentElement.ChildrenOrTextContent.Children.Length)).Id, CommandType: RENDER_COMMAND_TYPE_BORDER}
+						var transformScale float32 = currentElementTreeNode.TransformScale
+						var renderCommand RenderCommand = RenderCommand{BoundingBox: currentElementBoundingBox, RenderData: RenderData{Border: BorderRenderData{Color: borderConfig.Color, CornerRadius: __ScaleCornerRadius(sharedConfig.CornerRadius, transformScale), Width: BorderWidth{Left: __ScaleUInt16(borderConfig.Width.Left, transformScale), Right: __ScaleUInt16(borderConfig.Width.Right, transformScale), Top: __ScaleUInt16(borderConfig.Width.Top, transformScale), Bottom: __ScaleUInt16(borderConfig.Width.Bottom, transformScale), BetweenChildren: __ScaleUInt16(borderConfig.Width.BetweenChildren, transformScale)}, Gradient: borderConfig.Gradient, Colors: BorderColors{Left: __BorderSideColor(borderConfig.Colors.Left, borderConfig.Color), Right: __BorderSideColor(borderConfig.Colors.Right, borderConfig.Color), Top: __BorderSideColor(borderConfig.Colors.Top, borderConfig.Color), Bottom: __BorderSideColor(borderConfig.Colors.Bottom, borderConfig.Color)}, Style: borderConfig.Style, DashLength: borderConfig.DashLength * transformScale, GapLength: borderConfig.GapLength * transformScale}}, UserData: sharedConfig.UserData, Id: __HashNumber(currentElement.Id, uint32(currentElement.ChildrenOrTextContent.Children.Length)).Id, CommandType: RENDER_COMMAND_TYPE_BORDER}
 						__AddRenderCommand(renderCommand)
 						if int32(borderConfig.Width.BetweenChildren) > 0 && borderConfig.Color.A > 0 {
 							var (
@@ -4330,7 +6832,7 @@ func __CalculateFinalLayout() {
 								for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
 									var childElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, *(*int32)(unsafe.Add(unsafe.Pointer(currentElement.ChildrenOrTextContent.Children.Elements), unsafe.Sizeof(int32(0))*uintptr(i))))
 									if i > 0 {
-										__AddRenderCommand(RenderCommand{BoundingBox: BoundingBox{X: currentElementBoundingBox.X + borderOffset.X + scrollOffset.X, Y: currentElementBoundingBox.Y + scrollOffset.Y, Width: float32(borderConfig.Width.BetweenChildren), Height: currentElement.Dimensions.Height}, RenderData: RenderData{Rectangle: RectangleRenderData{BackgroundColor: borderConfig.Color}}, UserData: sharedConfig.UserData, Id: __HashNumber(currentElement.Id, uint32(int32(currentElement.ChildrenOrTextContent.Children.Length)+1+i)).Id, CommandType: RENDER_COMMAND_TYPE_RECTANGLE})
+										__AddRenderCommand(RenderCommand{BoundingBox: BoundingBox{X: currentElementBoundingBox.X + (borderOffset.X+scrollOffset.X)*transformScale, Y: currentElementBoundingBox.Y + scrollOffset.Y*transformScale, Width: float32(borderConfig.Width.BetweenChildren) * transformScale, Height: currentElement.Dimensions.Height * transformScale}, RenderData: RenderData{Rectangle: RectangleRenderData{BackgroundColor: borderConfig.Color}}, UserData: sharedConfig.UserData, Id: __HashNumber(currentElement.Id, uint32(int32(currentElement.ChildrenOrTextContent.Children.Length)+1+i)).Id, CommandType: RENDER_COMMAND_TYPE_RECTANGLE})
 									}
 									borderOffset.X += childElement.Dimensions.Width + float32(layoutConfig.ChildGap)
 								}
@@ -4338,7 +6840,7 @@ func __CalculateFinalLayout() {
 								for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
 									var childElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, *(*int32)(unsafe.Add(unsafe.Pointer(currentElement.ChildrenOrTextContent.Children.Elements), unsafe.Sizeof(int32(0))*uintptr(i))))
 									if i > 0 {
-										__AddRenderCommand(RenderCommand{BoundingBox: BoundingBox{X: currentElementBoundingBox.X + scrollOffset.X, Y: currentElementBoundingBox.Y + borderOffset.Y + scrollOffset.Y, Width: currentElement.Dimensions.Width, Height: float32(borderConfig.Width.BetweenChildren)}, RenderData: RenderData{Rectangle: RectangleRenderData{BackgroundColor: borderConfig.Color}}, UserData: sharedConfig.UserData, Id: __HashNumber(currentElement.Id, uint32(int32(currentElement.ChildrenOrTextContent.Children.Length)+1+i)).Id, CommandType: RENDER_COMMAND_TYPE_RECTANGLE})
+										__AddRenderCommand(RenderCommand{BoundingBox: BoundingBox{X: currentElementBoundingBox.X + scrollOffset.X*transformScale, Y: currentElementBoundingBox.Y + (borderOffset.Y+scrollOffset.Y)*transformScale, Width: currentElement.Dimensions.Width * transformScale, Height: float32(borderConfig.Width.BetweenChildren) * transformScale}, RenderData: RenderData{Rectangle: RectangleRenderData{BackgroundColor: borderConfig.Color}}, UserData: sharedConfig.UserData, Id: __HashNumber(currentElement.Id, uint32(int32(currentElement.ChildrenOrTextContent.Children.Length)+1+i)).Id, CommandType: RENDER_COMMAND_TYPE_RECTANGLE})
 									}
 									borderOffset.Y += childElement.Dimensions.Height + float32(layoutConfig.ChildGap)
 								}
@@ -4346,14 +6848,78 @@ func __CalculateFinalLayout() {
 						}
 					}
 				}
+				if closingScrollData != nil && clipConfig.Scrollbar.Width > 0 {
+					__AddScrollbarRenderCommands(closingScrollData, clipConfig, root.ZIndex)
+				}
 				if closeClipElement {
 					__AddRenderCommand(RenderCommand{Id: __HashNumber(currentElement.Id, uint32(int32(rootElement.ChildrenOrTextContent.Children.Length)+11)).Id, CommandType: RENDER_COMMAND_TYPE_SCISSOR_END})
 				}
+				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_OPACITY) {
+					var opacityConfig *OpacityElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_OPACITY).OpacityElementConfig
+					__AddRenderCommand(RenderCommand{RenderData: RenderData{Layer: LayerRenderData{Opacity: (func() float32 {
+						if opacityConfig.Value > 0 {
+							return opacityConfig.Value
+						}
+						return 0
+					}())}}, Id: __HashNumber(currentElement.Id, 15).Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_LAYER_END})
+				}
+				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_TRANSFORM) {
+					var (
+						transformConfig *TransformElementConfig = __FindElementConfigWithType(currentElement, __ELEMENT_CONFIG_TYPE_TRANSFORM).TransformElementConfig
+						transform       Matrix2D                = __ElementTransform(transformConfig, __GetHashMapItem(currentElement.Id).BoundingBox, currentElementTreeNode.TransformScale)
+					)
+					for i := int32(currentElementTreeNode.RenderCommandStart); i < context.renderCommands.Length; i++ {
+						var renderCommand *RenderCommand = RenderCommandArray_Get(&context.renderCommands, i)
+						renderCommand.Transform = __MultiplyMatrices(transform, renderCommand.Transform)
+					}
+				}
+				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_TRANSITION) {
+					var (
+						renderCommandStart int32 = currentElementTreeNode.RenderCommandStart + (func() int32 {
+							if currentElementTreeNode.Opacity < 1 {
+								return 1
+							}
+							return 0
+						}())
+						renderCommandCount int32                     = context.renderCommands.Length - renderCommandStart
+						transitionData     *__TransitionDataInternal = __GetTransitionData(currentElement.Id)
+					)
+					if transitionData != nil && transitionData.Config.Exit.Enabled {
+						transitionData.ExitCommandsStart = __SnapshotTransitionCommands((*RenderCommand)(unsafe.Add(unsafe.Pointer(context.renderCommands.InternalArray), unsafe.Sizeof(RenderCommand{})*uintptr(renderCommandStart))), renderCommandCount)
+						if transitionData.ExitCommandsStart < 0 {
+							transitionData.ExitCommandsLength = 0
+						} else {
+							transitionData.ExitCommandsLength = renderCommandCount
+						}
+					}
+					if currentElementTreeNode.Opacity < 1 {
+						__AddRenderCommand(RenderCommand{RenderData: RenderData{Layer: LayerRenderData{Opacity: (func() float32 {
+							if currentElementTreeNode.Opacity > 0 {
+								return currentElementTreeNode.Opacity
+							}
+							return 0
+						}())}}, Id: __HashNumber(currentElement.Id, 17).Id, ZIndex: root.ZIndex, CommandType: RENDER_COMMAND_TYPE_LAYER_END})
+					}
+				}
 				dfsBuffer.Length--
 				continue
 			}
 			if !__ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_TEXT) {
 				dfsBuffer.Length += int32(currentElement.ChildrenOrTextContent.Children.Length)
+				var hasStickyChild bool = false
+				var childTransformOffset Vector2 = currentElementTreeNode.TransformOffset
+				var childTransformScale float32 = currentElementTreeNode.TransformScale
+				var zoomPanData *__ZoomPanDataInternal
+				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_ZOOM_PAN) {
+					zoomPanData = __GetZoomPanDataForElement(currentElement)
+				} else {
+					zoomPanData = (*__ZoomPanDataInternal)(nil)
+				}
+				if zoomPanData != nil {
+					var origin Vector2 = currentElementTreeNode.Position
+					childTransformOffset = Vector2{X: (origin.X+zoomPanData.Pan.X-origin.X*zoomPanData.Zoom)*childTransformScale + childTransformOffset.X, Y: (origin.Y+zoomPanData.Pan.Y-origin.Y*zoomPanData.Zoom)*childTransformScale + childTransformOffset.Y}
+					childTransformScale *= zoomPanData.Zoom
+				}
 				for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
 					var childElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, *(*int32)(unsafe.Add(unsafe.Pointer(currentElement.ChildrenOrTextContent.Children.Elements), unsafe.Sizeof(int32(0))*uintptr(i))))
 					if layoutConfig.LayoutDirection == LEFT_TO_RIGHT {
@@ -4379,20 +6945,31 @@ func __CalculateFinalLayout() {
 					}
 					var childPosition Vector2 = Vector2{X: currentElementTreeNode.Position.X + currentElementTreeNode.NextChildOffset.X + scrollOffset.X, Y: currentElementTreeNode.Position.Y + currentElementTreeNode.NextChildOffset.Y + scrollOffset.Y}
 					var newNodeIndex uint32 = uint32(dfsBuffer.Length - 1 - i)
-					*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(newNodeIndex))) = __LayoutElementTreeNode{LayoutElement: childElement, Position: Vector2{X: childPosition.X, Y: childPosition.Y}, NextChildOffset: Vector2{X: float32(childElement.LayoutConfig.Padding.Left), Y: float32(childElement.LayoutConfig.Padding.Top)}}
+					*(*__LayoutElementTreeNode)(unsafe.Add(unsafe.Pointer(dfsBuffer.InternalArray), unsafe.Sizeof(__LayoutElementTreeNode{})*uintptr(newNodeIndex))) = __LayoutElementTreeNode{LayoutElement: childElement, Position: Vector2{X: childPosition.X, Y: childPosition.Y}, NextChildOffset: Vector2{X: float32(childElement.LayoutConfig.Padding.Left), Y: float32(childElement.LayoutConfig.Padding.Top)}, TransformOffset: childTransformOffset, TransformScale: childTransformScale, Transform: currentElementTreeNode.Transform, ParentOrigin: Vector2{X: currentElementTreeNode.Position.X + scrollOffset.X, Y: currentElementTreeNode.Position.Y + scrollOffset.Y}, TransitionOffset: currentElementTreeNode.ChildTransitionOffset, ClipBoundingBox: currentElementTreeNode.ClipBoundingBox}
 					*(*bool)(unsafe.Add(unsafe.Pointer(context.treeNodeVisited.InternalArray), newNodeIndex)) = false
 					if layoutConfig.LayoutDirection == LEFT_TO_RIGHT {
 						currentElementTreeNode.NextChildOffset.X += childElement.Dimensions.Width + float32(layoutConfig.ChildGap)
 					} else {
 						currentElementTreeNode.NextChildOffset.Y += childElement.Dimensions.Height + float32(layoutConfig.ChildGap)
 					}
+					hasStickyChild = hasStickyChild || __ElementHasConfig(childElement, __ELEMENT_CONFIG_TYPE_STICKY)
+				}
+				if hasStickyChild {
+					__ResolveStickyChildren(&dfsBuffer, currentElementTreeNode)
 				}
 			}
 		}
+		if !__MatrixIsIdentity(rootTransform) {
+			for i := int32(rootRenderCommandStart); i < context.renderCommands.Length; i++ {
+				var renderCommand *RenderCommand = RenderCommandArray_Get(&context.renderCommands, i)
+				renderCommand.Transform = __MultiplyMatrices(rootTransform, renderCommand.Transform)
+			}
+		}
 		if root.ClipElementId != 0 {
-			__AddRenderCommand(RenderCommand{Id: __HashNumber(rootElement.Id, uint32(int32(rootElement.ChildrenOrTextContent.Children.Length)+11)).Id, CommandType: RENDER_COMMAND_TYPE_SCISSOR_END})
+			__AddRenderCommand(RenderCommand{Transform: __GetHashMapItem(root.ClipElementId).Transform, Id: __HashNumber(rootElement.Id, uint32(int32(rootElement.ChildrenOrTextContent.Children.Length)+11)).Id, CommandType: RENDER_COMMAND_TYPE_SCISSOR_END})
 		}
 	}
+	__EndTransitions()
 }
 
 func GetPointerOverIds() ElementIdArray {
@@ -4450,54 +7027,527 @@ func __Array_RangeCheck(index int32, length int32) bool {
 	if index < length && index >= 0 {
 		return true
 	}
-	var context *Context = GetCurrentContext()
-	context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_INTERNAL_ERROR, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay attempted to make an out of bounds array access. This is an internal error and is likely a bug.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay attempted to make an out of bounds array access. This is an internal error and is likely a bug.")}, UserData: context.errorHandler.UserData})
+	var context *Context = GetCurrentContext()
+	context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_INTERNAL_ERROR, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay attempted to make an out of bounds array access. This is an internal error and is likely a bug.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay attempted to make an out of bounds array access. This is an internal error and is likely a bug.")}, UserData: context.errorHandler.UserData})
+	return false
+}
+
+func __Array_AddCapacityCheck(length int32, capacity int32) bool {
+	if length < capacity {
+		return true
+	}
+	var context *Context = GetCurrentContext()
+	context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_INTERNAL_ERROR, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay attempted to make an out of bounds array access. This is an internal error and is likely a bug.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay attempted to make an out of bounds array access. This is an internal error and is likely a bug.")}, UserData: context.errorHandler.UserData})
+	return false
+}
+
+func MinMemorySize() uint32 {
+	var (
+		fakeContext    Context  = Context{maxElementCount: __defaultMaxElementCount, maxMeasureTextCacheWordCount: __defaultMaxMeasureTextWordCacheCount, internalArena: Arena{Capacity: math.MaxUint64, Memory: nil}}
+		currentContext *Context = GetCurrentContext()
+	)
+	if currentContext != nil {
+		fakeContext.maxElementCount = currentContext.maxElementCount
+		fakeContext.maxMeasureTextCacheWordCount = currentContext.maxMeasureTextCacheWordCount
+	}
+	__Context_Allocate_Arena(&fakeContext.internalArena)
+	__InitializePersistentMemory(&fakeContext)
+	__InitializeEphemeralMemory(&fakeContext)
+	return uint32(fakeContext.internalArena.NextAllocation) + 128
+}
+
+func createArenaWithCapacityAndMemory(capacity uint64, memory unsafe.Pointer) Arena {
+	var arena Arena = Arena{Capacity: capacity, Memory: (*byte)(memory)}
+	return arena
+}
+
+func SetMeasureTextFunction(measureTextFunction func(text StringSlice, config *TextElementConfig, userData unsafe.Pointer) Dimensions, userData any) {
+	var context *Context = GetCurrentContext()
+	__MeasureText = measureTextFunction
+	context.measureTextUserData = userData
+}
+
+func SetQueryScrollOffsetFunction(queryScrollOffsetFunction func(elementId uint32, userData unsafe.Pointer) Vector2, userData any) {
+	var context *Context = GetCurrentContext()
+	__QueryScrollOffset = queryScrollOffsetFunction
+	context.queryScrollOffsetUserData = userData
+}
+
+func SetLayoutDimensions(dimensions Dimensions) {
+	GetCurrentContext().layoutDimensions = dimensions
+}
+
+func __GetScrollContainerDataInternal(elementId uint32) *__ScrollContainerDataInternal {
+	var context *Context = GetCurrentContext()
+	for i := int32(0); i < context.scrollContainerDatas.Length; i++ {
+		var scrollData *__ScrollContainerDataInternal = __ScrollContainerDataInternalArray_Get(&context.scrollContainerDatas, i)
+		if scrollData.ElementId == elementId {
+			return scrollData
+		}
+	}
+	return (*__ScrollContainerDataInternal)(nil)
+}
+
+func __ScrollAxisTarget(offset float32, size float32, viewportSize float32, scrollPosition float32, alignment ScrollAlignment) float32 {
+	switch alignment {
+	case SCROLL_ALIGN_START:
+		return -offset
+	case SCROLL_ALIGN_CENTER:
+		return (viewportSize-size)/2 - offset
+	case SCROLL_ALIGN_END:
+		return viewportSize - size - offset
+	default:
+		if offset+scrollPosition < 0 || size > viewportSize {
+			return -offset
+		}
+		if offset+size+scrollPosition > viewportSize {
+			return viewportSize - size - offset
+		}
+		return scrollPosition
+	}
+}
+
+func __ClampScrollPosition(scrollData *__ScrollContainerDataInternal, scrollPosition Vector2) Vector2 {
+	return Vector2{X: (func() float32 {
+		if (func() float32 {
+			if scrollPosition.X < 0 {
+				return scrollPosition.X
+			}
+			return 0
+		}()) > (-func() float32 {
+			if (scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width) > 0 {
+				return scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width
+			}
+			return 0
+		}()) {
+			if scrollPosition.X < 0 {
+				return scrollPosition.X
+			}
+			return 0
+		}
+		return -func() float32 {
+			if (scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width) > 0 {
+				return scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width
+			}
+			return 0
+		}()
+	}()), Y: (func() float32 {
+		if (func() float32 {
+			if scrollPosition.Y < 0 {
+				return scrollPosition.Y
+			}
+			return 0
+		}()) > (-func() float32 {
+			if (scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height) > 0 {
+				return scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height
+			}
+			return 0
+		}()) {
+			if scrollPosition.Y < 0 {
+				return scrollPosition.Y
+			}
+			return 0
+		}
+		return -func() float32 {
+			if (scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height) > 0 {
+				return scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height
+			}
+			return 0
+		}()
+	}())}
+}
+
+func __ScrollContainerToBox(scrollData *__ScrollContainerDataInternal, targetBox BoundingBox, alignment ScrollAlignment) Vector2 {
+	var clipConfig *ClipElementConfig = __FindElementConfigWithType(scrollData.LayoutElement, __ELEMENT_CONFIG_TYPE_CLIP).ClipElementConfig
+	if clipConfig == nil {
+		return Vector2{}
+	}
+	var scale float32 = scrollData.Scale
+	var viewport Dimensions = __ScrollViewportSize(scrollData)
+	var target Vector2 = scrollData.ScrollPosition
+	if clipConfig.Horizontal {
+		target.X = __ScrollAxisTarget((targetBox.X-scrollData.BoundingBox.X)/scale-scrollData.ScrollPosition.X, targetBox.Width/scale, viewport.Width, scrollData.ScrollPosition.X, alignment)
+	}
+	if clipConfig.Vertical {
+		target.Y = __ScrollAxisTarget((targetBox.Y-scrollData.BoundingBox.Y)/scale-scrollData.ScrollPosition.Y, targetBox.Height/scale, viewport.Height, scrollData.ScrollPosition.Y, alignment)
+	}
+	target = __ClampScrollPosition(scrollData, target)
+	scrollData.ScrollTarget = target
+	scrollData.ScrollAnimationActive = true
+	return Vector2{X: (target.X - scrollData.ScrollPosition.X) * scale, Y: (target.Y - scrollData.ScrollPosition.Y) * scale}
+}
+
+func __ElasticClamp(position float32, minPosition float32, overscroll float32) float32 {
+	var (
+		clamped float32 = (func() float32 {
+			if (func() float32 {
+				if position < 0 {
+					return position
+				}
+				return 0
+			}()) > minPosition {
+				if position < 0 {
+					return position
+				}
+				return 0
+			}
+			return minPosition
+		}())
+		excess float32 = position - clamped
+	)
+	if overscroll <= 0 || excess == 0 {
+		return clamped
+	}
+	var distance float32
+	if excess < 0 {
+		distance = -excess
+	} else {
+		distance = excess
+	}
+	var resisted float32 = overscroll * distance / (distance + overscroll)
+	_ = resisted
+	return clamped + (func() float32 {
+		if excess < 0 {
+			return -resisted
+		}
+		return resisted
+	}())
+}
+
+func __SnapAxis(scrollData *__ScrollContainerDataInternal, snap *ScrollSnapConfig, vertical bool, position float32) float32 {
+	var context *Context = GetCurrentContext()
+	if snap.Type == SCROLL_SNAP_INTERVAL {
+		if snap.Interval <= 0 {
+			return position
+		}
+		var rounding float32
+		if position < 0 {
+			rounding = -snap.Interval / 2
+		} else {
+			rounding = snap.Interval / 2
+		}
+		return float32(int32((position+rounding)/snap.Interval)) * snap.Interval
+	}
+	var box BoundingBox = scrollData.BoundingBox
+	var viewport Dimensions = __ScrollViewportSize(scrollData)
+	var scale float32 = scrollData.Scale
+	var alignment ScrollAlignment
+	if snap.Alignment == SCROLL_ALIGN_NEAREST {
+		alignment = SCROLL_ALIGN_START
+	} else {
+		alignment = snap.Alignment
+	}
+	var best float32 = position
+	var bestDistance float32 = __MAXFLOAT
+	var element *LayoutElement = scrollData.LayoutElement
+	for i := int32(0); i < int32(element.ChildrenOrTextContent.Children.Length); i++ {
+		var (
+			childElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, *(*int32)(unsafe.Add(unsafe.Pointer(element.ChildrenOrTextContent.Children.Elements), unsafe.Sizeof(int32(0))*uintptr(i))))
+			childBox     BoundingBox    = __GetHashMapItem(childElement.Id).BoundingBox
+			candidate    float32
+		)
+		if vertical {
+			candidate = __ScrollAxisTarget((childBox.Y-box.Y)/scale-scrollData.ScrollPosition.Y, childBox.Height/scale, viewport.Height, 0, alignment)
+		} else {
+			candidate = __ScrollAxisTarget((childBox.X-box.X)/scale-scrollData.ScrollPosition.X, childBox.Width/scale, viewport.Width, 0, alignment)
+		}
+		var distance float32
+		if candidate-position < 0 {
+			distance = position - candidate
+		} else {
+			distance = candidate - position
+		}
+		if distance < bestDistance {
+			best = candidate
+			bestDistance = distance
+		}
+	}
+	return best
+}
+
+func __SnapScrollContainer(scrollData *__ScrollContainerDataInternal, clipConfig *ClipElementConfig, restingPosition Vector2) {
+	var target Vector2 = scrollData.ScrollPosition
+	if clipConfig.Horizontal {
+		target.X = __SnapAxis(scrollData, &clipConfig.Snap, false, restingPosition.X)
+	}
+	if clipConfig.Vertical {
+		target.Y = __SnapAxis(scrollData, &clipConfig.Snap, true, restingPosition.Y)
+	}
+	scrollData.ScrollTarget = __ClampScrollPosition(scrollData, target)
+	scrollData.ScrollAnimationActive = true
+	scrollData.ScrollMomentum = Vector2{}
+	scrollData.SnapPending = false
+}
+
+func __ScrollElementIntoView(elementId uint32) {
+	var (
+		context        *Context                  = GetCurrentContext()
+		hashMapItem    *LayoutElementHashMapItem = __GetHashMapItem(elementId)
+		targetBox      BoundingBox               = hashMapItem.BoundingBox
+		currentElement *LayoutElement            = hashMapItem.LayoutElement
+	)
+	for currentElement != nil {
+		var clipElementId uint32 = uint32(__int32_tArray_GetValue(&context.layoutElementClipElementIds, int32(int64((uintptr(unsafe.Pointer(currentElement))-uintptr(unsafe.Pointer(context.layoutElements.InternalArray)))/unsafe.Sizeof(LayoutElement{})))))
+		if clipElementId == 0 {
+			return
+		}
+		var scrollData *__ScrollContainerDataInternal = __GetScrollContainerDataInternal(clipElementId)
+		if scrollData != nil {
+			var moved Vector2 = __ScrollContainerToBox(scrollData, targetBox, SCROLL_ALIGN_NEAREST)
+			targetBox.X += moved.X
+			targetBox.Y += moved.Y
+		}
+		currentElement = __GetHashMapItem(clipElementId).LayoutElement
+	}
+}
+
+func __GetFocusableIndex(elementId uint32) int32 {
+	var context *Context = GetCurrentContext()
+	for i := int32(0); i < context.focusableElementIndexes.Length; i++ {
+		if LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.focusableElementIndexes, i)).Id == elementId {
+			return i
+		}
+	}
+	return -1
+}
+
+func __GetFocusTabIndex(focusableIndex int32) int32 {
+	var (
+		context     *Context            = GetCurrentContext()
+		element     *LayoutElement      = LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.focusableElementIndexes, focusableIndex))
+		focusConfig *FocusElementConfig = __FindElementConfigWithType(element, __ELEMENT_CONFIG_TYPE_FOCUS).FocusElementConfig
+	)
+	if focusConfig != nil {
+		return focusConfig.TabIndex
+	}
+	return -1
+}
+
+func __FocusOrderBefore(leftIndex int32, rightIndex int32) bool {
+	var (
+		leftTabIndex  int32 = __GetFocusTabIndex(leftIndex)
+		rightTabIndex int32 = __GetFocusTabIndex(rightIndex)
+	)
+	if leftTabIndex != rightTabIndex {
+		if leftTabIndex == 0 {
+			return false
+		}
+		if rightTabIndex == 0 {
+			return true
+		}
+		return leftTabIndex < rightTabIndex
+	}
+	return leftIndex < rightIndex
+}
+
+func __GetModalRootElementIndex() int32 {
+	var context *Context = GetCurrentContext()
+	for rootIndex := int32(context.layoutElementTreeRoots.Length - 1); rootIndex >= 0; rootIndex-- {
+		var (
+			root        *__LayoutElementTreeRoot = __LayoutElementTreeRootArray_Get(&context.layoutElementTreeRoots, rootIndex)
+			rootElement *LayoutElement           = LayoutElementArray_Get(&context.layoutElements, root.LayoutElementIndex)
+		)
+		if __ElementHasConfig(rootElement, __ELEMENT_CONFIG_TYPE_FLOATING) && __FindElementConfigWithType(rootElement, __ELEMENT_CONFIG_TYPE_FLOATING).FloatingElementConfig.Modal {
+			return root.LayoutElementIndex
+		}
+	}
+	return -1
+}
+
+func __ElementIsInsideTree(rootElementIndex int32, elementIndex int32) bool {
+	var (
+		context   *Context       = GetCurrentContext()
+		dfsBuffer __int32_tArray = context.reusableElementIndexBuffer
+	)
+	dfsBuffer.Length = 0
+	__int32_tArray_Add(&dfsBuffer, rootElementIndex)
+	for dfsBuffer.Length > 0 {
+		var currentElementIndex int32 = __int32_tArray_GetValue(&dfsBuffer, dfsBuffer.Length-1)
+		dfsBuffer.Length--
+		if currentElementIndex == elementIndex {
+			return true
+		}
+		var currentElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, currentElementIndex)
+		if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_TEXT) {
+			continue
+		}
+		for i := int32(0); i < int32(currentElement.ChildrenOrTextContent.Children.Length); i++ {
+			__int32_tArray_Add(&dfsBuffer, *(*int32)(unsafe.Add(unsafe.Pointer(currentElement.ChildrenOrTextContent.Children.Elements), unsafe.Sizeof(int32(0))*uintptr(i))))
+		}
+	}
 	return false
 }
 
-func __Array_AddCapacityCheck(length int32, capacity int32) bool {
-	if length < capacity {
+func __FocusableIsInScope(modalRootElementIndex int32, focusableIndex int32) bool {
+	if modalRootElementIndex == -1 {
 		return true
 	}
-	var context *Context = GetCurrentContext()
-	context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_INTERNAL_ERROR, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("Clay attempted to make an out of bounds array access. This is an internal error and is likely a bug.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("Clay attempted to make an out of bounds array access. This is an internal error and is likely a bug.")}, UserData: context.errorHandler.UserData})
-	return false
+	return __ElementIsInsideTree(modalRootElementIndex, __int32_tArray_GetValue(&GetCurrentContext().focusableElementIndexes, focusableIndex))
 }
 
-func MinMemorySize() uint32 {
+func __SetFocusToFocusableIndex(focusableIndex int32) {
 	var (
-		fakeContext    Context  = Context{maxElementCount: __defaultMaxElementCount, maxMeasureTextCacheWordCount: __defaultMaxMeasureTextWordCacheCount, internalArena: Arena{Capacity: math.MaxUint64, Memory: nil}}
-		currentContext *Context = GetCurrentContext()
+		context *Context       = GetCurrentContext()
+		element *LayoutElement = LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.focusableElementIndexes, focusableIndex))
 	)
-	if currentContext != nil {
-		fakeContext.maxElementCount = currentContext.maxElementCount
-		fakeContext.maxMeasureTextCacheWordCount = currentContext.maxMeasureTextCacheWordCount
-	}
-	__Context_Allocate_Arena(&fakeContext.internalArena)
-	__InitializePersistentMemory(&fakeContext)
-	__InitializeEphemeralMemory(&fakeContext)
-	return uint32(fakeContext.internalArena.NextAllocation) + 128
-}
-
-func createArenaWithCapacityAndMemory(capacity uint64, memory unsafe.Pointer) Arena {
-	var arena Arena = Arena{Capacity: capacity, Memory: (*byte)(memory)}
-	return arena
+	context.focusedElementId = __GetHashMapItem(element.Id).ElementId
+	context.focusVisible = true
 }
 
-func SetMeasureTextFunction(measureTextFunction func(text StringSlice, config *TextElementConfig, userData unsafe.Pointer) Dimensions, userData any) {
-	var context *Context = GetCurrentContext()
-	__MeasureText = measureTextFunction
-	context.measureTextUserData = userData
+func __MoveFocusSequential(reverse bool) bool {
+	var (
+		context               *Context = GetCurrentContext()
+		modalRootElementIndex int32    = __GetModalRootElementIndex()
+		currentIndex          int32    = __GetFocusableIndex(context.focusedElementId.Id)
+	)
+	if currentIndex != -1 && (__GetFocusTabIndex(currentIndex) < 0 || !__FocusableIsInScope(modalRootElementIndex, currentIndex)) {
+		currentIndex = -1
+	}
+	var nextIndex int32 = -1
+	var wrapIndex int32 = -1
+	for i := int32(0); i < context.focusableElementIndexes.Length; i++ {
+		if i == currentIndex || __GetFocusTabIndex(i) < 0 || !__FocusableIsInScope(modalRootElementIndex, i) {
+			continue
+		}
+		if wrapIndex == -1 || (func() bool {
+			if reverse {
+				return __FocusOrderBefore(wrapIndex, i)
+			}
+			return __FocusOrderBefore(i, wrapIndex)
+		}()) {
+			wrapIndex = i
+		}
+		if currentIndex != -1 && (func() bool {
+			if reverse {
+				return __FocusOrderBefore(i, currentIndex)
+			}
+			return __FocusOrderBefore(currentIndex, i)
+		}()) {
+			if nextIndex == -1 || (func() bool {
+				if reverse {
+					return __FocusOrderBefore(nextIndex, i)
+				}
+				return __FocusOrderBefore(i, nextIndex)
+			}()) {
+				nextIndex = i
+			}
+		}
+	}
+	if nextIndex == -1 {
+		nextIndex = wrapIndex
+	}
+	if nextIndex == -1 {
+		return false
+	}
+	__SetFocusToFocusableIndex(nextIndex)
+	__ScrollElementIntoView(context.focusedElementId.Id)
+	return true
 }
 
-func SetQueryScrollOffsetFunction(queryScrollOffsetFunction func(elementId uint32, userData unsafe.Pointer) Vector2, userData any) {
-	var context *Context = GetCurrentContext()
-	__QueryScrollOffset = queryScrollOffsetFunction
-	context.queryScrollOffsetUserData = userData
+func __SpatialFocusScore(from BoundingBox, to BoundingBox, direction FocusDirection) float32 {
+	var (
+		fromStart      float32
+		fromEnd        float32
+		toStart        float32
+		toEnd          float32
+		fromCrossStart float32
+		fromCrossEnd   float32
+		toCrossStart   float32
+		toCrossEnd     float32
+	)
+	switch direction {
+	case FOCUS_DIRECTION_RIGHT:
+		fallthrough
+	case FOCUS_DIRECTION_LEFT:
+		fromStart = from.X
+		fromEnd = from.X + from.Width
+		toStart = to.X
+		toEnd = to.X + to.Width
+		fromCrossStart = from.Y
+		fromCrossEnd = from.Y + from.Height
+		toCrossStart = to.Y
+		toCrossEnd = to.Y + to.Height
+	case FOCUS_DIRECTION_DOWN:
+		fallthrough
+	case FOCUS_DIRECTION_UP:
+		fromStart = from.Y
+		fromEnd = from.Y + from.Height
+		toStart = to.Y
+		toEnd = to.Y + to.Height
+		fromCrossStart = from.X
+		fromCrossEnd = from.X + from.Width
+		toCrossStart = to.X
+		toCrossEnd = to.X + to.Width
+	default:
+		return -1
+	}
+	if direction == FOCUS_DIRECTION_LEFT || direction == FOCUS_DIRECTION_UP {
+		var swap float32 = fromStart
+		fromStart = -fromEnd
+		fromEnd = -swap
+		swap = toStart
+		toStart = -toEnd
+		toEnd = -swap
+	}
+	if toStart+toEnd <= fromStart+fromEnd || toEnd <= fromEnd {
+		return -1
+	}
+	var distance float32 = (func() float32 {
+		if (toStart - fromEnd) > 0 {
+			return toStart - fromEnd
+		}
+		return 0
+	}())
+	var crossGap float32 = (func() float32 {
+		if (func() float32 {
+			if (toCrossStart - fromCrossEnd) > (fromCrossStart - toCrossEnd) {
+				return toCrossStart - fromCrossEnd
+			}
+			return fromCrossStart - toCrossEnd
+		}()) > 0 {
+			if (toCrossStart - fromCrossEnd) > (fromCrossStart - toCrossEnd) {
+				return toCrossStart - fromCrossEnd
+			}
+			return fromCrossStart - toCrossEnd
+		}
+		return 0
+	}())
+	var crossCenterOffset float32 = ((toCrossStart + toCrossEnd) - (fromCrossStart + fromCrossEnd)) / 2
+	if crossCenterOffset < 0 {
+		crossCenterOffset = -crossCenterOffset
+	}
+	return distance + crossGap*2 + crossCenterOffset*0.25
 }
 
-func SetLayoutDimensions(dimensions Dimensions) {
-	GetCurrentContext().layoutDimensions = dimensions
+func __MoveFocusSpatial(direction FocusDirection) bool {
+	var (
+		context               *Context = GetCurrentContext()
+		modalRootElementIndex int32    = __GetModalRootElementIndex()
+		currentIndex          int32    = __GetFocusableIndex(context.focusedElementId.Id)
+	)
+	if currentIndex == -1 || !__FocusableIsInScope(modalRootElementIndex, currentIndex) {
+		return __MoveFocusSequential(false)
+	}
+	var currentBox BoundingBox = __GetHashMapItem(context.focusedElementId.Id).BoundingBox
+	var bestIndex int32 = -1
+	var bestScore float32 = __MAXFLOAT
+	for i := int32(0); i < context.focusableElementIndexes.Length; i++ {
+		if i == currentIndex || __GetFocusTabIndex(i) < 0 || !__FocusableIsInScope(modalRootElementIndex, i) {
+			continue
+		}
+		var element *LayoutElement = LayoutElementArray_Get(&context.layoutElements, __int32_tArray_GetValue(&context.focusableElementIndexes, i))
+		var score float32 = __SpatialFocusScore(currentBox, __GetHashMapItem(element.Id).BoundingBox, direction)
+		if score >= 0 && score < bestScore {
+			bestScore = score
+			bestIndex = i
+		}
+	}
+	if bestIndex == -1 {
+		return false
+	}
+	__SetFocusToFocusableIndex(bestIndex)
+	__ScrollElementIntoView(context.focusedElementId.Id)
+	return true
 }
 
 func SetPointerState(position Vector2, isPointerDown bool) {
@@ -4507,6 +7557,8 @@ func SetPointerState(position Vector2, isPointerDown bool) {
 	}
 	context.pointerInfo.Position = position
 	context.pointerOverIds.Length = 0
+	var pointerFocusTarget ElementId = ElementId{}
+	var pointerFocusResolved bool = false
 	var dfsBuffer __int32_tArray = context.layoutElementChildrenBuffer
 	for rootIndex := int32(context.layoutElementTreeRoots.Length - 1); rootIndex >= 0; rootIndex-- {
 		dfsBuffer.Length = 0
@@ -4528,11 +7580,15 @@ func SetPointerState(position Vector2, isPointerDown bool) {
 				var elementBox BoundingBox = mapItem.BoundingBox
 				elementBox.X -= root.PointerOffset.X
 				elementBox.Y -= root.PointerOffset.Y
-				if __PointIsInsideRect(position, elementBox) && (clipElementId == 0 || __PointIsInsideRect(position, clipItem.BoundingBox) || context.externalScrollHandlingEnabled) {
+				var elementPosition Vector2 = __InverseTransformPoint(mapItem.Transform, position)
+				if __PointIsInsideRect(elementPosition, elementBox) && (clipElementId == 0 || __PointIsInsideRect(__InverseTransformPoint(clipItem.Transform, position), clipItem.BoundingBox) || context.externalScrollHandlingEnabled) {
 					if mapItem.OnHoverFunction != nil {
 						mapItem.OnHoverFunction(mapItem.ElementId, context.pointerInfo, mapItem.HoverFunctionUserData.(int64))
 					}
 					ElementIdArray_Add(&context.pointerOverIds, mapItem.ElementId)
+					if !pointerFocusResolved && __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_FOCUS) {
+						pointerFocusTarget = mapItem.ElementId
+					}
 					found = true
 				}
 				if __ElementHasConfig(currentElement, __ELEMENT_CONFIG_TYPE_TEXT) {
@@ -4547,6 +7603,9 @@ func SetPointerState(position Vector2, isPointerDown bool) {
 				dfsBuffer.Length--
 			}
 		}
+		if found {
+			pointerFocusResolved = true
+		}
 		var rootElement *LayoutElement = LayoutElementArray_Get(&context.layoutElements, root.LayoutElementIndex)
 		if found && __ElementHasConfig(rootElement, __ELEMENT_CONFIG_TYPE_FLOATING) && __FindElementConfigWithType(rootElement, __ELEMENT_CONFIG_TYPE_FLOATING).FloatingElementConfig.PointerCaptureMode == POINTER_CAPTURE_MODE_CAPTURE {
 			break
@@ -4557,6 +7616,8 @@ func SetPointerState(position Vector2, isPointerDown bool) {
 			context.pointerInfo.State = POINTER_DATA_PRESSED
 		} else if context.pointerInfo.State != POINTER_DATA_PRESSED {
 			context.pointerInfo.State = POINTER_DATA_PRESSED_THIS_FRAME
+			context.focusedElementId = pointerFocusTarget
+			context.focusVisible = false
 		}
 	} else {
 		if context.pointerInfo.State == POINTER_DATA_RELEASED_THIS_FRAME {
@@ -4567,6 +7628,35 @@ func SetPointerState(position Vector2, isPointerDown bool) {
 	}
 }
 
+func setKeyboardState(keyEvents *KeyEvent, keyEventCount int32) {
+	var context *Context = GetCurrentContext()
+	if context.booleanWarnings.MaxElementsExceeded {
+		return
+	}
+	for i := int32(0); i < keyEventCount; i++ {
+		var keyEvent KeyEvent = *(*KeyEvent)(unsafe.Add(unsafe.Pointer(keyEvents), unsafe.Sizeof(KeyEvent{})*uintptr(i)))
+		if context.focusedElementId.Id != 0 {
+			var focusedItem *LayoutElementHashMapItem = __GetHashMapItem(context.focusedElementId.Id)
+			if focusedItem.OnKeyFunction != nil && focusedItem.OnKeyFunction(focusedItem.ElementId, keyEvent, focusedItem.KeyFunctionUserData) {
+				continue
+			}
+		}
+		switch keyEvent.Key {
+		case KEY_TAB:
+			__MoveFocusSequential((keyEvent.Modifiers & KEY_MODIFIER_SHIFT) != 0)
+		case KEY_UP:
+			__MoveFocusSpatial(FOCUS_DIRECTION_UP)
+		case KEY_DOWN:
+			__MoveFocusSpatial(FOCUS_DIRECTION_DOWN)
+		case KEY_LEFT:
+			__MoveFocusSpatial(FOCUS_DIRECTION_LEFT)
+		case KEY_RIGHT:
+			__MoveFocusSpatial(FOCUS_DIRECTION_RIGHT)
+		default:
+		}
+	}
+}
+
 func Initialize(arena Arena, layoutDimensions Dimensions, errorHandler ErrorHandler) *Context {
 	var baseOffset uint64 = 64 - uint64(uintptr(unsafe.Pointer(arena.Memory)))%64
 	if baseOffset == 64 {
@@ -4579,6 +7669,7 @@ func Initialize(arena Arena, layoutDimensions Dimensions, errorHandler ErrorHand
 	if context == nil {
 		return nil
 	}
+	__ReleaseContextState(context)
 	var oldContext *Context = GetCurrentContext()
 	*context = Context{maxElementCount: func() int32 {
 		if oldContext != nil {
@@ -4642,6 +7733,7 @@ func UpdateScrollContainers(enableDragScrolling bool, scrollDelta Vector2, delta
 		isPointerActive             bool                           = enableDragScrolling && (context.pointerInfo.State == POINTER_DATA_PRESSED || context.pointerInfo.State == POINTER_DATA_PRESSED_THIS_FRAME)
 		highestPriorityElementIndex int32                          = -1
 		highestPriorityScrollData   *__ScrollContainerDataInternal = (*__ScrollContainerDataInternal)(nil)
+		scrollbarPressed            bool                           = false
 	)
 	for i := int32(0); i < context.scrollContainerDatas.Length; i++ {
 		var scrollData *__ScrollContainerDataInternal = __ScrollContainerDataInternalArray_Get(&context.scrollContainerDatas, i)
@@ -4655,92 +7747,150 @@ func UpdateScrollContainers(enableDragScrolling bool, scrollDelta Vector2, delta
 			__ScrollContainerDataInternalArray_RemoveSwapback(&context.scrollContainerDatas, i)
 			continue
 		}
+		var clipConfig *ClipElementConfig = __FindElementConfigWithType(scrollData.LayoutElement, __ELEMENT_CONFIG_TYPE_CLIP).ClipElementConfig
+		var physics ScrollPhysicsConfig
+		if clipConfig != nil {
+			physics = clipConfig.Physics
+		} else {
+			physics = ScrollPhysicsConfig{}
+		}
+		var friction float32
+		if physics.Friction > 0 {
+			if physics.Friction < 1 {
+				friction = physics.Friction
+			} else {
+				friction = 1
+			}
+		} else {
+			friction = 0.05
+		}
+		var dragThreshold float32
+		if physics.DragThreshold > 0 {
+			dragThreshold = physics.DragThreshold
+		} else {
+			dragThreshold = 10
+		}
+		var snapEnabled bool = clipConfig != nil && clipConfig.Snap.Type != SCROLL_SNAP_NONE
+		var minPosition Vector2 = Vector2{X: -(func() float32 {
+			if (scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width) > 0 {
+				return scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width
+			}
+			return 0
+		}()), Y: -(func() float32 {
+			if (scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height) > 0 {
+				return scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height
+			}
+			return 0
+		}())}
 		if !isPointerActive && scrollData.PointerScrollActive {
 			var xDiff float32 = scrollData.ScrollPosition.X - scrollData.ScrollOrigin.X
-			if xDiff < -10 || xDiff > 10 {
+			if xDiff < -dragThreshold || xDiff > dragThreshold {
 				scrollData.ScrollMomentum.X = (scrollData.ScrollPosition.X - scrollData.ScrollOrigin.X) / (scrollData.MomentumTime * 25)
 			}
 			var yDiff float32 = scrollData.ScrollPosition.Y - scrollData.ScrollOrigin.Y
-			if yDiff < -10 || yDiff > 10 {
+			if yDiff < -dragThreshold || yDiff > dragThreshold {
 				scrollData.ScrollMomentum.Y = (scrollData.ScrollPosition.Y - scrollData.ScrollOrigin.Y) / (scrollData.MomentumTime * 25)
 			}
 			scrollData.PointerScrollActive = false
 			scrollData.PointerOrigin = Vector2{}
 			scrollData.ScrollOrigin = Vector2{}
 			scrollData.MomentumTime = 0
+			if snapEnabled {
+				__SnapScrollContainer(scrollData, clipConfig, Vector2{X: scrollData.ScrollPosition.X + scrollData.ScrollMomentum.X/friction, Y: scrollData.ScrollPosition.Y + scrollData.ScrollMomentum.Y/friction})
+			}
+		}
+		if clipConfig != nil && clipConfig.Scrollbar.Width > 0 {
+			var wasScrollbarPressed bool = scrollData.ScrollbarPressed
+			if __UpdateScrollbars(scrollData, clipConfig, deltaTime) {
+				scrollbarPressed = true
+			} else if wasScrollbarPressed {
+				scrollData.SnapPending = snapEnabled
+				scrollData.SnapIdleTime = 0
+			}
 		}
-		scrollData.ScrollPosition.X += scrollData.ScrollMomentum.X
-		scrollData.ScrollMomentum.X *= 0.95
 		var scrollOccurred bool = scrollDelta.X != 0 || scrollDelta.Y != 0
+		var momentumActive bool = scrollData.ScrollMomentum.X != 0 || scrollData.ScrollMomentum.Y != 0
+		if scrollData.SnapPending && !scrollOccurred && !scrollData.PointerScrollActive && !scrollData.ScrollbarPressed && !momentumActive && !scrollData.ScrollAnimationActive {
+			scrollData.SnapIdleTime += deltaTime
+			if scrollData.SnapIdleTime > 0.15 {
+				__SnapScrollContainer(scrollData, clipConfig, scrollData.ScrollPosition)
+			}
+		}
+		var overscrolled bool = scrollData.ScrollPosition.X > 0 || scrollData.ScrollPosition.X < minPosition.X || scrollData.ScrollPosition.Y > 0 || scrollData.ScrollPosition.Y < minPosition.Y
+		if overscrolled && !scrollData.PointerScrollActive && !momentumActive && !scrollData.ScrollAnimationActive {
+			scrollData.ScrollTarget = __ClampScrollPosition(scrollData, scrollData.ScrollPosition)
+			scrollData.ScrollAnimationActive = true
+		}
+		if scrollData.ScrollAnimationActive {
+			if scrollOccurred || scrollData.PointerScrollActive {
+				scrollData.ScrollAnimationActive = false
+			} else {
+				var (
+					target    Vector2 = __ClampScrollPosition(scrollData, scrollData.ScrollTarget)
+					remaining Vector2 = Vector2{X: target.X - scrollData.ScrollPosition.X, Y: target.Y - scrollData.ScrollPosition.Y}
+				)
+				if remaining.X > -0.5 && remaining.X < 0.5 && remaining.Y > -0.5 && remaining.Y < 0.5 {
+					scrollData.ScrollPosition = target
+					scrollData.ScrollMomentum = Vector2{}
+					scrollData.ScrollAnimationActive = false
+				} else {
+					var step float32
+					if deltaTime > 0 {
+						if (deltaTime * 15) < 1 {
+							step = deltaTime * 15
+						} else {
+							step = 1
+						}
+					} else {
+						step = 1
+					}
+					scrollData.ScrollMomentum = Vector2{X: remaining.X * step, Y: remaining.Y * step}
+				}
+			}
+		}
+		scrollData.ScrollPosition.X += scrollData.ScrollMomentum.X
+		scrollData.ScrollMomentum.X *= 1 - friction
+		if physics.Overscroll > 0 && (scrollData.ScrollPosition.X > 0 || scrollData.ScrollPosition.X < minPosition.X) {
+			scrollData.ScrollMomentum.X *= 0.5
+		}
 		if scrollData.ScrollMomentum.X > -0.1 && scrollData.ScrollMomentum.X < 0.1 || scrollOccurred {
 			scrollData.ScrollMomentum.X = 0
 		}
 		if (func() float32 {
-			if scrollData.ScrollPosition.X > (-func() float32 {
-				if (scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width) > 0 {
-					return scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width
-				}
-				return 0
-			}()) {
+			if scrollData.ScrollPosition.X > (minPosition.X - physics.Overscroll) {
 				return scrollData.ScrollPosition.X
 			}
-			return -func() float32 {
-				if (scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width) > 0 {
-					return scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width
-				}
-				return 0
-			}()
-		}()) < 0 {
-			if scrollData.ScrollPosition.X > (-func() float32 {
-				if (scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width) > 0 {
-					return scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width
-				}
-				return 0
-			}()) {
+			return minPosition.X - physics.Overscroll
+		}()) < physics.Overscroll {
+			if scrollData.ScrollPosition.X > (minPosition.X - physics.Overscroll) {
 				/* (018) */
-			} else if (scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width) > 0 {
-				scrollData.ScrollPosition.X = -(scrollData.ContentSize.Width - scrollData.LayoutElement.Dimensions.Width)
 			} else {
-				scrollData.ScrollPosition.X = 0
+				scrollData.ScrollPosition.X = minPosition.X - physics.Overscroll
 			}
 		} else {
-			scrollData.ScrollPosition.X = 0
+			scrollData.ScrollPosition.X = physics.Overscroll
 		}
 		scrollData.ScrollPosition.Y += scrollData.ScrollMomentum.Y
-		scrollData.ScrollMomentum.Y *= 0.95
+		scrollData.ScrollMomentum.Y *= 1 - friction
+		if physics.Overscroll > 0 && (scrollData.ScrollPosition.Y > 0 || scrollData.ScrollPosition.Y < minPosition.Y) {
+			scrollData.ScrollMomentum.Y *= 0.5
+		}
 		if scrollData.ScrollMomentum.Y > -0.1 && scrollData.ScrollMomentum.Y < 0.1 || scrollOccurred {
 			scrollData.ScrollMomentum.Y = 0
 		}
 		if (func() float32 {
-			if scrollData.ScrollPosition.Y > (-func() float32 {
-				if (scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height) > 0 {
-					return scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height
-				}
-				return 0
-			}()) {
+			if scrollData.ScrollPosition.Y > (minPosition.Y - physics.Overscroll) {
 				return scrollData.ScrollPosition.Y
 			}
-			return -func() float32 {
-				if (scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height) > 0 {
-					return scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height
-				}
-				return 0
-			}()
-		}()) < 0 {
-			if scrollData.ScrollPosition.Y > (-func() float32 {
-				if (scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height) > 0 {
-					return scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height
-				}
-				return 0
-			}()) {
+			return minPosition.Y - physics.Overscroll
+		}()) < physics.Overscroll {
+			if scrollData.ScrollPosition.Y > (minPosition.Y - physics.Overscroll) {
 				/* (019) */
-			} else if (scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height) > 0 {
-				scrollData.ScrollPosition.Y = -(scrollData.ContentSize.Height - scrollData.LayoutElement.Dimensions.Height)
 			} else {
-				scrollData.ScrollPosition.Y = 0
+				scrollData.ScrollPosition.Y = minPosition.Y - physics.Overscroll
 			}
 		} else {
-			scrollData.ScrollPosition.Y = 0
+			scrollData.ScrollPosition.Y = physics.Overscroll
 		}
 		for j := int32(0); j < context.pointerOverIds.Length; j++ {
 			if scrollData.LayoutElement.Id == ElementIdArray_Get(&context.pointerOverIds, j).Id {
@@ -4762,7 +7912,12 @@ func UpdateScrollContainers(enableDragScrolling bool, scrollDelta Vector2, delta
 		if canScrollHorizontally {
 			highestPriorityScrollData.ScrollPosition.X = highestPriorityScrollData.ScrollPosition.X + scrollDelta.X*10
 		}
-		if isPointerActive {
+		if canScrollVertically && scrollDelta.Y != 0 || canScrollHorizontally && scrollDelta.X != 0 {
+			highestPriorityScrollData.SnapPending = clipConfig.Snap.Type != SCROLL_SNAP_NONE
+			highestPriorityScrollData.SnapIdleTime = 0
+			highestPriorityScrollData.ScrollbarIdleTime = 0
+		}
+		if isPointerActive && !scrollbarPressed {
 			highestPriorityScrollData.ScrollMomentum = Vector2{}
 			if !highestPriorityScrollData.PointerScrollActive {
 				highestPriorityScrollData.PointerOrigin = context.pointerInfo.Position
@@ -4770,45 +7925,21 @@ func UpdateScrollContainers(enableDragScrolling bool, scrollDelta Vector2, delta
 				highestPriorityScrollData.PointerScrollActive = true
 			} else {
 				var (
-					scrollDeltaX float32 = 0
-					scrollDeltaY float32 = 0
+					scrollDeltaX float32    = 0
+					scrollDeltaY float32    = 0
+					scale        float32    = highestPriorityScrollData.Scale
+					viewport     Dimensions = __ScrollViewportSize(highestPriorityScrollData)
 				)
 				if canScrollHorizontally {
 					var oldXScrollPosition float32 = highestPriorityScrollData.ScrollPosition.X
-					highestPriorityScrollData.ScrollPosition.X = highestPriorityScrollData.ScrollOrigin.X + (context.pointerInfo.Position.X - highestPriorityScrollData.PointerOrigin.X)
-					if (func() float32 {
-						if highestPriorityScrollData.ScrollPosition.X < 0 {
-							return highestPriorityScrollData.ScrollPosition.X
-						}
-						return 0
-					}()) > (-(highestPriorityScrollData.ContentSize.Width - highestPriorityScrollData.BoundingBox.Width)) {
-						if highestPriorityScrollData.ScrollPosition.X < 0 {
-							/* (020) */
-						} else {
-							highestPriorityScrollData.ScrollPosition.X = 0
-						}
-					} else {
-						highestPriorityScrollData.ScrollPosition.X = -(highestPriorityScrollData.ContentSize.Width - highestPriorityScrollData.BoundingBox.Width)
-					}
+					highestPriorityScrollData.ScrollPosition.X = highestPriorityScrollData.ScrollOrigin.X + (context.pointerInfo.Position.X-highestPriorityScrollData.PointerOrigin.X)/scale
+					highestPriorityScrollData.ScrollPosition.X = __ElasticClamp(highestPriorityScrollData.ScrollPosition.X, -(highestPriorityScrollData.ContentSize.Width - viewport.Width), clipConfig.Physics.Overscroll)
 					scrollDeltaX = highestPriorityScrollData.ScrollPosition.X - oldXScrollPosition
 				}
 				if canScrollVertically {
 					var oldYScrollPosition float32 = highestPriorityScrollData.ScrollPosition.Y
-					highestPriorityScrollData.ScrollPosition.Y = highestPriorityScrollData.ScrollOrigin.Y + (context.pointerInfo.Position.Y - highestPriorityScrollData.PointerOrigin.Y)
-					if (func() float32 {
-						if highestPriorityScrollData.ScrollPosition.Y < 0 {
-							return highestPriorityScrollData.ScrollPosition.Y
-						}
-						return 0
-					}()) > (-(highestPriorityScrollData.ContentSize.Height - highestPriorityScrollData.BoundingBox.Height)) {
-						if highestPriorityScrollData.ScrollPosition.Y < 0 {
-							/* (021) */
-						} else {
-							highestPriorityScrollData.ScrollPosition.Y = 0
-						}
-					} else {
-						highestPriorityScrollData.ScrollPosition.Y = -(highestPriorityScrollData.ContentSize.Height - highestPriorityScrollData.BoundingBox.Height)
-					}
+					highestPriorityScrollData.ScrollPosition.Y = highestPriorityScrollData.ScrollOrigin.Y + (context.pointerInfo.Position.Y-highestPriorityScrollData.PointerOrigin.Y)/scale
+					highestPriorityScrollData.ScrollPosition.Y = __ElasticClamp(highestPriorityScrollData.ScrollPosition.Y, -(highestPriorityScrollData.ContentSize.Height - viewport.Height), clipConfig.Physics.Overscroll)
 					scrollDeltaY = highestPriorityScrollData.ScrollPosition.Y - oldYScrollPosition
 				}
 				if scrollDeltaX > -0.1 && scrollDeltaX < 0.1 && scrollDeltaY > -0.1 && scrollDeltaY < 0.1 && highestPriorityScrollData.MomentumTime > 0.15 {
@@ -4820,7 +7951,8 @@ func UpdateScrollContainers(enableDragScrolling bool, scrollDelta Vector2, delta
 				}
 			}
 		}
-		if canScrollVertically {
+		var elasticDrag bool = highestPriorityScrollData.PointerScrollActive && clipConfig.Physics.Overscroll > 0
+		if canScrollVertically && !elasticDrag {
 			if (func() float32 {
 				if highestPriorityScrollData.ScrollPosition.Y < 0 {
 					return highestPriorityScrollData.ScrollPosition.Y
@@ -4836,7 +7968,7 @@ func UpdateScrollContainers(enableDragScrolling bool, scrollDelta Vector2, delta
 				highestPriorityScrollData.ScrollPosition.Y = -(highestPriorityScrollData.ContentSize.Height - scrollElement.Dimensions.Height)
 			}
 		}
-		if canScrollHorizontally {
+		if canScrollHorizontally && !elasticDrag {
 			if (func() float32 {
 				if highestPriorityScrollData.ScrollPosition.X < 0 {
 					return highestPriorityScrollData.ScrollPosition.X
@@ -4895,7 +8027,12 @@ func EndLayout() RenderCommandArray {
 	if context.openLayoutElementStack.Length > 1 {
 		context.errorHandler.ErrorHandlerFunction(ErrorData{ErrorType: ERROR_TYPE_UNBALANCED_OPEN_CLOSE, ErrorText: String{IsStaticallyAllocated: true, Length: int32(((len("There were still open layout elements when EndLayout was called. This results from an unequal number of calls to __OpenElement and __CloseElement.") + 1) / int(unsafe.Sizeof(byte(0)))) - int(unsafe.Sizeof(byte(0)))), Chars: libc.CString("There were still open layout elements when EndLayout was called. This results from an unequal number of calls to __OpenElement and __CloseElement.")}, UserData: context.errorHandler.UserData})
 	}
+	if context.focusedElementId.Id != 0 && __GetFocusableIndex(context.focusedElementId.Id) == -1 {
+		context.focusedElementId = ElementId{}
+		context.focusVisible = false
+	}
 	__CalculateFinalLayout()
+	__DispatchLifecycleEvents()
 	return context.renderCommands
 }
 
@@ -4938,6 +8075,87 @@ func OnHover(onHoverFunction func(elementId ElementId, pointerInfo PointerData,
 	hashMapItem.HoverFunctionUserData = userData
 }
 
+func Focused() bool {
+	var context *Context = GetCurrentContext()
+	if context.booleanWarnings.MaxElementsExceeded {
+		return false
+	}
+	var openLayoutElement *LayoutElement = __GetOpenLayoutElement()
+	if openLayoutElement.Id == 0 {
+		__GenerateIdForAnonymousElement(openLayoutElement)
+	}
+	return context.focusedElementId.Id != 0 && context.focusedElementId.Id == openLayoutElement.Id
+}
+
+func FocusVisible() bool {
+	return GetCurrentContext().focusVisible && Focused()
+}
+
+func OnKey(onKeyFunction func(elementId ElementId, keyEvent KeyEvent, userData any) bool, userData any) {
+	var context *Context = GetCurrentContext()
+	if context.booleanWarnings.MaxElementsExceeded {
+		return
+	}
+	var openLayoutElement *LayoutElement = __GetOpenLayoutElement()
+	if openLayoutElement.Id == 0 {
+		__GenerateIdForAnonymousElement(openLayoutElement)
+	}
+	var hashMapItem *LayoutElementHashMapItem = __GetHashMapItem(openLayoutElement.Id)
+	hashMapItem.OnKeyFunction = onKeyFunction
+	hashMapItem.KeyFunctionUserData = userData
+}
+
+func OnLifecycle(onLifecycleFunction func(elementId ElementId, event LifecycleEvent, visibility ElementVisibility, userData any), userData any) {
+	var context *Context = GetCurrentContext()
+	if context.booleanWarnings.MaxElementsExceeded {
+		return
+	}
+	var openLayoutElement *LayoutElement = __GetOpenLayoutElement()
+	if openLayoutElement.Id == 0 {
+		__GenerateIdForAnonymousElement(openLayoutElement)
+	}
+	var hashMapItem *LayoutElementHashMapItem = __GetHashMapItem(openLayoutElement.Id)
+	hashMapItem.OnLifecycleFunction = onLifecycleFunction
+	hashMapItem.LifecycleFunctionUserData = userData
+}
+
+func GetElementVisibility(elementId ElementId) ElementVisibility {
+	var hashMapItem *LayoutElementHashMapItem = __GetHashMapItem(elementId.Id)
+	if hashMapItem == &LayoutElementHashMapItem_DEFAULT {
+		return ElementVisibility{}
+	}
+	return hashMapItem.Visibility
+}
+
+func GetFocusedElementId() ElementId {
+	return GetCurrentContext().focusedElementId
+}
+
+func SetFocus(elementId ElementId) {
+	GetCurrentContext().focusedElementId = elementId
+}
+
+func ClearFocus() {
+	var context *Context = GetCurrentContext()
+	context.focusedElementId = ElementId{}
+	context.focusVisible = false
+}
+
+func MoveFocus(direction FocusDirection) bool {
+	var context *Context = GetCurrentContext()
+	if context.booleanWarnings.MaxElementsExceeded {
+		return false
+	}
+	switch direction {
+	case FOCUS_DIRECTION_NEXT:
+		return __MoveFocusSequential(false)
+	case FOCUS_DIRECTION_PREVIOUS:
+		return __MoveFocusSequential(true)
+	default:
+		return __MoveFocusSpatial(direction)
+	}
+}
+
 func PointerOver(elementId ElementId) bool {
 	var context *Context = GetCurrentContext()
 	for i := int32(0); i < context.pointerOverIds.Length; i++ {
@@ -4957,12 +8175,153 @@ func GetScrollContainerData(id ElementId) ScrollContainerData {
 			if clipElementConfig == nil {
 				return ScrollContainerData{}
 			}
-			return ScrollContainerData{ScrollPosition: &scrollContainerData.ScrollPosition, ScrollContainerDimensions: Dimensions{Width: scrollContainerData.BoundingBox.Width, Height: scrollContainerData.BoundingBox.Height}, ContentDimensions: scrollContainerData.ContentSize, Config: *clipElementConfig, Found: true}
+			return ScrollContainerData{ScrollPosition: &scrollContainerData.ScrollPosition, ScrollContainerDimensions: __ScrollViewportSize(scrollContainerData), ContentDimensions: scrollContainerData.ContentSize, Config: *clipElementConfig, Found: true}
 		}
 	}
 	return ScrollContainerData{}
 }
 
+func ScrollIntoView(containerId ElementId, targetId ElementId, alignment ScrollAlignment) {
+	var (
+		scrollData *__ScrollContainerDataInternal = __GetScrollContainerDataInternal(containerId.Id)
+		targetItem *LayoutElementHashMapItem      = __GetHashMapItem(targetId.Id)
+	)
+	if scrollData == nil || targetItem == &LayoutElementHashMapItem_DEFAULT {
+		return
+	}
+	__ScrollContainerToBox(scrollData, targetItem.BoundingBox, alignment)
+}
+
+func ScrollTo(containerId ElementId, scrollPosition Vector2, animate bool) {
+	var scrollData *__ScrollContainerDataInternal = __GetScrollContainerDataInternal(containerId.Id)
+	if scrollData == nil {
+		return
+	}
+	if animate {
+		scrollData.ScrollTarget = scrollPosition
+		scrollData.ScrollAnimationActive = true
+	} else {
+		scrollData.ScrollPosition = __ClampScrollPosition(scrollData, scrollPosition)
+		scrollData.ScrollMomentum = Vector2{}
+		scrollData.ScrollAnimationActive = false
+	}
+}
+
+func SetDeltaTime(deltaTime float32) {
+	GetCurrentContext().deltaTime = deltaTime
+}
+
+func GetZoomPanData(id ElementId) ZoomPanData {
+	var context *Context = GetCurrentContext()
+	for i := int32(0); i < context.zoomPanDatas.Length; i++ {
+		var zoomPanData *__ZoomPanDataInternal = __ZoomPanDataInternalArray_Get(&context.zoomPanDatas, i)
+		if zoomPanData.ElementId == id.Id {
+			return ZoomPanData{Pan: &zoomPanData.Pan, Zoom: &zoomPanData.Zoom, BoundingBox: zoomPanData.BoundingBox, Found: true}
+		}
+	}
+	return ZoomPanData{}
+}
+
+func UpdateZoomPanContainers(enableDragPanning bool, zoomDelta float32) {
+	var (
+		context                     *Context               = GetCurrentContext()
+		pointer                     PointerData            = context.pointerInfo
+		isPointerActive             bool                   = enableDragPanning && (pointer.State == POINTER_DATA_PRESSED || pointer.State == POINTER_DATA_PRESSED_THIS_FRAME)
+		highestPriorityElementIndex int32                  = -1
+		highestPriorityZoomPanData  *__ZoomPanDataInternal = (*__ZoomPanDataInternal)(nil)
+		dragActive                  bool                   = false
+	)
+	for i := int32(0); i < context.zoomPanDatas.Length; i++ {
+		var zoomPanData *__ZoomPanDataInternal = __ZoomPanDataInternalArray_Get(&context.zoomPanDatas, i)
+		if !zoomPanData.OpenThisFrame {
+			__ZoomPanDataInternalArray_RemoveSwapback(&context.zoomPanDatas, i)
+			i--
+			continue
+		}
+		zoomPanData.OpenThisFrame = false
+		var parentScale float32
+		if zoomPanData.ParentScale > 0 {
+			parentScale = zoomPanData.ParentScale
+		} else {
+			parentScale = 1
+		}
+		if !isPointerActive {
+			zoomPanData.DragActive = false
+		}
+		if zoomPanData.DragActive {
+			zoomPanData.Pan.X = zoomPanData.PanOrigin.X + (pointer.Position.X-zoomPanData.PointerOrigin.X)/parentScale
+			zoomPanData.Pan.Y = zoomPanData.PanOrigin.Y + (pointer.Position.Y-zoomPanData.PointerOrigin.Y)/parentScale
+			dragActive = true
+		}
+		for j := int32(0); j < context.pointerOverIds.Length; j++ {
+			if zoomPanData.ElementId == ElementIdArray_Get(&context.pointerOverIds, j).Id && j > highestPriorityElementIndex {
+				highestPriorityElementIndex = j
+				highestPriorityZoomPanData = zoomPanData
+			}
+		}
+	}
+	if highestPriorityZoomPanData == nil {
+		return
+	}
+	if zoomDelta != 0 {
+		var (
+			config  *ZoomPanElementConfig = __FindElementConfigWithType(highestPriorityZoomPanData.LayoutElement, __ELEMENT_CONFIG_TYPE_ZOOM_PAN).ZoomPanElementConfig
+			minZoom float32
+		)
+		if config != nil && config.MinZoom > 0 {
+			minZoom = config.MinZoom
+		} else {
+			minZoom = 0.1
+		}
+		var maxZoom float32
+		if config != nil && config.MaxZoom > 0 {
+			maxZoom = config.MaxZoom
+		} else {
+			maxZoom = 10
+		}
+		var zoom float32 = (func() float32 {
+			if (func() float32 {
+				if (highestPriorityZoomPanData.Zoom * (zoomDelta + 1)) > minZoom {
+					return highestPriorityZoomPanData.Zoom * (zoomDelta + 1)
+				}
+				return minZoom
+			}()) < maxZoom {
+				if (highestPriorityZoomPanData.Zoom * (zoomDelta + 1)) > minZoom {
+					return highestPriorityZoomPanData.Zoom * (zoomDelta + 1)
+				}
+				return minZoom
+			}
+			return maxZoom
+		}())
+		var canvasPoint Vector2 = ZoomPanScreenToCanvas(ElementId{Id: highestPriorityZoomPanData.ElementId}, pointer.Position)
+		highestPriorityZoomPanData.Pan.X += canvasPoint.X * (highestPriorityZoomPanData.Zoom - zoom)
+		highestPriorityZoomPanData.Pan.Y += canvasPoint.Y * (highestPriorityZoomPanData.Zoom - zoom)
+		highestPriorityZoomPanData.Zoom = zoom
+	}
+	if isPointerActive && !dragActive {
+		highestPriorityZoomPanData.DragActive = true
+		highestPriorityZoomPanData.PointerOrigin = pointer.Position
+		highestPriorityZoomPanData.PanOrigin = highestPriorityZoomPanData.Pan
+	}
+}
+
+func ZoomPanScreenToCanvas(id ElementId, position Vector2) Vector2 {
+	var context *Context = GetCurrentContext()
+	for i := int32(0); i < context.zoomPanDatas.Length; i++ {
+		var zoomPanData *__ZoomPanDataInternal = __ZoomPanDataInternalArray_Get(&context.zoomPanDatas, i)
+		if zoomPanData.ElementId == id.Id {
+			var parentScale float32
+			if zoomPanData.ParentScale > 0 {
+				parentScale = zoomPanData.ParentScale
+			} else {
+				parentScale = 1
+			}
+			return Vector2{X: ((position.X-zoomPanData.BoundingBox.X)/parentScale - zoomPanData.Pan.X) / zoomPanData.Zoom, Y: ((position.Y-zoomPanData.BoundingBox.Y)/parentScale - zoomPanData.Pan.Y) / zoomPanData.Zoom}
+		}
+	}
+	return position
+}
+
 func GetElementData(id ElementId) ElementData {
 	var item *LayoutElementHashMapItem = __GetHashMapItem(id.Id)
 	if item == &LayoutElementHashMapItem_DEFAULT {
@@ -236,6 +236,11 @@ typedef struct Clay_BoundingBox {
     float x, y, width, height;
 } Clay_BoundingBox;
 
+// A 2D affine transform, which maps the point (x, y) to (a * x + c * y + tx, b * x + d * y + ty).
+typedef struct Clay_Matrix2D {
+    float a, b, c, d, tx, ty;
+} Clay_Matrix2D;
+
 // Primarily created via the CLAY_ID(), CLAY_IDI(), CLAY_ID_LOCAL() and CLAY_IDI_LOCAL() macros.
 // Represents a hashed string ID used for identifying and finding specific clay UI elements, required
 // by functions such as Clay_PointerOver() and Clay_GetElementData().
@@ -263,6 +268,45 @@ typedef struct Clay_CornerRadius {
     float bottomRight;
 } Clay_CornerRadius;
 
+// Controls the shape of a gradient fill.
+typedef CLAY_PACKED_ENUM {
+    // (Default) No gradient, the solid color is used instead.
+    CLAY_GRADIENT_TYPE_NONE,
+    // Colors change along a straight line across the element, in the direction of .angle.
+    CLAY_GRADIENT_TYPE_LINEAR,
+    // Colors change with the distance from .center, out to .radius.
+    CLAY_GRADIENT_TYPE_RADIAL,
+} Clay_GradientType;
+
+// The maximum number of color stops in a Clay_Gradient.
+#define CLAY_GRADIENT_MAX_STOPS 8
+
+// A color reached at a position along a gradient.
+typedef struct Clay_GradientStop {
+    // Conventionally represented as 0-255 for each channel, but interpretation is up to the renderer.
+    Clay_Color color;
+    // Where the color is reached, from 0 at the start of the gradient to 1 at its end.
+    float position;
+} Clay_GradientStop;
+
+// A fill whose color changes across the element, blending each channel linearly between neighbouring stops.
+// Before the first stop and after the last one, the color of the closest stop is used.
+typedef struct Clay_Gradient {
+    // Controls the shape of the gradient. The gradient is only used if this isn't CLAY_GRADIENT_TYPE_NONE.
+    Clay_GradientType type;
+    // LINEAR: the direction of the gradient in degrees, clockwise from pointing up, e.g. 90 runs from left to right and 180 from top to bottom.
+    // The gradient's line is long enough for the first and last stops to reach the element's corners.
+    float angle;
+    // RADIAL: the position of the gradient's center, as a fraction of the element's size, e.g. { 0.5, 0.5 } is its middle.
+    Clay_Vector2 center;
+    // RADIAL: the distance from the center in pixels at which the last stop is reached. 0 reaches it at the farthest corner.
+    float radius;
+    // The number of stops used, at most CLAY_GRADIENT_MAX_STOPS.
+    int32_t stopCount;
+    // The colors of the gradient, in order of increasing position.
+    Clay_GradientStop stops[CLAY_GRADIENT_MAX_STOPS];
+} Clay_Gradient;
+
 // Element Configs ---------------------------
 
 // Controls the direction in which child elements will be automatically laid out.
@@ -418,9 +462,48 @@ CLAY__WRAPPER_STRUCT(Clay_AspectRatioElementConfig);
 
 // Image --------------------------------
 
+// Controls how an image is sized to fit inside its element.
+typedef CLAY_PACKED_ENUM {
+    // (default) Stretches the image to fill the element, ignoring its aspect ratio.
+    CLAY_IMAGE_FIT_FILL,
+    // Scales the image to be as large as possible while staying entirely inside the element, keeping its aspect ratio.
+    CLAY_IMAGE_FIT_CONTAIN,
+    // Scales the image to cover the whole element, keeping its aspect ratio. Parts outside of the element are cropped.
+    CLAY_IMAGE_FIT_COVER,
+    // Draws the image at its natural size. Parts outside of the element are cropped.
+    CLAY_IMAGE_FIT_NONE,
+    // Draws the image at its natural size, or as CLAY_IMAGE_FIT_CONTAIN if that would be smaller.
+    CLAY_IMAGE_FIT_SCALE_DOWN,
+} Clay_ImageFit;
+
+// Draws an image as a nine-slice, e.g. a panel skin that can be stretched to any size.
+// The insets cut the image into a 3x3 grid: the corners are drawn at their size, the top and bottom edges are stretched
+// horizontally, the left and right edges vertically, and the center in both directions.
+typedef struct Clay_NineSlice {
+    // The sizes of the image's corners and edges, in the image's own pixels.
+    float left;
+    float top;
+    float right;
+    float bottom;
+    // Repeats the edges and the center from their top left corner instead of stretching them.
+    bool tiled;
+} Clay_NineSlice;
+
 // Controls various settings related to image elements.
 typedef struct Clay_ImageElementConfig {
     void* imageData; // A transparent pointer used to pass image data through to the renderer.
+    // Controls how the image is sized to fit inside the element.
+    Clay_ImageFit fit;
+    // Controls where the image is placed inside the element when it doesn't fill it, and which part of it is kept when it's cropped.
+    Clay_ChildAlignment alignment;
+    // The part of the image to draw, in the image's own pixels, e.g. a single sprite of an atlas. The whole image is drawn when
+    // the width or height is 0.
+    Clay_BoundingBox sourceRect;
+    // The natural size of the image, or of .sourceRect. When set, FIT sized elements are at least this big, and elements whose
+    // width isn't FIT and whose height isn't FIXED or PERCENT keep the image's aspect ratio unless .aspectRatio is set.
+    Clay_Dimensions intrinsicSize;
+    // Draws the image as a nine-slice filling the element when any of its insets are set, in which case .fit and .alignment are ignored.
+    Clay_NineSlice nineSlice;
 } Clay_ImageElementConfig;
 
 CLAY__WRAPPER_STRUCT(Clay_ImageElementConfig);
@@ -477,6 +560,16 @@ typedef CLAY_PACKED_ENUM {
     CLAY_CLIP_TO_ATTACHED_PARENT
 } Clay_FloatingClipToElement;
 
+// Bit flags controlling how a floating element avoids overflowing the layout root, or the clip element containing the element it's attached to.
+typedef CLAY_PACKED_ENUM {
+    // (default) - The floating element is positioned by its attach points, even if it overflows.
+    CLAY_FLOATING_COLLISION_NONE = 0,
+    // On each axis where the floating element overflows, mirror the attach points and offset to the opposite side, if that overflows less.
+    CLAY_FLOATING_COLLISION_FLIP = 1,
+    // Slide the floating element along the edges it overflows until it fits, or is aligned to the top left edge if it is too large to fit.
+    CLAY_FLOATING_COLLISION_SHIFT = 2,
+} Clay_FloatingCollisionFlags;
+
 // Controls various settings related to "floating" elements, which are elements that "float" above other elements, potentially overlapping their boundaries,
 // and not affecting the layout of sibling or parent elements.
 typedef struct Clay_FloatingElementConfig {
@@ -509,6 +602,10 @@ typedef struct Clay_FloatingElementConfig {
     // CLAY_CLIP_TO_NONE (default) - The floating element does not inherit clipping.
     // CLAY_CLIP_TO_ATTACHED_PARENT - The floating element is clipped to the same clipping rectangle as the element it's attached to.
     Clay_FloatingClipToElement clipTo;
+    // When true, keyboard and gamepad focus navigation is confined to this floating element and its children while it is the topmost modal floating element.
+    bool modal;
+    // A combination of Clay_FloatingCollisionFlags that keeps the floating element on screen. The final position is reported by Clay_GetElementData.
+    Clay_FloatingCollisionFlags collision;
 } Clay_FloatingElementConfig;
 
 CLAY__WRAPPER_STRUCT(Clay_FloatingElementConfig);
@@ -526,15 +623,276 @@ CLAY__WRAPPER_STRUCT(Clay_CustomElementConfig);
 
 // Scroll -----------------------------
 
+// Controls where Clay_ScrollIntoView places the target element inside the scroll container.
+typedef CLAY_PACKED_ENUM {
+    // (default) Scrolls as little as possible to make the target fully visible. A target that is already visible doesn't cause a scroll.
+    CLAY_SCROLL_ALIGN_NEAREST,
+    // Aligns the start (left or top) of the target with the start of the scroll container.
+    CLAY_SCROLL_ALIGN_START,
+    // Centers the target inside the scroll container.
+    CLAY_SCROLL_ALIGN_CENTER,
+    // Aligns the end (right or bottom) of the target with the end of the scroll container.
+    CLAY_SCROLL_ALIGN_END,
+} Clay_ScrollAlignment;
+
+// Controls what a scroll container snaps to once the user stops scrolling it.
+typedef CLAY_PACKED_ENUM {
+    // (default) The container stops wherever its momentum runs out.
+    CLAY_SCROLL_SNAP_NONE,
+    // Snaps so that one of the container's direct children is placed at the snap alignment.
+    CLAY_SCROLL_SNAP_CHILDREN,
+    // Snaps the scroll offset to a multiple of a fixed interval.
+    CLAY_SCROLL_SNAP_INTERVAL,
+} Clay_ScrollSnapType;
+
+// Controls scroll snapping of a clip element, e.g. for carousels and pickers.
+typedef struct Clay_ScrollSnapConfig {
+    Clay_ScrollSnapType type; // What the container snaps to.
+    Clay_ScrollAlignment alignment; // Where a snapped child is placed when type is CLAY_SCROLL_SNAP_CHILDREN. CLAY_SCROLL_ALIGN_NEAREST snaps like CLAY_SCROLL_ALIGN_START.
+    float interval; // The distance between snap points in pixels when type is CLAY_SCROLL_SNAP_INTERVAL.
+} Clay_ScrollSnapConfig;
+
+// Controls how a clip element responds to drag scrolling and momentum. Zero values keep the default behaviour.
+typedef struct Clay_ScrollPhysicsConfig {
+    float friction; // The fraction of momentum lost on each call to Clay_UpdateScrollContainers, between 0 and 1. Defaults to 0.05.
+    float dragThreshold; // A drag has to cover at least this many pixels for releasing it to start momentum. Defaults to 10.
+    float overscroll; // Lets drags and momentum pull the content up to this many pixels past its ends, springing back afterwards. 0 disables elastic overscroll.
+} Clay_ScrollPhysicsConfig;
+
+// Controls the scrollbars that Clay draws on top of a scroll container. Scrollbars are only drawn on clipped axes where the content overflows.
+typedef struct Clay_ScrollbarConfig {
+    float width; // The thickness of the scrollbars in pixels. Scrollbars are disabled when this is 0.
+    float minThumbLength; // The thumb never gets shorter than this, or than width, however small the viewport is relative to the content.
+    Clay_Color thumbColor; // The color of the draggable thumb.
+    Clay_Color trackColor; // The color of the track behind the thumb. Clicking the track pages towards the pointer. A transparent track isn't rendered.
+    Clay_CornerRadius cornerRadius; // Corner radius used for both the track and the thumb.
+    float autoHideDelay; // Hides the scrollbars after this many seconds without scrolling or hovering them. 0 keeps them visible.
+} Clay_ScrollbarConfig;
+
 // Controls the axis on which an element switches to "scrolling", which clips the contents and allows scrolling in that direction.
 typedef struct Clay_ClipElementConfig {
     bool horizontal; // Clip overflowing elements on the X axis.
     bool vertical; // Clip overflowing elements on the Y axis.
     Clay_Vector2 childOffset; // Offsets the x,y positions of all child elements. Used primarily for scrolling containers.
+    Clay_ScrollbarConfig scrollbar; // Opt-in scrollbars, drawn over the content and driven by Clay_UpdateScrollContainers.
+    Clay_ScrollPhysicsConfig physics; // Tunes the momentum and overscroll applied by Clay_UpdateScrollContainers.
+    Clay_ScrollSnapConfig snap; // Snaps the scroll position once the user stops scrolling.
 } Clay_ClipElementConfig;
 
 CLAY__WRAPPER_STRUCT(Clay_ClipElementConfig);
 
+// Focus -----------------------------
+
+// Controls whether an element can receive keyboard focus, and where it appears in the tab order.
+typedef struct Clay_FocusElementConfig {
+    bool focusable; // Allows the element to be focused by tab navigation, a pointer press or Clay_SetFocus().
+    // Elements with a positive tabIndex are visited first in ascending order, followed by elements with a tabIndex of 0 in declaration order.
+    // Elements with a negative tabIndex are skipped by tab navigation, but can still be focused by a pointer press or Clay_SetFocus().
+    int32_t tabIndex;
+} Clay_FocusElementConfig;
+
+CLAY__WRAPPER_STRUCT(Clay_FocusElementConfig);
+
+// Sticky -----------------------------
+
+// Keeps an element inside the viewport of its nearest clip ancestor while that ancestor scrolls, like a section header in a grouped list.
+// A sticky element never moves outside its parent's content, and is pushed away by the next sticky sibling on the same axis.
+typedef struct Clay_StickyElementConfig {
+    bool horizontal; // Sticks the element to the left edge of the clip ancestor.
+    bool vertical; // Sticks the element to the top edge of the clip ancestor.
+    Clay_Vector2 offset; // The distance kept between the element and the edges it sticks to.
+} Clay_StickyElementConfig;
+
+CLAY__WRAPPER_STRUCT(Clay_StickyElementConfig);
+
+// Zoom Pan -----------------------------
+
+// Turns an element into a canvas whose children are zoomed and panned by a viewport transform that Clay keeps per element id.
+// Children are laid out as usual in canvas space, and their render commands, text sizes and bounding boxes are transformed onto the screen.
+// Combine with .clip = { .horizontal = true, .vertical = true } to clip the canvas to the element's bounds.
+typedef struct Clay_ZoomPanElementConfig {
+    bool enabled; // Enables the zoom-pan transform for this element.
+    float minZoom; // The smallest zoom reachable through Clay_UpdateZoomPanContainers. Defaults to 0.1.
+    float maxZoom; // The largest zoom reachable through Clay_UpdateZoomPanContainers. Defaults to 10.
+} Clay_ZoomPanElementConfig;
+
+CLAY__WRAPPER_STRUCT(Clay_ZoomPanElementConfig);
+
+// Transition -----------------------------
+
+// Bit flags selecting which properties of an element animate when they change between frames.
+typedef CLAY_PACKED_ENUM {
+    CLAY_TRANSITION_PROPERTY_NONE = 0,
+    // The element's position relative to its parent's content.
+    CLAY_TRANSITION_PROPERTY_POSITION = 1,
+    CLAY_TRANSITION_PROPERTY_SIZE = 2,
+    CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR = 4,
+    // The opacity of the element and its children, see Clay_TransitionElementConfig.opacity. Faded elements are drawn as a group
+    // between LAYER_START and LAYER_END render commands, like with Clay_OpacityElementConfig.
+    CLAY_TRANSITION_PROPERTY_OPACITY = 8,
+    CLAY_TRANSITION_PROPERTY_ALL = 15,
+} Clay_TransitionProperty;
+
+// Controls how a transition progresses over its duration.
+typedef CLAY_PACKED_ENUM {
+    // (default) Progresses at a constant rate.
+    CLAY_EASING_LINEAR,
+    // Starts slowly and speeds up.
+    CLAY_EASING_EASE_IN,
+    // Starts quickly and slows down.
+    CLAY_EASING_EASE_OUT,
+    // Starts and ends slowly.
+    CLAY_EASING_EASE_IN_OUT,
+} Clay_EasingCurve;
+
+// Controls how an element animates when it first appears, or after it is no longer declared.
+typedef struct Clay_TransitionEnterExitConfig {
+    // Enables the animation. An enabled animation with no other fields set fades the element in or out.
+    bool enabled;
+    // The opacity the element fades in from, or out to.
+    float opacity;
+    // The offset in pixels the element slides in from, or out to.
+    Clay_Vector2 offset;
+} Clay_TransitionEnterExitConfig;
+
+// Controls animations of an element's rendered position, size, background color and opacity, keyed by the element's id.
+// Layout isn't affected: the element's render commands and bounding box move towards the laid out values over time.
+// Elements with transitions should have a stable id, e.g. from CLAY_ID, since generated ids change when siblings are added or removed.
+// Transitions advance by the time passed to Clay_SetDeltaTime.
+typedef struct Clay_TransitionElementConfig {
+    // A combination of Clay_TransitionProperty flags. Changes to other properties apply immediately.
+    Clay_TransitionProperty properties;
+    // The length of each transition in seconds.
+    float duration;
+    Clay_EasingCurve easing;
+    // The opacity of the element and its children from 0 to 1, only used when properties includes CLAY_TRANSITION_PROPERTY_OPACITY.
+    // 0 is treated as unset and the element is fully opaque, use the exit animation to fade an element out completely.
+    float opacity;
+    // Animates the element from these values when it is declared for the first time.
+    Clay_TransitionEnterExitConfig enter;
+    // Keeps rendering the element's last frame for the duration after it is no longer declared, animating it to these values.
+    Clay_TransitionEnterExitConfig exit;
+} Clay_TransitionElementConfig;
+
+CLAY__WRAPPER_STRUCT(Clay_TransitionElementConfig);
+
+// Shadow -----------------------------
+
+// Controls a drop shadow drawn around, or inside, the element's rounded rectangle. A shadow is only drawn when its color has a non zero alpha.
+typedef struct Clay_ShadowElementConfig {
+    // Conventionally represented as 0-255 for each channel, but interpretation is up to the renderer.
+    Clay_Color color;
+    // Moves the shadow relative to the element in pixels, e.g. { 0, 4 } for light from above.
+    Clay_Vector2 offset;
+    // The distance in pixels over which the shadow fades out. 0 gives a hard edged shadow.
+    float blurRadius;
+    // Grows the shadow in every direction by this many pixels before it is blurred. Negative values shrink it.
+    float spread;
+    // Draws the shadow inside the element's edges, above its background, instead of behind the element.
+    bool inset;
+} Clay_ShadowElementConfig;
+
+CLAY__WRAPPER_STRUCT(Clay_ShadowElementConfig);
+
+// Opacity -----------------------------
+
+// Controls the opacity of an element and all of its descendants, which are drawn together and faded as a single group,
+// so overlapping children don't show through each other.
+typedef struct Clay_OpacityElementConfig {
+    // Enables group opacity, and will generate LAYER_START and LAYER_END render commands around the element when .value is below 1.
+    bool enabled;
+    // The opacity of the group, from 0 to 1.
+    float value;
+} Clay_OpacityElementConfig;
+
+CLAY__WRAPPER_STRUCT(Clay_OpacityElementConfig);
+
+// Transform ---------------------------
+
+// Controls a visual 2D transform of an element and all of its descendants. The element keeps the space it was laid out in,
+// and is only moved when it's drawn, by the matrix set on its render commands.
+typedef struct Clay_TransformElementConfig {
+    // Enables the transform.
+    bool enabled;
+    // The point the element is rotated and scaled around, as a fraction of its size. { 0.5, 0.5 } is its center.
+    Clay_Vector2 origin;
+    // The clockwise rotation in degrees.
+    float rotation;
+    // The scale along the element's x and y axes. Note: { 1, 1 } leaves the element's size unchanged, a scale of 0 collapses it.
+    Clay_Vector2 scale;
+    // The distance to move the element by in pixels, after it has been rotated and scaled.
+    Clay_Vector2 translate;
+} Clay_TransformElementConfig;
+
+CLAY__WRAPPER_STRUCT(Clay_TransformElementConfig);
+
+// Path -------------------------------
+
+// The kinds of commands a path is made of.
+typedef CLAY_PACKED_ENUM {
+    // Starts a new subpath at points[0].
+    CLAY_PATH_COMMAND_MOVE_TO,
+    // Draws a straight line to points[0].
+    CLAY_PATH_COMMAND_LINE_TO,
+    // Draws a quadratic curve to points[1], with points[0] as its control point.
+    CLAY_PATH_COMMAND_QUAD_TO,
+    // Draws a cubic curve to points[2], with points[0] and points[1] as its control points.
+    CLAY_PATH_COMMAND_CUBIC_TO,
+    // Draws a circular arc around points[0], from .startAngle to .endAngle. A straight line joins the arc to the end of the
+    // previous command, unless it starts the subpath.
+    CLAY_PATH_COMMAND_ARC,
+    // Closes the subpath with a straight line back to its start.
+    CLAY_PATH_COMMAND_CLOSE,
+} Clay_PathCommandType;
+
+// A single command of a path. Points are in pixels, relative to the top left corner of the element.
+typedef struct Clay_PathCommand {
+    Clay_PathCommandType type;
+    // The end and control points of the command, see Clay_PathCommandType.
+    Clay_Vector2 points[3];
+    // The radius of CLAY_PATH_COMMAND_ARC.
+    float radius;
+    // The angles in degrees CLAY_PATH_COMMAND_ARC starts and ends at, clockwise from the positive x axis.
+    // The arc is drawn clockwise when .endAngle is larger than .startAngle, and counter clockwise otherwise.
+    float startAngle;
+    float endAngle;
+} Clay_PathCommand;
+
+// A list of path commands.
+typedef struct Clay_PathCommandArray {
+    int32_t length;
+    Clay_PathCommand *internalArray;
+} Clay_PathCommandArray;
+
+// Controls how the straight segments of a path's stroke are joined.
+typedef CLAY_PACKED_ENUM {
+    // (default) Extends the outer edges of both segments until they meet, or bevels the corner when the miter would be more
+    // than 4 times as long as the stroke is wide.
+    CLAY_LINE_JOIN_MITER,
+    // Rounds the corner off with a circle around the point the segments meet at.
+    CLAY_LINE_JOIN_ROUND,
+    // Cuts the corner off with a straight line between the outer edges of both segments.
+    CLAY_LINE_JOIN_BEVEL,
+} Clay_LineJoin;
+
+// Controls a vector path drawn over the element's background, beneath its children.
+typedef struct Clay_PathElementConfig {
+    // The commands making up the path. They are copied when the element is declared, into room for 4 commands per element
+    // of Clay_SetMaxElementCount shared by every path in the layout.
+    Clay_PathCommandArray commands;
+    // The color the inside of the path is filled with, where its subpaths wind around a point a non zero number of times.
+    // Subpaths are closed for filling. Nothing is filled when the alpha is 0.
+    Clay_Color fillColor;
+    // The color of the path's outline. Nothing is stroked when the alpha or .strokeWidth is 0.
+    Clay_Color strokeColor;
+    // The width of the path's outline in pixels, centered on the path.
+    float strokeWidth;
+    // Controls how the segments of the path's outline are joined.
+    Clay_LineJoin join;
+} Clay_PathElementConfig;
+
+CLAY__WRAPPER_STRUCT(Clay_PathElementConfig);
+
 // Border -----------------------------
 
 // Controls the widths of individual element borders.
@@ -549,10 +907,38 @@ typedef struct Clay_BorderWidth {
     uint16_t betweenChildren;
 } Clay_BorderWidth;
 
+// Controls the colors of individual element borders. Sides with a color of alpha 0 use the border's shared .color.
+typedef struct Clay_BorderColors {
+    Clay_Color left;
+    Clay_Color right;
+    Clay_Color top;
+    Clay_Color bottom;
+} Clay_BorderColors;
+
+// Controls the line style borders are drawn with.
+typedef CLAY_PACKED_ENUM {
+    // (default) Borders are drawn as continuous lines.
+    CLAY_BORDER_STYLE_SOLID,
+    // Borders are drawn as dashes, .dashLength long with .gapLength between them.
+    CLAY_BORDER_STYLE_DASHED,
+    // Borders are drawn as round dots as wide as the border, with .gapLength between them.
+    CLAY_BORDER_STYLE_DOTTED,
+} Clay_BorderStyle;
+
 // Controls settings related to element borders.
 typedef struct Clay_BorderElementConfig {
     Clay_Color color; // Controls the color of all borders with width > 0. Conventionally represented as 0-255, but interpretation is up to the renderer.
     Clay_BorderWidth width; // Controls the widths of individual borders. At least one of these should be > 0 for a BORDER render command to be generated.
+    // Fills the borders with a gradient across the whole element instead of .color. .betweenChildren borders still use .color.
+    Clay_Gradient gradient;
+    // Overrides .color for individual sides. .betweenChildren borders still use .color.
+    Clay_BorderColors colors;
+    // Controls whether the borders are drawn solid, dashed or dotted. .betweenChildren borders are always solid.
+    Clay_BorderStyle style;
+    // The length of each dash when .style is CLAY_BORDER_STYLE_DASHED. Defaults to three times the border width when 0.
+    float dashLength;
+    // The space between dashes or dots. Defaults to the border width when 0.
+    float gapLength;
 } Clay_BorderElementConfig;
 
 CLAY__WRAPPER_STRUCT(Clay_BorderElementConfig);
@@ -582,6 +968,8 @@ typedef struct Clay_RectangleRenderData {
     // Controls the "radius", or corner rounding of elements, including rectangles, borders and images.
     // The rounding is determined by drawing a circle inset into the element corner by (radius, radius) pixels.
     Clay_CornerRadius cornerRadius;
+    // When .type isn't CLAY_GRADIENT_TYPE_NONE, the rectangle should be filled with this gradient, relative to its bounding box, instead of .backgroundColor.
+    Clay_Gradient gradient;
 } Clay_RectangleRenderData;
 
 // Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_IMAGE
@@ -595,6 +983,19 @@ typedef struct Clay_ImageRenderData {
     Clay_CornerRadius cornerRadius;
     // A pointer transparently passed through from the original element definition, typically used to represent image data.
     void* imageData;
+    // Controls how the image should be sized to fit inside the boundingBox.
+    Clay_ImageFit fit;
+    // Controls where the image should be placed inside the boundingBox when it doesn't fill it, and which part of it is kept when it's cropped.
+    Clay_ChildAlignment alignment;
+    // The part of the image to draw, in the image's own pixels. The whole image should be drawn when the width or height is 0.
+    Clay_BoundingBox sourceRect;
+    // The natural size of the image on screen, used by CLAY_IMAGE_FIT_NONE and CLAY_IMAGE_FIT_SCALE_DOWN. When the width or
+    // height is 0, the size of .sourceRect, or of the whole image, should be used instead.
+    Clay_Dimensions intrinsicSize;
+    // When any of its insets are set, the image should be drawn as a nine-slice filling the boundingBox, ignoring .fit and .alignment.
+    Clay_NineSlice nineSlice;
+    // The size on screen of a single pixel of the nine-slice's corners, edges and tiles.
+    float nineSliceScale;
 } Clay_ImageRenderData;
 
 // Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_CUSTOM
@@ -613,8 +1014,49 @@ typedef struct Clay_CustomRenderData {
 typedef struct Clay_ScrollRenderData {
     bool horizontal;
     bool vertical;
+    // The corner radius of the clipping element. Renderers should clip to the rounded rectangle, intersected with any
+    // clipping that is already active, when any corner is above zero.
+    Clay_CornerRadius cornerRadius;
 } Clay_ClipRenderData;
 
+// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_SHADOW
+typedef struct Clay_ShadowRenderData {
+    // Conventionally represented as 0-255 for each channel, but interpretation is up to the renderer.
+    Clay_Color color;
+    // The corner rounding of the element casting the shadow. The shadow's own corners are rounded by this radius plus the spread.
+    Clay_CornerRadius cornerRadius;
+    // The shadow's offset from the render command's bounding box, which is the bounding box of the element casting it.
+    Clay_Vector2 offset;
+    // The distance in pixels over which the shadow fades out.
+    float blurRadius;
+    // How far the shadow extends past the bounding box in every direction before it is blurred, or into it for inset shadows.
+    float spread;
+    // When true, the shadow is drawn inside the bounding box, cast by its edges. Otherwise it is drawn outside of it.
+    bool inset;
+} Clay_ShadowRenderData;
+
+// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_PATH
+typedef struct Clay_PathRenderData {
+    // The commands making up the path, relative to the top left corner of the boundingBox and multiplied by .scale.
+    Clay_PathCommandArray commands;
+    // The color the inside of the path should be filled with, using the non zero winding rule. Nothing should be filled when the alpha is 0.
+    Clay_Color fillColor;
+    // The color of the path's outline. Nothing should be stroked when the alpha or .strokeWidth is 0.
+    Clay_Color strokeColor;
+    // The width of the path's outline, centered on the path.
+    float strokeWidth;
+    // Controls how the segments of the path's outline should be joined.
+    Clay_LineJoin join;
+    // The size on screen of a single pixel of the path's commands.
+    float scale;
+} Clay_PathRenderData;
+
+// Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_LAYER_START || commandType == CLAY_RENDER_COMMAND_TYPE_LAYER_END
+typedef struct Clay_LayerRenderData {
+    // The opacity the layer's contents are composited with, from 0 to 1.
+    float opacity;
+} Clay_LayerRenderData;
+
 // Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_BORDER
 typedef struct Clay_BorderRenderData {
     // Controls a shared color for all this element's borders.
@@ -625,6 +1067,17 @@ typedef struct Clay_BorderRenderData {
     Clay_CornerRadius cornerRadius;
     // Controls individual border side widths.
     Clay_BorderWidth width;
+    // When .type isn't CLAY_GRADIENT_TYPE_NONE, the borders should be filled with this gradient, relative to the bounding box, instead of .color.
+    Clay_Gradient gradient;
+    // The color of each side, with .color already filled in for sides without their own. Where two sides meet, the corner
+    // is split along the line from the outer corner to the inner corner.
+    Clay_BorderColors colors;
+    // Controls whether the borders should be drawn solid, dashed or dotted. Dashes and dots follow the borders around rounded corners.
+    Clay_BorderStyle style;
+    // The length of each dash. 0 means three times the border width.
+    float dashLength;
+    // The space between dashes or dots. 0 means the border width.
+    float gapLength;
 } Clay_BorderRenderData;
 
 // A struct union containing data specific to this command's .commandType
@@ -641,6 +1094,12 @@ typedef union Clay_RenderData {
     Clay_BorderRenderData border;
     // Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_SCISSOR_START|END
     Clay_ClipRenderData clip;
+    // Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_SHADOW
+    Clay_ShadowRenderData shadow;
+    // Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_LAYER_START|END
+    Clay_LayerRenderData layer;
+    // Render command data when commandType == CLAY_RENDER_COMMAND_TYPE_PATH
+    Clay_PathRenderData path;
 } Clay_RenderData;
 
 // Miscellaneous Structs & Enums ---------------------------------
@@ -650,7 +1109,7 @@ typedef struct Clay_ScrollContainerData {
     // Note: This is a pointer to the real internal scroll position, mutating it may cause a change in final layout.
     // Intended for use with external functionality that modifies scroll position, such as scroll bars or auto scrolling.
     Clay_Vector2 *scrollPosition;
-    // The bounding box of the scroll element.
+    // The bounding box of the scroll element. Inside a zoom-pan container this is measured in canvas space, like contentDimensions and scrollPosition.
     Clay_Dimensions scrollContainerDimensions;
     // The outer dimensions of the inner scroll container content, including the padding of the parent scroll container.
     Clay_Dimensions contentDimensions;
@@ -660,6 +1119,41 @@ typedef struct Clay_ScrollContainerData {
     bool found;
 } Clay_ScrollContainerData;
 
+// Data representing the current viewport transform of a zoom-pan container.
+typedef struct Clay_ZoomPanData {
+    // Note: These are pointers to the real internal transform, and can be mutated to move the viewport.
+    // pan is the offset of the canvas origin from the top left of the container, in the container's unzoomed pixels.
+    Clay_Vector2 *pan;
+    // zoom is the scale applied to the canvas, where 1 shows it at its laid out size.
+    float *zoom;
+    // The bounding box of the container on screen.
+    Clay_BoundingBox boundingBox;
+    // Indicates whether an actual zoom-pan container matched the provided ID or if the default struct was returned.
+    bool found;
+} Clay_ZoomPanData;
+
+// How much of an element could be seen after the last layout, once it has been clipped by its clip ancestors and the root.
+typedef struct Clay_ElementVisibility {
+    // The part of the element's bounding box that isn't clipped, or a zero sized box if none of it is visible.
+    Clay_BoundingBox visibleBoundingBox;
+    // The visible area divided by the element's area, from 0 to 1.
+    float visibleFraction;
+    // True if any part of the element is visible.
+    bool visible;
+    // Indicates whether the element was declared in the last layout.
+    bool found;
+} Clay_ElementVisibility;
+
+// The changes in an element's lifecycle that are reported to the function bound by Clay_OnLifecycle.
+typedef CLAY_PACKED_ENUM {
+    // The element was declared in this layout, but not in the previous one.
+    CLAY_LIFECYCLE_EVENT_APPEARED,
+    // The element was declared in the previous layout, but not in this one.
+    CLAY_LIFECYCLE_EVENT_DISAPPEARED,
+    // The fraction of the element that is visible changed since the previous layout, e.g. because it was scrolled into view.
+    CLAY_LIFECYCLE_EVENT_VISIBILITY_CHANGED,
+} Clay_LifecycleEvent;
+
 // Bounding box and other data for a specific UI element.
 typedef struct Clay_ElementData {
     // The rectangle that encloses this UI element, with the position relative to the root of the layout.
@@ -686,11 +1180,23 @@ typedef CLAY_PACKED_ENUM {
     CLAY_RENDER_COMMAND_TYPE_SCISSOR_END,
     // The renderer should provide a custom implementation for handling this render command based on its .customData
     CLAY_RENDER_COMMAND_TYPE_CUSTOM,
+    // The renderer should draw a blurred rounded rectangle shadow, either outside or inside the boundingBox.
+    CLAY_RENDER_COMMAND_TYPE_SHADOW,
+    // The renderer should draw all future commands into a new transparent layer, until the matching LAYER_END.
+    CLAY_RENDER_COMMAND_TYPE_LAYER_START,
+    // The renderer should composite the current layer onto the one below it with the layer's opacity. Layers nest.
+    CLAY_RENDER_COMMAND_TYPE_LAYER_END,
+    // The renderer should fill and stroke a vector path.
+    CLAY_RENDER_COMMAND_TYPE_PATH,
 } Clay_RenderCommandType;
 
 typedef struct Clay_RenderCommand {
     // A rectangular box that fully encloses this UI element, with the position relative to the root of the layout.
     Clay_BoundingBox boundingBox;
+    // The transform the renderer should apply to everything this command draws, including its boundingBox, to place it on the screen.
+    // The identity matrix for elements without a transform. Renderers that can only clip to axis aligned rectangles can clip
+    // SCISSOR_START commands to the bounds of the transformed boundingBox.
+    Clay_Matrix2D transform;
     // A struct union containing data specific to this command's commandType.
     Clay_RenderData renderData;
     // A pointer transparently passed through from the original element declaration.
@@ -709,6 +1215,10 @@ typedef struct Clay_RenderCommand {
     // CLAY_RENDER_COMMAND_TYPE_SCISSOR_START - The renderer should begin clipping all future draw commands, only rendering content that falls within the provided boundingBox.
     // CLAY_RENDER_COMMAND_TYPE_SCISSOR_END - The renderer should finish any previously active clipping, and begin rendering elements in full again.
     // CLAY_RENDER_COMMAND_TYPE_CUSTOM - The renderer should provide a custom implementation for handling this render command based on its .customData
+    // CLAY_RENDER_COMMAND_TYPE_SHADOW - The renderer should draw a blurred rounded rectangle shadow, either outside or inside the boundingBox.
+    // CLAY_RENDER_COMMAND_TYPE_LAYER_START - The renderer should draw all future commands into a new transparent layer, until the matching LAYER_END.
+    // CLAY_RENDER_COMMAND_TYPE_LAYER_END - The renderer should composite the current layer onto the one below it with the layer's opacity. Layers nest.
+    // CLAY_RENDER_COMMAND_TYPE_PATH - The renderer should fill and stroke a vector path.
     Clay_RenderCommandType commandType;
 } Clay_RenderCommand;
 
@@ -746,6 +1256,64 @@ typedef struct Clay_PointerData {
     Clay_PointerDataInteractionState state;
 } Clay_PointerData;
 
+// Identifies a key on the keyboard, independent of the platform or windowing library.
+typedef CLAY_PACKED_ENUM {
+    CLAY_KEY_NONE,
+    CLAY_KEY_TAB,
+    CLAY_KEY_ENTER,
+    CLAY_KEY_ESCAPE,
+    CLAY_KEY_SPACE,
+    CLAY_KEY_BACKSPACE,
+    CLAY_KEY_DELETE,
+    CLAY_KEY_INSERT,
+    CLAY_KEY_LEFT,
+    CLAY_KEY_RIGHT,
+    CLAY_KEY_UP,
+    CLAY_KEY_DOWN,
+    CLAY_KEY_HOME,
+    CLAY_KEY_END,
+    CLAY_KEY_PAGE_UP,
+    CLAY_KEY_PAGE_DOWN,
+    CLAY_KEY_A, CLAY_KEY_B, CLAY_KEY_C, CLAY_KEY_D, CLAY_KEY_E, CLAY_KEY_F, CLAY_KEY_G, CLAY_KEY_H, CLAY_KEY_I,
+    CLAY_KEY_J, CLAY_KEY_K, CLAY_KEY_L, CLAY_KEY_M, CLAY_KEY_N, CLAY_KEY_O, CLAY_KEY_P, CLAY_KEY_Q, CLAY_KEY_R,
+    CLAY_KEY_S, CLAY_KEY_T, CLAY_KEY_U, CLAY_KEY_V, CLAY_KEY_W, CLAY_KEY_X, CLAY_KEY_Y, CLAY_KEY_Z,
+} Clay_Key;
+
+// Bit flags describing which modifier keys were held when a key event occurred.
+typedef CLAY_PACKED_ENUM {
+    CLAY_KEY_MODIFIER_NONE = 0,
+    CLAY_KEY_MODIFIER_SHIFT = 1,
+    CLAY_KEY_MODIFIER_CTRL = 2,
+    CLAY_KEY_MODIFIER_ALT = 4,
+    CLAY_KEY_MODIFIER_SUPER = 8,
+} Clay_KeyModifiers;
+
+// A single key press, as reported to Clay_SetKeyboardState.
+typedef struct Clay_KeyEvent {
+    // The key that was pressed.
+    Clay_Key key;
+    // A combination of Clay_KeyModifiers flags that were held while the key was pressed.
+    Clay_KeyModifiers modifiers;
+    // True if this event was generated by the key being held down, rather than a fresh press.
+    bool repeat;
+} Clay_KeyEvent;
+
+// Controls the direction in which Clay_MoveFocus moves keyboard focus.
+typedef CLAY_PACKED_ENUM {
+    // Moves focus to the next focusable element in tab order.
+    CLAY_FOCUS_DIRECTION_NEXT,
+    // Moves focus to the previous focusable element in tab order.
+    CLAY_FOCUS_DIRECTION_PREVIOUS,
+    // Moves focus to the closest focusable element above the focused element's bounding box.
+    CLAY_FOCUS_DIRECTION_UP,
+    // Moves focus to the closest focusable element below the focused element's bounding box.
+    CLAY_FOCUS_DIRECTION_DOWN,
+    // Moves focus to the closest focusable element to the left of the focused element's bounding box.
+    CLAY_FOCUS_DIRECTION_LEFT,
+    // Moves focus to the closest focusable element to the right of the focused element's bounding box.
+    CLAY_FOCUS_DIRECTION_RIGHT,
+} Clay_FocusDirection;
+
 typedef struct Clay_ElementDeclaration {
     // Controls various settings that affect the size and position of an element, as well as the sizes and positions of any child elements.
     Clay_LayoutConfig layout;
@@ -753,6 +1321,8 @@ typedef struct Clay_ElementDeclaration {
     // By convention specified as 0-255, but interpretation is up to the renderer.
     // If no other config is specified, .backgroundColor will generate a RECTANGLE render command, otherwise it will be passed as a property to IMAGE or CUSTOM render commands.
     Clay_Color backgroundColor;
+    // Fills the background with a gradient instead of .backgroundColor when its .type isn't CLAY_GRADIENT_TYPE_NONE.
+    Clay_Gradient backgroundGradient;
     // Controls the "radius", or corner rounding of elements, including rectangles, borders and images.
     Clay_CornerRadius cornerRadius;
     // Controls settings related to aspect ratio scaling.
@@ -768,6 +1338,24 @@ typedef struct Clay_ElementDeclaration {
     Clay_ClipElementConfig clip;
     // Controls settings related to element borders, and will generate BORDER render commands.
     Clay_BorderElementConfig border;
+    // Controls whether the element can receive keyboard focus, and its position in the tab order.
+    Clay_FocusElementConfig focus;
+    // Controls whether the element sticks to the edges of its nearest clip ancestor while it scrolls.
+    Clay_StickyElementConfig sticky;
+    // Controls whether the element is a zoomable and pannable canvas for its children.
+    Clay_ZoomPanElementConfig zoomPan;
+    // Controls animations of the element's position, size, background color and opacity, and when it appears and disappears.
+    Clay_TransitionElementConfig transition;
+    // Controls a drop shadow behind the element, or an inner shadow inside it, and will generate SHADOW render commands.
+    Clay_ShadowElementConfig shadow;
+    // Controls the opacity of the element and all of its descendants as a group, and will generate LAYER_START and LAYER_END render commands.
+    // Floating elements attached to descendants are drawn separately, and aren't affected.
+    Clay_OpacityElementConfig opacity;
+    // Controls a visual transform of the element and all of its descendants, including floating elements attached to them, that is set
+    // as a matrix on their render commands. Layout is unaffected, and pointer hit testing maps the pointer through the inverse transform.
+    Clay_TransformElementConfig transform;
+    // Controls a vector path drawn over the element's background, and will generate PATH render commands.
+    Clay_PathElementConfig path;
     // A pointer that will be transparently passed through to resulting render commands.
     void *userData;
 } Clay_ElementDeclaration;
@@ -795,6 +1383,8 @@ typedef CLAY_PACKED_ENUM {
     CLAY_ERROR_TYPE_INTERNAL_ERROR,
     // Clay__OpenElement was called more times than Clay__CloseElement, so there were still remaining open elements when the layout ended.
     CLAY_ERROR_TYPE_UNBALANCED_OPEN_CLOSE,
+    // A component was declared without a value it needs, and a default was used in its place.
+    CLAY_ERROR_TYPE_INVALID_CONFIG,
 } Clay_ErrorType;
 
 // Data to identify the error that clay has encountered.
@@ -808,6 +1398,7 @@ typedef struct Clay_ErrorData {
     // CLAY_ERROR_TYPE_FLOATING_CONTAINER_PARENT_NOT_FOUND - A floating element was declared using CLAY_ATTACH_TO_ELEMENT_ID and either an invalid .parentId was provided or no element with the provided .parentId was found.
     // CLAY_ERROR_TYPE_PERCENTAGE_OVER_1 - An element was declared that using CLAY_SIZING_PERCENT but the percentage value was over 1. Percentage values are expected to be in the 0-1 range.
     // CLAY_ERROR_TYPE_INTERNAL_ERROR - Clay encountered an internal error. It would be wonderful if you could report this so we can fix it!
+    // CLAY_ERROR_TYPE_INVALID_CONFIG - A component was declared without a value it needs, and a default was used in its place.
     Clay_ErrorType errorType;
     // A string containing human-readable error text that explains the error in more detail.
     Clay_String errorText;
@@ -837,6 +1428,11 @@ CLAY_DLL_EXPORT Clay_Arena Clay_CreateArenaWithCapacityAndMemory(size_t capacity
 // Sets the state of the "pointer" (i.e. the mouse or touch) in Clay's internal data. Used for detecting and responding to mouse events in the debug view,
 // as well as for Clay_Hovered() and scroll element handling.
 CLAY_DLL_EXPORT void Clay_SetPointerState(Clay_Vector2 position, bool pointerDown);
+// Delivers the key presses that occurred since the last frame to the currently focused element, in order.
+// Each event is first offered to the focused element's Clay_OnKey callback. Unhandled CLAY_KEY_TAB events move focus to the next
+// focusable element in tab order, or the previous one when CLAY_KEY_MODIFIER_SHIFT is held. Unhandled arrow keys move focus spatially,
+// see Clay_MoveFocus.
+CLAY_DLL_EXPORT void Clay_SetKeyboardState(Clay_KeyEvent *keyEvents, int32_t keyEventCount);
 // Initialize Clay's internal arena and setup required data before layout can begin. Only needs to be called once.
 // - arena can be created using Clay_CreateArenaWithCapacityAndMemory()
 // - layoutDimensions are the initial bounding dimensions of the layout (i.e. the screen width and height for a full screen layout)
@@ -885,11 +1481,66 @@ CLAY_DLL_EXPORT void Clay_OnHover(void (*onHoverFunction)(Clay_ElementId element
 CLAY_DLL_EXPORT bool Clay_PointerOver(Clay_ElementId elementId);
 // Returns the array of element IDs that the pointer is currently over.
 CLAY_DLL_EXPORT Clay_ElementIdArray Clay_GetPointerOverIds(void);
+// Returns true if the currently open element has keyboard focus.
+// Works during element declaration, e.g. CLAY({ .border = Clay_Focused() ? focusBorder : defaultBorder });
+CLAY_DLL_EXPORT bool Clay_Focused(void);
+// Returns true if the currently open element has keyboard focus and focus was last moved using the keyboard.
+// Intended for drawing focus rings only when they are useful, similar to the CSS :focus-visible selector.
+CLAY_DLL_EXPORT bool Clay_FocusVisible(void);
+// The callback bound by Clay_OnKey, which returns true if it handled the key event.
+typedef bool Clay_OnKeyFunction(Clay_ElementId elementId, Clay_KeyEvent keyEvent, intptr_t userData);
+// Bind a callback that will be called for each key event delivered by Clay_SetKeyboardState while the current element is focused.
+// - onKeyFunction should return true if it handled the event, which prevents Clay's default handling such as tab navigation.
+// - userData is a pointer that will be transparently passed through when the onKeyFunction is called.
+CLAY_DLL_EXPORT void Clay_OnKey(Clay_OnKeyFunction *onKeyFunction, intptr_t userData);
+// The callback bound by Clay_OnLifecycle.
+typedef void Clay_OnLifecycleFunction(Clay_ElementId elementId, Clay_LifecycleEvent event, Clay_ElementVisibility visibility, intptr_t userData);
+// Bind a callback that will be called at the end of Clay_EndLayout when the current element appears, disappears or changes visibility.
+// Like Clay_OnHover it needs to be bound every layout; the binding from the last layout the element was declared in receives CLAY_LIFECYCLE_EVENT_DISAPPEARED.
+// - visibility is the same as the result of Clay_GetElementVisibility for the element, and is zeroed for CLAY_LIFECYCLE_EVENT_DISAPPEARED.
+// - userData is a pointer that will be transparently passed through when the onLifecycleFunction is called.
+CLAY_DLL_EXPORT void Clay_OnLifecycle(Clay_OnLifecycleFunction *onLifecycleFunction, intptr_t userData);
+// Returns how much of the element with the provided ID is visible inside its clip containers and the root, as of the last call to Clay_EndLayout.
+// The returned Clay_ElementVisibility contains a `found` bool that will be true if the element was declared in the last layout.
+CLAY_DLL_EXPORT Clay_ElementVisibility Clay_GetElementVisibility(Clay_ElementId elementId);
+// Returns the ID of the element that currently has keyboard focus, or an ID of 0 if no element is focused.
+CLAY_DLL_EXPORT Clay_ElementId Clay_GetFocusedElementId(void);
+// Moves keyboard focus to the element with the provided ID. The element must be declared focusable, otherwise focus is cleared at the end of the next layout.
+CLAY_DLL_EXPORT void Clay_SetFocus(Clay_ElementId elementId);
+// Removes keyboard focus from the currently focused element, if any.
+CLAY_DLL_EXPORT void Clay_ClearFocus(void);
+// Moves keyboard focus in the provided direction, using the layout from the last call to Clay_EndLayout.
+// Spatial directions pick the closest focusable element on that side of the focused element's bounding box, and scroll it into view
+// inside any clip containers. While a floating element with .modal = true is open, only focusable elements inside it can be reached.
+// Returns true if focus moved to a different element.
+CLAY_DLL_EXPORT bool Clay_MoveFocus(Clay_FocusDirection direction);
 // Returns data representing the state of the scrolling element with the provided ID.
 // The returned Clay_ScrollContainerData contains a `found` bool that will be true if a scroll element was found with the provided ID.
 // An imperative function that returns true if the pointer position provided by Clay_SetPointerState is within the element with the provided ID's bounding box.
 // This ID can be calculated either with CLAY_ID() for string literal IDs, or Clay_GetElementId for dynamic strings.
 CLAY_DLL_EXPORT Clay_ScrollContainerData Clay_GetScrollContainerData(Clay_ElementId id);
+// Starts an animated scroll of the container with the provided ID so that the target element ends up at the provided alignment.
+// Positions are taken from the last call to Clay_EndLayout, and the target can be any descendant of the container.
+// The animation is advanced by Clay_UpdateScrollContainers and is cancelled by wheel input or drag scrolling.
+CLAY_DLL_EXPORT void Clay_ScrollIntoView(Clay_ElementId containerId, Clay_ElementId targetId, Clay_ScrollAlignment alignment);
+// Moves the scroll position of the container with the provided ID to scrollPosition, using the same negative offsets as Clay_ScrollContainerData.
+// The position is clamped to the content every time Clay_UpdateScrollContainers runs, so a target of { 0, -CLAY__MAXFLOAT } keeps
+// following the bottom of content that grows while the animation is running, e.g. a chat log.
+// - animate: if true, the container scrolls to the position over the next few frames, otherwise it jumps there immediately.
+CLAY_DLL_EXPORT void Clay_ScrollTo(Clay_ElementId containerId, Clay_Vector2 scrollPosition, bool animate);
+// Sets the time in seconds since the last frame, used to advance element transitions during the next Clay_EndLayout,
+// and to time double-clicks on split pane dividers and tooltip delays. Call it once per frame before Clay_BeginLayout.
+CLAY_DLL_EXPORT void Clay_SetDeltaTime(float deltaTime);
+// Returns data representing the viewport transform of the zoom-pan container with the provided ID.
+// The returned Clay_ZoomPanData contains a `found` bool that will be true if a zoom-pan container was found with the provided ID.
+CLAY_DLL_EXPORT Clay_ZoomPanData Clay_GetZoomPanData(Clay_ElementId id);
+// Updates the zoom-pan container under the pointer position provided by Clay_SetPointerState. Call it once per frame, like Clay_UpdateScrollContainers.
+// - enableDragPanning pans the container under the pointer while the pointer is held down, e.g. only while a middle mouse button or space bar is held.
+// - zoomDelta zooms around the pointer by a factor of (1 + zoomDelta). Pass the wheel delta scaled down, or the change in pinch scale with the pointer
+//   placed at the center of the pinch. Wheel input that zooms a container shouldn't also be passed to Clay_UpdateScrollContainers.
+CLAY_DLL_EXPORT void Clay_UpdateZoomPanContainers(bool enableDragPanning, float zoomDelta);
+// Maps a screen position, e.g. the pointer position, into the canvas space of the zoom-pan container with the provided ID, using the last layout.
+CLAY_DLL_EXPORT Clay_Vector2 Clay_ZoomPanScreenToCanvas(Clay_ElementId id, Clay_Vector2 position);
 // Binds a callback function that Clay will call to determine the dimensions of a given string slice.
 // - measureTextFunction is a user provided function that adheres to the interface Clay_Dimensions (Clay_StringSlice text, Clay_TextElementConfig *config, void *userData);
 // - userData is a pointer that will be transparently passed through when the measureTextFunction is called.
@@ -1039,6 +1690,7 @@ typedef struct {
     bool maxElementsExceeded;
     bool maxRenderCommandsExceeded;
     bool maxTextMeasureCacheExceeded;
+    bool maxPathCommandsExceeded;
     bool textMeasurementFunctionNotSet;
 } Clay_BooleanWarnings;
 
@@ -1057,6 +1709,7 @@ typedef struct {
 
 typedef struct {
     Clay_Color backgroundColor;
+    Clay_Gradient backgroundGradient;
     Clay_CornerRadius cornerRadius;
     void* userData;
 } Clay_SharedElementConfig;
@@ -1083,6 +1736,15 @@ CLAY__ARRAY_DEFINE(Clay_ClipElementConfig, Clay__ClipElementConfigArray)
 CLAY__ARRAY_DEFINE(Clay_BorderElementConfig, Clay__BorderElementConfigArray)
 CLAY__ARRAY_DEFINE(Clay_String, Clay__StringArray)
 CLAY__ARRAY_DEFINE(Clay_SharedElementConfig, Clay__SharedElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_FocusElementConfig, Clay__FocusElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_StickyElementConfig, Clay__StickyElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_ZoomPanElementConfig, Clay__ZoomPanElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_TransitionElementConfig, Clay__TransitionElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_ShadowElementConfig, Clay__ShadowElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_OpacityElementConfig, Clay__OpacityElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_TransformElementConfig, Clay__TransformElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_PathElementConfig, Clay__PathElementConfigArray)
+CLAY__ARRAY_DEFINE(Clay_PathCommand, Clay__PathCommandArray)
 CLAY__ARRAY_DEFINE_FUNCTIONS(Clay_RenderCommand, Clay_RenderCommandArray)
 
 typedef CLAY_PACKED_ENUM {
@@ -1095,6 +1757,14 @@ typedef CLAY_PACKED_ENUM {
     CLAY__ELEMENT_CONFIG_TYPE_TEXT,
     CLAY__ELEMENT_CONFIG_TYPE_CUSTOM,
     CLAY__ELEMENT_CONFIG_TYPE_SHARED,
+    CLAY__ELEMENT_CONFIG_TYPE_FOCUS,
+    CLAY__ELEMENT_CONFIG_TYPE_STICKY,
+    CLAY__ELEMENT_CONFIG_TYPE_ZOOM_PAN,
+    CLAY__ELEMENT_CONFIG_TYPE_TRANSITION,
+    CLAY__ELEMENT_CONFIG_TYPE_SHADOW,
+    CLAY__ELEMENT_CONFIG_TYPE_OPACITY,
+    CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM,
+    CLAY__ELEMENT_CONFIG_TYPE_PATH,
 } Clay__ElementConfigType;
 
 typedef union {
@@ -1106,6 +1776,14 @@ typedef union {
     Clay_ClipElementConfig *clipElementConfig;
     Clay_BorderElementConfig *borderElementConfig;
     Clay_SharedElementConfig *sharedElementConfig;
+    Clay_FocusElementConfig *focusElementConfig;
+    Clay_StickyElementConfig *stickyElementConfig;
+    Clay_ZoomPanElementConfig *zoomPanElementConfig;
+    Clay_TransitionElementConfig *transitionElementConfig;
+    Clay_ShadowElementConfig *shadowElementConfig;
+    Clay_OpacityElementConfig *opacityElementConfig;
+    Clay_TransformElementConfig *transformElementConfig;
+    Clay_PathElementConfig *pathElementConfig;
 } Clay_ElementConfigUnion;
 
 typedef struct {
@@ -1152,21 +1830,74 @@ CLAY__ARRAY_DEFINE(Clay_LayoutElement, Clay_LayoutElementArray)
 
 typedef struct {
     Clay_LayoutElement *layoutElement;
-    Clay_BoundingBox boundingBox;
+    Clay_BoundingBox boundingBox; // In screen space, while contentSize and the scroll positions are in layout space.
     Clay_Dimensions contentSize;
     Clay_Vector2 scrollOrigin;
     Clay_Vector2 pointerOrigin;
     Clay_Vector2 scrollMomentum;
     Clay_Vector2 scrollPosition;
     Clay_Vector2 previousDelta;
+    Clay_Vector2 scrollTarget;
+    Clay_Vector2 scrollbarPreviousPosition;
     float momentumTime;
+    float scrollbarIdleTime;
+    float snapIdleTime;
+    float scale; // The size of a layout pixel on screen, which differs from 1 inside zoom-pan containers.
     uint32_t elementId;
+    int32_t scrollbarDragAxis; // 0 when no thumb is being dragged, 1 for the horizontal thumb and 2 for the vertical thumb
     bool openThisFrame;
     bool pointerScrollActive;
+    bool scrollAnimationActive;
+    bool scrollbarPressed;
+    bool snapPending;
 } Clay__ScrollContainerDataInternal;
 
 CLAY__ARRAY_DEFINE(Clay__ScrollContainerDataInternal, Clay__ScrollContainerDataInternalArray)
 
+typedef struct {
+    Clay_LayoutElement *layoutElement;
+    Clay_BoundingBox boundingBox;
+    Clay_Vector2 pan;
+    Clay_Vector2 panOrigin;
+    Clay_Vector2 pointerOrigin;
+    float zoom;
+    float parentScale; // The scale of any zoom-pan containers around this one, used to map screen distances into the container
+    uint32_t elementId;
+    bool openThisFrame;
+    bool dragActive;
+} Clay__ZoomPanDataInternal;
+
+CLAY__ARRAY_DEFINE(Clay__ZoomPanDataInternal, Clay__ZoomPanDataInternalArray)
+
+typedef struct {
+    uint32_t elementId;
+    uint32_t parentId; // Exiting elements are drawn at the end of their former parent's contents
+    Clay_TransitionElementConfig config;
+    // Positions are relative to the parent's content origin, so that moving or scrolling the parent doesn't animate its children
+    Clay_Vector2 positionFrom;
+    Clay_Vector2 positionTo;
+    Clay_Dimensions sizeFrom;
+    Clay_Dimensions sizeTo;
+    Clay_Color colorFrom;
+    Clay_Color colorTo;
+    float opacityFrom;
+    float opacityTo;
+    float positionElapsed;
+    float sizeElapsed;
+    float colorElapsed;
+    float opacityElapsed;
+    float exitElapsed;
+    float exitOpacity;
+    // The render commands of the element and its children from the last frame it was declared, replayed while it exits
+    int32_t exitCommandsStart;
+    int32_t exitCommandsLength;
+    bool openThisFrame;
+    bool entering;
+    bool exiting;
+} Clay__TransitionDataInternal;
+
+CLAY__ARRAY_DEFINE(Clay__TransitionDataInternal, Clay__TransitionDataInternalArray)
+
 typedef struct {
     bool collision;
     bool collapsed;
@@ -1180,9 +1911,22 @@ typedef struct { // todo get this struct into a single cache line
     Clay_LayoutElement* layoutElement;
     void (*onHoverFunction)(Clay_ElementId elementId, Clay_PointerData pointerInfo, intptr_t userData);
     intptr_t hoverFunctionUserData;
+    Clay_OnKeyFunction *onKeyFunction;
+    intptr_t keyFunctionUserData;
+    Clay_OnLifecycleFunction *onLifecycleFunction;
+    intptr_t lifecycleFunctionUserData;
+    Clay_ElementVisibility visibility;
+    Clay_ElementVisibility previousVisibility;
+    // True if the element wasn't declared in the previous layout
+    bool appeared;
     int32_t nextIndex;
     uint32_t generation;
     Clay__DebugElementData *debugData;
+    // The transform from layout space to screen space applied to this element by zoom-pan containers around it. A scale of 0 means no transform.
+    Clay_Vector2 transformOffset;
+    float transformScale;
+    // The transforms of the element and its ancestors, applied on top of boundingBox when the element is drawn
+    Clay_Matrix2D transform;
 } Clay_LayoutElementHashMapItem;
 
 CLAY__ARRAY_DEFINE(Clay_LayoutElementHashMapItem, Clay__LayoutElementHashMapItemArray)
@@ -1213,6 +1957,20 @@ typedef struct {
     Clay_LayoutElement *layoutElement;
     Clay_Vector2 position;
     Clay_Vector2 nextChildOffset;
+    // Maps position from layout space to screen space: screen = position * transformScale + transformOffset
+    Clay_Vector2 transformOffset;
+    float transformScale;
+    // The transforms of the element's ancestors, and once it has been visited, of the element itself
+    Clay_Matrix2D transform;
+    // The parent's content origin, which transition positions are relative to
+    Clay_Vector2 parentOrigin;
+    // The distance ancestors have been moved by transitions, and the distance to move this element's children
+    Clay_Vector2 transitionOffset;
+    Clay_Vector2 childTransitionOffset;
+    float opacity;
+    int32_t renderCommandStart;
+    // The part of the screen left visible by clip ancestors and the root. The size is negative if nothing is.
+    Clay_BoundingBox clipBoundingBox;
 } Clay__LayoutElementTreeNode;
 
 CLAY__ARRAY_DEFINE(Clay__LayoutElementTreeNode, Clay__LayoutElementTreeNodeArray)
@@ -1243,6 +2001,8 @@ struct Clay_Context {
     bool disableCulling;
     bool externalScrollHandlingEnabled;
     uint32_t debugSelectedElementId;
+    Clay_ElementId focusedElementId;
+    bool focusVisible;
     uint32_t generation;
     uintptr_t arenaResetOffset;
     void *measureTextUserData;
@@ -1256,6 +2016,7 @@ struct Clay_Context {
     Clay__int32_tArray layoutElementChildrenBuffer;
     Clay__TextElementDataArray textElementData;
     Clay__int32_tArray aspectRatioElementIndexes;
+    Clay__int32_tArray focusableElementIndexes;
     Clay__int32_tArray reusableElementIndexBuffer;
     Clay__int32_tArray layoutElementClipElementIds;
     // Configs
@@ -1269,6 +2030,15 @@ struct Clay_Context {
     Clay__CustomElementConfigArray customElementConfigs;
     Clay__BorderElementConfigArray borderElementConfigs;
     Clay__SharedElementConfigArray sharedElementConfigs;
+    Clay__FocusElementConfigArray focusElementConfigs;
+    Clay__StickyElementConfigArray stickyElementConfigs;
+    Clay__ZoomPanElementConfigArray zoomPanElementConfigs;
+    Clay__TransitionElementConfigArray transitionElementConfigs;
+    Clay__ShadowElementConfigArray shadowElementConfigs;
+    Clay__OpacityElementConfigArray opacityElementConfigs;
+    Clay__TransformElementConfigArray transformElementConfigs;
+    Clay__PathElementConfigArray pathElementConfigs;
+    Clay__PathCommandArray pathCommands;
     // Misc Data Structures
     Clay__StringArray layoutElementIdStrings;
     Clay__WrappedTextLineArray wrappedTextLines;
@@ -1284,6 +2054,17 @@ struct Clay_Context {
     Clay__int32_tArray openClipElementStack;
     Clay_ElementIdArray pointerOverIds;
     Clay__ScrollContainerDataInternalArray scrollContainerDatas;
+    Clay__ZoomPanDataInternalArray zoomPanDatas;
+    Clay__TransitionDataInternalArray transitionDatas;
+    // Snapshots of render commands for exit transitions, double buffered so that each layout can keep the snapshots it still needs
+    Clay_RenderCommandArray transitionCommands;
+    Clay_RenderCommandArray transitionCommandsNext;
+    Clay__charArray transitionText;
+    Clay__charArray transitionTextNext;
+    Clay__PathCommandArray transitionPathCommands;
+    Clay__PathCommandArray transitionPathCommandsNext;
+    int32_t exitingTransitionCount;
+    float deltaTime;
     Clay__boolArray treeNodeVisited;
     Clay__charArray dynamicStringData;
     Clay__DebugElementDataArray debugElementData;
@@ -1307,6 +2088,18 @@ Clay_String Clay__WriteStringToCharBuffer(Clay__charArray *buffer, Clay_String s
     return CLAY__INIT(Clay_String) { .length = string.length, .chars = (const char *)(buffer->internalArray + buffer->length - string.length) };
 }
 
+// Copies path commands to the end of the buffer, or returns an empty array if they don't fit.
+Clay_PathCommandArray Clay__WritePathCommandsToBuffer(Clay__PathCommandArray *buffer, Clay_PathCommandArray commands) {
+    if (buffer->length + commands.length > buffer->capacity) {
+        return CLAY__INIT(Clay_PathCommandArray) CLAY__DEFAULT_STRUCT;
+    }
+    for (int32_t i = 0; i < commands.length; i++) {
+        buffer->internalArray[buffer->length + i] = commands.internalArray[i];
+    }
+    buffer->length += commands.length;
+    return CLAY__INIT(Clay_PathCommandArray) { .length = commands.length, .internalArray = buffer->internalArray + buffer->length - commands.length };
+}
+
 #ifdef CLAY_WASM
     __attribute__((import_module("clay"), import_name("measureTextFunction"))) Clay_Dimensions Clay__MeasureText(Clay_StringSlice text, Clay_TextElementConfig *config, void *userData);
     __attribute__((import_module("clay"), import_name("queryScrollOffsetFunction"))) Clay_Vector2 Clay__QueryScrollOffset(uint32_t elementId, void *userData);
@@ -1334,6 +2127,25 @@ Clay_CustomElementConfig * Clay__StoreCustomElementConfig(Clay_CustomElementConf
 Clay_ClipElementConfig * Clay__StoreClipElementConfig(Clay_ClipElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_ClipElementConfig_DEFAULT : Clay__ClipElementConfigArray_Add(&Clay_GetCurrentContext()->clipElementConfigs, config); }
 Clay_BorderElementConfig * Clay__StoreBorderElementConfig(Clay_BorderElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_BorderElementConfig_DEFAULT : Clay__BorderElementConfigArray_Add(&Clay_GetCurrentContext()->borderElementConfigs, config); }
 Clay_SharedElementConfig * Clay__StoreSharedElementConfig(Clay_SharedElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_SharedElementConfig_DEFAULT : Clay__SharedElementConfigArray_Add(&Clay_GetCurrentContext()->sharedElementConfigs, config); }
+Clay_FocusElementConfig * Clay__StoreFocusElementConfig(Clay_FocusElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_FocusElementConfig_DEFAULT : Clay__FocusElementConfigArray_Add(&Clay_GetCurrentContext()->focusElementConfigs, config); }
+Clay__TransitionDataInternal* Clay__GetTransitionData(uint32_t elementId) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t i = 0; i < context->transitionDatas.length; i++) {
+        Clay__TransitionDataInternal *transitionData = Clay__TransitionDataInternalArray_Get(&context->transitionDatas, i);
+        if (transitionData->elementId == elementId) {
+            return transitionData;
+        }
+    }
+    return CLAY__NULL;
+}
+
+Clay_TransitionElementConfig * Clay__StoreTransitionElementConfig(Clay_TransitionElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_TransitionElementConfig_DEFAULT : Clay__TransitionElementConfigArray_Add(&Clay_GetCurrentContext()->transitionElementConfigs, config); }
+Clay_ShadowElementConfig * Clay__StoreShadowElementConfig(Clay_ShadowElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_ShadowElementConfig_DEFAULT : Clay__ShadowElementConfigArray_Add(&Clay_GetCurrentContext()->shadowElementConfigs, config); }
+Clay_OpacityElementConfig * Clay__StoreOpacityElementConfig(Clay_OpacityElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_OpacityElementConfig_DEFAULT : Clay__OpacityElementConfigArray_Add(&Clay_GetCurrentContext()->opacityElementConfigs, config); }
+Clay_TransformElementConfig * Clay__StoreTransformElementConfig(Clay_TransformElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_TransformElementConfig_DEFAULT : Clay__TransformElementConfigArray_Add(&Clay_GetCurrentContext()->transformElementConfigs, config); }
+Clay_PathElementConfig * Clay__StorePathElementConfig(Clay_PathElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_PathElementConfig_DEFAULT : Clay__PathElementConfigArray_Add(&Clay_GetCurrentContext()->pathElementConfigs, config); }
+Clay_ZoomPanElementConfig * Clay__StoreZoomPanElementConfig(Clay_ZoomPanElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_ZoomPanElementConfig_DEFAULT : Clay__ZoomPanElementConfigArray_Add(&Clay_GetCurrentContext()->zoomPanElementConfigs, config); }
+Clay_StickyElementConfig * Clay__StoreStickyElementConfig(Clay_StickyElementConfig config) {  return Clay_GetCurrentContext()->booleanWarnings.maxElementsExceeded ? &Clay_StickyElementConfig_DEFAULT : Clay__StickyElementConfigArray_Add(&Clay_GetCurrentContext()->stickyElementConfigs, config); }
 
 Clay_ElementConfig Clay__AttachElementConfig(Clay_ElementConfigUnion config, Clay__ElementConfigType type) {
     Clay_Context* context = Clay_GetCurrentContext();
@@ -1720,7 +2532,7 @@ Clay_LayoutElementHashMapItem* Clay__AddHashMapItem(Clay_ElementId elementId, Cl
     if (context->layoutElementsHashMapInternal.length == context->layoutElementsHashMapInternal.capacity - 1) {
         return NULL;
     }
-    Clay_LayoutElementHashMapItem item = { .elementId = elementId, .layoutElement = layoutElement, .nextIndex = -1, .generation = context->generation + 1 };
+    Clay_LayoutElementHashMapItem item = { .elementId = elementId, .layoutElement = layoutElement, .nextIndex = -1, .generation = context->generation + 1, .appeared = true };
     uint32_t hashBucket = elementId.id % context->layoutElementsHashMap.capacity;
     int32_t hashItemPrevious = -1;
     int32_t hashItemIndex = context->layoutElementsHashMap.internalArray[hashBucket];
@@ -1730,11 +2542,16 @@ Clay_LayoutElementHashMapItem* Clay__AddHashMapItem(Clay_ElementId elementId, Cl
             item.nextIndex = hashItem->nextIndex;
             if (hashItem->generation <= context->generation) { // First collision - assume this is the "same" element
                 hashItem->elementId = elementId; // Make sure to copy this across. If the stringId reference has changed, we should update the hash item to use the new one.
+                hashItem->appeared = hashItem->generation < context->generation;
                 hashItem->generation = context->generation + 1;
                 hashItem->layoutElement = layoutElement;
                 hashItem->debugData->collision = false;
                 hashItem->onHoverFunction = NULL;
                 hashItem->hoverFunctionUserData = 0;
+                hashItem->onKeyFunction = NULL;
+                hashItem->keyFunctionUserData = 0;
+                hashItem->onLifecycleFunction = NULL;
+                hashItem->lifecycleFunctionUserData = 0;
             } else { // Multiple collisions this frame - two elements have the same ID
                 context->errorHandler.errorHandlerFunction(CLAY__INIT(Clay_ErrorData) {
                     .errorType = CLAY_ERROR_TYPE_DUPLICATE_ID,
@@ -1889,6 +2706,17 @@ void Clay__CloseElement(void) {
 
     context->layoutElementChildrenBuffer.length -= openLayoutElement->childrenOrTextContent.children.length;
 
+    // Images with an intrinsic size are at least as big as it, like elements are as big as their children
+    Clay_ImageElementConfig *imageConfig = Clay__FindElementConfigWithType(openLayoutElement, CLAY__ELEMENT_CONFIG_TYPE_IMAGE).imageElementConfig;
+    if (imageConfig && imageConfig->intrinsicSize.width > 0 && imageConfig->intrinsicSize.height > 0) {
+        if (openLayoutElement->dimensions.width < imageConfig->intrinsicSize.width + leftRightPadding) {
+            openLayoutElement->dimensions.width = imageConfig->intrinsicSize.width + leftRightPadding;
+        }
+        if (openLayoutElement->dimensions.height < imageConfig->intrinsicSize.height + topBottomPadding) {
+            openLayoutElement->dimensions.height = imageConfig->intrinsicSize.height + topBottomPadding;
+        }
+    }
+
     // Clamp element min and max width to the values configured in the layout
     if (layoutConfig->sizing.width.type != CLAY__SIZING_TYPE_PERCENT) {
         if (layoutConfig->sizing.width.size.minMax.max <= 0) { // Set the max size if the user didn't specify, makes calculations easier
@@ -2077,6 +2905,14 @@ void Clay__ConfigureOpenElementPtr(const Clay_ElementDeclaration *declaration) {
         sharedConfig = Clay__StoreSharedElementConfig(CLAY__INIT(Clay_SharedElementConfig) { .backgroundColor = declaration->backgroundColor });
         Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .sharedElementConfig = sharedConfig }, CLAY__ELEMENT_CONFIG_TYPE_SHARED);
     }
+    if (declaration->backgroundGradient.type != CLAY_GRADIENT_TYPE_NONE) {
+        if (sharedConfig) {
+            sharedConfig->backgroundGradient = declaration->backgroundGradient;
+        } else {
+            sharedConfig = Clay__StoreSharedElementConfig(CLAY__INIT(Clay_SharedElementConfig) { .backgroundGradient = declaration->backgroundGradient });
+            Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .sharedElementConfig = sharedConfig }, CLAY__ELEMENT_CONFIG_TYPE_SHARED);
+        }
+    }
     if (!Clay__MemCmp((char *)(&declaration->cornerRadius), (char *)(&Clay__CornerRadius_DEFAULT), sizeof(Clay_CornerRadius))) {
         if (sharedConfig) {
             sharedConfig->cornerRadius = declaration->cornerRadius;
@@ -2099,6 +2935,12 @@ void Clay__ConfigureOpenElementPtr(const Clay_ElementDeclaration *declaration) {
     if (declaration->aspectRatio.aspectRatio > 0) {
         Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .aspectRatioElementConfig = Clay__StoreAspectRatioElementConfig(declaration->aspectRatio) }, CLAY__ELEMENT_CONFIG_TYPE_ASPECT);
         Clay__int32_tArray_Add(&context->aspectRatioElementIndexes, context->layoutElements.length - 1);
+    } else if (declaration->image.imageData && declaration->image.intrinsicSize.width > 0 && declaration->image.intrinsicSize.height > 0
+        && declaration->layout.sizing.width.type != CLAY__SIZING_TYPE_FIT && declaration->layout.sizing.height.type != CLAY__SIZING_TYPE_FIXED && declaration->layout.sizing.height.type != CLAY__SIZING_TYPE_PERCENT) {
+        // The height of images follows their width, keeping the aspect ratio of their intrinsic size
+        Clay_AspectRatioElementConfig aspectConfig = { .aspectRatio = declaration->image.intrinsicSize.width / declaration->image.intrinsicSize.height };
+        Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .aspectRatioElementConfig = Clay__StoreAspectRatioElementConfig(aspectConfig) }, CLAY__ELEMENT_CONFIG_TYPE_ASPECT);
+        Clay__int32_tArray_Add(&context->aspectRatioElementIndexes, context->layoutElements.length - 1);
     }
     if (declaration->floating.attachTo != CLAY_ATTACH_TO_NONE) {
         Clay_FloatingElementConfig floatingConfig = declaration->floating;
@@ -2158,7 +3000,7 @@ void Clay__ConfigureOpenElementPtr(const Clay_ElementDeclaration *declaration) {
             }
         }
         if (!scrollOffset) {
-            scrollOffset = Clay__ScrollContainerDataInternalArray_Add(&context->scrollContainerDatas, CLAY__INIT(Clay__ScrollContainerDataInternal){.layoutElement = openLayoutElement, .scrollOrigin = {-1,-1}, .elementId = openLayoutElement->id, .openThisFrame = true});
+            scrollOffset = Clay__ScrollContainerDataInternalArray_Add(&context->scrollContainerDatas, CLAY__INIT(Clay__ScrollContainerDataInternal){.layoutElement = openLayoutElement, .scrollOrigin = {-1,-1}, .scale = 1, .elementId = openLayoutElement->id, .openThisFrame = true});
         }
         if (context->externalScrollHandlingEnabled) {
             scrollOffset->scrollPosition = Clay__QueryScrollOffset(scrollOffset->elementId, context->queryScrollOffsetUserData);
@@ -2167,6 +3009,70 @@ void Clay__ConfigureOpenElementPtr(const Clay_ElementDeclaration *declaration) {
     if (!Clay__MemCmp((char *)(&declaration->border.width), (char *)(&Clay__BorderWidth_DEFAULT), sizeof(Clay_BorderWidth))) {
         Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .borderElementConfig = Clay__StoreBorderElementConfig(declaration->border) }, CLAY__ELEMENT_CONFIG_TYPE_BORDER);
     }
+    if (declaration->focus.focusable) {
+        // Focus is tracked by element id, so anonymous elements need a stable one
+        if (openLayoutElement->id == 0) {
+            Clay__GenerateIdForAnonymousElement(openLayoutElement);
+        }
+        Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .focusElementConfig = Clay__StoreFocusElementConfig(declaration->focus) }, CLAY__ELEMENT_CONFIG_TYPE_FOCUS);
+        Clay__int32_tArray_Add(&context->focusableElementIndexes, context->layoutElements.length - 1);
+    }
+    if (declaration->sticky.horizontal || declaration->sticky.vertical) {
+        Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .stickyElementConfig = Clay__StoreStickyElementConfig(declaration->sticky) }, CLAY__ELEMENT_CONFIG_TYPE_STICKY);
+    }
+    if (declaration->zoomPan.enabled) {
+        Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .zoomPanElementConfig = Clay__StoreZoomPanElementConfig(declaration->zoomPan) }, CLAY__ELEMENT_CONFIG_TYPE_ZOOM_PAN);
+        // Retrieve or create cached data to track the viewport transform across frames
+        Clay__ZoomPanDataInternal *zoomPanData = CLAY__NULL;
+        for (int32_t i = 0; i < context->zoomPanDatas.length; i++) {
+            Clay__ZoomPanDataInternal *mapping = Clay__ZoomPanDataInternalArray_Get(&context->zoomPanDatas, i);
+            if (openLayoutElement->id == mapping->elementId) {
+                zoomPanData = mapping;
+                zoomPanData->layoutElement = openLayoutElement;
+                zoomPanData->openThisFrame = true;
+            }
+        }
+        if (!zoomPanData) {
+            Clay__ZoomPanDataInternalArray_Add(&context->zoomPanDatas, CLAY__INIT(Clay__ZoomPanDataInternal){.layoutElement = openLayoutElement, .zoom = 1, .parentScale = 1, .elementId = openLayoutElement->id, .openThisFrame = true});
+        }
+    }
+    if (declaration->shadow.color.a > 0) {
+        Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .shadowElementConfig = Clay__StoreShadowElementConfig(declaration->shadow) }, CLAY__ELEMENT_CONFIG_TYPE_SHADOW);
+    }
+    if (declaration->opacity.enabled && declaration->opacity.value < 1) {
+        Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .opacityElementConfig = Clay__StoreOpacityElementConfig(declaration->opacity) }, CLAY__ELEMENT_CONFIG_TYPE_OPACITY);
+    }
+    if (declaration->transform.enabled) {
+        Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .transformElementConfig = Clay__StoreTransformElementConfig(declaration->transform) }, CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM);
+    }
+    if (declaration->path.commands.length > 0 && (declaration->path.fillColor.a > 0 || (declaration->path.strokeColor.a > 0 && declaration->path.strokeWidth > 0))) {
+        // The commands are copied so that they only need to stay valid during the declaration
+        Clay_PathElementConfig pathConfig = declaration->path;
+        pathConfig.commands = Clay__WritePathCommandsToBuffer(&context->pathCommands, declaration->path.commands);
+        if (pathConfig.commands.length > 0) {
+            Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .pathElementConfig = Clay__StorePathElementConfig(pathConfig) }, CLAY__ELEMENT_CONFIG_TYPE_PATH);
+        } else if (!context->booleanWarnings.maxPathCommandsExceeded) {
+            context->booleanWarnings.maxPathCommandsExceeded = true;
+            context->errorHandler.errorHandlerFunction(CLAY__INIT(Clay_ErrorData) {
+                .errorType = CLAY_ERROR_TYPE_ELEMENTS_CAPACITY_EXCEEDED,
+                .errorText = CLAY_STRING("Clay ran out of capacity while attempting to store path commands. Try using Clay_SetMaxElementCount() with a higher value."),
+                .userData = context->errorHandler.userData });
+        }
+    }
+    if (declaration->transition.properties != CLAY_TRANSITION_PROPERTY_NONE || declaration->transition.enter.enabled || declaration->transition.exit.enabled) {
+        Clay__AttachElementConfig(CLAY__INIT(Clay_ElementConfigUnion) { .transitionElementConfig = Clay__StoreTransitionElementConfig(declaration->transition) }, CLAY__ELEMENT_CONFIG_TYPE_TRANSITION);
+        // Retrieve or create cached data to track the animated values across frames
+        uint32_t parentId = Clay_LayoutElementArray_Get(&context->layoutElements, Clay__int32_tArray_GetValue(&context->openLayoutElementStack, context->openLayoutElementStack.length - 2))->id;
+        Clay__TransitionDataInternal *transitionData = Clay__GetTransitionData(openLayoutElement->id);
+        if (!transitionData && context->transitionDatas.length < context->transitionDatas.capacity) {
+            transitionData = Clay__TransitionDataInternalArray_Add(&context->transitionDatas, CLAY__INIT(Clay__TransitionDataInternal){.elementId = openLayoutElement->id, .entering = true});
+        }
+        if (transitionData) {
+            transitionData->config = declaration->transition;
+            transitionData->parentId = parentId;
+            transitionData->openThisFrame = true;
+        }
+    }
 }
 
 void Clay__ConfigureOpenElement(const Clay_ElementDeclaration declaration) {
@@ -2193,6 +3099,15 @@ void Clay__InitializeEphemeralMemory(Clay_Context* context) {
     context->customElementConfigs = Clay__CustomElementConfigArray_Allocate_Arena(maxElementCount, arena);
     context->borderElementConfigs = Clay__BorderElementConfigArray_Allocate_Arena(maxElementCount, arena);
     context->sharedElementConfigs = Clay__SharedElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->focusElementConfigs = Clay__FocusElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->stickyElementConfigs = Clay__StickyElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->zoomPanElementConfigs = Clay__ZoomPanElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->transitionElementConfigs = Clay__TransitionElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->shadowElementConfigs = Clay__ShadowElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->opacityElementConfigs = Clay__OpacityElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->transformElementConfigs = Clay__TransformElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->pathElementConfigs = Clay__PathElementConfigArray_Allocate_Arena(maxElementCount, arena);
+    context->pathCommands = Clay__PathCommandArray_Allocate_Arena(maxElementCount * 4, arena);
 
     context->layoutElementIdStrings = Clay__StringArray_Allocate_Arena(maxElementCount, arena);
     context->wrappedTextLines = Clay__WrappedTextLineArray_Allocate_Arena(maxElementCount, arena);
@@ -2202,6 +3117,7 @@ void Clay__InitializeEphemeralMemory(Clay_Context* context) {
     context->openLayoutElementStack = Clay__int32_tArray_Allocate_Arena(maxElementCount, arena);
     context->textElementData = Clay__TextElementDataArray_Allocate_Arena(maxElementCount, arena);
     context->aspectRatioElementIndexes = Clay__int32_tArray_Allocate_Arena(maxElementCount, arena);
+    context->focusableElementIndexes = Clay__int32_tArray_Allocate_Arena(maxElementCount, arena);
     context->renderCommands = Clay_RenderCommandArray_Allocate_Arena(maxElementCount, arena);
     context->treeNodeVisited = Clay__boolArray_Allocate_Arena(maxElementCount, arena);
     context->treeNodeVisited.length = context->treeNodeVisited.capacity; // This array is accessed directly rather than behaving as a list
@@ -2218,6 +3134,14 @@ void Clay__InitializePersistentMemory(Clay_Context* context) {
     Clay_Arena *arena = &context->internalArena;
 
     context->scrollContainerDatas = Clay__ScrollContainerDataInternalArray_Allocate_Arena(100, arena);
+    context->zoomPanDatas = Clay__ZoomPanDataInternalArray_Allocate_Arena(100, arena);
+    context->transitionDatas = Clay__TransitionDataInternalArray_Allocate_Arena(maxElementCount / 4, arena);
+    context->transitionCommands = Clay_RenderCommandArray_Allocate_Arena(maxElementCount / 4, arena);
+    context->transitionCommandsNext = Clay_RenderCommandArray_Allocate_Arena(maxElementCount / 4, arena);
+    context->transitionText = Clay__charArray_Allocate_Arena(maxElementCount * 4, arena);
+    context->transitionTextNext = Clay__charArray_Allocate_Arena(maxElementCount * 4, arena);
+    context->transitionPathCommands = Clay__PathCommandArray_Allocate_Arena(maxElementCount, arena);
+    context->transitionPathCommandsNext = Clay__PathCommandArray_Allocate_Arena(maxElementCount, arena);
     context->layoutElementsHashMapInternal = Clay__LayoutElementHashMapItemArray_Allocate_Arena(maxElementCount, arena);
     context->layoutElementsHashMap = Clay__int32_tArray_Allocate_Arena(maxElementCount, arena);
     context->measureTextHashMapInternal = Clay__MeasureTextCacheItemArray_Allocate_Arena(maxElementCount, arena);
@@ -2493,8 +3417,99 @@ Clay_String Clay__IntToString(int32_t integer) {
     return CLAY__INIT(Clay_String) { .length = length, .chars = chars };
 }
 
+Clay_Matrix2D Clay__Matrix2D_IDENTITY = { 1, 0, 0, 1, 0, 0 };
+
+bool Clay__MatrixIsIdentity(Clay_Matrix2D m) {
+    return m.a == 1 && m.b == 0 && m.c == 0 && m.d == 1 && m.tx == 0 && m.ty == 0;
+}
+
+// Returns the transform that applies n and then m.
+Clay_Matrix2D Clay__MultiplyMatrices(Clay_Matrix2D m, Clay_Matrix2D n) {
+    return CLAY__INIT(Clay_Matrix2D) {
+        m.a * n.a + m.c * n.b,
+        m.b * n.a + m.d * n.b,
+        m.a * n.c + m.c * n.d,
+        m.b * n.c + m.d * n.d,
+        m.a * n.tx + m.c * n.ty + m.tx,
+        m.b * n.tx + m.d * n.ty + m.ty,
+    };
+}
+
+Clay_Vector2 Clay__TransformPoint(Clay_Matrix2D m, Clay_Vector2 point) {
+    return CLAY__INIT(Clay_Vector2) { m.a * point.x + m.c * point.y + m.tx, m.b * point.x + m.d * point.y + m.ty };
+}
+
+// Maps a point through the inverse of m. Transforms that collapse the plane can't be inverted, and move every point to infinity instead.
+Clay_Vector2 Clay__InverseTransformPoint(Clay_Matrix2D m, Clay_Vector2 point) {
+    float determinant = m.a * m.d - m.b * m.c;
+    if (determinant == 0) {
+        return CLAY__INIT(Clay_Vector2) { CLAY__MAXFLOAT, CLAY__MAXFLOAT };
+    }
+    float x = point.x - m.tx;
+    float y = point.y - m.ty;
+    return CLAY__INIT(Clay_Vector2) { (m.d * x - m.c * y) / determinant, (m.a * y - m.b * x) / determinant };
+}
+
+// Returns the smallest axis aligned box that contains the box once it has been transformed by m.
+Clay_BoundingBox Clay__TransformBoundingBox(Clay_Matrix2D m, Clay_BoundingBox box) {
+    if (Clay__MatrixIsIdentity(m)) {
+        return box;
+    }
+    Clay_Vector2 corners[4] = {
+        Clay__TransformPoint(m, CLAY__INIT(Clay_Vector2) { box.x, box.y }),
+        Clay__TransformPoint(m, CLAY__INIT(Clay_Vector2) { box.x + box.width, box.y }),
+        Clay__TransformPoint(m, CLAY__INIT(Clay_Vector2) { box.x, box.y + box.height }),
+        Clay__TransformPoint(m, CLAY__INIT(Clay_Vector2) { box.x + box.width, box.y + box.height }),
+    };
+    Clay_Vector2 minCorner = corners[0];
+    Clay_Vector2 maxCorner = corners[0];
+    for (int32_t i = 1; i < 4; ++i) {
+        if (corners[i].x < minCorner.x) minCorner.x = corners[i].x;
+        if (corners[i].y < minCorner.y) minCorner.y = corners[i].y;
+        if (corners[i].x > maxCorner.x) maxCorner.x = corners[i].x;
+        if (corners[i].y > maxCorner.y) maxCorner.y = corners[i].y;
+    }
+    return CLAY__INIT(Clay_BoundingBox) { minCorner.x, minCorner.y, maxCorner.x - minCorner.x, maxCorner.y - minCorner.y };
+}
+
+// Returns the sine and cosine of an angle in degrees as x and y, without depending on libm. Multiples of 90 degrees are exact.
+Clay_Vector2 Clay__SinCosDegrees(float degrees) {
+    float quarterTurns = degrees / 90;
+    int32_t quadrant = (int32_t)(quarterTurns + 0.5f);
+    if (quarterTurns < 0) {
+        quadrant = (int32_t)(quarterTurns - 0.5f);
+    }
+    // Reduced to within 45 degrees of a quarter turn, where a few terms of the Taylor series are accurate to float precision
+    float x = (degrees - (float)quadrant * 90) * 0.017453292519943295f;
+    float x2 = x * x;
+    float sine = x * (1 - x2 / 6 * (1 - x2 / 20 * (1 - x2 / 42 * (1 - x2 / 72))));
+    float cosine = 1 - x2 / 2 * (1 - x2 / 12 * (1 - x2 / 30 * (1 - x2 / 56)));
+    switch (quadrant & 3) {
+        case 0: return CLAY__INIT(Clay_Vector2) { sine, cosine };
+        case 1: return CLAY__INIT(Clay_Vector2) { cosine, -sine };
+        case 2: return CLAY__INIT(Clay_Vector2) { -sine, -cosine };
+        default: return CLAY__INIT(Clay_Vector2) { -cosine, sine };
+    }
+}
+
+// Returns the transform of an element drawn in boundingBox. The translation is scaled by the zoom-pan transformScale the box was drawn with.
+Clay_Matrix2D Clay__ElementTransform(Clay_TransformElementConfig *config, Clay_BoundingBox boundingBox, float transformScale) {
+    Clay_Vector2 sinCos = Clay__SinCosDegrees(config->rotation);
+    Clay_Vector2 origin = { boundingBox.x + boundingBox.width * config->origin.x, boundingBox.y + boundingBox.height * config->origin.y };
+    // Scaled, then rotated clockwise around the origin, then translated
+    Clay_Matrix2D m = { sinCos.y * config->scale.x, sinCos.x * config->scale.x, -sinCos.x * config->scale.y, sinCos.y * config->scale.y, 0, 0 };
+    m.tx = origin.x + config->translate.x * transformScale - (m.a * origin.x + m.c * origin.y);
+    m.ty = origin.y + config->translate.y * transformScale - (m.b * origin.x + m.d * origin.y);
+    return m;
+}
+
 void Clay__AddRenderCommand(Clay_RenderCommand renderCommand) {
     Clay_Context* context = Clay_GetCurrentContext();
+    // Commands are created without a transform, transformed elements multiply theirs into the commands they and their children rendered
+    Clay_Matrix2D transform = renderCommand.transform;
+    if (transform.a == 0 && transform.b == 0 && transform.c == 0 && transform.d == 0 && transform.tx == 0 && transform.ty == 0) {
+        renderCommand.transform = Clay__Matrix2D_IDENTITY;
+    }
     if (context->renderCommands.length < context->renderCommands.capacity - 1) {
         Clay_RenderCommandArray_Add(&context->renderCommands, renderCommand);
     } else {
@@ -2520,127 +3535,793 @@ bool Clay__ElementIsOffscreen(Clay_BoundingBox *boundingBox) {
            (boundingBox->y + boundingBox->height < 0);
 }
 
-void Clay__CalculateFinalLayout(void) {
-    Clay_Context* context = Clay_GetCurrentContext();
-    // Calculate sizing along the X axis
-    Clay__SizeContainersAlongAxis(true);
-
-    // Wrap text
-    for (int32_t textElementIndex = 0; textElementIndex < context->textElementData.length; ++textElementIndex) {
-        Clay__TextElementData *textElementData = Clay__TextElementDataArray_Get(&context->textElementData, textElementIndex);
-        textElementData->wrappedLines = CLAY__INIT(Clay__WrappedTextLineArraySlice) { .length = 0, .internalArray = &context->wrappedTextLines.internalArray[context->wrappedTextLines.length] };
-        Clay_LayoutElement *containerElement = Clay_LayoutElementArray_Get(&context->layoutElements, (int)textElementData->elementIndex);
-        Clay_TextElementConfig *textConfig = Clay__FindElementConfigWithType(containerElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT).textElementConfig;
-        Clay__MeasureTextCacheItem *measureTextCacheItem = Clay__MeasureTextCached(&textElementData->text, textConfig);
-        float lineWidth = 0;
-        float lineHeight = textConfig->lineHeight > 0 ? (float)textConfig->lineHeight : textElementData->preferredDimensions.height;
-        int32_t lineLengthChars = 0;
-        int32_t lineStartOffset = 0;
-        if (!measureTextCacheItem->containsNewlines && textElementData->preferredDimensions.width <= containerElement->dimensions.width) {
-            Clay__WrappedTextLineArray_Add(&context->wrappedTextLines, CLAY__INIT(Clay__WrappedTextLine) { containerElement->dimensions,  textElementData->text });
-            textElementData->wrappedLines.length++;
-            continue;
-        }
-        float spaceWidth = Clay__MeasureText(CLAY__INIT(Clay_StringSlice) { .length = 1, .chars = CLAY__SPACECHAR.chars, .baseChars = CLAY__SPACECHAR.chars }, textConfig, context->measureTextUserData).width;
-        int32_t wordIndex = measureTextCacheItem->measuredWordsStartIndex;
-        while (wordIndex != -1) {
-            if (context->wrappedTextLines.length > context->wrappedTextLines.capacity - 1) {
-                break;
-            }
-            Clay__MeasuredWord *measuredWord = Clay__MeasuredWordArray_Get(&context->measuredWords, wordIndex);
-            // Only word on the line is too large, just render it anyway
-            if (lineLengthChars == 0 && lineWidth + measuredWord->width > containerElement->dimensions.width) {
-                Clay__WrappedTextLineArray_Add(&context->wrappedTextLines, CLAY__INIT(Clay__WrappedTextLine) { { measuredWord->width, lineHeight }, { .length = measuredWord->length, .chars = &textElementData->text.chars[measuredWord->startOffset] } });
-                textElementData->wrappedLines.length++;
-                wordIndex = measuredWord->next;
-                lineStartOffset = measuredWord->startOffset + measuredWord->length;
-            }
-            // measuredWord->length == 0 means a newline character
-            else if (measuredWord->length == 0 || lineWidth + measuredWord->width > containerElement->dimensions.width) {
-                // Wrapped text lines list has overflowed, just render out the line
-                bool finalCharIsSpace = textElementData->text.chars[CLAY__MAX(lineStartOffset + lineLengthChars - 1, 0)] == ' ';
-                Clay__WrappedTextLineArray_Add(&context->wrappedTextLines, CLAY__INIT(Clay__WrappedTextLine) { { lineWidth + (finalCharIsSpace ? -spaceWidth : 0), lineHeight }, { .length = lineLengthChars + (finalCharIsSpace ? -1 : 0), .chars = &textElementData->text.chars[lineStartOffset] } });
-                textElementData->wrappedLines.length++;
-                if (lineLengthChars == 0 || measuredWord->length == 0) {
-                    wordIndex = measuredWord->next;
-                }
-                lineWidth = 0;
-                lineLengthChars = 0;
-                lineStartOffset = measuredWord->startOffset;
-            } else {
-                lineWidth += measuredWord->width + textConfig->letterSpacing;
-                lineLengthChars += measuredWord->length;
-                wordIndex = measuredWord->next;
-            }
-        }
-        if (lineLengthChars > 0) {
-            Clay__WrappedTextLineArray_Add(&context->wrappedTextLines, CLAY__INIT(Clay__WrappedTextLine) { { lineWidth - textConfig->letterSpacing, lineHeight }, {.length = lineLengthChars, .chars = &textElementData->text.chars[lineStartOffset] } });
-            textElementData->wrappedLines.length++;
-        }
-        containerElement->dimensions.height = lineHeight * (float)textElementData->wrappedLines.length;
+// Returns the top left position of a floating element with the provided dimensions, attached to parentBoundingBox by attachPoints and offset.
+Clay_Vector2 Clay__FloatingAttachPosition(Clay_FloatingAttachPoints attachPoints, Clay_Vector2 offset, Clay_BoundingBox parentBoundingBox, Clay_Dimensions rootDimensions) {
+    // Set X position
+    Clay_Vector2 targetAttachPosition = CLAY__DEFAULT_STRUCT;
+    switch (attachPoints.parent) {
+        case CLAY_ATTACH_POINT_LEFT_TOP:
+        case CLAY_ATTACH_POINT_LEFT_CENTER:
+        case CLAY_ATTACH_POINT_LEFT_BOTTOM: targetAttachPosition.x = parentBoundingBox.x; break;
+        case CLAY_ATTACH_POINT_CENTER_TOP:
+        case CLAY_ATTACH_POINT_CENTER_CENTER:
+        case CLAY_ATTACH_POINT_CENTER_BOTTOM: targetAttachPosition.x = parentBoundingBox.x + (parentBoundingBox.width / 2); break;
+        case CLAY_ATTACH_POINT_RIGHT_TOP:
+        case CLAY_ATTACH_POINT_RIGHT_CENTER:
+        case CLAY_ATTACH_POINT_RIGHT_BOTTOM: targetAttachPosition.x = parentBoundingBox.x + parentBoundingBox.width; break;
+    }
+    switch (attachPoints.element) {
+        case CLAY_ATTACH_POINT_LEFT_TOP:
+        case CLAY_ATTACH_POINT_LEFT_CENTER:
+        case CLAY_ATTACH_POINT_LEFT_BOTTOM: break;
+        case CLAY_ATTACH_POINT_CENTER_TOP:
+        case CLAY_ATTACH_POINT_CENTER_CENTER:
+        case CLAY_ATTACH_POINT_CENTER_BOTTOM: targetAttachPosition.x -= (rootDimensions.width / 2); break;
+        case CLAY_ATTACH_POINT_RIGHT_TOP:
+        case CLAY_ATTACH_POINT_RIGHT_CENTER:
+        case CLAY_ATTACH_POINT_RIGHT_BOTTOM: targetAttachPosition.x -= rootDimensions.width; break;
+    }
+    switch (attachPoints.parent) { // I know I could merge the x and y switch statements, but this is easier to read
+        case CLAY_ATTACH_POINT_LEFT_TOP:
+        case CLAY_ATTACH_POINT_RIGHT_TOP:
+        case CLAY_ATTACH_POINT_CENTER_TOP: targetAttachPosition.y = parentBoundingBox.y; break;
+        case CLAY_ATTACH_POINT_LEFT_CENTER:
+        case CLAY_ATTACH_POINT_CENTER_CENTER:
+        case CLAY_ATTACH_POINT_RIGHT_CENTER: targetAttachPosition.y = parentBoundingBox.y + (parentBoundingBox.height / 2); break;
+        case CLAY_ATTACH_POINT_LEFT_BOTTOM:
+        case CLAY_ATTACH_POINT_CENTER_BOTTOM:
+        case CLAY_ATTACH_POINT_RIGHT_BOTTOM: targetAttachPosition.y = parentBoundingBox.y + parentBoundingBox.height; break;
     }
+    switch (attachPoints.element) {
+        case CLAY_ATTACH_POINT_LEFT_TOP:
+        case CLAY_ATTACH_POINT_RIGHT_TOP:
+        case CLAY_ATTACH_POINT_CENTER_TOP: break;
+        case CLAY_ATTACH_POINT_LEFT_CENTER:
+        case CLAY_ATTACH_POINT_CENTER_CENTER:
+        case CLAY_ATTACH_POINT_RIGHT_CENTER: targetAttachPosition.y -= (rootDimensions.height / 2); break;
+        case CLAY_ATTACH_POINT_LEFT_BOTTOM:
+        case CLAY_ATTACH_POINT_CENTER_BOTTOM:
+        case CLAY_ATTACH_POINT_RIGHT_BOTTOM: targetAttachPosition.y -= rootDimensions.height; break;
+    }
+    targetAttachPosition.x += offset.x;
+    targetAttachPosition.y += offset.y;
+    return targetAttachPosition;
+}
 
-    // Scale vertical heights according to aspect ratio
-    for (int32_t i = 0; i < context->aspectRatioElementIndexes.length; ++i) {
-        Clay_LayoutElement* aspectElement = Clay_LayoutElementArray_Get(&context->layoutElements, Clay__int32_tArray_GetValue(&context->aspectRatioElementIndexes, i));
-        Clay_AspectRatioElementConfig *config = Clay__FindElementConfigWithType(aspectElement, CLAY__ELEMENT_CONFIG_TYPE_ASPECT).aspectRatioElementConfig;
-        aspectElement->dimensions.height = (1 / config->aspectRatio) * aspectElement->dimensions.width;
-        aspectElement->layoutConfig->sizing.height.size.minMax.max = aspectElement->dimensions.height;
+Clay_FloatingAttachPointType Clay__FlipAttachPoint(Clay_FloatingAttachPointType attachPoint, bool xAxis) {
+    int32_t column = attachPoint / 3, row = attachPoint % 3;
+    if (xAxis) {
+        column = 2 - column;
+    } else {
+        row = 2 - row;
     }
+    return (Clay_FloatingAttachPointType)(column * 3 + row);
+}
 
-    // Propagate effect of text wrapping, aspect scaling etc. on height of parents
-    Clay__LayoutElementTreeNodeArray dfsBuffer = context->layoutElementTreeNodeArray1;
-    dfsBuffer.length = 0;
-    for (int32_t i = 0; i < context->layoutElementTreeRoots.length; ++i) {
-        Clay__LayoutElementTreeRoot *root = Clay__LayoutElementTreeRootArray_Get(&context->layoutElementTreeRoots, i);
-        context->treeNodeVisited.internalArray[dfsBuffer.length] = false;
-        Clay__LayoutElementTreeNodeArray_Add(&dfsBuffer, CLAY__INIT(Clay__LayoutElementTreeNode) { .layoutElement = Clay_LayoutElementArray_Get(&context->layoutElements, (int)root->layoutElementIndex) });
+float Clay__AxisOverflow(float position, float size, float boundaryStart, float boundaryEnd) {
+    return CLAY__MAX(boundaryStart - position, 0) + CLAY__MAX(position + size - boundaryEnd, 0);
+}
+
+// Flips and shifts a floating element's position to keep it inside the layout root and the clip element around the element it's attached to.
+// All boxes are in the floating element's layout space, which differs from screen space inside zoom-pan containers.
+Clay_Vector2 Clay__ResolveFloatingCollision(Clay_FloatingElementConfig *config, Clay_LayoutElementHashMapItem *parentItem, Clay_BoundingBox parentBoundingBox, Clay_Dimensions rootDimensions, Clay_Vector2 position, Clay_Vector2 transformOffset, float transformScale) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay_BoundingBox boundary = { 0, 0, context->layoutDimensions.width, context->layoutDimensions.height };
+    if (parentItem->layoutElement) {
+        int32_t clipElementId = Clay__int32_tArray_GetValue(&context->layoutElementClipElementIds, (int32_t)(parentItem->layoutElement - context->layoutElements.internalArray));
+        if (Clay__ElementHasConfig(parentItem->layoutElement, CLAY__ELEMENT_CONFIG_TYPE_CLIP)) {
+            clipElementId = (int32_t)parentItem->layoutElement->id;
+        }
+        Clay_LayoutElementHashMapItem *clipHashMapItem = Clay__GetHashMapItem(clipElementId);
+        if (clipElementId != 0 && clipHashMapItem != &Clay_LayoutElementHashMapItem_DEFAULT) {
+            Clay_BoundingBox clipBox = clipHashMapItem->boundingBox;
+            float left = CLAY__MAX(boundary.x, clipBox.x);
+            float top = CLAY__MAX(boundary.y, clipBox.y);
+            float right = CLAY__MIN(boundary.x + boundary.width, clipBox.x + clipBox.width);
+            float bottom = CLAY__MIN(boundary.y + boundary.height, clipBox.y + clipBox.height);
+            boundary = CLAY__INIT(Clay_BoundingBox) { left, top, CLAY__MAX(right - left, 0), CLAY__MAX(bottom - top, 0) };
+        }
     }
-    while (dfsBuffer.length > 0) {
-        Clay__LayoutElementTreeNode *currentElementTreeNode = Clay__LayoutElementTreeNodeArray_Get(&dfsBuffer, (int)dfsBuffer.length - 1);
-        Clay_LayoutElement *currentElement = currentElementTreeNode->layoutElement;
-        if (!context->treeNodeVisited.internalArray[dfsBuffer.length - 1]) {
-            context->treeNodeVisited.internalArray[dfsBuffer.length - 1] = true;
-            // If the element has no children or is the container for a text element, don't bother inspecting it
-            if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) || currentElement->childrenOrTextContent.children.length == 0) {
-                dfsBuffer.length--;
+    boundary = CLAY__INIT(Clay_BoundingBox) {
+        (boundary.x - transformOffset.x) / transformScale,
+        (boundary.y - transformOffset.y) / transformScale,
+        boundary.width / transformScale,
+        boundary.height / transformScale,
+    };
+
+    if (config->collision & CLAY_FLOATING_COLLISION_FLIP) {
+        for (int32_t axis = 0; axis < 2; ++axis) {
+            bool xAxis = axis == 0;
+            float overflow = xAxis
+                ? Clay__AxisOverflow(position.x, rootDimensions.width, boundary.x, boundary.x + boundary.width)
+                : Clay__AxisOverflow(position.y, rootDimensions.height, boundary.y, boundary.y + boundary.height);
+            if (overflow <= 0) {
                 continue;
             }
-            // Add the children to the DFS buffer (needs to be pushed in reverse so that stack traversal is in correct layout order)
-            for (int32_t i = 0; i < currentElement->childrenOrTextContent.children.length; i++) {
-                context->treeNodeVisited.internalArray[dfsBuffer.length] = false;
-                Clay__LayoutElementTreeNodeArray_Add(&dfsBuffer, CLAY__INIT(Clay__LayoutElementTreeNode) { .layoutElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[i]) });
+            Clay_FloatingAttachPoints flippedAttachPoints = { Clay__FlipAttachPoint(config->attachPoints.element, xAxis), Clay__FlipAttachPoint(config->attachPoints.parent, xAxis) };
+            Clay_Vector2 flippedOffset = { xAxis ? -config->offset.x : config->offset.x, xAxis ? config->offset.y : -config->offset.y };
+            Clay_Vector2 flippedPosition = Clay__FloatingAttachPosition(flippedAttachPoints, flippedOffset, parentBoundingBox, rootDimensions);
+            if (xAxis && Clay__AxisOverflow(flippedPosition.x, rootDimensions.width, boundary.x, boundary.x + boundary.width) < overflow) {
+                position.x = flippedPosition.x;
+            } else if (!xAxis && Clay__AxisOverflow(flippedPosition.y, rootDimensions.height, boundary.y, boundary.y + boundary.height) < overflow) {
+                position.y = flippedPosition.y;
             }
-            continue;
         }
-        dfsBuffer.length--;
-
-        // DFS node has been visited, this is on the way back up to the root
-        Clay_LayoutConfig *layoutConfig = currentElement->layoutConfig;
-        if (layoutConfig->layoutDirection == CLAY_LEFT_TO_RIGHT) {
-            // Resize any parent containers that have grown in height along their non layout axis
-            for (int32_t j = 0; j < currentElement->childrenOrTextContent.children.length; ++j) {
-                Clay_LayoutElement *childElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[j]);
-                float childHeightWithPadding = CLAY__MAX(childElement->dimensions.height + layoutConfig->padding.top + layoutConfig->padding.bottom, currentElement->dimensions.height);
-                currentElement->dimensions.height = CLAY__MIN(CLAY__MAX(childHeightWithPadding, layoutConfig->sizing.height.size.minMax.min), layoutConfig->sizing.height.size.minMax.max);
-            }
-        } else if (layoutConfig->layoutDirection == CLAY_TOP_TO_BOTTOM) {
-            // Resizing along the layout axis
-            float contentHeight = (float)(layoutConfig->padding.top + layoutConfig->padding.bottom);
-            for (int32_t j = 0; j < currentElement->childrenOrTextContent.children.length; ++j) {
-                Clay_LayoutElement *childElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[j]);
-                contentHeight += childElement->dimensions.height;
-            }
-            contentHeight += (float)(CLAY__MAX(currentElement->childrenOrTextContent.children.length - 1, 0) * layoutConfig->childGap);
-            currentElement->dimensions.height = CLAY__MIN(CLAY__MAX(contentHeight, layoutConfig->sizing.height.size.minMax.min), layoutConfig->sizing.height.size.minMax.max);
+    }
+    if (config->collision & CLAY_FLOATING_COLLISION_SHIFT) {
+        if (position.x + rootDimensions.width > boundary.x + boundary.width) {
+            position.x = boundary.x + boundary.width - rootDimensions.width;
+        }
+        if (position.x < boundary.x) {
+            position.x = boundary.x;
+        }
+        if (position.y + rootDimensions.height > boundary.y + boundary.height) {
+            position.y = boundary.y + boundary.height - rootDimensions.height;
+        }
+        if (position.y < boundary.y) {
+            position.y = boundary.y;
         }
     }
+    return position;
+}
 
-    // Calculate sizing along the Y axis
-    Clay__SizeContainersAlongAxis(false);
+float Clay__Ease(Clay_EasingCurve easing, float t) {
+    switch (easing) {
+        case CLAY_EASING_EASE_IN: return t * t * t;
+        case CLAY_EASING_EASE_OUT: return 1 - (1 - t) * (1 - t) * (1 - t);
+        case CLAY_EASING_EASE_IN_OUT: return t < 0.5f ? 4 * t * t * t : 1 - (2 - 2 * t) * (2 - 2 * t) * (2 - 2 * t) / 2;
+        default: return t;
+    }
+}
 
-    // Scale horizontal widths according to aspect ratio
-    for (int32_t i = 0; i < context->aspectRatioElementIndexes.length; ++i) {
+float Clay__TransitionProgress(Clay_TransitionElementConfig *config, float elapsed) {
+    if (config->duration <= 0 || elapsed >= config->duration) {
+        return 1;
+    }
+    return Clay__Ease(config->easing, elapsed / config->duration);
+}
+
+float Clay__Lerp(float from, float to, float t) {
+    return from + (to - from) * t;
+}
+
+Clay_Vector2 Clay__TransitionPosition(Clay__TransitionDataInternal *data) {
+    float t = Clay__TransitionProgress(&data->config, data->positionElapsed);
+    return CLAY__INIT(Clay_Vector2) { Clay__Lerp(data->positionFrom.x, data->positionTo.x, t), Clay__Lerp(data->positionFrom.y, data->positionTo.y, t) };
+}
+
+Clay_Dimensions Clay__TransitionSize(Clay__TransitionDataInternal *data) {
+    float t = Clay__TransitionProgress(&data->config, data->sizeElapsed);
+    return CLAY__INIT(Clay_Dimensions) { Clay__Lerp(data->sizeFrom.width, data->sizeTo.width, t), Clay__Lerp(data->sizeFrom.height, data->sizeTo.height, t) };
+}
+
+Clay_Color Clay__TransitionColor(Clay__TransitionDataInternal *data) {
+    float t = Clay__TransitionProgress(&data->config, data->colorElapsed);
+    return CLAY__INIT(Clay_Color) {
+        Clay__Lerp(data->colorFrom.r, data->colorTo.r, t),
+        Clay__Lerp(data->colorFrom.g, data->colorTo.g, t),
+        Clay__Lerp(data->colorFrom.b, data->colorTo.b, t),
+        Clay__Lerp(data->colorFrom.a, data->colorTo.a, t),
+    };
+}
+
+float Clay__TransitionOpacity(Clay__TransitionDataInternal *data) {
+    return Clay__Lerp(data->opacityFrom, data->opacityTo, Clay__TransitionProgress(&data->config, data->opacityElapsed));
+}
+
+// Advances a transition by the frame's delta time and retargets any property whose laid out value changed.
+// target is relative to the parent's content origin.
+void Clay__UpdateTransition(Clay__TransitionDataInternal *data, Clay_BoundingBox target, Clay_Color targetColor, float targetOpacity) {
+    Clay_TransitionElementConfig *config = &data->config;
+    Clay_Vector2 targetPosition = { target.x, target.y };
+    Clay_Dimensions targetSize = { target.width, target.height };
+    if (data->entering) {
+        data->entering = false;
+        data->positionFrom = targetPosition;
+        data->positionTo = targetPosition;
+        data->sizeFrom = targetSize;
+        data->sizeTo = targetSize;
+        data->colorFrom = targetColor;
+        data->colorTo = targetColor;
+        data->opacityFrom = targetOpacity;
+        data->opacityTo = targetOpacity;
+        data->positionElapsed = config->duration;
+        data->sizeElapsed = config->duration;
+        data->colorElapsed = config->duration;
+        data->opacityElapsed = config->duration;
+        if (config->enter.enabled) {
+            data->positionFrom = CLAY__INIT(Clay_Vector2) { targetPosition.x + config->enter.offset.x, targetPosition.y + config->enter.offset.y };
+            data->opacityFrom = config->enter.opacity;
+            data->positionElapsed = 0;
+            data->opacityElapsed = 0;
+        }
+        return;
+    }
+    float deltaTime = Clay_GetCurrentContext()->deltaTime;
+    if (data->exiting) {
+        // Declared again while exiting, so animate back from where the exit had got to
+        float t = Clay__TransitionProgress(config, data->exitElapsed);
+        data->exiting = false;
+        data->positionFrom = CLAY__INIT(Clay_Vector2) { data->positionTo.x + config->exit.offset.x * t, data->positionTo.y + config->exit.offset.y * t };
+        data->opacityFrom = Clay__Lerp(data->exitOpacity, config->exit.opacity, t);
+        data->positionElapsed = 0;
+        data->opacityElapsed = 0;
+        deltaTime = 0;
+    }
+    data->positionElapsed += deltaTime;
+    data->sizeElapsed += deltaTime;
+    data->colorElapsed += deltaTime;
+    data->opacityElapsed += deltaTime;
+
+    // Properties without a transition jump to their new value, but still finish an enter animation
+    if (targetPosition.x != data->positionTo.x || targetPosition.y != data->positionTo.y) {
+        data->positionFrom = (config->properties & CLAY_TRANSITION_PROPERTY_POSITION) ? Clay__TransitionPosition(data) : targetPosition;
+        data->positionTo = targetPosition;
+        data->positionElapsed = (config->properties & CLAY_TRANSITION_PROPERTY_POSITION) ? 0 : config->duration;
+    }
+    if (targetSize.width != data->sizeTo.width || targetSize.height != data->sizeTo.height) {
+        data->sizeFrom = (config->properties & CLAY_TRANSITION_PROPERTY_SIZE) ? Clay__TransitionSize(data) : targetSize;
+        data->sizeTo = targetSize;
+        data->sizeElapsed = (config->properties & CLAY_TRANSITION_PROPERTY_SIZE) ? 0 : config->duration;
+    }
+    if (!Clay__MemCmp((char *)&targetColor, (char *)&data->colorTo, sizeof(Clay_Color))) {
+        data->colorFrom = (config->properties & CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR) ? Clay__TransitionColor(data) : targetColor;
+        data->colorTo = targetColor;
+        data->colorElapsed = (config->properties & CLAY_TRANSITION_PROPERTY_BACKGROUND_COLOR) ? 0 : config->duration;
+    }
+    if (targetOpacity != data->opacityTo) {
+        data->opacityFrom = (config->properties & CLAY_TRANSITION_PROPERTY_OPACITY) ? Clay__TransitionOpacity(data) : targetOpacity;
+        data->opacityTo = targetOpacity;
+        data->opacityElapsed = (config->properties & CLAY_TRANSITION_PROPERTY_OPACITY) ? 0 : config->duration;
+    }
+}
+
+// Copies render commands into the next exit snapshot buffer, along with the text and path commands they reference. Returns the start of the copy, or -1 if it doesn't fit.
+int32_t Clay__SnapshotTransitionCommands(Clay_RenderCommand *renderCommands, int32_t count) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    int32_t textLength = 0;
+    int32_t pathLength = 0;
+    for (int32_t i = 0; i < count; ++i) {
+        if (renderCommands[i].commandType == CLAY_RENDER_COMMAND_TYPE_TEXT) {
+            textLength += renderCommands[i].renderData.text.stringContents.length;
+        } else if (renderCommands[i].commandType == CLAY_RENDER_COMMAND_TYPE_PATH) {
+            pathLength += renderCommands[i].renderData.path.commands.length;
+        }
+    }
+    if (context->transitionCommandsNext.length + count > context->transitionCommandsNext.capacity || context->transitionTextNext.length + textLength > context->transitionTextNext.capacity || context->transitionPathCommandsNext.length + pathLength > context->transitionPathCommandsNext.capacity) {
+        return -1;
+    }
+    int32_t start = context->transitionCommandsNext.length;
+    for (int32_t i = 0; i < count; ++i) {
+        Clay_RenderCommand *copy = Clay_RenderCommandArray_Add(&context->transitionCommandsNext, renderCommands[i]);
+        if (copy->commandType == CLAY_RENDER_COMMAND_TYPE_TEXT) {
+            Clay_StringSlice text = copy->renderData.text.stringContents;
+            Clay_String written = Clay__WriteStringToCharBuffer(&context->transitionTextNext, CLAY__INIT(Clay_String) { .length = text.length, .chars = text.chars });
+            copy->renderData.text.stringContents = CLAY__INIT(Clay_StringSlice) { .length = written.length, .chars = written.chars, .baseChars = written.chars };
+        } else if (copy->commandType == CLAY_RENDER_COMMAND_TYPE_PATH) {
+            copy->renderData.path.commands = Clay__WritePathCommandsToBuffer(&context->transitionPathCommandsNext, copy->renderData.path.commands);
+        }
+    }
+    return start;
+}
+
+// Draws the last frame of the exiting children of parentId, or of every exiting element that hasn't been drawn yet when parentId is 0.
+void Clay__AddExitingTransitionCommands(uint32_t parentId, int16_t zIndex) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t i = 0; i < context->transitionDatas.length; i++) {
+        Clay__TransitionDataInternal *transitionData = Clay__TransitionDataInternalArray_Get(&context->transitionDatas, i);
+        if (!transitionData->exiting || transitionData->exitCommandsLength <= 0 || (parentId != 0 && transitionData->parentId != parentId)) {
+            continue;
+        }
+        Clay_TransitionElementConfig *config = &transitionData->config;
+        float t = Clay__TransitionProgress(config, transitionData->exitElapsed);
+        float opacity = Clay__Lerp(transitionData->exitOpacity, config->exit.opacity, t);
+        // The snapshot is faded as a single group, the same way the element was while it was declared
+        if (opacity < 1) {
+            Clay_RenderCommand first = *Clay_RenderCommandArray_Get(&context->transitionCommandsNext, transitionData->exitCommandsStart);
+            Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+                .boundingBox = { first.boundingBox.x + config->exit.offset.x * t, first.boundingBox.y + config->exit.offset.y * t, first.boundingBox.width, first.boundingBox.height },
+                .renderData = { .layer = { .opacity = CLAY__MAX(opacity, 0) } },
+                .id = first.id,
+                .zIndex = zIndex,
+                .commandType = CLAY_RENDER_COMMAND_TYPE_LAYER_START,
+            });
+        }
+        for (int32_t j = 0; j < transitionData->exitCommandsLength; ++j) {
+            Clay_RenderCommand renderCommand = *Clay_RenderCommandArray_Get(&context->transitionCommandsNext, transitionData->exitCommandsStart + j);
+            renderCommand.boundingBox.x += config->exit.offset.x * t;
+            renderCommand.boundingBox.y += config->exit.offset.y * t;
+            renderCommand.zIndex = zIndex;
+            Clay__AddRenderCommand(renderCommand);
+        }
+        if (opacity < 1) {
+            Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+                .renderData = { .layer = { .opacity = CLAY__MAX(opacity, 0) } },
+                .id = Clay_RenderCommandArray_Get(&context->transitionCommandsNext, transitionData->exitCommandsStart)->id,
+                .zIndex = zIndex,
+                .commandType = CLAY_RENDER_COMMAND_TYPE_LAYER_END,
+            });
+        }
+        // Only drawn once per layout
+        transitionData->exitCommandsLength = -transitionData->exitCommandsLength;
+    }
+}
+
+// Advances exit animations and carries their snapshots over into the next snapshot buffer before the layout's render commands are generated.
+void Clay__BeginTransitions(void) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    context->transitionCommandsNext.length = 0;
+    context->transitionTextNext.length = 0;
+    context->transitionPathCommandsNext.length = 0;
+    context->exitingTransitionCount = 0;
+    for (int32_t i = 0; i < context->transitionDatas.length; i++) {
+        Clay__TransitionDataInternal *transitionData = Clay__TransitionDataInternalArray_Get(&context->transitionDatas, i);
+        if (transitionData->openThisFrame) {
+            continue;
+        }
+        if (!transitionData->exiting) {
+            transitionData->exiting = true;
+            transitionData->exitElapsed = 0;
+            transitionData->exitOpacity = Clay__TransitionOpacity(transitionData);
+        } else {
+            transitionData->exitElapsed += context->deltaTime;
+        }
+        bool finished = !transitionData->config.exit.enabled || transitionData->exitCommandsLength <= 0 || transitionData->exitElapsed >= transitionData->config.duration;
+        if (!finished) {
+            transitionData->exitCommandsStart = Clay__SnapshotTransitionCommands(&context->transitionCommands.internalArray[transitionData->exitCommandsStart], transitionData->exitCommandsLength);
+            finished = transitionData->exitCommandsStart < 0;
+        }
+        if (finished) {
+            Clay__TransitionDataInternalArray_RemoveSwapback(&context->transitionDatas, i);
+            i--;
+            continue;
+        }
+        context->exitingTransitionCount++;
+    }
+}
+
+// Draws exiting elements whose parents are gone, and swaps the snapshot buffers once every element has been visited.
+void Clay__EndTransitions(void) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    if (context->exitingTransitionCount > 0) {
+        Clay__AddExitingTransitionCommands(0, 0);
+    }
+    for (int32_t i = 0; i < context->transitionDatas.length; i++) {
+        Clay__TransitionDataInternal *transitionData = Clay__TransitionDataInternalArray_Get(&context->transitionDatas, i);
+        transitionData->openThisFrame = false;
+        if (transitionData->exitCommandsLength < 0) {
+            transitionData->exitCommandsLength = -transitionData->exitCommandsLength;
+        }
+    }
+    Clay_RenderCommandArray renderCommands = context->transitionCommands;
+    context->transitionCommands = context->transitionCommandsNext;
+    context->transitionCommandsNext = renderCommands;
+    Clay__charArray text = context->transitionText;
+    context->transitionText = context->transitionTextNext;
+    context->transitionTextNext = text;
+    Clay__PathCommandArray pathCommands = context->transitionPathCommands;
+    context->transitionPathCommands = context->transitionPathCommandsNext;
+    context->transitionPathCommandsNext = pathCommands;
+}
+
+Clay__ZoomPanDataInternal* Clay__GetZoomPanDataForElement(Clay_LayoutElement *layoutElement) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t i = 0; i < context->zoomPanDatas.length; i++) {
+        Clay__ZoomPanDataInternal *zoomPanData = Clay__ZoomPanDataInternalArray_Get(&context->zoomPanDatas, i);
+        if (zoomPanData->layoutElement == layoutElement) {
+            return zoomPanData;
+        }
+    }
+    return CLAY__NULL;
+}
+
+uint16_t Clay__ScaleUInt16(uint16_t value, float scale) {
+    return (uint16_t)((float)value * scale + 0.5f);
+}
+
+Clay_Color Clay__BorderSideColor(Clay_Color sideColor, Clay_Color sharedColor) {
+    if (sideColor.a > 0) {
+        return sideColor;
+    }
+    return sharedColor;
+}
+
+Clay_CornerRadius Clay__ScaleCornerRadius(Clay_CornerRadius cornerRadius, float scale) {
+    return CLAY__INIT(Clay_CornerRadius) { cornerRadius.topLeft * scale, cornerRadius.topRight * scale, cornerRadius.bottomLeft * scale, cornerRadius.bottomRight * scale };
+}
+
+// Returns the SHADOW render command for an element, or a NONE command if the shadow lies entirely outside the screen.
+Clay_RenderCommand Clay__CreateShadowRenderCommand(Clay_ShadowElementConfig *config, Clay_BoundingBox boundingBox, Clay_CornerRadius cornerRadius, float transformScale, Clay_Matrix2D transform, uint32_t id, int16_t zIndex) {
+    Clay_ShadowRenderData shadow = {
+        .color = config->color,
+        .cornerRadius = Clay__ScaleCornerRadius(cornerRadius, transformScale),
+        .offset = { config->offset.x * transformScale, config->offset.y * transformScale },
+        .blurRadius = config->blurRadius * transformScale,
+        .spread = config->spread * transformScale,
+        .inset = config->inset,
+    };
+    float extent = CLAY__MAX(shadow.blurRadius + shadow.spread, 0);
+    Clay_BoundingBox shadowBoundingBox = { boundingBox.x + shadow.offset.x - extent, boundingBox.y + shadow.offset.y - extent, boundingBox.width + extent * 2, boundingBox.height + extent * 2 };
+    Clay_BoundingBox cullingBoundingBox = Clay__TransformBoundingBox(transform, config->inset ? boundingBox : shadowBoundingBox);
+    bool offscreen = Clay__ElementIsOffscreen(&cullingBoundingBox);
+    return CLAY__INIT(Clay_RenderCommand) {
+        .boundingBox = boundingBox,
+        .renderData = { .shadow = shadow },
+        .id = Clay__HashNumber(id, 13).id,
+        .zIndex = zIndex,
+        .commandType = offscreen ? CLAY_RENDER_COMMAND_TYPE_NONE : CLAY_RENDER_COMMAND_TYPE_SHADOW,
+    };
+}
+
+// Moves the sticky children that were just pushed onto the DFS buffer so they stay inside the viewport of their nearest clip ancestor.
+// Sticky children are then moved to the end of the sibling order, so that the siblings scrolling underneath them are drawn first.
+void Clay__ResolveStickyChildren(Clay__LayoutElementTreeNodeArray *dfsBuffer, Clay__LayoutElementTreeNode *parentNode) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay_LayoutElement *parent = parentNode->layoutElement;
+    int32_t childCount = parent->childrenOrTextContent.children.length;
+    int32_t firstNodeIndex = dfsBuffer->length - childCount;
+    // Children of the DFS buffer are stored in reverse, so child i lives at dfsBuffer->length - 1 - i
+    Clay_Vector2 contentEnd = {
+        parentNode->position.x + parent->dimensions.width - (float)parent->layoutConfig->padding.right,
+        parentNode->position.y + parent->dimensions.height - (float)parent->layoutConfig->padding.bottom,
+    };
+    for (int32_t i = 0; i < childCount; i++) {
+        Clay__LayoutElementTreeNode *node = &dfsBuffer->internalArray[dfsBuffer->length - 1 - i];
+        if (node->position.x + node->layoutElement->dimensions.width > contentEnd.x) {
+            contentEnd.x = node->position.x + node->layoutElement->dimensions.width;
+        }
+        if (node->position.y + node->layoutElement->dimensions.height > contentEnd.y) {
+            contentEnd.y = node->position.y + node->layoutElement->dimensions.height;
+        }
+    }
+
+    for (int32_t i = 0; i < childCount; i++) {
+        Clay__LayoutElementTreeNode *node = &dfsBuffer->internalArray[dfsBuffer->length - 1 - i];
+        Clay_LayoutElement *childElement = node->layoutElement;
+        Clay_StickyElementConfig *stickyConfig = Clay__FindElementConfigWithType(childElement, CLAY__ELEMENT_CONFIG_TYPE_STICKY).stickyElementConfig;
+        if (!stickyConfig) {
+            continue;
+        }
+        Clay_BoundingBox viewport = { 0, 0, context->layoutDimensions.width, context->layoutDimensions.height };
+        uint32_t clipElementId = Clay__int32_tArray_GetValue(&context->layoutElementClipElementIds, parent->childrenOrTextContent.children.elements[i]);
+        if (clipElementId != 0) {
+            viewport = Clay__GetHashMapItem(clipElementId)->boundingBox;
+        }
+        Clay_Vector2 limit = { contentEnd.x - childElement->dimensions.width, contentEnd.y - childElement->dimensions.height };
+        bool pushedHorizontally = false, pushedVertically = false;
+        for (int32_t j = i + 1; j < childCount && !(pushedHorizontally && pushedVertically); j++) {
+            Clay__LayoutElementTreeNode *nextNode = &dfsBuffer->internalArray[dfsBuffer->length - 1 - j];
+            Clay_StickyElementConfig *nextConfig = Clay__FindElementConfigWithType(nextNode->layoutElement, CLAY__ELEMENT_CONFIG_TYPE_STICKY).stickyElementConfig;
+            if (!nextConfig) {
+                continue;
+            }
+            if (nextConfig->horizontal && !pushedHorizontally) {
+                if (nextNode->position.x - childElement->dimensions.width < limit.x) {
+                    limit.x = nextNode->position.x - childElement->dimensions.width;
+                }
+                pushedHorizontally = true;
+            }
+            if (nextConfig->vertical && !pushedVertically) {
+                if (nextNode->position.y - childElement->dimensions.height < limit.y) {
+                    limit.y = nextNode->position.y - childElement->dimensions.height;
+                }
+                pushedVertically = true;
+            }
+        }
+        // A sticky element only ever moves forwards from where the layout put it
+        float stuckX = CLAY__MIN(viewport.x + stickyConfig->offset.x, limit.x);
+        if (stickyConfig->horizontal && stuckX > node->position.x) {
+            node->position.x = stuckX;
+        }
+        float stuckY = CLAY__MIN(viewport.y + stickyConfig->offset.y, limit.y);
+        if (stickyConfig->vertical && stuckY > node->position.y) {
+            node->position.y = stuckY;
+        }
+    }
+
+    // Stable partition that moves sticky nodes to the bottom of the segment, which the DFS visits last
+    int32_t writeIndex = firstNodeIndex;
+    for (int32_t nodeIndex = firstNodeIndex; nodeIndex < dfsBuffer->length; nodeIndex++) {
+        Clay__LayoutElementTreeNode node = dfsBuffer->internalArray[nodeIndex];
+        if (!Clay__ElementHasConfig(node.layoutElement, CLAY__ELEMENT_CONFIG_TYPE_STICKY)) {
+            continue;
+        }
+        for (int32_t k = nodeIndex; k > writeIndex; k--) {
+            dfsBuffer->internalArray[k] = dfsBuffer->internalArray[k - 1];
+        }
+        dfsBuffer->internalArray[writeIndex] = node;
+        writeIndex++;
+    }
+}
+
+// Returns the size of a scroll container's viewport in layout space, the same space as its content size and scroll position.
+Clay_Dimensions Clay__ScrollViewportSize(Clay__ScrollContainerDataInternal *scrollData) {
+    return CLAY__INIT(Clay_Dimensions) { scrollData->boundingBox.width / scrollData->scale, scrollData->boundingBox.height / scrollData->scale };
+}
+
+// Computes the track and thumb of one of a scroll container's scrollbars from its current layout.
+// Returns false if the scrollbar isn't shown because scrollbars are disabled, the axis isn't clipped, or the content fits.
+bool Clay__GetScrollbarGeometry(Clay__ScrollContainerDataInternal *scrollData, Clay_ClipElementConfig *clipConfig, bool vertical, Clay_BoundingBox *track, Clay_BoundingBox *thumb) {
+    Clay_ScrollbarConfig *config = &clipConfig->scrollbar;
+    Clay_BoundingBox box = scrollData->boundingBox;
+    Clay_Dimensions viewport = Clay__ScrollViewportSize(scrollData);
+    bool showHorizontal = clipConfig->horizontal && scrollData->contentSize.width > viewport.width;
+    bool showVertical = clipConfig->vertical && scrollData->contentSize.height > viewport.height;
+    if (config->width <= 0 || (vertical ? !showVertical : !showHorizontal)) {
+        return false;
+    }
+    // Scrollbars are drawn in screen space, so they zoom with the rest of a zoom-pan container
+    float width = config->width * scrollData->scale;
+    float minThumbLength = CLAY__MAX(config->minThumbLength, config->width) * scrollData->scale;
+    if (vertical) {
+        *track = CLAY__INIT(Clay_BoundingBox) { box.x + box.width - width, box.y, width, box.height - (showHorizontal ? width : 0) };
+        float thumbLength = CLAY__MIN(CLAY__MAX(track->height * viewport.height / scrollData->contentSize.height, minThumbLength), track->height);
+        float progress = CLAY__MIN(CLAY__MAX(-scrollData->scrollPosition.y / (scrollData->contentSize.height - viewport.height), 0), 1);
+        *thumb = CLAY__INIT(Clay_BoundingBox) { track->x, track->y + (track->height - thumbLength) * progress, width, thumbLength };
+    } else {
+        *track = CLAY__INIT(Clay_BoundingBox) { box.x, box.y + box.height - width, box.width - (showVertical ? width : 0), width };
+        float thumbLength = CLAY__MIN(CLAY__MAX(track->width * viewport.width / scrollData->contentSize.width, minThumbLength), track->width);
+        float progress = CLAY__MIN(CLAY__MAX(-scrollData->scrollPosition.x / (scrollData->contentSize.width - viewport.width), 0), 1);
+        *thumb = CLAY__INIT(Clay_BoundingBox) { track->x + (track->width - thumbLength) * progress, track->y, thumbLength, width };
+    }
+    return true;
+}
+
+// The track and thumb are given IDs derived from the scroll container's ID and the axis, so they stay the same while its children change.
+void Clay__AddScrollbarRenderCommands(Clay__ScrollContainerDataInternal *scrollData, Clay_ClipElementConfig *clipConfig, int16_t zIndex) {
+    Clay_ScrollbarConfig *config = &clipConfig->scrollbar;
+    if (config->autoHideDelay > 0 && scrollData->scrollbarIdleTime > config->autoHideDelay) {
+        return;
+    }
+    for (int32_t axis = 0; axis < 2; axis++) {
+        Clay_BoundingBox track, thumb;
+        if (!Clay__GetScrollbarGeometry(scrollData, clipConfig, axis == 1, &track, &thumb)) {
+            continue;
+        }
+        if (config->trackColor.a > 0) {
+            Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+                .boundingBox = track,
+                .renderData = { .rectangle = { .backgroundColor = config->trackColor, .cornerRadius = config->cornerRadius } },
+                .id = Clay__HashStringWithOffset(CLAY_STRING("Clay__ScrollbarTrack"), axis, scrollData->elementId).id,
+                .zIndex = zIndex,
+                .commandType = CLAY_RENDER_COMMAND_TYPE_RECTANGLE,
+            });
+        }
+        Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+            .boundingBox = thumb,
+            .renderData = { .rectangle = { .backgroundColor = config->thumbColor, .cornerRadius = config->cornerRadius } },
+            .id = Clay__HashStringWithOffset(CLAY_STRING("Clay__ScrollbarThumb"), axis, scrollData->elementId).id,
+            .zIndex = zIndex,
+            .commandType = CLAY_RENDER_COMMAND_TYPE_RECTANGLE,
+        });
+    }
+}
+
+// Drags thumbs and pages on track presses. Returns true while the pointer is held down on one of the container's scrollbars.
+bool Clay__UpdateScrollbars(Clay__ScrollContainerDataInternal *scrollData, Clay_ClipElementConfig *clipConfig, float deltaTime) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay_PointerData pointer = context->pointerInfo;
+    if (pointer.state != CLAY_POINTER_DATA_PRESSED && pointer.state != CLAY_POINTER_DATA_PRESSED_THIS_FRAME) {
+        scrollData->scrollbarPressed = false;
+        scrollData->scrollbarDragAxis = 0;
+    }
+    bool hovered = false;
+    for (int32_t axis = 0; axis < 2; axis++) {
+        bool vertical = axis == 1;
+        Clay_BoundingBox track, thumb;
+        if (!Clay__GetScrollbarGeometry(scrollData, clipConfig, vertical, &track, &thumb)) {
+            if (scrollData->scrollbarDragAxis == axis + 1) {
+                scrollData->scrollbarDragAxis = 0;
+            }
+            continue;
+        }
+        bool overTrack = Clay__PointIsInsideRect(pointer.position, track);
+        hovered = hovered || overTrack;
+        if (pointer.state == CLAY_POINTER_DATA_PRESSED_THIS_FRAME && overTrack) {
+            scrollData->scrollbarPressed = true;
+            scrollData->scrollMomentum = CLAY__INIT(Clay_Vector2) CLAY__DEFAULT_STRUCT;
+            if (Clay__PointIsInsideRect(pointer.position, thumb)) {
+                scrollData->scrollbarDragAxis = axis + 1;
+                scrollData->pointerOrigin = pointer.position;
+                scrollData->scrollOrigin = scrollData->scrollPosition;
+                scrollData->scrollAnimationActive = false;
+            } else {
+                // Page by one viewport towards the pointer, continuing from any page that is still animating
+                Clay_Vector2 target = scrollData->scrollAnimationActive ? scrollData->scrollTarget : scrollData->scrollPosition;
+                Clay_Dimensions viewport = Clay__ScrollViewportSize(scrollData);
+                if (vertical) {
+                    target.y += pointer.position.y < thumb.y ? viewport.height : -viewport.height;
+                } else {
+                    target.x += pointer.position.x < thumb.x ? viewport.width : -viewport.width;
+                }
+                scrollData->scrollTarget = Clay__ClampScrollPosition(scrollData, target);
+                scrollData->scrollAnimationActive = true;
+            }
+        } else if (scrollData->scrollbarDragAxis == axis + 1) {
+            // The thumb moves with the pointer, so the scroll position moves by the pointer delta scaled from track space to content space
+            Clay_Dimensions viewport = Clay__ScrollViewportSize(scrollData);
+            if (vertical && track.height > thumb.height) {
+                scrollData->scrollPosition.y = scrollData->scrollOrigin.y - (pointer.position.y - scrollData->pointerOrigin.y) * (scrollData->contentSize.height - viewport.height) / (track.height - thumb.height);
+            } else if (!vertical && track.width > thumb.width) {
+                scrollData->scrollPosition.x = scrollData->scrollOrigin.x - (pointer.position.x - scrollData->pointerOrigin.x) * (scrollData->contentSize.width - viewport.width) / (track.width - thumb.width);
+            }
+            scrollData->scrollPosition = Clay__ClampScrollPosition(scrollData, scrollData->scrollPosition);
+        }
+    }
+
+    bool moved = scrollData->scrollPosition.x != scrollData->scrollbarPreviousPosition.x || scrollData->scrollPosition.y != scrollData->scrollbarPreviousPosition.y;
+    scrollData->scrollbarPreviousPosition = scrollData->scrollPosition;
+    scrollData->scrollbarIdleTime = (moved || hovered || scrollData->scrollbarPressed) ? 0 : scrollData->scrollbarIdleTime + deltaTime;
+    return scrollData->scrollbarPressed;
+}
+
+// Returns the overlap of two boxes. The width or height is negative if they don't overlap.
+Clay_BoundingBox Clay__IntersectBoundingBoxes(Clay_BoundingBox a, Clay_BoundingBox b) {
+    float x = CLAY__MAX(a.x, b.x);
+    float y = CLAY__MAX(a.y, b.y);
+    return CLAY__INIT(Clay_BoundingBox) { x, y, CLAY__MIN(a.x + a.width, b.x + b.width) - x, CLAY__MIN(a.y + a.height, b.y + b.height) - y };
+}
+
+Clay_ElementVisibility Clay__CalculateVisibility(Clay_BoundingBox boundingBox, Clay_BoundingBox clipBoundingBox) {
+    Clay_BoundingBox visibleBoundingBox = Clay__IntersectBoundingBoxes(boundingBox, clipBoundingBox);
+    float area = boundingBox.width * boundingBox.height;
+    // Elements without an area are visible as long as they are inside the clip region, others need some of their area to be
+    float visibleFraction = area > 0 ? (visibleBoundingBox.width * visibleBoundingBox.height) / area : 1;
+    if (visibleBoundingBox.width < 0 || visibleBoundingBox.height < 0 || visibleFraction <= 0) {
+        return CLAY__INIT(Clay_ElementVisibility) { .found = true };
+    }
+    return CLAY__INIT(Clay_ElementVisibility) { .visibleBoundingBox = visibleBoundingBox, .visibleFraction = visibleFraction, .visible = visibleFraction > 0, .found = true };
+}
+
+// Reports appearance, disappearance and visibility changes to the elements that bound Clay_OnLifecycle, once the layout is final.
+// Elements that weren't declared in this layout lose their visibility.
+void Clay__DispatchLifecycleEvents(void) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t i = 0; i < context->layoutElementsHashMapInternal.length; ++i) {
+        Clay_LayoutElementHashMapItem *hashItem = Clay__LayoutElementHashMapItemArray_Get(&context->layoutElementsHashMapInternal, i);
+        if (hashItem->generation <= context->generation) {
+            hashItem->visibility = CLAY__INIT(Clay_ElementVisibility) CLAY__DEFAULT_STRUCT;
+        }
+        if (!hashItem->onLifecycleFunction) {
+            continue;
+        }
+        if (hashItem->generation == context->generation) {
+            // Declared in the previous layout but not this one
+            hashItem->onLifecycleFunction(hashItem->elementId, CLAY_LIFECYCLE_EVENT_DISAPPEARED, hashItem->visibility, hashItem->lifecycleFunctionUserData);
+            hashItem->onLifecycleFunction = NULL;
+        } else if (hashItem->generation == context->generation + 1) {
+            Clay_ElementVisibility visibility = hashItem->visibility;
+            if (hashItem->appeared) {
+                hashItem->onLifecycleFunction(hashItem->elementId, CLAY_LIFECYCLE_EVENT_APPEARED, visibility, hashItem->lifecycleFunctionUserData);
+            } else if (visibility.visible != hashItem->previousVisibility.visible || visibility.visibleFraction != hashItem->previousVisibility.visibleFraction) {
+                hashItem->onLifecycleFunction(hashItem->elementId, CLAY_LIFECYCLE_EVENT_VISIBILITY_CHANGED, visibility, hashItem->lifecycleFunctionUserData);
+            }
+        }
+    }
+}
+
+void Clay__CalculateFinalLayout(void) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay__BeginTransitions();
+    // Calculate sizing along the X axis
+    Clay__SizeContainersAlongAxis(true);
+
+    // Wrap text
+    for (int32_t textElementIndex = 0; textElementIndex < context->textElementData.length; ++textElementIndex) {
+        Clay__TextElementData *textElementData = Clay__TextElementDataArray_Get(&context->textElementData, textElementIndex);
+        textElementData->wrappedLines = CLAY__INIT(Clay__WrappedTextLineArraySlice) { .length = 0, .internalArray = &context->wrappedTextLines.internalArray[context->wrappedTextLines.length] };
+        Clay_LayoutElement *containerElement = Clay_LayoutElementArray_Get(&context->layoutElements, (int)textElementData->elementIndex);
+        Clay_TextElementConfig *textConfig = Clay__FindElementConfigWithType(containerElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT).textElementConfig;
+        Clay__MeasureTextCacheItem *measureTextCacheItem = Clay__MeasureTextCached(&textElementData->text, textConfig);
+        float lineWidth = 0;
+        float lineHeight = textConfig->lineHeight > 0 ? (float)textConfig->lineHeight : textElementData->preferredDimensions.height;
+        int32_t lineLengthChars = 0;
+        int32_t lineStartOffset = 0;
+        if (!measureTextCacheItem->containsNewlines && textElementData->preferredDimensions.width <= containerElement->dimensions.width) {
+            Clay__WrappedTextLineArray_Add(&context->wrappedTextLines, CLAY__INIT(Clay__WrappedTextLine) { containerElement->dimensions,  textElementData->text });
+            textElementData->wrappedLines.length++;
+            continue;
+        }
+        float spaceWidth = Clay__MeasureText(CLAY__INIT(Clay_StringSlice) { .length = 1, .chars = CLAY__SPACECHAR.chars, .baseChars = CLAY__SPACECHAR.chars }, textConfig, context->measureTextUserData).width;
+        int32_t wordIndex = measureTextCacheItem->measuredWordsStartIndex;
+        while (wordIndex != -1) {
+            if (context->wrappedTextLines.length > context->wrappedTextLines.capacity - 1) {
+                break;
+            }
+            Clay__MeasuredWord *measuredWord = Clay__MeasuredWordArray_Get(&context->measuredWords, wordIndex);
+            // Only word on the line is too large, just render it anyway
+            if (lineLengthChars == 0 && lineWidth + measuredWord->width > containerElement->dimensions.width) {
+                Clay__WrappedTextLineArray_Add(&context->wrappedTextLines, CLAY__INIT(Clay__WrappedTextLine) { { measuredWord->width, lineHeight }, { .length = measuredWord->length, .chars = &textElementData->text.chars[measuredWord->startOffset] } });
+                textElementData->wrappedLines.length++;
+                wordIndex = measuredWord->next;
+                lineStartOffset = measuredWord->startOffset + measuredWord->length;
+            }
+            // measuredWord->length == 0 means a newline character
+            else if (measuredWord->length == 0 || lineWidth + measuredWord->width > containerElement->dimensions.width) {
+                // Wrapped text lines list has overflowed, just render out the line
+                bool finalCharIsSpace = textElementData->text.chars[CLAY__MAX(lineStartOffset + lineLengthChars - 1, 0)] == ' ';
+                Clay__WrappedTextLineArray_Add(&context->wrappedTextLines, CLAY__INIT(Clay__WrappedTextLine) { { lineWidth + (finalCharIsSpace ? -spaceWidth : 0), lineHeight }, { .length = lineLengthChars + (finalCharIsSpace ? -1 : 0), .chars = &textElementData->text.chars[lineStartOffset] } });
+                textElementData->wrappedLines.length++;
+                if (lineLengthChars == 0 || measuredWord->length == 0) {
+                    wordIndex = measuredWord->next;
+                }
+                lineWidth = 0;
+                lineLengthChars = 0;
+                lineStartOffset = measuredWord->startOffset;
+            } else {
+                lineWidth += measuredWord->width + textConfig->letterSpacing;
+                lineLengthChars += measuredWord->length;
+                wordIndex = measuredWord->next;
+            }
+        }
+        if (lineLengthChars > 0) {
+            Clay__WrappedTextLineArray_Add(&context->wrappedTextLines, CLAY__INIT(Clay__WrappedTextLine) { { lineWidth - textConfig->letterSpacing, lineHeight }, {.length = lineLengthChars, .chars = &textElementData->text.chars[lineStartOffset] } });
+            textElementData->wrappedLines.length++;
+        }
+        containerElement->dimensions.height = lineHeight * (float)textElementData->wrappedLines.length;
+    }
+
+    // Scale vertical heights according to aspect ratio
+    for (int32_t i = 0; i < context->aspectRatioElementIndexes.length; ++i) {
+        Clay_LayoutElement* aspectElement = Clay_LayoutElementArray_Get(&context->layoutElements, Clay__int32_tArray_GetValue(&context->aspectRatioElementIndexes, i));
+        Clay_AspectRatioElementConfig *config = Clay__FindElementConfigWithType(aspectElement, CLAY__ELEMENT_CONFIG_TYPE_ASPECT).aspectRatioElementConfig;
+        aspectElement->dimensions.height = (1 / config->aspectRatio) * aspectElement->dimensions.width;
+        aspectElement->layoutConfig->sizing.height.size.minMax.max = aspectElement->dimensions.height;
+    }
+
+    // Propagate effect of text wrapping, aspect scaling etc. on height of parents
+    Clay__LayoutElementTreeNodeArray dfsBuffer = context->layoutElementTreeNodeArray1;
+    dfsBuffer.length = 0;
+    for (int32_t i = 0; i < context->layoutElementTreeRoots.length; ++i) {
+        Clay__LayoutElementTreeRoot *root = Clay__LayoutElementTreeRootArray_Get(&context->layoutElementTreeRoots, i);
+        context->treeNodeVisited.internalArray[dfsBuffer.length] = false;
+        Clay__LayoutElementTreeNodeArray_Add(&dfsBuffer, CLAY__INIT(Clay__LayoutElementTreeNode) { .layoutElement = Clay_LayoutElementArray_Get(&context->layoutElements, (int)root->layoutElementIndex) });
+    }
+    while (dfsBuffer.length > 0) {
+        Clay__LayoutElementTreeNode *currentElementTreeNode = Clay__LayoutElementTreeNodeArray_Get(&dfsBuffer, (int)dfsBuffer.length - 1);
+        Clay_LayoutElement *currentElement = currentElementTreeNode->layoutElement;
+        if (!context->treeNodeVisited.internalArray[dfsBuffer.length - 1]) {
+            context->treeNodeVisited.internalArray[dfsBuffer.length - 1] = true;
+            // If the element has no children or is the container for a text element, don't bother inspecting it
+            if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT) || currentElement->childrenOrTextContent.children.length == 0) {
+                dfsBuffer.length--;
+                continue;
+            }
+            // Add the children to the DFS buffer (needs to be pushed in reverse so that stack traversal is in correct layout order)
+            for (int32_t i = 0; i < currentElement->childrenOrTextContent.children.length; i++) {
+                context->treeNodeVisited.internalArray[dfsBuffer.length] = false;
+                Clay__LayoutElementTreeNodeArray_Add(&dfsBuffer, CLAY__INIT(Clay__LayoutElementTreeNode) { .layoutElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[i]) });
+            }
+            continue;
+        }
+        dfsBuffer.length--;
+
+        // DFS node has been visited, this is on the way back up to the root
+        Clay_LayoutConfig *layoutConfig = currentElement->layoutConfig;
+        if (layoutConfig->layoutDirection == CLAY_LEFT_TO_RIGHT) {
+            // Resize any parent containers that have grown in height along their non layout axis
+            for (int32_t j = 0; j < currentElement->childrenOrTextContent.children.length; ++j) {
+                Clay_LayoutElement *childElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[j]);
+                float childHeightWithPadding = CLAY__MAX(childElement->dimensions.height + layoutConfig->padding.top + layoutConfig->padding.bottom, currentElement->dimensions.height);
+                currentElement->dimensions.height = CLAY__MIN(CLAY__MAX(childHeightWithPadding, layoutConfig->sizing.height.size.minMax.min), layoutConfig->sizing.height.size.minMax.max);
+            }
+        } else if (layoutConfig->layoutDirection == CLAY_TOP_TO_BOTTOM) {
+            // Resizing along the layout axis
+            float contentHeight = (float)(layoutConfig->padding.top + layoutConfig->padding.bottom);
+            for (int32_t j = 0; j < currentElement->childrenOrTextContent.children.length; ++j) {
+                Clay_LayoutElement *childElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[j]);
+                contentHeight += childElement->dimensions.height;
+            }
+            contentHeight += (float)(CLAY__MAX(currentElement->childrenOrTextContent.children.length - 1, 0) * layoutConfig->childGap);
+            currentElement->dimensions.height = CLAY__MIN(CLAY__MAX(contentHeight, layoutConfig->sizing.height.size.minMax.min), layoutConfig->sizing.height.size.minMax.max);
+        }
+    }
+
+    // Calculate sizing along the Y axis
+    Clay__SizeContainersAlongAxis(false);
+
+    // Scale horizontal widths according to aspect ratio
+    for (int32_t i = 0; i < context->aspectRatioElementIndexes.length; ++i) {
         Clay_LayoutElement* aspectElement = Clay_LayoutElementArray_Get(&context->layoutElements, Clay__int32_tArray_GetValue(&context->aspectRatioElementIndexes, i));
         Clay_AspectRatioElementConfig *config = Clay__FindElementConfigWithType(aspectElement, CLAY__ELEMENT_CONFIG_TYPE_ASPECT).aspectRatioElementConfig;
         aspectElement->dimensions.width = config->aspectRatio * aspectElement->dimensions.height;
@@ -2668,61 +4349,33 @@ void Clay__CalculateFinalLayout(void) {
         Clay__LayoutElementTreeRoot *root = Clay__LayoutElementTreeRootArray_Get(&context->layoutElementTreeRoots, rootIndex);
         Clay_LayoutElement *rootElement = Clay_LayoutElementArray_Get(&context->layoutElements, (int)root->layoutElementIndex);
         Clay_Vector2 rootPosition = CLAY__DEFAULT_STRUCT;
+        Clay_Vector2 rootTransformOffset = CLAY__DEFAULT_STRUCT;
+        float rootTransformScale = 1;
+        Clay_Matrix2D rootTransform = Clay__Matrix2D_IDENTITY;
+        Clay_BoundingBox rootClipBoundingBox = { 0, 0, context->layoutDimensions.width, context->layoutDimensions.height };
         Clay_LayoutElementHashMapItem *parentHashMapItem = Clay__GetHashMapItem(root->parentId);
         // Position root floating containers
         if (Clay__ElementHasConfig(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING) && parentHashMapItem) {
             Clay_FloatingElementConfig *config = Clay__FindElementConfigWithType(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING).floatingElementConfig;
             Clay_Dimensions rootDimensions = rootElement->dimensions;
             Clay_BoundingBox parentBoundingBox = parentHashMapItem->boundingBox;
-            // Set X position
-            Clay_Vector2 targetAttachPosition = CLAY__DEFAULT_STRUCT;
-            switch (config->attachPoints.parent) {
-                case CLAY_ATTACH_POINT_LEFT_TOP:
-                case CLAY_ATTACH_POINT_LEFT_CENTER:
-                case CLAY_ATTACH_POINT_LEFT_BOTTOM: targetAttachPosition.x = parentBoundingBox.x; break;
-                case CLAY_ATTACH_POINT_CENTER_TOP:
-                case CLAY_ATTACH_POINT_CENTER_CENTER:
-                case CLAY_ATTACH_POINT_CENTER_BOTTOM: targetAttachPosition.x = parentBoundingBox.x + (parentBoundingBox.width / 2); break;
-                case CLAY_ATTACH_POINT_RIGHT_TOP:
-                case CLAY_ATTACH_POINT_RIGHT_CENTER:
-                case CLAY_ATTACH_POINT_RIGHT_BOTTOM: targetAttachPosition.x = parentBoundingBox.x + parentBoundingBox.width; break;
-            }
-            switch (config->attachPoints.element) {
-                case CLAY_ATTACH_POINT_LEFT_TOP:
-                case CLAY_ATTACH_POINT_LEFT_CENTER:
-                case CLAY_ATTACH_POINT_LEFT_BOTTOM: break;
-                case CLAY_ATTACH_POINT_CENTER_TOP:
-                case CLAY_ATTACH_POINT_CENTER_CENTER:
-                case CLAY_ATTACH_POINT_CENTER_BOTTOM: targetAttachPosition.x -= (rootDimensions.width / 2); break;
-                case CLAY_ATTACH_POINT_RIGHT_TOP:
-                case CLAY_ATTACH_POINT_RIGHT_CENTER:
-                case CLAY_ATTACH_POINT_RIGHT_BOTTOM: targetAttachPosition.x -= rootDimensions.width; break;
+            // Floating elements inside a zoom-pan container are positioned in layout space and share their parent's transform
+            if (parentHashMapItem->transformScale > 0) {
+                rootTransformOffset = parentHashMapItem->transformOffset;
+                rootTransformScale = parentHashMapItem->transformScale;
+                parentBoundingBox = CLAY__INIT(Clay_BoundingBox) {
+                    (parentBoundingBox.x - rootTransformOffset.x) / rootTransformScale,
+                    (parentBoundingBox.y - rootTransformOffset.y) / rootTransformScale,
+                    parentBoundingBox.width / rootTransformScale,
+                    parentBoundingBox.height / rootTransformScale,
+                };
             }
-            switch (config->attachPoints.parent) { // I know I could merge the x and y switch statements, but this is easier to read
-                case CLAY_ATTACH_POINT_LEFT_TOP:
-                case CLAY_ATTACH_POINT_RIGHT_TOP:
-                case CLAY_ATTACH_POINT_CENTER_TOP: targetAttachPosition.y = parentBoundingBox.y; break;
-                case CLAY_ATTACH_POINT_LEFT_CENTER:
-                case CLAY_ATTACH_POINT_CENTER_CENTER:
-                case CLAY_ATTACH_POINT_RIGHT_CENTER: targetAttachPosition.y = parentBoundingBox.y + (parentBoundingBox.height / 2); break;
-                case CLAY_ATTACH_POINT_LEFT_BOTTOM:
-                case CLAY_ATTACH_POINT_CENTER_BOTTOM:
-                case CLAY_ATTACH_POINT_RIGHT_BOTTOM: targetAttachPosition.y = parentBoundingBox.y + parentBoundingBox.height; break;
+            // Floating elements are drawn with the transform of the element they're attached to
+            rootTransform = parentHashMapItem->transform;
+            rootPosition = Clay__FloatingAttachPosition(config->attachPoints, config->offset, parentBoundingBox, rootDimensions);
+            if (config->collision != CLAY_FLOATING_COLLISION_NONE) {
+                rootPosition = Clay__ResolveFloatingCollision(config, parentHashMapItem, parentBoundingBox, rootDimensions, rootPosition, rootTransformOffset, rootTransformScale);
             }
-            switch (config->attachPoints.element) {
-                case CLAY_ATTACH_POINT_LEFT_TOP:
-                case CLAY_ATTACH_POINT_RIGHT_TOP:
-                case CLAY_ATTACH_POINT_CENTER_TOP: break;
-                case CLAY_ATTACH_POINT_LEFT_CENTER:
-                case CLAY_ATTACH_POINT_CENTER_CENTER:
-                case CLAY_ATTACH_POINT_RIGHT_CENTER: targetAttachPosition.y -= (rootDimensions.height / 2); break;
-                case CLAY_ATTACH_POINT_LEFT_BOTTOM:
-                case CLAY_ATTACH_POINT_CENTER_BOTTOM:
-                case CLAY_ATTACH_POINT_RIGHT_BOTTOM: targetAttachPosition.y -= rootDimensions.height; break;
-            }
-            targetAttachPosition.x += config->offset.x;
-            targetAttachPosition.y += config->offset.y;
-            rootPosition = targetAttachPosition;
         }
         if (root->clipElementId) {
             Clay_LayoutElementHashMapItem *clipHashMapItem = Clay__GetHashMapItem(root->clipElementId);
@@ -2737,8 +4390,21 @@ void Clay__CalculateFinalLayout(void) {
                         rootPosition.y += clipConfig->childOffset.y;
                     }
                 }
+                if (clipHashMapItem->visibility.visible) {
+                    rootClipBoundingBox = clipHashMapItem->visibility.visibleBoundingBox;
+                } else {
+                    rootClipBoundingBox.width = -1;
+                }
+                // Floating elements are clipped to the rounded corners of the element they're clipped by
+                Clay_CornerRadius clipCornerRadius = CLAY__DEFAULT_STRUCT;
+                if (Clay__ElementHasConfig(clipHashMapItem->layoutElement, CLAY__ELEMENT_CONFIG_TYPE_SHARED)) {
+                    float clipTransformScale = clipHashMapItem->transformScale > 0 ? clipHashMapItem->transformScale : 1;
+                    clipCornerRadius = Clay__ScaleCornerRadius(Clay__FindElementConfigWithType(clipHashMapItem->layoutElement, CLAY__ELEMENT_CONFIG_TYPE_SHARED).sharedElementConfig->cornerRadius, clipTransformScale);
+                }
                 Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
                     .boundingBox = clipHashMapItem->boundingBox,
+                    .renderData = { .clip = { .cornerRadius = clipCornerRadius } },
+                    .transform = clipHashMapItem->transform,
                     .userData = 0,
                     .id = Clay__HashNumber(rootElement->id, rootElement->childrenOrTextContent.children.length + 10).id, // TODO need a better strategy for managing derived ids
                     .zIndex = root->zIndex,
@@ -2746,7 +4412,8 @@ void Clay__CalculateFinalLayout(void) {
                 });
             }
         }
-        Clay__LayoutElementTreeNodeArray_Add(&dfsBuffer, CLAY__INIT(Clay__LayoutElementTreeNode) { .layoutElement = rootElement, .position = rootPosition, .nextChildOffset = { .x = (float)rootElement->layoutConfig->padding.left, .y = (float)rootElement->layoutConfig->padding.top } });
+        Clay__LayoutElementTreeNodeArray_Add(&dfsBuffer, CLAY__INIT(Clay__LayoutElementTreeNode) { .layoutElement = rootElement, .position = rootPosition, .nextChildOffset = { .x = (float)rootElement->layoutConfig->padding.left, .y = (float)rootElement->layoutConfig->padding.top }, .transformOffset = rootTransformOffset, .transformScale = rootTransformScale, .transform = rootTransform, .parentOrigin = rootPosition, .clipBoundingBox = rootClipBoundingBox });
+        int32_t rootRenderCommandStart = context->renderCommands.length;
 
         context->treeNodeVisited.internalArray[0] = false;
         while (dfsBuffer.length > 0) {
@@ -2768,6 +4435,59 @@ void Clay__CalculateFinalLayout(void) {
                     currentElementBoundingBox.y -= expand.height;
                     currentElementBoundingBox.height += expand.height * 2;
                 }
+                // Transitions animate the rendered box towards the laid out one, and children follow their parent's animated position
+                currentElementTreeNode->renderCommandStart = context->renderCommands.length;
+                currentElementTreeNode->opacity = 1;
+                currentElementBoundingBox.x += currentElementTreeNode->transitionOffset.x;
+                currentElementBoundingBox.y += currentElementTreeNode->transitionOffset.y;
+                currentElementTreeNode->childTransitionOffset = currentElementTreeNode->transitionOffset;
+                Clay__TransitionDataInternal *transitionData = Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TRANSITION) ? Clay__GetTransitionData(currentElement->id) : CLAY__NULL;
+                if (transitionData) {
+                    Clay_SharedElementConfig *targetSharedConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_SHARED).sharedElementConfig;
+                    Clay_Vector2 origin = { currentElementTreeNode->parentOrigin.x + currentElementTreeNode->transitionOffset.x, currentElementTreeNode->parentOrigin.y + currentElementTreeNode->transitionOffset.y };
+                    Clay__UpdateTransition(transitionData,
+                        CLAY__INIT(Clay_BoundingBox) { currentElementBoundingBox.x - origin.x, currentElementBoundingBox.y - origin.y, currentElementBoundingBox.width, currentElementBoundingBox.height },
+                        targetSharedConfig ? targetSharedConfig->backgroundColor : CLAY__INIT(Clay_Color) CLAY__DEFAULT_STRUCT,
+                        (transitionData->config.properties & CLAY_TRANSITION_PROPERTY_OPACITY) && transitionData->config.opacity > 0 ? transitionData->config.opacity : 1);
+                    Clay_Vector2 position = Clay__TransitionPosition(transitionData);
+                    Clay_Dimensions size = Clay__TransitionSize(transitionData);
+                    currentElementTreeNode->childTransitionOffset.x += origin.x + position.x - currentElementBoundingBox.x;
+                    currentElementTreeNode->childTransitionOffset.y += origin.y + position.y - currentElementBoundingBox.y;
+                    currentElementBoundingBox = CLAY__INIT(Clay_BoundingBox) { origin.x + position.x, origin.y + position.y, size.width, size.height };
+                    currentElementTreeNode->opacity = Clay__TransitionOpacity(transitionData);
+                }
+                // Elements inside zoom-pan containers are laid out in canvas space and transformed onto the screen here
+                float transformScale = currentElementTreeNode->transformScale;
+                currentElementBoundingBox = CLAY__INIT(Clay_BoundingBox) {
+                    currentElementBoundingBox.x * transformScale + currentElementTreeNode->transformOffset.x,
+                    currentElementBoundingBox.y * transformScale + currentElementTreeNode->transformOffset.y,
+                    currentElementBoundingBox.width * transformScale,
+                    currentElementBoundingBox.height * transformScale,
+                };
+                // Transition opacity fades everything the element and its children render as a single group, like group opacity
+                if (currentElementTreeNode->opacity < 1) {
+                    Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+                        .boundingBox = currentElementBoundingBox,
+                        .renderData = { .layer = { .opacity = CLAY__MAX(currentElementTreeNode->opacity, 0) } },
+                        .id = Clay__HashNumber(currentElement->id, 16).id,
+                        .zIndex = root->zIndex,
+                        .commandType = CLAY_RENDER_COMMAND_TYPE_LAYER_START,
+                    });
+                }
+                if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_ZOOM_PAN)) {
+                    Clay__ZoomPanDataInternal *zoomPanData = Clay__GetZoomPanDataForElement(currentElement);
+                    if (zoomPanData) {
+                        zoomPanData->boundingBox = currentElementBoundingBox;
+                        zoomPanData->parentScale = transformScale;
+                    }
+                }
+                // Visual transforms are applied on top of the final box, after those of the element's ancestors
+                Clay_TransformElementConfig *transformConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM).transformElementConfig;
+                if (transformConfig) {
+                    currentElementTreeNode->transform = Clay__MultiplyMatrices(currentElementTreeNode->transform, Clay__ElementTransform(transformConfig, currentElementBoundingBox, transformScale));
+                }
+                bool transformed = !Clay__MatrixIsIdentity(currentElementTreeNode->transform);
+                Clay_BoundingBox cullingBoundingBox = Clay__TransformBoundingBox(currentElementTreeNode->transform, currentElementBoundingBox);
 
                 Clay__ScrollContainerDataInternal *scrollContainerData = CLAY__NULL;
                 // Apply scroll offsets to container
@@ -2780,6 +4500,7 @@ void Clay__CalculateFinalLayout(void) {
                         if (mapping->layoutElement == currentElement) {
                             scrollContainerData = mapping;
                             mapping->boundingBox = currentElementBoundingBox;
+                            mapping->scale = transformScale;
                             scrollOffset = clipConfig->childOffset;
                             if (context->externalScrollHandlingEnabled) {
                                 scrollOffset = CLAY__INIT(Clay_Vector2) CLAY__DEFAULT_STRUCT;
@@ -2792,6 +4513,15 @@ void Clay__CalculateFinalLayout(void) {
                 Clay_LayoutElementHashMapItem *hashMapItem = Clay__GetHashMapItem(currentElement->id);
                 if (hashMapItem) {
                     hashMapItem->boundingBox = currentElementBoundingBox;
+                    hashMapItem->transformOffset = currentElementTreeNode->transformOffset;
+                    hashMapItem->transformScale = transformScale;
+                    hashMapItem->transform = currentElementTreeNode->transform;
+                    hashMapItem->previousVisibility = hashMapItem->visibility;
+                    hashMapItem->visibility = Clay__CalculateVisibility(currentElementBoundingBox, currentElementTreeNode->clipBoundingBox);
+                }
+                // Children of clip containers are clipped to the visible part of the container
+                if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_CLIP)) {
+                    currentElementTreeNode->clipBoundingBox = Clay__IntersectBoundingBoxes(currentElementBoundingBox, currentElementTreeNode->clipBoundingBox);
                 }
 
                 int32_t sortedConfigIndexes[20];
@@ -2816,13 +4546,41 @@ void Clay__CalculateFinalLayout(void) {
                 bool emitRectangle = false;
                 // Create the render commands for this element
                 Clay_SharedElementConfig *sharedConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_SHARED).sharedElementConfig;
-                if (sharedConfig && sharedConfig->backgroundColor.a > 0) {
+                if (sharedConfig && (sharedConfig->backgroundColor.a > 0 || sharedConfig->backgroundGradient.type != CLAY_GRADIENT_TYPE_NONE)) {
                    emitRectangle = true;
                 }
                 else if (!sharedConfig) {
                     emitRectangle = false;
                     sharedConfig = &Clay_SharedElementConfig_DEFAULT;
                 }
+                Clay_SharedElementConfig transitionSharedConfig;
+                if (transitionData) {
+                    transitionSharedConfig = *sharedConfig;
+                    transitionSharedConfig.backgroundColor = Clay__TransitionColor(transitionData);
+                    sharedConfig = &transitionSharedConfig;
+                    emitRectangle = transitionSharedConfig.backgroundColor.a > 0 || transitionSharedConfig.backgroundGradient.type != CLAY_GRADIENT_TYPE_NONE;
+                }
+                // Everything the element and its children render is drawn into a layer, composited with the group's opacity
+                Clay_OpacityElementConfig *opacityConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_OPACITY).opacityElementConfig;
+                if (opacityConfig) {
+                    Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+                        .boundingBox = currentElementBoundingBox,
+                        .renderData = { .layer = { .opacity = CLAY__MAX(opacityConfig->value, 0) } },
+                        .id = Clay__HashNumber(currentElement->id, 14).id,
+                        .zIndex = root->zIndex,
+                        .commandType = CLAY_RENDER_COMMAND_TYPE_LAYER_START,
+                    });
+                }
+                // Outer shadows are drawn behind everything else the element renders, and outside of its own clipping
+                Clay_ShadowElementConfig *shadowConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_SHADOW).shadowElementConfig;
+                Clay_RenderCommand shadowRenderCommand = CLAY__DEFAULT_STRUCT;
+                if (shadowConfig) {
+                    shadowRenderCommand = Clay__CreateShadowRenderCommand(shadowConfig, currentElementBoundingBox, sharedConfig->cornerRadius, transformScale, currentElementTreeNode->transform, currentElement->id, root->zIndex);
+                    shadowRenderCommand.userData = sharedConfig->userData;
+                }
+                if (shadowRenderCommand.commandType == CLAY_RENDER_COMMAND_TYPE_SHADOW && !shadowConfig->inset) {
+                    Clay__AddRenderCommand(shadowRenderCommand);
+                }
                 for (int32_t elementConfigIndex = 0; elementConfigIndex < currentElement->elementConfigs.length; ++elementConfigIndex) {
                     Clay_ElementConfig *elementConfig = Clay__ElementConfigArraySlice_Get(&currentElement->elementConfigs, sortedConfigIndexes[elementConfigIndex]);
                     Clay_RenderCommand renderCommand = {
@@ -2831,13 +4589,21 @@ void Clay__CalculateFinalLayout(void) {
                         .id = currentElement->id,
                     };
 
-                    bool offscreen = Clay__ElementIsOffscreen(&currentElementBoundingBox);
+                    bool offscreen = Clay__ElementIsOffscreen(&cullingBoundingBox);
                     // Culling - Don't bother to generate render commands for rectangles entirely outside the screen - this won't stop their children from being rendered if they overflow
                     bool shouldRender = !offscreen;
                     switch (elementConfig->type) {
                         case CLAY__ELEMENT_CONFIG_TYPE_ASPECT:
                         case CLAY__ELEMENT_CONFIG_TYPE_FLOATING:
                         case CLAY__ELEMENT_CONFIG_TYPE_SHARED:
+                        case CLAY__ELEMENT_CONFIG_TYPE_FOCUS:
+                        case CLAY__ELEMENT_CONFIG_TYPE_STICKY:
+                        case CLAY__ELEMENT_CONFIG_TYPE_ZOOM_PAN:
+                        case CLAY__ELEMENT_CONFIG_TYPE_TRANSITION:
+                        case CLAY__ELEMENT_CONFIG_TYPE_SHADOW:
+                        case CLAY__ELEMENT_CONFIG_TYPE_OPACITY:
+                        case CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM:
+                        case CLAY__ELEMENT_CONFIG_TYPE_PATH:
                         case CLAY__ELEMENT_CONFIG_TYPE_BORDER: {
                             shouldRender = false;
                             break;
@@ -2848,6 +4614,7 @@ void Clay__CalculateFinalLayout(void) {
                                 .clip = {
                                     .horizontal = elementConfig->config.clipElementConfig->horizontal,
                                     .vertical = elementConfig->config.clipElementConfig->vertical,
+                                    .cornerRadius = Clay__ScaleCornerRadius(sharedConfig->cornerRadius, transformScale),
                                 }
                             };
                             break;
@@ -2857,8 +4624,17 @@ void Clay__CalculateFinalLayout(void) {
                             renderCommand.renderData = CLAY__INIT(Clay_RenderData) {
                                 .image = {
                                     .backgroundColor = sharedConfig->backgroundColor,
-                                    .cornerRadius = sharedConfig->cornerRadius,
+                                    .cornerRadius = Clay__ScaleCornerRadius(sharedConfig->cornerRadius, transformScale),
                                     .imageData = elementConfig->config.imageElementConfig->imageData,
+                                    .fit = elementConfig->config.imageElementConfig->fit,
+                                    .alignment = elementConfig->config.imageElementConfig->alignment,
+                                    .sourceRect = elementConfig->config.imageElementConfig->sourceRect,
+                                    .intrinsicSize = {
+                                        elementConfig->config.imageElementConfig->intrinsicSize.width * transformScale,
+                                        elementConfig->config.imageElementConfig->intrinsicSize.height * transformScale,
+                                    },
+                                    .nineSlice = elementConfig->config.imageElementConfig->nineSlice,
+                                    .nineSliceScale = transformScale,
                                }
                             };
                             emitRectangle = false;
@@ -2881,7 +4657,7 @@ void Clay__CalculateFinalLayout(void) {
                                     yPosition += finalLineHeight;
                                     continue;
                                 }
-                                float offset = (currentElementBoundingBox.width - wrappedLine->dimensions.width);
+                                float offset = (currentElement->dimensions.width - wrappedLine->dimensions.width);
                                 if (textElementConfig->textAlignment == CLAY_TEXT_ALIGN_LEFT) {
                                     offset = 0;
                                 }
@@ -2889,14 +4665,14 @@ void Clay__CalculateFinalLayout(void) {
                                     offset /= 2;
                                 }
                                 Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
-                                    .boundingBox = { currentElementBoundingBox.x + offset, currentElementBoundingBox.y + yPosition, wrappedLine->dimensions.width, wrappedLine->dimensions.height },
+                                    .boundingBox = { currentElementBoundingBox.x + offset * transformScale, currentElementBoundingBox.y + yPosition * transformScale, wrappedLine->dimensions.width * transformScale, wrappedLine->dimensions.height * transformScale },
                                     .renderData = { .text = {
                                         .stringContents = CLAY__INIT(Clay_StringSlice) { .length = wrappedLine->line.length, .chars = wrappedLine->line.chars, .baseChars = currentElement->childrenOrTextContent.textElementData->text.chars },
                                         .textColor = textElementConfig->textColor,
                                         .fontId = textElementConfig->fontId,
-                                        .fontSize = textElementConfig->fontSize,
-                                        .letterSpacing = textElementConfig->letterSpacing,
-                                        .lineHeight = textElementConfig->lineHeight,
+                                        .fontSize = Clay__ScaleUInt16(textElementConfig->fontSize, transformScale),
+                                        .letterSpacing = Clay__ScaleUInt16(textElementConfig->letterSpacing, transformScale),
+                                        .lineHeight = Clay__ScaleUInt16(textElementConfig->lineHeight, transformScale),
                                     }},
                                     .userData = textElementConfig->userData,
                                     .id = Clay__HashNumber(lineIndex, currentElement->id).id,
@@ -2905,7 +4681,7 @@ void Clay__CalculateFinalLayout(void) {
                                 });
                                 yPosition += finalLineHeight;
 
-                                if (!context->disableCulling && (currentElementBoundingBox.y + yPosition > context->layoutDimensions.height)) {
+                                if (!context->disableCulling && !transformed && (currentElementBoundingBox.y + yPosition * transformScale > context->layoutDimensions.height)) {
                                     break;
                                 }
                             }
@@ -2916,7 +4692,7 @@ void Clay__CalculateFinalLayout(void) {
                             renderCommand.renderData = CLAY__INIT(Clay_RenderData) {
                                 .custom = {
                                     .backgroundColor = sharedConfig->backgroundColor,
-                                    .cornerRadius = sharedConfig->cornerRadius,
+                                    .cornerRadius = Clay__ScaleCornerRadius(sharedConfig->cornerRadius, transformScale),
                                     .customData = elementConfig->config.customElementConfig->customData,
                                 }
                             };
@@ -2940,7 +4716,8 @@ void Clay__CalculateFinalLayout(void) {
                         .boundingBox = currentElementBoundingBox,
                         .renderData = { .rectangle = {
                                 .backgroundColor = sharedConfig->backgroundColor,
-                                .cornerRadius = sharedConfig->cornerRadius,
+                                .cornerRadius = Clay__ScaleCornerRadius(sharedConfig->cornerRadius, transformScale),
+                                .gradient = sharedConfig->backgroundGradient,
                         }},
                         .userData = sharedConfig->userData,
                         .id = currentElement->id,
@@ -2948,6 +4725,29 @@ void Clay__CalculateFinalLayout(void) {
                         .commandType = CLAY_RENDER_COMMAND_TYPE_RECTANGLE,
                     });
                 }
+                // Inset shadows are drawn over the background, beneath the children and border
+                if (shadowRenderCommand.commandType == CLAY_RENDER_COMMAND_TYPE_SHADOW && shadowConfig->inset) {
+                    Clay__AddRenderCommand(shadowRenderCommand);
+                }
+                // Paths are drawn over the background and inset shadow, beneath the children and border
+                Clay_PathElementConfig *pathConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_PATH).pathElementConfig;
+                if (pathConfig && !Clay__ElementIsOffscreen(&cullingBoundingBox)) {
+                    Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+                        .boundingBox = currentElementBoundingBox,
+                        .renderData = { .path = {
+                                .commands = pathConfig->commands,
+                                .fillColor = pathConfig->fillColor,
+                                .strokeColor = pathConfig->strokeColor,
+                                .strokeWidth = pathConfig->strokeWidth * transformScale,
+                                .join = pathConfig->join,
+                                .scale = transformScale,
+                        }},
+                        .userData = sharedConfig->userData,
+                        .id = currentElement->id,
+                        .zIndex = root->zIndex,
+                        .commandType = CLAY_RENDER_COMMAND_TYPE_PATH,
+                    });
+                }
 
                 // Setup initial on-axis alignment
                 if (!Clay__ElementHasConfig(currentElementTreeNode->layoutElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT)) {
@@ -2993,11 +4793,13 @@ void Clay__CalculateFinalLayout(void) {
                 // DFS is returning upwards backwards
                 bool closeClipElement = false;
                 Clay_ClipElementConfig *clipConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_CLIP).clipElementConfig;
+                Clay__ScrollContainerDataInternal *closingScrollData = CLAY__NULL;
                 if (clipConfig) {
                     closeClipElement = true;
                     for (int32_t i = 0; i < context->scrollContainerDatas.length; i++) {
                         Clay__ScrollContainerDataInternal *mapping = Clay__ScrollContainerDataInternalArray_Get(&context->scrollContainerDatas, i);
                         if (mapping->layoutElement == currentElement) {
+                            closingScrollData = mapping;
                             scrollOffset = clipConfig->childOffset;
                             if (context->externalScrollHandlingEnabled) {
                                 scrollOffset = CLAY__INIT(Clay_Vector2) CLAY__DEFAULT_STRUCT;
@@ -3007,20 +4809,41 @@ void Clay__CalculateFinalLayout(void) {
                     }
                 }
 
+                if (context->exitingTransitionCount > 0) {
+                    Clay__AddExitingTransitionCommands(currentElement->id, root->zIndex);
+                }
                 if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_BORDER)) {
                     Clay_LayoutElementHashMapItem *currentElementData = Clay__GetHashMapItem(currentElement->id);
                     Clay_BoundingBox currentElementBoundingBox = currentElementData->boundingBox;
+                    Clay_BoundingBox cullingBoundingBox = Clay__TransformBoundingBox(currentElementData->transform, currentElementBoundingBox);
 
                     // Culling - Don't bother to generate render commands for rectangles entirely outside the screen - this won't stop their children from being rendered if they overflow
-                    if (!Clay__ElementIsOffscreen(&currentElementBoundingBox)) {
+                    if (!Clay__ElementIsOffscreen(&cullingBoundingBox)) {
                         Clay_SharedElementConfig *sharedConfig = Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_SHARED) ? Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_SHARED).sharedElementConfig : &Clay_SharedElementConfig_DEFAULT;
                         Clay_BorderElementConfig *borderConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_BORDER).borderElementConfig;
+                        float transformScale = currentElementTreeNode->transformScale;
                         Clay_RenderCommand renderCommand = {
                                 .boundingBox = currentElementBoundingBox,
                                 .renderData = { .border = {
                                     .color = borderConfig->color,
-                                    .cornerRadius = sharedConfig->cornerRadius,
-                                    .width = borderConfig->width
+                                    .cornerRadius = Clay__ScaleCornerRadius(sharedConfig->cornerRadius, transformScale),
+                                    .width = {
+                                        Clay__ScaleUInt16(borderConfig->width.left, transformScale),
+                                        Clay__ScaleUInt16(borderConfig->width.right, transformScale),
+                                        Clay__ScaleUInt16(borderConfig->width.top, transformScale),
+                                        Clay__ScaleUInt16(borderConfig->width.bottom, transformScale),
+                                        Clay__ScaleUInt16(borderConfig->width.betweenChildren, transformScale),
+                                    },
+                                    .gradient = borderConfig->gradient,
+                                    .colors = {
+                                        Clay__BorderSideColor(borderConfig->colors.left, borderConfig->color),
+                                        Clay__BorderSideColor(borderConfig->colors.right, borderConfig->color),
+                                        Clay__BorderSideColor(borderConfig->colors.top, borderConfig->color),
+                                        Clay__BorderSideColor(borderConfig->colors.bottom, borderConfig->color),
+                                    },
+                                    .style = borderConfig->style,
+                                    .dashLength = borderConfig->dashLength * transformScale,
+                                    .gapLength = borderConfig->gapLength * transformScale,
                                 }},
                                 .userData = sharedConfig->userData,
                                 .id = Clay__HashNumber(currentElement->id, currentElement->childrenOrTextContent.children.length).id,
@@ -3035,7 +4858,7 @@ void Clay__CalculateFinalLayout(void) {
                                     Clay_LayoutElement *childElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[i]);
                                     if (i > 0) {
                                         Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
-                                            .boundingBox = { currentElementBoundingBox.x + borderOffset.x + scrollOffset.x, currentElementBoundingBox.y + scrollOffset.y, (float)borderConfig->width.betweenChildren, currentElement->dimensions.height },
+                                            .boundingBox = { currentElementBoundingBox.x + (borderOffset.x + scrollOffset.x) * transformScale, currentElementBoundingBox.y + scrollOffset.y * transformScale, (float)borderConfig->width.betweenChildren * transformScale, currentElement->dimensions.height * transformScale },
                                             .renderData = { .rectangle = {
                                                 .backgroundColor = borderConfig->color,
                                             } },
@@ -3051,7 +4874,7 @@ void Clay__CalculateFinalLayout(void) {
                                     Clay_LayoutElement *childElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[i]);
                                     if (i > 0) {
                                         Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
-                                            .boundingBox = { currentElementBoundingBox.x + scrollOffset.x, currentElementBoundingBox.y + borderOffset.y + scrollOffset.y, currentElement->dimensions.width, (float)borderConfig->width.betweenChildren },
+                                            .boundingBox = { currentElementBoundingBox.x + scrollOffset.x * transformScale, currentElementBoundingBox.y + (borderOffset.y + scrollOffset.y) * transformScale, currentElement->dimensions.width * transformScale, (float)borderConfig->width.betweenChildren * transformScale },
                                             .renderData = { .rectangle = {
                                                     .backgroundColor = borderConfig->color,
                                             } },
@@ -3066,6 +4889,10 @@ void Clay__CalculateFinalLayout(void) {
                         }
                     }
                 }
+                // Scrollbars are drawn over the content and borders, but still inside the scissor
+                if (closingScrollData && clipConfig->scrollbar.width > 0) {
+                    Clay__AddScrollbarRenderCommands(closingScrollData, clipConfig, root->zIndex);
+                }
                 // This exists because the scissor needs to end _after_ borders between elements
                 if (closeClipElement) {
                     Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
@@ -3073,6 +4900,42 @@ void Clay__CalculateFinalLayout(void) {
                         .commandType = CLAY_RENDER_COMMAND_TYPE_SCISSOR_END,
                     });
                 }
+                if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_OPACITY)) {
+                    Clay_OpacityElementConfig *opacityConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_OPACITY).opacityElementConfig;
+                    Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+                        .renderData = { .layer = { .opacity = CLAY__MAX(opacityConfig->value, 0) } },
+                        .id = Clay__HashNumber(currentElement->id, 15).id,
+                        .zIndex = root->zIndex,
+                        .commandType = CLAY_RENDER_COMMAND_TYPE_LAYER_END,
+                    });
+                }
+                // Everything the element and its children rendered is moved by its transform, on top of those of its descendants
+                if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM)) {
+                    Clay_TransformElementConfig *transformConfig = Clay__FindElementConfigWithType(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TRANSFORM).transformElementConfig;
+                    Clay_Matrix2D transform = Clay__ElementTransform(transformConfig, Clay__GetHashMapItem(currentElement->id)->boundingBox, currentElementTreeNode->transformScale);
+                    for (int32_t i = currentElementTreeNode->renderCommandStart; i < context->renderCommands.length; ++i) {
+                        Clay_RenderCommand *renderCommand = Clay_RenderCommandArray_Get(&context->renderCommands, i);
+                        renderCommand->transform = Clay__MultiplyMatrices(transform, renderCommand->transform);
+                    }
+                }
+                if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TRANSITION)) {
+                    // The snapshot leaves out the transition's own layer, the exit animation fades it with its own
+                    int32_t renderCommandStart = currentElementTreeNode->renderCommandStart + (currentElementTreeNode->opacity < 1 ? 1 : 0);
+                    int32_t renderCommandCount = context->renderCommands.length - renderCommandStart;
+                    Clay__TransitionDataInternal *transitionData = Clay__GetTransitionData(currentElement->id);
+                    if (transitionData && transitionData->config.exit.enabled) {
+                        transitionData->exitCommandsStart = Clay__SnapshotTransitionCommands(&context->renderCommands.internalArray[renderCommandStart], renderCommandCount);
+                        transitionData->exitCommandsLength = transitionData->exitCommandsStart < 0 ? 0 : renderCommandCount;
+                    }
+                    if (currentElementTreeNode->opacity < 1) {
+                        Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) {
+                            .renderData = { .layer = { .opacity = CLAY__MAX(currentElementTreeNode->opacity, 0) } },
+                            .id = Clay__HashNumber(currentElement->id, 17).id,
+                            .zIndex = root->zIndex,
+                            .commandType = CLAY_RENDER_COMMAND_TYPE_LAYER_END,
+                        });
+                    }
+                }
 
                 dfsBuffer.length--;
                 continue;
@@ -3081,6 +4944,19 @@ void Clay__CalculateFinalLayout(void) {
             // Add children to the DFS buffer
             if (!Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT)) {
                 dfsBuffer.length += currentElement->childrenOrTextContent.children.length;
+                bool hasStickyChild = false;
+                // Children of a zoom-pan container are scaled by zoom around the container's position and offset by pan, on top of any outer transform
+                Clay_Vector2 childTransformOffset = currentElementTreeNode->transformOffset;
+                float childTransformScale = currentElementTreeNode->transformScale;
+                Clay__ZoomPanDataInternal *zoomPanData = Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_ZOOM_PAN) ? Clay__GetZoomPanDataForElement(currentElement) : CLAY__NULL;
+                if (zoomPanData) {
+                    Clay_Vector2 origin = currentElementTreeNode->position;
+                    childTransformOffset = CLAY__INIT(Clay_Vector2) {
+                        (origin.x + zoomPanData->pan.x - origin.x * zoomPanData->zoom) * childTransformScale + childTransformOffset.x,
+                        (origin.y + zoomPanData->pan.y - origin.y * zoomPanData->zoom) * childTransformScale + childTransformOffset.y,
+                    };
+                    childTransformScale *= zoomPanData->zoom;
+                }
                 for (int32_t i = 0; i < currentElement->childrenOrTextContent.children.length; ++i) {
                     Clay_LayoutElement *childElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElement->childrenOrTextContent.children.elements[i]);
                     // Alignment along non layout axis
@@ -3113,6 +4989,12 @@ void Clay__CalculateFinalLayout(void) {
                         .layoutElement = childElement,
                         .position = { childPosition.x, childPosition.y },
                         .nextChildOffset = { .x = (float)childElement->layoutConfig->padding.left, .y = (float)childElement->layoutConfig->padding.top },
+                        .transformOffset = childTransformOffset,
+                        .transformScale = childTransformScale,
+                        .transform = currentElementTreeNode->transform,
+                        .parentOrigin = { currentElementTreeNode->position.x + scrollOffset.x, currentElementTreeNode->position.y + scrollOffset.y },
+                        .transitionOffset = currentElementTreeNode->childTransitionOffset,
+                        .clipBoundingBox = currentElementTreeNode->clipBoundingBox,
                     };
                     context->treeNodeVisited.internalArray[newNodeIndex] = false;
 
@@ -3122,14 +5004,25 @@ void Clay__CalculateFinalLayout(void) {
                     } else {
                         currentElementTreeNode->nextChildOffset.y += childElement->dimensions.height + (float)layoutConfig->childGap;
                     }
+                    hasStickyChild = hasStickyChild || Clay__ElementHasConfig(childElement, CLAY__ELEMENT_CONFIG_TYPE_STICKY);
+                }
+                if (hasStickyChild) {
+                    Clay__ResolveStickyChildren(&dfsBuffer, currentElementTreeNode);
                 }
             }
         }
 
+        if (!Clay__MatrixIsIdentity(rootTransform)) {
+            for (int32_t i = rootRenderCommandStart; i < context->renderCommands.length; ++i) {
+                Clay_RenderCommand *renderCommand = Clay_RenderCommandArray_Get(&context->renderCommands, i);
+                renderCommand->transform = Clay__MultiplyMatrices(rootTransform, renderCommand->transform);
+            }
+        }
         if (root->clipElementId) {
-            Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) { .id = Clay__HashNumber(rootElement->id, rootElement->childrenOrTextContent.children.length + 11).id, .commandType = CLAY_RENDER_COMMAND_TYPE_SCISSOR_END });
+            Clay__AddRenderCommand(CLAY__INIT(Clay_RenderCommand) { .transform = Clay__GetHashMapItem(root->clipElementId)->transform, .id = Clay__HashNumber(rootElement->id, rootElement->childrenOrTextContent.children.length + 11).id, .commandType = CLAY_RENDER_COMMAND_TYPE_SCISSOR_END });
         }
     }
+    Clay__EndTransitions();
 }
 
 CLAY_WASM_EXPORT("Clay_GetPointerOverIds")
@@ -3958,6 +5851,330 @@ void Clay_SetLayoutDimensions(Clay_Dimensions dimensions) {
     Clay_GetCurrentContext()->layoutDimensions = dimensions;
 }
 
+Clay__ScrollContainerDataInternal* Clay__GetScrollContainerDataInternal(uint32_t elementId) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t i = 0; i < context->scrollContainerDatas.length; i++) {
+        Clay__ScrollContainerDataInternal *scrollData = Clay__ScrollContainerDataInternalArray_Get(&context->scrollContainerDatas, i);
+        if (scrollData->elementId == elementId) {
+            return scrollData;
+        }
+    }
+    return CLAY__NULL;
+}
+
+// Returns the scroll position along one axis that places a target at offset (relative to the start of the content) at the provided alignment.
+float Clay__ScrollAxisTarget(float offset, float size, float viewportSize, float scrollPosition, Clay_ScrollAlignment alignment) {
+    switch (alignment) {
+        case CLAY_SCROLL_ALIGN_START: return -offset;
+        case CLAY_SCROLL_ALIGN_CENTER: return (viewportSize - size) / 2 - offset;
+        case CLAY_SCROLL_ALIGN_END: return viewportSize - size - offset;
+        default: {
+            if (offset + scrollPosition < 0 || size > viewportSize) {
+                return -offset;
+            }
+            if (offset + size + scrollPosition > viewportSize) {
+                return viewportSize - size - offset;
+            }
+            return scrollPosition;
+        }
+    }
+}
+
+Clay_Vector2 Clay__ClampScrollPosition(Clay__ScrollContainerDataInternal *scrollData, Clay_Vector2 scrollPosition) {
+    return CLAY__INIT(Clay_Vector2) {
+        CLAY__MAX(CLAY__MIN(scrollPosition.x, 0), -CLAY__MAX(scrollData->contentSize.width - scrollData->layoutElement->dimensions.width, 0)),
+        CLAY__MAX(CLAY__MIN(scrollPosition.y, 0), -CLAY__MAX(scrollData->contentSize.height - scrollData->layoutElement->dimensions.height, 0)),
+    };
+}
+
+// Starts an animated scroll that moves targetBox (from the last layout, in screen space) to the provided alignment inside the container.
+// Returns how far targetBox will have moved on screen once the animation finishes.
+Clay_Vector2 Clay__ScrollContainerToBox(Clay__ScrollContainerDataInternal *scrollData, Clay_BoundingBox targetBox, Clay_ScrollAlignment alignment) {
+    Clay_ClipElementConfig *clipConfig = Clay__FindElementConfigWithType(scrollData->layoutElement, CLAY__ELEMENT_CONFIG_TYPE_CLIP).clipElementConfig;
+    if (!clipConfig) {
+        return CLAY__INIT(Clay_Vector2) CLAY__DEFAULT_STRUCT;
+    }
+    float scale = scrollData->scale;
+    Clay_Dimensions viewport = Clay__ScrollViewportSize(scrollData);
+    Clay_Vector2 target = scrollData->scrollPosition;
+    if (clipConfig->horizontal) {
+        target.x = Clay__ScrollAxisTarget((targetBox.x - scrollData->boundingBox.x) / scale - scrollData->scrollPosition.x, targetBox.width / scale, viewport.width, scrollData->scrollPosition.x, alignment);
+    }
+    if (clipConfig->vertical) {
+        target.y = Clay__ScrollAxisTarget((targetBox.y - scrollData->boundingBox.y) / scale - scrollData->scrollPosition.y, targetBox.height / scale, viewport.height, scrollData->scrollPosition.y, alignment);
+    }
+    target = Clay__ClampScrollPosition(scrollData, target);
+    scrollData->scrollTarget = target;
+    scrollData->scrollAnimationActive = true;
+    return CLAY__INIT(Clay_Vector2) { (target.x - scrollData->scrollPosition.x) * scale, (target.y - scrollData->scrollPosition.y) * scale };
+}
+
+// Clamps one axis of a scroll position, letting it travel up to overscroll pixels past either end with increasing resistance.
+float Clay__ElasticClamp(float position, float minPosition, float overscroll) {
+    float clamped = CLAY__MAX(CLAY__MIN(position, 0), minPosition);
+    float excess = position - clamped;
+    if (overscroll <= 0 || excess == 0) {
+        return clamped;
+    }
+    float distance = excess < 0 ? -excess : excess;
+    float resisted = overscroll * distance / (distance + overscroll);
+    return clamped + (excess < 0 ? -resisted : resisted);
+}
+
+// Returns the snap point along one axis that is closest to position.
+float Clay__SnapAxis(Clay__ScrollContainerDataInternal *scrollData, Clay_ScrollSnapConfig *snap, bool vertical, float position) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    if (snap->type == CLAY_SCROLL_SNAP_INTERVAL) {
+        if (snap->interval <= 0) {
+            return position;
+        }
+        float rounding = position < 0 ? -snap->interval / 2 : snap->interval / 2;
+        return (float)(int32_t)((position + rounding) / snap->interval) * snap->interval;
+    }
+    Clay_BoundingBox box = scrollData->boundingBox;
+    Clay_Dimensions viewport = Clay__ScrollViewportSize(scrollData);
+    float scale = scrollData->scale;
+    Clay_ScrollAlignment alignment = snap->alignment == CLAY_SCROLL_ALIGN_NEAREST ? CLAY_SCROLL_ALIGN_START : snap->alignment;
+    float best = position;
+    float bestDistance = CLAY__MAXFLOAT;
+    Clay_LayoutElement *element = scrollData->layoutElement;
+    for (int32_t i = 0; i < element->childrenOrTextContent.children.length; i++) {
+        Clay_LayoutElement *childElement = Clay_LayoutElementArray_Get(&context->layoutElements, element->childrenOrTextContent.children.elements[i]);
+        Clay_BoundingBox childBox = Clay__GetHashMapItem(childElement->id)->boundingBox;
+        float candidate = vertical
+            ? Clay__ScrollAxisTarget((childBox.y - box.y) / scale - scrollData->scrollPosition.y, childBox.height / scale, viewport.height, 0, alignment)
+            : Clay__ScrollAxisTarget((childBox.x - box.x) / scale - scrollData->scrollPosition.x, childBox.width / scale, viewport.width, 0, alignment);
+        float distance = candidate - position < 0 ? position - candidate : candidate - position;
+        if (distance < bestDistance) {
+            best = candidate;
+            bestDistance = distance;
+        }
+    }
+    return best;
+}
+
+// Starts an animated scroll to the snap point closest to where the container would come to rest by itself.
+void Clay__SnapScrollContainer(Clay__ScrollContainerDataInternal *scrollData, Clay_ClipElementConfig *clipConfig, Clay_Vector2 restingPosition) {
+    Clay_Vector2 target = scrollData->scrollPosition;
+    if (clipConfig->horizontal) {
+        target.x = Clay__SnapAxis(scrollData, &clipConfig->snap, false, restingPosition.x);
+    }
+    if (clipConfig->vertical) {
+        target.y = Clay__SnapAxis(scrollData, &clipConfig->snap, true, restingPosition.y);
+    }
+    scrollData->scrollTarget = Clay__ClampScrollPosition(scrollData, target);
+    scrollData->scrollAnimationActive = true;
+    scrollData->scrollMomentum = CLAY__INIT(Clay_Vector2) CLAY__DEFAULT_STRUCT;
+    scrollData->snapPending = false;
+}
+
+// Scrolls every clip container around an element so that the element's bounding box from the last layout becomes visible.
+void Clay__ScrollElementIntoView(uint32_t elementId) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay_LayoutElementHashMapItem *hashMapItem = Clay__GetHashMapItem(elementId);
+    Clay_BoundingBox targetBox = hashMapItem->boundingBox;
+    Clay_LayoutElement *currentElement = hashMapItem->layoutElement;
+    while (currentElement) {
+        uint32_t clipElementId = Clay__int32_tArray_GetValue(&context->layoutElementClipElementIds, (int32_t)(currentElement - context->layoutElements.internalArray));
+        if (clipElementId == 0) {
+            return;
+        }
+        Clay__ScrollContainerDataInternal *scrollData = Clay__GetScrollContainerDataInternal(clipElementId);
+        if (scrollData) {
+            // Containers further out need to reveal the element at the position it will be drawn at after this scroll
+            Clay_Vector2 moved = Clay__ScrollContainerToBox(scrollData, targetBox, CLAY_SCROLL_ALIGN_NEAREST);
+            targetBox.x += moved.x;
+            targetBox.y += moved.y;
+        }
+        currentElement = Clay__GetHashMapItem(clipElementId)->layoutElement;
+    }
+}
+
+int32_t Clay__GetFocusableIndex(uint32_t elementId) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t i = 0; i < context->focusableElementIndexes.length; ++i) {
+        if (Clay_LayoutElementArray_Get(&context->layoutElements, Clay__int32_tArray_GetValue(&context->focusableElementIndexes, i))->id == elementId) {
+            return i;
+        }
+    }
+    return -1;
+}
+
+int32_t Clay__GetFocusTabIndex(int32_t focusableIndex) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay_LayoutElement *element = Clay_LayoutElementArray_Get(&context->layoutElements, Clay__int32_tArray_GetValue(&context->focusableElementIndexes, focusableIndex));
+    Clay_FocusElementConfig *focusConfig = Clay__FindElementConfigWithType(element, CLAY__ELEMENT_CONFIG_TYPE_FOCUS).focusElementConfig;
+    return focusConfig ? focusConfig->tabIndex : -1;
+}
+
+// Positive tab indexes come first in ascending order, followed by tab index 0. Ties are broken by declaration order.
+bool Clay__FocusOrderBefore(int32_t leftIndex, int32_t rightIndex) {
+    int32_t leftTabIndex = Clay__GetFocusTabIndex(leftIndex);
+    int32_t rightTabIndex = Clay__GetFocusTabIndex(rightIndex);
+    if (leftTabIndex != rightTabIndex) {
+        if (leftTabIndex == 0) {
+            return false;
+        }
+        if (rightTabIndex == 0) {
+            return true;
+        }
+        return leftTabIndex < rightTabIndex;
+    }
+    return leftIndex < rightIndex;
+}
+
+// Returns the layout element index of the topmost modal floating element, or -1 if there is none.
+int32_t Clay__GetModalRootElementIndex(void) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t rootIndex = context->layoutElementTreeRoots.length - 1; rootIndex >= 0; --rootIndex) {
+        Clay__LayoutElementTreeRoot *root = Clay__LayoutElementTreeRootArray_Get(&context->layoutElementTreeRoots, rootIndex);
+        Clay_LayoutElement *rootElement = Clay_LayoutElementArray_Get(&context->layoutElements, root->layoutElementIndex);
+        if (Clay__ElementHasConfig(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING) && Clay__FindElementConfigWithType(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING).floatingElementConfig->modal) {
+            return root->layoutElementIndex;
+        }
+    }
+    return -1;
+}
+
+bool Clay__ElementIsInsideTree(int32_t rootElementIndex, int32_t elementIndex) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay__int32_tArray dfsBuffer = context->reusableElementIndexBuffer;
+    dfsBuffer.length = 0;
+    Clay__int32_tArray_Add(&dfsBuffer, rootElementIndex);
+    while (dfsBuffer.length > 0) {
+        int32_t currentElementIndex = Clay__int32_tArray_GetValue(&dfsBuffer, dfsBuffer.length - 1);
+        dfsBuffer.length--;
+        if (currentElementIndex == elementIndex) {
+            return true;
+        }
+        Clay_LayoutElement *currentElement = Clay_LayoutElementArray_Get(&context->layoutElements, currentElementIndex);
+        if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT)) {
+            continue;
+        }
+        for (int32_t i = 0; i < currentElement->childrenOrTextContent.children.length; ++i) {
+            Clay__int32_tArray_Add(&dfsBuffer, currentElement->childrenOrTextContent.children.elements[i]);
+        }
+    }
+    return false;
+}
+
+bool Clay__FocusableIsInScope(int32_t modalRootElementIndex, int32_t focusableIndex) {
+    if (modalRootElementIndex == -1) {
+        return true;
+    }
+    return Clay__ElementIsInsideTree(modalRootElementIndex, Clay__int32_tArray_GetValue(&Clay_GetCurrentContext()->focusableElementIndexes, focusableIndex));
+}
+
+void Clay__SetFocusToFocusableIndex(int32_t focusableIndex) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay_LayoutElement *element = Clay_LayoutElementArray_Get(&context->layoutElements, Clay__int32_tArray_GetValue(&context->focusableElementIndexes, focusableIndex));
+    context->focusedElementId = Clay__GetHashMapItem(element->id)->elementId;
+    context->focusVisible = true;
+}
+
+bool Clay__MoveFocusSequential(bool reverse) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    int32_t modalRootElementIndex = Clay__GetModalRootElementIndex();
+    int32_t currentIndex = Clay__GetFocusableIndex(context->focusedElementId.id);
+    if (currentIndex != -1 && (Clay__GetFocusTabIndex(currentIndex) < 0 || !Clay__FocusableIsInScope(modalRootElementIndex, currentIndex))) {
+        currentIndex = -1;
+    }
+    int32_t nextIndex = -1;
+    int32_t wrapIndex = -1;
+    for (int32_t i = 0; i < context->focusableElementIndexes.length; ++i) {
+        if (i == currentIndex || Clay__GetFocusTabIndex(i) < 0 || !Clay__FocusableIsInScope(modalRootElementIndex, i)) {
+            continue;
+        }
+        if (wrapIndex == -1 || (reverse ? Clay__FocusOrderBefore(wrapIndex, i) : Clay__FocusOrderBefore(i, wrapIndex))) {
+            wrapIndex = i;
+        }
+        if (currentIndex != -1 && (reverse ? Clay__FocusOrderBefore(i, currentIndex) : Clay__FocusOrderBefore(currentIndex, i))) {
+            if (nextIndex == -1 || (reverse ? Clay__FocusOrderBefore(nextIndex, i) : Clay__FocusOrderBefore(i, nextIndex))) {
+                nextIndex = i;
+            }
+        }
+    }
+    if (nextIndex == -1) {
+        nextIndex = wrapIndex;
+    }
+    if (nextIndex == -1) {
+        return false;
+    }
+    Clay__SetFocusToFocusableIndex(nextIndex);
+    Clay__ScrollElementIntoView(context->focusedElementId.id);
+    return true;
+}
+
+// Scores how good a candidate is when moving focus from one box in a direction. Lower is better, negative means the candidate isn't in that direction.
+float Clay__SpatialFocusScore(Clay_BoundingBox from, Clay_BoundingBox to, Clay_FocusDirection direction) {
+    // Project both boxes onto the axis of movement, flipped so that moving forward always increases the value
+    float fromStart, fromEnd, toStart, toEnd, fromCrossStart, fromCrossEnd, toCrossStart, toCrossEnd;
+    switch (direction) {
+        case CLAY_FOCUS_DIRECTION_RIGHT:
+        case CLAY_FOCUS_DIRECTION_LEFT: {
+            fromStart = from.x; fromEnd = from.x + from.width; toStart = to.x; toEnd = to.x + to.width;
+            fromCrossStart = from.y; fromCrossEnd = from.y + from.height; toCrossStart = to.y; toCrossEnd = to.y + to.height;
+            break;
+        }
+        case CLAY_FOCUS_DIRECTION_DOWN:
+        case CLAY_FOCUS_DIRECTION_UP: {
+            fromStart = from.y; fromEnd = from.y + from.height; toStart = to.y; toEnd = to.y + to.height;
+            fromCrossStart = from.x; fromCrossEnd = from.x + from.width; toCrossStart = to.x; toCrossEnd = to.x + to.width;
+            break;
+        }
+        default: return -1;
+    }
+    if (direction == CLAY_FOCUS_DIRECTION_LEFT || direction == CLAY_FOCUS_DIRECTION_UP) {
+        float swap = fromStart;
+        fromStart = -fromEnd;
+        fromEnd = -swap;
+        swap = toStart;
+        toStart = -toEnd;
+        toEnd = -swap;
+    }
+    // The candidate's center has to be ahead of the focused element, and it has to extend past it
+    if (toStart + toEnd <= fromStart + fromEnd || toEnd <= fromEnd) {
+        return -1;
+    }
+    float distance = CLAY__MAX(toStart - fromEnd, 0);
+    float crossGap = CLAY__MAX(CLAY__MAX(toCrossStart - fromCrossEnd, fromCrossStart - toCrossEnd), 0);
+    float crossCenterOffset = ((toCrossStart + toCrossEnd) - (fromCrossStart + fromCrossEnd)) / 2;
+    if (crossCenterOffset < 0) {
+        crossCenterOffset = -crossCenterOffset;
+    }
+    // Prefer candidates that overlap on the cross axis, then those that are closely aligned
+    return distance + crossGap * 2 + crossCenterOffset * 0.25f;
+}
+
+bool Clay__MoveFocusSpatial(Clay_FocusDirection direction) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    int32_t modalRootElementIndex = Clay__GetModalRootElementIndex();
+    int32_t currentIndex = Clay__GetFocusableIndex(context->focusedElementId.id);
+    if (currentIndex == -1 || !Clay__FocusableIsInScope(modalRootElementIndex, currentIndex)) {
+        return Clay__MoveFocusSequential(false);
+    }
+    Clay_BoundingBox currentBox = Clay__GetHashMapItem(context->focusedElementId.id)->boundingBox;
+    int32_t bestIndex = -1;
+    float bestScore = CLAY__MAXFLOAT;
+    for (int32_t i = 0; i < context->focusableElementIndexes.length; ++i) {
+        if (i == currentIndex || Clay__GetFocusTabIndex(i) < 0 || !Clay__FocusableIsInScope(modalRootElementIndex, i)) {
+            continue;
+        }
+        Clay_LayoutElement *element = Clay_LayoutElementArray_Get(&context->layoutElements, Clay__int32_tArray_GetValue(&context->focusableElementIndexes, i));
+        float score = Clay__SpatialFocusScore(currentBox, Clay__GetHashMapItem(element->id)->boundingBox, direction);
+        if (score >= 0 && score < bestScore) {
+            bestScore = score;
+            bestIndex = i;
+        }
+    }
+    if (bestIndex == -1) {
+        return false;
+    }
+    Clay__SetFocusToFocusableIndex(bestIndex);
+    Clay__ScrollElementIntoView(context->focusedElementId.id);
+    return true;
+}
+
 CLAY_WASM_EXPORT("Clay_SetPointerState")
 void Clay_SetPointerState(Clay_Vector2 position, bool isPointerDown) {
     Clay_Context* context = Clay_GetCurrentContext();
@@ -3966,6 +6183,8 @@ void Clay_SetPointerState(Clay_Vector2 position, bool isPointerDown) {
     }
     context->pointerInfo.position = position;
     context->pointerOverIds.length = 0;
+    Clay_ElementId pointerFocusTarget = CLAY__DEFAULT_STRUCT;
+    bool pointerFocusResolved = false;
     Clay__int32_tArray dfsBuffer = context->layoutElementChildrenBuffer;
     for (int32_t rootIndex = context->layoutElementTreeRoots.length - 1; rootIndex >= 0; --rootIndex) {
         dfsBuffer.length = 0;
@@ -3987,11 +6206,16 @@ void Clay_SetPointerState(Clay_Vector2 position, bool isPointerDown) {
                 Clay_BoundingBox elementBox = mapItem->boundingBox;
                 elementBox.x -= root->pointerOffset.x;
                 elementBox.y -= root->pointerOffset.y;
-                if ((Clay__PointIsInsideRect(position, elementBox)) && (clipElementId == 0 || (Clay__PointIsInsideRect(position, clipItem->boundingBox)) || context->externalScrollHandlingEnabled)) {
+                // Boxes are tested before they're transformed, against the pointer mapped back through the transform
+                Clay_Vector2 elementPosition = Clay__InverseTransformPoint(mapItem->transform, position);
+                if ((Clay__PointIsInsideRect(elementPosition, elementBox)) && (clipElementId == 0 || (Clay__PointIsInsideRect(Clay__InverseTransformPoint(clipItem->transform, position), clipItem->boundingBox)) || context->externalScrollHandlingEnabled)) {
                     if (mapItem->onHoverFunction) {
                         mapItem->onHoverFunction(mapItem->elementId, context->pointerInfo, mapItem->hoverFunctionUserData);
                     }
                     Clay_ElementIdArray_Add(&context->pointerOverIds, mapItem->elementId);
+                    if (!pointerFocusResolved && Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_FOCUS)) {
+                        pointerFocusTarget = mapItem->elementId;
+                    }
                     found = true;
                 }
                 if (Clay__ElementHasConfig(currentElement, CLAY__ELEMENT_CONFIG_TYPE_TEXT)) {
@@ -4007,6 +6231,10 @@ void Clay_SetPointerState(Clay_Vector2 position, bool isPointerDown) {
             }
         }
 
+        // Only the topmost tree under the pointer can take focus from a press
+        if (found) {
+            pointerFocusResolved = true;
+        }
         Clay_LayoutElement *rootElement = Clay_LayoutElementArray_Get(&context->layoutElements, root->layoutElementIndex);
         if (found && Clay__ElementHasConfig(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING) &&
                 Clay__FindElementConfigWithType(rootElement, CLAY__ELEMENT_CONFIG_TYPE_FLOATING).floatingElementConfig->pointerCaptureMode == CLAY_POINTER_CAPTURE_MODE_CAPTURE) {
@@ -4019,6 +6247,8 @@ void Clay_SetPointerState(Clay_Vector2 position, bool isPointerDown) {
             context->pointerInfo.state = CLAY_POINTER_DATA_PRESSED;
         } else if (context->pointerInfo.state != CLAY_POINTER_DATA_PRESSED) {
             context->pointerInfo.state = CLAY_POINTER_DATA_PRESSED_THIS_FRAME;
+            context->focusedElementId = pointerFocusTarget;
+            context->focusVisible = false;
         }
     } else {
         if (context->pointerInfo.state == CLAY_POINTER_DATA_RELEASED_THIS_FRAME) {
@@ -4029,7 +6259,35 @@ void Clay_SetPointerState(Clay_Vector2 position, bool isPointerDown) {
     }
 }
 
+CLAY_WASM_EXPORT("Clay_SetKeyboardState")
+void Clay_SetKeyboardState(Clay_KeyEvent *keyEvents, int32_t keyEventCount) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    if (context->booleanWarnings.maxElementsExceeded) {
+        return;
+    }
+    for (int32_t i = 0; i < keyEventCount; ++i) {
+        Clay_KeyEvent keyEvent = keyEvents[i];
+        if (context->focusedElementId.id != 0) {
+            Clay_LayoutElementHashMapItem *focusedItem = Clay__GetHashMapItem(context->focusedElementId.id);
+            if (focusedItem->onKeyFunction && focusedItem->onKeyFunction(focusedItem->elementId, keyEvent, focusedItem->keyFunctionUserData)) {
+                continue;
+            }
+        }
+        switch (keyEvent.key) {
+            case CLAY_KEY_TAB: Clay__MoveFocusSequential((keyEvent.modifiers & CLAY_KEY_MODIFIER_SHIFT) != 0); break;
+            case CLAY_KEY_UP: Clay__MoveFocusSpatial(CLAY_FOCUS_DIRECTION_UP); break;
+            case CLAY_KEY_DOWN: Clay__MoveFocusSpatial(CLAY_FOCUS_DIRECTION_DOWN); break;
+            case CLAY_KEY_LEFT: Clay__MoveFocusSpatial(CLAY_FOCUS_DIRECTION_LEFT); break;
+            case CLAY_KEY_RIGHT: Clay__MoveFocusSpatial(CLAY_FOCUS_DIRECTION_RIGHT); break;
+            default: break;
+        }
+    }
+}
+
 CLAY_WASM_EXPORT("Clay_Initialize")
+// Implemented by the bindings, discards the state they keep for a context outside of its arena.
+void Clay__ReleaseContextState(Clay_Context *context);
+
 Clay_Context* Clay_Initialize(Clay_Arena arena, Clay_Dimensions layoutDimensions, Clay_ErrorHandler errorHandler) {
     // Cacheline align memory passed in
     uintptr_t baseOffset = 64 - ((uintptr_t)arena.memory % 64);
@@ -4037,6 +6295,8 @@ Clay_Context* Clay_Initialize(Clay_Arena arena, Clay_Dimensions layoutDimensions
     arena.memory += baseOffset;
     Clay_Context *context = Clay__Context_Allocate_Arena(&arena);
     if (context == NULL) return NULL;
+    // The context's memory may have belonged to another context, or to this one before it was initialized again
+    Clay__ReleaseContextState(context);
     // DEFAULTS
     Clay_Context *oldContext = Clay_GetCurrentContext();
     *context = CLAY__INIT(Clay_Context) {
@@ -4097,6 +6357,8 @@ void Clay_UpdateScrollContainers(bool enableDragScrolling, Clay_Vector2 scrollDe
     // Don't apply scroll events to ancestors of the inner element
     int32_t highestPriorityElementIndex = -1;
     Clay__ScrollContainerDataInternal *highestPriorityScrollData = CLAY__NULL;
+    // Pressing a scrollbar must not also drag the content underneath it
+    bool scrollbarPressed = false;
     for (int32_t i = 0; i < context->scrollContainerDatas.length; i++) {
         Clay__ScrollContainerDataInternal *scrollData = Clay__ScrollContainerDataInternalArray_Get(&context->scrollContainerDatas, i);
         if (!scrollData->openThisFrame) {
@@ -4111,14 +6373,21 @@ void Clay_UpdateScrollContainers(bool enableDragScrolling, Clay_Vector2 scrollDe
             continue;
         }
 
+        Clay_ClipElementConfig *clipConfig = Clay__FindElementConfigWithType(scrollData->layoutElement, CLAY__ELEMENT_CONFIG_TYPE_CLIP).clipElementConfig;
+        Clay_ScrollPhysicsConfig physics = clipConfig ? clipConfig->physics : CLAY__INIT(Clay_ScrollPhysicsConfig) CLAY__DEFAULT_STRUCT;
+        float friction = physics.friction > 0 ? CLAY__MIN(physics.friction, 1) : 0.05f;
+        float dragThreshold = physics.dragThreshold > 0 ? physics.dragThreshold : 10;
+        bool snapEnabled = clipConfig && clipConfig->snap.type != CLAY_SCROLL_SNAP_NONE;
+        Clay_Vector2 minPosition = { -CLAY__MAX(scrollData->contentSize.width - scrollData->layoutElement->dimensions.width, 0), -CLAY__MAX(scrollData->contentSize.height - scrollData->layoutElement->dimensions.height, 0) };
+
         // Touch / click is released
         if (!isPointerActive && scrollData->pointerScrollActive) {
             float xDiff = scrollData->scrollPosition.x - scrollData->scrollOrigin.x;
-            if (xDiff < -10 || xDiff > 10) {
+            if (xDiff < -dragThreshold || xDiff > dragThreshold) {
                 scrollData->scrollMomentum.x = (scrollData->scrollPosition.x - scrollData->scrollOrigin.x) / (scrollData->momentumTime * 25);
             }
             float yDiff = scrollData->scrollPosition.y - scrollData->scrollOrigin.y;
-            if (yDiff < -10 || yDiff > 10) {
+            if (yDiff < -dragThreshold || yDiff > dragThreshold) {
                 scrollData->scrollMomentum.y = (scrollData->scrollPosition.y - scrollData->scrollOrigin.y) / (scrollData->momentumTime * 25);
             }
             scrollData->pointerScrollActive = false;
@@ -4126,23 +6395,77 @@ void Clay_UpdateScrollContainers(bool enableDragScrolling, Clay_Vector2 scrollDe
             scrollData->pointerOrigin = CLAY__INIT(Clay_Vector2){0,0};
             scrollData->scrollOrigin = CLAY__INIT(Clay_Vector2){0,0};
             scrollData->momentumTime = 0;
+
+            if (snapEnabled) {
+                // Momentum decays geometrically, so the distance it would still travel is momentum / friction
+                Clay__SnapScrollContainer(scrollData, clipConfig, CLAY__INIT(Clay_Vector2) { scrollData->scrollPosition.x + scrollData->scrollMomentum.x / friction, scrollData->scrollPosition.y + scrollData->scrollMomentum.y / friction });
+            }
+        }
+
+        if (clipConfig && clipConfig->scrollbar.width > 0) {
+            bool wasScrollbarPressed = scrollData->scrollbarPressed;
+            if (Clay__UpdateScrollbars(scrollData, clipConfig, deltaTime)) {
+                scrollbarPressed = true;
+            } else if (wasScrollbarPressed) {
+                scrollData->snapPending = snapEnabled;
+                scrollData->snapIdleTime = 0;
+            }
         }
 
-        // Apply existing momentum
-        scrollData->scrollPosition.x += scrollData->scrollMomentum.x;
-        scrollData->scrollMomentum.x *= 0.95f;
         bool scrollOccurred = scrollDelta.x != 0 || scrollDelta.y != 0;
+        bool momentumActive = scrollData->scrollMomentum.x != 0 || scrollData->scrollMomentum.y != 0;
+        // Wheel scrolling snaps once the wheel has been idle for a moment
+        if (scrollData->snapPending && !scrollOccurred && !scrollData->pointerScrollActive && !scrollData->scrollbarPressed && !momentumActive && !scrollData->scrollAnimationActive) {
+            scrollData->snapIdleTime += deltaTime;
+            if (scrollData->snapIdleTime > 0.15f) {
+                Clay__SnapScrollContainer(scrollData, clipConfig, scrollData->scrollPosition);
+            }
+        }
+        // Content pulled past its ends springs back once nothing else is moving it
+        bool overscrolled = scrollData->scrollPosition.x > 0 || scrollData->scrollPosition.x < minPosition.x || scrollData->scrollPosition.y > 0 || scrollData->scrollPosition.y < minPosition.y;
+        if (overscrolled && !scrollData->pointerScrollActive && !momentumActive && !scrollData->scrollAnimationActive) {
+            scrollData->scrollTarget = Clay__ClampScrollPosition(scrollData, scrollData->scrollPosition);
+            scrollData->scrollAnimationActive = true;
+        }
+
+        // Animated scrolls steer the momentum towards their target, and are cancelled by any user scroll
+        if (scrollData->scrollAnimationActive) {
+            if (scrollOccurred || scrollData->pointerScrollActive) {
+                scrollData->scrollAnimationActive = false;
+            } else {
+                Clay_Vector2 target = Clay__ClampScrollPosition(scrollData, scrollData->scrollTarget);
+                Clay_Vector2 remaining = { target.x - scrollData->scrollPosition.x, target.y - scrollData->scrollPosition.y };
+                if (remaining.x > -0.5f && remaining.x < 0.5f && remaining.y > -0.5f && remaining.y < 0.5f) {
+                    scrollData->scrollPosition = target;
+                    scrollData->scrollMomentum = CLAY__INIT(Clay_Vector2) CLAY__DEFAULT_STRUCT;
+                    scrollData->scrollAnimationActive = false;
+                } else {
+                    float step = deltaTime > 0 ? CLAY__MIN(deltaTime * 15, 1) : 1;
+                    scrollData->scrollMomentum = CLAY__INIT(Clay_Vector2) { remaining.x * step, remaining.y * step };
+                }
+            }
+        }
+
+        // Apply existing momentum. With elastic overscroll, momentum carries the content past its ends but dies quickly there.
+        scrollData->scrollPosition.x += scrollData->scrollMomentum.x;
+        scrollData->scrollMomentum.x *= 1 - friction;
+        if (physics.overscroll > 0 && (scrollData->scrollPosition.x > 0 || scrollData->scrollPosition.x < minPosition.x)) {
+            scrollData->scrollMomentum.x *= 0.5f;
+        }
         if ((scrollData->scrollMomentum.x > -0.1f && scrollData->scrollMomentum.x < 0.1f) || scrollOccurred) {
             scrollData->scrollMomentum.x = 0;
         }
-        scrollData->scrollPosition.x = CLAY__MIN(CLAY__MAX(scrollData->scrollPosition.x, -(CLAY__MAX(scrollData->contentSize.width - scrollData->layoutElement->dimensions.width, 0))), 0);
+        scrollData->scrollPosition.x = CLAY__MIN(CLAY__MAX(scrollData->scrollPosition.x, minPosition.x - physics.overscroll), physics.overscroll);
 
         scrollData->scrollPosition.y += scrollData->scrollMomentum.y;
-        scrollData->scrollMomentum.y *= 0.95f;
+        scrollData->scrollMomentum.y *= 1 - friction;
+        if (physics.overscroll > 0 && (scrollData->scrollPosition.y > 0 || scrollData->scrollPosition.y < minPosition.y)) {
+            scrollData->scrollMomentum.y *= 0.5f;
+        }
         if ((scrollData->scrollMomentum.y > -0.1f && scrollData->scrollMomentum.y < 0.1f) || scrollOccurred) {
             scrollData->scrollMomentum.y = 0;
         }
-        scrollData->scrollPosition.y = CLAY__MIN(CLAY__MAX(scrollData->scrollPosition.y, -(CLAY__MAX(scrollData->contentSize.height - scrollData->layoutElement->dimensions.height, 0))), 0);
+        scrollData->scrollPosition.y = CLAY__MIN(CLAY__MAX(scrollData->scrollPosition.y, minPosition.y - physics.overscroll), physics.overscroll);
 
         for (int32_t j = 0; j < context->pointerOverIds.length; ++j) { // TODO n & m are small here but this being n*m gives me the creeps
             if (scrollData->layoutElement->id == Clay_ElementIdArray_Get(&context->pointerOverIds, j)->id) {
@@ -4164,8 +6487,14 @@ void Clay_UpdateScrollContainers(bool enableDragScrolling, Clay_Vector2 scrollDe
         if (canScrollHorizontally) {
             highestPriorityScrollData->scrollPosition.x = highestPriorityScrollData->scrollPosition.x + scrollDelta.x * 10;
         }
+        if ((canScrollVertically && scrollDelta.y != 0) || (canScrollHorizontally && scrollDelta.x != 0)) {
+            highestPriorityScrollData->snapPending = clipConfig->snap.type != CLAY_SCROLL_SNAP_NONE;
+            highestPriorityScrollData->snapIdleTime = 0;
+            // Scrollbars notice movement on the next update, so hidden ones are shown right away instead
+            highestPriorityScrollData->scrollbarIdleTime = 0;
+        }
         // Handle click / touch scroll
-        if (isPointerActive) {
+        if (isPointerActive && !scrollbarPressed) {
             highestPriorityScrollData->scrollMomentum = CLAY__INIT(Clay_Vector2)CLAY__DEFAULT_STRUCT;
             if (!highestPriorityScrollData->pointerScrollActive) {
                 highestPriorityScrollData->pointerOrigin = context->pointerInfo.position;
@@ -4173,16 +6502,19 @@ void Clay_UpdateScrollContainers(bool enableDragScrolling, Clay_Vector2 scrollDe
                 highestPriorityScrollData->pointerScrollActive = true;
             } else {
                 float scrollDeltaX = 0, scrollDeltaY = 0;
+                // The pointer moves in screen space, so the content follows it by the pointer delta in layout space
+                float scale = highestPriorityScrollData->scale;
+                Clay_Dimensions viewport = Clay__ScrollViewportSize(highestPriorityScrollData);
                 if (canScrollHorizontally) {
                     float oldXScrollPosition = highestPriorityScrollData->scrollPosition.x;
-                    highestPriorityScrollData->scrollPosition.x = highestPriorityScrollData->scrollOrigin.x + (context->pointerInfo.position.x - highestPriorityScrollData->pointerOrigin.x);
-                    highestPriorityScrollData->scrollPosition.x = CLAY__MAX(CLAY__MIN(highestPriorityScrollData->scrollPosition.x, 0), -(highestPriorityScrollData->contentSize.width - highestPriorityScrollData->boundingBox.width));
+                    highestPriorityScrollData->scrollPosition.x = highestPriorityScrollData->scrollOrigin.x + (context->pointerInfo.position.x - highestPriorityScrollData->pointerOrigin.x) / scale;
+                    highestPriorityScrollData->scrollPosition.x = Clay__ElasticClamp(highestPriorityScrollData->scrollPosition.x, -(highestPriorityScrollData->contentSize.width - viewport.width), clipConfig->physics.overscroll);
                     scrollDeltaX = highestPriorityScrollData->scrollPosition.x - oldXScrollPosition;
                 }
                 if (canScrollVertically) {
                     float oldYScrollPosition = highestPriorityScrollData->scrollPosition.y;
-                    highestPriorityScrollData->scrollPosition.y = highestPriorityScrollData->scrollOrigin.y + (context->pointerInfo.position.y - highestPriorityScrollData->pointerOrigin.y);
-                    highestPriorityScrollData->scrollPosition.y = CLAY__MAX(CLAY__MIN(highestPriorityScrollData->scrollPosition.y, 0), -(highestPriorityScrollData->contentSize.height - highestPriorityScrollData->boundingBox.height));
+                    highestPriorityScrollData->scrollPosition.y = highestPriorityScrollData->scrollOrigin.y + (context->pointerInfo.position.y - highestPriorityScrollData->pointerOrigin.y) / scale;
+                    highestPriorityScrollData->scrollPosition.y = Clay__ElasticClamp(highestPriorityScrollData->scrollPosition.y, -(highestPriorityScrollData->contentSize.height - viewport.height), clipConfig->physics.overscroll);
                     scrollDeltaY = highestPriorityScrollData->scrollPosition.y - oldYScrollPosition;
                 }
                 if (scrollDeltaX > -0.1f && scrollDeltaX < 0.1f && scrollDeltaY > -0.1f && scrollDeltaY < 0.1f && highestPriorityScrollData->momentumTime > 0.15f) {
@@ -4194,11 +6526,12 @@ void Clay_UpdateScrollContainers(bool enableDragScrolling, Clay_Vector2 scrollDe
                 }
             }
         }
-        // Clamp any changes to scroll position to the maximum size of the contents
-        if (canScrollVertically) {
+        // Clamp any changes to scroll position to the maximum size of the contents, unless an elastic drag is pulling past them
+        bool elasticDrag = highestPriorityScrollData->pointerScrollActive && clipConfig->physics.overscroll > 0;
+        if (canScrollVertically && !elasticDrag) {
             highestPriorityScrollData->scrollPosition.y = CLAY__MAX(CLAY__MIN(highestPriorityScrollData->scrollPosition.y, 0), -(highestPriorityScrollData->contentSize.height - scrollElement->dimensions.height));
         }
-        if (canScrollHorizontally) {
+        if (canScrollHorizontally && !elasticDrag) {
             highestPriorityScrollData->scrollPosition.x = CLAY__MAX(CLAY__MIN(highestPriorityScrollData->scrollPosition.x, 0), -(highestPriorityScrollData->contentSize.width - scrollElement->dimensions.width));
         }
     }
@@ -4253,7 +6586,13 @@ Clay_RenderCommandArray Clay_EndLayout(void) {
                 .errorText = CLAY_STRING("There were still open layout elements when EndLayout was called. This results from an unequal number of calls to Clay__OpenElement and Clay__CloseElement."),
                 .userData = context->errorHandler.userData });
     }
+    // Drop focus from elements that are no longer declared, or no longer focusable
+    if (context->focusedElementId.id != 0 && Clay__GetFocusableIndex(context->focusedElementId.id) == -1) {
+        context->focusedElementId = CLAY__INIT(Clay_ElementId) CLAY__DEFAULT_STRUCT;
+        context->focusVisible = false;
+    }
     Clay__CalculateFinalLayout();
+    Clay__DispatchLifecycleEvents();
     return context->renderCommands;
 }
 
@@ -4299,6 +6638,89 @@ void Clay_OnHover(void (*onHoverFunction)(Clay_ElementId elementId, Clay_Pointer
     hashMapItem->hoverFunctionUserData = userData;
 }
 
+bool Clay_Focused(void) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    if (context->booleanWarnings.maxElementsExceeded) {
+        return false;
+    }
+    Clay_LayoutElement *openLayoutElement = Clay__GetOpenLayoutElement();
+    if (openLayoutElement->id == 0) {
+        Clay__GenerateIdForAnonymousElement(openLayoutElement);
+    }
+    return context->focusedElementId.id != 0 && context->focusedElementId.id == openLayoutElement->id;
+}
+
+bool Clay_FocusVisible(void) {
+    return Clay_GetCurrentContext()->focusVisible && Clay_Focused();
+}
+
+void Clay_OnKey(Clay_OnKeyFunction *onKeyFunction, intptr_t userData) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    if (context->booleanWarnings.maxElementsExceeded) {
+        return;
+    }
+    Clay_LayoutElement *openLayoutElement = Clay__GetOpenLayoutElement();
+    if (openLayoutElement->id == 0) {
+        Clay__GenerateIdForAnonymousElement(openLayoutElement);
+    }
+    Clay_LayoutElementHashMapItem *hashMapItem = Clay__GetHashMapItem(openLayoutElement->id);
+    hashMapItem->onKeyFunction = onKeyFunction;
+    hashMapItem->keyFunctionUserData = userData;
+}
+
+void Clay_OnLifecycle(Clay_OnLifecycleFunction *onLifecycleFunction, intptr_t userData) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    if (context->booleanWarnings.maxElementsExceeded) {
+        return;
+    }
+    Clay_LayoutElement *openLayoutElement = Clay__GetOpenLayoutElement();
+    if (openLayoutElement->id == 0) {
+        Clay__GenerateIdForAnonymousElement(openLayoutElement);
+    }
+    Clay_LayoutElementHashMapItem *hashMapItem = Clay__GetHashMapItem(openLayoutElement->id);
+    hashMapItem->onLifecycleFunction = onLifecycleFunction;
+    hashMapItem->lifecycleFunctionUserData = userData;
+}
+
+CLAY_WASM_EXPORT("Clay_GetElementVisibility")
+Clay_ElementVisibility Clay_GetElementVisibility(Clay_ElementId elementId) {
+    Clay_LayoutElementHashMapItem *hashMapItem = Clay__GetHashMapItem(elementId.id);
+    if (hashMapItem == &Clay_LayoutElementHashMapItem_DEFAULT) {
+        return CLAY__INIT(Clay_ElementVisibility) CLAY__DEFAULT_STRUCT;
+    }
+    return hashMapItem->visibility;
+}
+
+CLAY_WASM_EXPORT("Clay_GetFocusedElementId")
+Clay_ElementId Clay_GetFocusedElementId(void) {
+    return Clay_GetCurrentContext()->focusedElementId;
+}
+
+CLAY_WASM_EXPORT("Clay_SetFocus")
+void Clay_SetFocus(Clay_ElementId elementId) {
+    Clay_GetCurrentContext()->focusedElementId = elementId;
+}
+
+CLAY_WASM_EXPORT("Clay_ClearFocus")
+void Clay_ClearFocus(void) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    context->focusedElementId = CLAY__INIT(Clay_ElementId) CLAY__DEFAULT_STRUCT;
+    context->focusVisible = false;
+}
+
+CLAY_WASM_EXPORT("Clay_MoveFocus")
+bool Clay_MoveFocus(Clay_FocusDirection direction) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    if (context->booleanWarnings.maxElementsExceeded) {
+        return false;
+    }
+    switch (direction) {
+        case CLAY_FOCUS_DIRECTION_NEXT: return Clay__MoveFocusSequential(false);
+        case CLAY_FOCUS_DIRECTION_PREVIOUS: return Clay__MoveFocusSequential(true);
+        default: return Clay__MoveFocusSpatial(direction);
+    }
+}
+
 CLAY_WASM_EXPORT("Clay_PointerOver")
 bool Clay_PointerOver(Clay_ElementId elementId) { // TODO return priority for separating multiple results
     Clay_Context* context = Clay_GetCurrentContext();
@@ -4322,7 +6744,7 @@ Clay_ScrollContainerData Clay_GetScrollContainerData(Clay_ElementId id) {
             }
             return CLAY__INIT(Clay_ScrollContainerData) {
                 .scrollPosition = &scrollContainerData->scrollPosition,
-                .scrollContainerDimensions = { scrollContainerData->boundingBox.width, scrollContainerData->boundingBox.height },
+                .scrollContainerDimensions = Clay__ScrollViewportSize(scrollContainerData),
                 .contentDimensions = scrollContainerData->contentSize,
                 .config = *clipElementConfig,
                 .found = true
@@ -4332,6 +6754,125 @@ Clay_ScrollContainerData Clay_GetScrollContainerData(Clay_ElementId id) {
     return CLAY__INIT(Clay_ScrollContainerData) CLAY__DEFAULT_STRUCT;
 }
 
+CLAY_WASM_EXPORT("Clay_ScrollIntoView")
+void Clay_ScrollIntoView(Clay_ElementId containerId, Clay_ElementId targetId, Clay_ScrollAlignment alignment) {
+    Clay__ScrollContainerDataInternal *scrollData = Clay__GetScrollContainerDataInternal(containerId.id);
+    Clay_LayoutElementHashMapItem *targetItem = Clay__GetHashMapItem(targetId.id);
+    if (!scrollData || targetItem == &Clay_LayoutElementHashMapItem_DEFAULT) {
+        return;
+    }
+    Clay__ScrollContainerToBox(scrollData, targetItem->boundingBox, alignment);
+}
+
+CLAY_WASM_EXPORT("Clay_ScrollTo")
+void Clay_ScrollTo(Clay_ElementId containerId, Clay_Vector2 scrollPosition, bool animate) {
+    Clay__ScrollContainerDataInternal *scrollData = Clay__GetScrollContainerDataInternal(containerId.id);
+    if (!scrollData) {
+        return;
+    }
+    if (animate) {
+        scrollData->scrollTarget = scrollPosition;
+        scrollData->scrollAnimationActive = true;
+    } else {
+        scrollData->scrollPosition = Clay__ClampScrollPosition(scrollData, scrollPosition);
+        scrollData->scrollMomentum = CLAY__INIT(Clay_Vector2) CLAY__DEFAULT_STRUCT;
+        scrollData->scrollAnimationActive = false;
+    }
+}
+
+CLAY_WASM_EXPORT("Clay_SetDeltaTime")
+void Clay_SetDeltaTime(float deltaTime) {
+    Clay_GetCurrentContext()->deltaTime = deltaTime;
+}
+
+CLAY_WASM_EXPORT("Clay_GetZoomPanData")
+Clay_ZoomPanData Clay_GetZoomPanData(Clay_ElementId id) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t i = 0; i < context->zoomPanDatas.length; ++i) {
+        Clay__ZoomPanDataInternal *zoomPanData = Clay__ZoomPanDataInternalArray_Get(&context->zoomPanDatas, i);
+        if (zoomPanData->elementId == id.id) {
+            return CLAY__INIT(Clay_ZoomPanData) {
+                .pan = &zoomPanData->pan,
+                .zoom = &zoomPanData->zoom,
+                .boundingBox = zoomPanData->boundingBox,
+                .found = true
+            };
+        }
+    }
+    return CLAY__INIT(Clay_ZoomPanData) CLAY__DEFAULT_STRUCT;
+}
+
+CLAY_WASM_EXPORT("Clay_UpdateZoomPanContainers")
+void Clay_UpdateZoomPanContainers(bool enableDragPanning, float zoomDelta) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    Clay_PointerData pointer = context->pointerInfo;
+    bool isPointerActive = enableDragPanning && (pointer.state == CLAY_POINTER_DATA_PRESSED || pointer.state == CLAY_POINTER_DATA_PRESSED_THIS_FRAME);
+    // Only the innermost zoom-pan container under the pointer responds to new input
+    int32_t highestPriorityElementIndex = -1;
+    Clay__ZoomPanDataInternal *highestPriorityZoomPanData = CLAY__NULL;
+    bool dragActive = false;
+    for (int32_t i = 0; i < context->zoomPanDatas.length; i++) {
+        Clay__ZoomPanDataInternal *zoomPanData = Clay__ZoomPanDataInternalArray_Get(&context->zoomPanDatas, i);
+        if (!zoomPanData->openThisFrame) {
+            Clay__ZoomPanDataInternalArray_RemoveSwapback(&context->zoomPanDatas, i);
+            i--;
+            continue;
+        }
+        zoomPanData->openThisFrame = false;
+        float parentScale = zoomPanData->parentScale > 0 ? zoomPanData->parentScale : 1;
+        if (!isPointerActive) {
+            zoomPanData->dragActive = false;
+        }
+        if (zoomPanData->dragActive) {
+            zoomPanData->pan.x = zoomPanData->panOrigin.x + (pointer.position.x - zoomPanData->pointerOrigin.x) / parentScale;
+            zoomPanData->pan.y = zoomPanData->panOrigin.y + (pointer.position.y - zoomPanData->pointerOrigin.y) / parentScale;
+            dragActive = true;
+        }
+        for (int32_t j = 0; j < context->pointerOverIds.length; ++j) {
+            if (zoomPanData->elementId == Clay_ElementIdArray_Get(&context->pointerOverIds, j)->id && j > highestPriorityElementIndex) {
+                highestPriorityElementIndex = j;
+                highestPriorityZoomPanData = zoomPanData;
+            }
+        }
+    }
+
+    if (!highestPriorityZoomPanData) {
+        return;
+    }
+    if (zoomDelta != 0) {
+        Clay_ZoomPanElementConfig *config = Clay__FindElementConfigWithType(highestPriorityZoomPanData->layoutElement, CLAY__ELEMENT_CONFIG_TYPE_ZOOM_PAN).zoomPanElementConfig;
+        float minZoom = config && config->minZoom > 0 ? config->minZoom : 0.1f;
+        float maxZoom = config && config->maxZoom > 0 ? config->maxZoom : 10;
+        float zoom = CLAY__MIN(CLAY__MAX(highestPriorityZoomPanData->zoom * (1 + zoomDelta), minZoom), maxZoom);
+        // Keep the canvas point under the pointer in place
+        Clay_Vector2 canvasPoint = Clay_ZoomPanScreenToCanvas(CLAY__INIT(Clay_ElementId) { .id = highestPriorityZoomPanData->elementId }, pointer.position);
+        highestPriorityZoomPanData->pan.x += canvasPoint.x * (highestPriorityZoomPanData->zoom - zoom);
+        highestPriorityZoomPanData->pan.y += canvasPoint.y * (highestPriorityZoomPanData->zoom - zoom);
+        highestPriorityZoomPanData->zoom = zoom;
+    }
+    if (isPointerActive && !dragActive) {
+        highestPriorityZoomPanData->dragActive = true;
+        highestPriorityZoomPanData->pointerOrigin = pointer.position;
+        highestPriorityZoomPanData->panOrigin = highestPriorityZoomPanData->pan;
+    }
+}
+
+CLAY_WASM_EXPORT("Clay_ZoomPanScreenToCanvas")
+Clay_Vector2 Clay_ZoomPanScreenToCanvas(Clay_ElementId id, Clay_Vector2 position) {
+    Clay_Context* context = Clay_GetCurrentContext();
+    for (int32_t i = 0; i < context->zoomPanDatas.length; ++i) {
+        Clay__ZoomPanDataInternal *zoomPanData = Clay__ZoomPanDataInternalArray_Get(&context->zoomPanDatas, i);
+        if (zoomPanData->elementId == id.id) {
+            float parentScale = zoomPanData->parentScale > 0 ? zoomPanData->parentScale : 1;
+            return CLAY__INIT(Clay_Vector2) {
+                ((position.x - zoomPanData->boundingBox.x) / parentScale - zoomPanData->pan.x) / zoomPanData->zoom,
+                ((position.y - zoomPanData->boundingBox.y) / parentScale - zoomPanData->pan.y) / zoomPanData->zoom,
+            };
+        }
+    }
+    return position;
+}
+
 CLAY_WASM_EXPORT("Clay_GetElementData")
 Clay_ElementData Clay_GetElementData(Clay_ElementId id){
     Clay_LayoutElementHashMapItem * item = Clay__GetHashMapItem(id.id);
@@ -0,0 +1,87 @@
+package clay_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// roundedClip declares a 10x10 clipping element with the given corner radius.
+func roundedClip(id clay.ElementId, radius clay.CornerRadius, children func()) {
+	clay.UI(id)(clay.ElementDeclaration{
+		Layout:       clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+		CornerRadius: radius,
+		Clip:         clay.ClipElementConfig{Horizontal: true, Vertical: true},
+	}, children)
+}
+
+// filler declares a red element filling its parent.
+func filler() {
+	clay.UI()(clay.ElementDeclaration{
+		Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingGrow(0), Height: clay.SizingGrow(0)}},
+		BackgroundColor: red,
+	}, nil)
+}
+
+func TestClipCornerRadius(t *testing.T) {
+	newTestContext(t)
+	clay.BeginLayout()
+	roundedClip(clay.ID("Card"), clay.CornerRadiusAll(5), filler)
+	cmds := clay.EndLayout()
+	var found bool
+	for cmd := range cmds.Iter() {
+		if cmd.CommandType == clay.RENDER_COMMAND_TYPE_SCISSOR_START {
+			found = true
+			if cmd.RenderData.Clip.CornerRadius != clay.CornerRadiusAll(5) {
+				t.Errorf("expected the clip to carry the corner radius, got %v", cmd.RenderData.Clip.CornerRadius)
+			}
+		}
+	}
+	if !found {
+		t.Fatal("expected a SCISSOR_START command")
+	}
+}
+
+func TestClipRoundedSoftwareRenderer(t *testing.T) {
+	img := renderLayout(t, 10, 10, func() {
+		roundedClip(clay.ID("Card"), clay.CornerRadiusAll(5), filler)
+	})
+	redRGBA := color.RGBA{R: 255, A: 255}
+	expectPixels(t, img, []image.Point{{0, 0}, {9, 0}, {0, 9}, {9, 9}, {5, 5}, {1, 5}, {5, 1}},
+		[]color.RGBA{black, black, black, black, redRGBA, redRGBA, redRGBA})
+}
+
+func TestClipRoundedNested(t *testing.T) {
+	img := renderLayout(t, 10, 10, func() {
+		roundedClip(clay.ID("Outer"), clay.CornerRadius{TopLeft: 5}, func() {
+			roundedClip(clay.ID("Inner"), clay.CornerRadius{BottomRight: 5}, filler)
+		})
+	})
+	redRGBA := color.RGBA{R: 255, A: 255}
+	// Each clip cuts off its own corner, and the inner one stays inside the outer one
+	expectPixels(t, img, []image.Point{{0, 0}, {9, 9}, {9, 0}, {0, 9}, {5, 5}},
+		[]color.RGBA{black, black, redRGBA, redRGBA, redRGBA})
+}
+
+func TestClipNestedRestoresOuter(t *testing.T) {
+	img := renderLayout(t, 10, 10, func() {
+		clay.UI(clay.ID("Outer"))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(4), Height: clay.SizingFixed(4)}, LayoutDirection: clay.TOP_TO_BOTTOM},
+			Clip:   clay.ClipElementConfig{Horizontal: true, Vertical: true},
+		}, func() {
+			clay.UI(clay.ID("Inner"))(clay.ElementDeclaration{
+				Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(2), Height: clay.SizingFixed(2)}},
+				Clip:   clay.ClipElementConfig{Horizontal: true, Vertical: true},
+			}, nil)
+			clay.UI()(clay.ElementDeclaration{
+				Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+				BackgroundColor: red,
+			}, nil)
+		})
+	})
+	redRGBA := color.RGBA{R: 255, A: 255}
+	// The sibling after the inner clip is still clipped by the outer one
+	expectPixels(t, img, []image.Point{{0, 2}, {3, 3}, {4, 4}, {0, 6}}, []color.RGBA{redRGBA, redRGBA, black, black})
+}
@@ -0,0 +1,26 @@
+package clay
+
+// Clipboard gives text inputs access to a clipboard. Implement it on top of the platform clipboard and install it with SetClipboard.
+type Clipboard interface {
+	ReadText() string
+	WriteText(text string)
+}
+
+// MemoryClipboard is a Clipboard that only keeps its contents in memory.
+// It is used when no other clipboard has been set, and is useful as a fake in tests.
+type MemoryClipboard struct {
+	Text string
+}
+
+func (c *MemoryClipboard) ReadText() string {
+	return c.Text
+}
+
+func (c *MemoryClipboard) WriteText(text string) {
+	c.Text = text
+}
+
+// SetClipboard sets the clipboard used by text inputs in the current context.
+func SetClipboard(clipboard Clipboard) {
+	currentTextInputContext().clipboard = clipboard
+}
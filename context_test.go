@@ -0,0 +1,18 @@
+package clay_test
+
+import (
+	"testing"
+	"unsafe"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// newTestContext sets up a 400x300 context where every byte of text is 10 pixels wide and 20 pixels tall, and errors panic.
+func newTestContext(t *testing.T) {
+	t.Helper()
+	memory := make([]byte, clay.MinMemorySize())
+	clay.Initialize(clay.CreateArenaWithCapacityAndMemory(memory), clay.Dimensions{Width: 400, Height: 300}, clay.ErrorHandler{ErrorHandlerFunction: handleClayError})
+	clay.SetMeasureTextFunction(func(text clay.StringSlice, _ *clay.TextElementConfig, _ unsafe.Pointer) clay.Dimensions {
+		return clay.Dimensions{Width: float32(text.Length) * 10, Height: 20}
+	}, unsafe.Pointer(t))
+}
@@ -0,0 +1,30 @@
+package clay_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+func TestCornerRadiusSoftwareRenderer(t *testing.T) {
+	redRGBA := color.RGBA{R: 255, A: 255}
+	img := renderElement(t, 8, 8, clay.ElementDeclaration{
+		BackgroundColor: red,
+		CornerRadius:    clay.CornerRadius{TopLeft: 4, BottomRight: 4},
+	})
+	// Only the corners with a radius are rounded
+	expectPixels(t, img, row(0, 0, 4, 7), []color.RGBA{black, redRGBA, redRGBA})
+	expectPixels(t, img, row(7, 0, 3, 7), []color.RGBA{redRGBA, redRGBA, black})
+}
+
+func TestCornerRadiusBorderSoftwareRenderer(t *testing.T) {
+	whiteRGBA := color.RGBA{R: 255, G: 255, B: 255, A: 255}
+	img := renderElement(t, 8, 8, clay.ElementDeclaration{
+		Border:       clay.BorderElementConfig{Color: white, Width: clay.BorderOutside(1)},
+		CornerRadius: clay.CornerRadius{TopRight: 4},
+	})
+	expectPixels(t, img, row(0, 0, 3, 7), []color.RGBA{whiteRGBA, whiteRGBA, black})
+	expectPixels(t, img, row(7, 0, 7), []color.RGBA{whiteRGBA, whiteRGBA})
+	expectPixels(t, img, row(4, 1, 6), []color.RGBA{black, black})
+}
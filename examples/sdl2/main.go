@@ -67,6 +67,7 @@ func main() {
 	} else {
 		renderer, _ = sdl.CreateRenderer(window, -1, sdl.RENDERER_ACCELERATED)
 	}
+	defer sdl2.ReleaseRenderer(renderer)
 	_ = renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND) // for alpha blending
 
 	const screenWidth, screenHeight = 800, 600
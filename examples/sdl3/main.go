@@ -70,6 +70,7 @@ func main() {
 			font,
 		},
 	}
+	defer rendererData.Release()
 	surface, err := sdl.CreateSurfaceFrom(
 		videodemo.SquirrelImage.Bounds().Dx(),
 		videodemo.SquirrelImage.Bounds().Dy(),
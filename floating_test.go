@@ -0,0 +1,105 @@
+package clay_test
+
+import (
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutPopup lays out a 40x20 anchor at the provided position, and a popup 5 pixels below it that avoids collisions as configured.
+// With clip, the anchor is placed inside a 200x150 clip element at the top left of the root.
+func layoutPopup(anchor clay.Vector2, popup clay.Dimensions, collision clay.FloatingCollisionFlags, clip bool) clay.BoundingBox {
+	clay.BeginLayout()
+	container := clay.ElementDeclaration{Layout: clay.LayoutConfig{Padding: clay.Padding{Left: uint16(anchor.X), Top: uint16(anchor.Y)}}}
+	if clip {
+		container.Layout.Sizing = clay.Sizing{Width: clay.SizingFixed(200), Height: clay.SizingFixed(150)}
+		container.Clip = clay.ClipElementConfig{Horizontal: true, Vertical: true}
+	}
+	clay.UI(clay.ID("Container"))(container, func() {
+		clay.UI(clay.ID("Anchor"))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(20)}},
+		}, func() {
+			clay.UI(clay.ID("Popup"))(clay.ElementDeclaration{
+				Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(popup.Width), Height: clay.SizingFixed(popup.Height)}},
+				Floating: clay.FloatingElementConfig{
+					AttachTo:     clay.ATTACH_TO_PARENT,
+					AttachPoints: clay.FloatingAttachPoints{Element: clay.ATTACH_POINT_LEFT_TOP, Parent: clay.ATTACH_POINT_LEFT_BOTTOM},
+					Offset:       clay.Vector2{Y: 5},
+					Collision:    collision,
+				},
+			}, nil)
+		})
+	})
+	clay.EndLayout()
+	return clay.GetElementData(clay.ID("Popup")).BoundingBox
+}
+
+func TestFloatingCollision(t *testing.T) {
+	tests := []struct {
+		name      string
+		anchor    clay.Vector2
+		popup     clay.Dimensions
+		collision clay.FloatingCollisionFlags
+		clip      bool
+		want      clay.BoundingBox
+	}{
+		{"none", clay.Vector2{X: 350, Y: 270}, clay.Dimensions{Width: 100, Height: 50}, clay.FLOATING_COLLISION_NONE, false, clay.BoundingBox{X: 350, Y: 295, Width: 100, Height: 50}},
+		{"fits", clay.Vector2{X: 10, Y: 10}, clay.Dimensions{Width: 100, Height: 50}, clay.FLOATING_COLLISION_FLIP | clay.FLOATING_COLLISION_SHIFT, false, clay.BoundingBox{X: 10, Y: 35, Width: 100, Height: 50}},
+		// Flipping mirrors the attach points and the offset, so the popup opens above the anchor
+		{"flip y", clay.Vector2{X: 10, Y: 270}, clay.Dimensions{Width: 100, Height: 50}, clay.FLOATING_COLLISION_FLIP, false, clay.BoundingBox{X: 10, Y: 215, Width: 100, Height: 50}},
+		// and right aligned with it
+		{"flip both", clay.Vector2{X: 350, Y: 270}, clay.Dimensions{Width: 100, Height: 50}, clay.FLOATING_COLLISION_FLIP, false, clay.BoundingBox{X: 290, Y: 215, Width: 100, Height: 50}},
+		{"shift", clay.Vector2{X: 350, Y: 270}, clay.Dimensions{Width: 100, Height: 50}, clay.FLOATING_COLLISION_SHIFT, false, clay.BoundingBox{X: 300, Y: 250, Width: 100, Height: 50}},
+		// A popup that doesn't fit either way is aligned to the top left edge
+		{"too large", clay.Vector2{X: 350, Y: 10}, clay.Dimensions{Width: 500, Height: 50}, clay.FLOATING_COLLISION_FLIP | clay.FLOATING_COLLISION_SHIFT, false, clay.BoundingBox{X: 0, Y: 35, Width: 500, Height: 50}},
+		// Flipping only happens when it overflows less, otherwise shifting pushes the popup back inside
+		{"flip overflows more", clay.Vector2{X: 10, Y: 40}, clay.Dimensions{Width: 100, Height: 260}, clay.FLOATING_COLLISION_FLIP | clay.FLOATING_COLLISION_SHIFT, false, clay.BoundingBox{X: 10, Y: 40, Width: 100, Height: 260}},
+		// The clip element around the anchor is the boundary, even though the popup would fit in the root
+		{"clip parent", clay.Vector2{X: 10, Y: 120}, clay.Dimensions{Width: 100, Height: 50}, clay.FLOATING_COLLISION_FLIP, true, clay.BoundingBox{X: 10, Y: 65, Width: 100, Height: 50}},
+		{"clip parent shift", clay.Vector2{X: 150, Y: 10}, clay.Dimensions{Width: 100, Height: 50}, clay.FLOATING_COLLISION_SHIFT, true, clay.BoundingBox{X: 100, Y: 35, Width: 100, Height: 50}},
+	}
+	for _, test := range tests {
+		newTestContext(t)
+		if got := layoutPopup(test.anchor, test.popup, test.collision, test.clip); got != test.want {
+			t.Errorf("%s: got %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFloatingCollisionRenderCommand(t *testing.T) {
+	newTestContext(t)
+	clay.BeginLayout()
+	clay.UI()(clay.ElementDeclaration{Layout: clay.LayoutConfig{Padding: clay.Padding{Left: 350, Top: 270}}}, func() {
+		clay.UI(clay.ID("Anchor"))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(20)}},
+		}, func() {
+			clay.UI(clay.ID("Popup"))(clay.ElementDeclaration{
+				Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(50)}},
+				BackgroundColor: red,
+				Floating: clay.FloatingElementConfig{
+					AttachTo:     clay.ATTACH_TO_PARENT,
+					AttachPoints: clay.FloatingAttachPoints{Element: clay.ATTACH_POINT_LEFT_TOP, Parent: clay.ATTACH_POINT_LEFT_BOTTOM},
+					Collision:    clay.FLOATING_COLLISION_SHIFT,
+				},
+			}, nil)
+		})
+	})
+	cmds := clay.EndLayout()
+	// The popup is drawn where GetElementData reports it
+	var drawn bool
+	for cmd := range cmds.Iter() {
+		if cmd.Id != clay.ID("Popup").Id {
+			continue
+		}
+		drawn = true
+		if cmd.BoundingBox != clay.GetElementData(clay.ID("Popup")).BoundingBox {
+			t.Fatalf("expected the popup to be drawn at %v, got %v", clay.GetElementData(clay.ID("Popup")).BoundingBox, cmd.BoundingBox)
+		}
+	}
+	if !drawn {
+		t.Fatal("expected a render command for the popup")
+	}
+	if box := clay.GetElementData(clay.ID("Popup")).BoundingBox; box != (clay.BoundingBox{X: 300, Y: 250, Width: 100, Height: 50}) {
+		t.Fatalf("expected the popup to be shifted inside the root, got %v", box)
+	}
+}
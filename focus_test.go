@@ -0,0 +1,243 @@
+package clay_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// button declares a focusable 50x50 element and records whether it was focused, and whether the focus was visible.
+func button(name string, tabIndex int32, focused map[string]bool) {
+	clay.UI(clay.ID(name))(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(50), Height: clay.SizingFixed(50)}},
+		Focus:  clay.FocusElementConfig{Focusable: true, TabIndex: tabIndex},
+	}, func() {
+		if clay.Focused() {
+			focused[name] = clay.FocusVisible()
+		}
+	})
+}
+
+// layoutTabOrder lays out a row of buttons declared in the order A to E and returns the focused button.
+func layoutTabOrder() map[string]bool {
+	focused := map[string]bool{}
+	clay.BeginLayout()
+	clay.UI(clay.ID("Row"))(clay.ElementDeclaration{}, func() {
+		button("A", 0, focused)
+		button("B", 2, focused)
+		button("C", 1, focused)
+		button("D", -1, focused)
+		button("E", 0, focused)
+	})
+	clay.EndLayout()
+	return focused
+}
+
+func TestFocusTabOrder(t *testing.T) {
+	newTestContext(t)
+	layoutTabOrder()
+	// Positive tab indexes come first in ascending order, then tab index 0 in declaration order, and D is skipped
+	for _, want := range []string{"C", "B", "A", "E", "C"} {
+		if !clay.MoveFocus(clay.FOCUS_DIRECTION_NEXT) {
+			t.Fatalf("expected focus to move to %s", want)
+		}
+		if got := clay.GetFocusedElementId(); got.Id != clay.ID(want).Id {
+			t.Fatalf("expected %s to be focused, got %s", want, got.StringId)
+		}
+	}
+	for _, want := range []string{"E", "A", "B"} {
+		clay.MoveFocus(clay.FOCUS_DIRECTION_PREVIOUS)
+		if got := clay.GetFocusedElementId(); got.Id != clay.ID(want).Id {
+			t.Fatalf("expected %s to be focused going backwards, got %s", want, got.StringId)
+		}
+	}
+}
+
+func TestFocusKeyboard(t *testing.T) {
+	newTestContext(t)
+	layoutTabOrder()
+	clay.SetKeyboardState([]clay.KeyEvent{{Key: clay.KEY_TAB}, {Key: clay.KEY_TAB}})
+	if focused := layoutTabOrder(); len(focused) != 1 || !focused["B"] {
+		t.Fatalf("expected B to have visible focus after two tabs, got %v", focused)
+	}
+	clay.SetKeyboardState([]clay.KeyEvent{{Key: clay.KEY_TAB, Modifiers: clay.KEY_MODIFIER_SHIFT}})
+	if focused := layoutTabOrder(); len(focused) != 1 || !focused["C"] {
+		t.Fatalf("expected shift tab to focus C, got %v", focused)
+	}
+}
+
+func TestFocusOnKey(t *testing.T) {
+	newTestContext(t)
+	var handled []string
+	layout := func() {
+		clay.BeginLayout()
+		clay.UI(clay.ID("Input"))(clay.ElementDeclaration{Focus: clay.FocusElementConfig{Focusable: true}}, func() {
+			clay.OnKey(func(_ clay.ElementId, keyEvent clay.KeyEvent, userData any) bool {
+				handled = append(handled, userData.(string))
+				return keyEvent.Key == clay.KEY_TAB
+			}, "Input")
+		})
+		clay.UI(clay.ID("Next"))(clay.ElementDeclaration{Focus: clay.FocusElementConfig{Focusable: true}}, nil)
+		clay.EndLayout()
+	}
+	layout()
+	clay.SetFocus(clay.ID("Input"))
+	// The callback handles tab itself, so focus doesn't move
+	clay.SetKeyboardState([]clay.KeyEvent{{Key: clay.KEY_TAB}})
+	if len(handled) != 1 || handled[0] != "Input" {
+		t.Fatalf("expected the key to be passed to the callback with its user data, got %v", handled)
+	}
+	if got := clay.GetFocusedElementId(); got.Id != clay.ID("Input").Id {
+		t.Fatalf("expected Input to stay focused, got %s", got.StringId)
+	}
+}
+
+func TestFocusPointer(t *testing.T) {
+	newTestContext(t)
+	layoutTabOrder()
+	// D is skipped by tab navigation, but can still be focused with the pointer, without a focus ring
+	clay.SetPointerState(clay.Vector2{X: 175, Y: 25}, false)
+	clay.SetPointerState(clay.Vector2{X: 175, Y: 25}, true)
+	if visible, ok := layoutTabOrder()["D"]; !ok || visible {
+		t.Fatalf("expected D to have focus that isn't visible, got %s", clay.GetFocusedElementId().StringId)
+	}
+	clay.SetPointerState(clay.Vector2{X: 175, Y: 25}, false)
+	clay.SetPointerState(clay.Vector2{X: 300, Y: 25}, true)
+	if focused := layoutTabOrder(); len(focused) != 0 {
+		t.Fatalf("expected a press outside every button to clear focus, got %v", focused)
+	}
+}
+
+func TestFocusNotFocusable(t *testing.T) {
+	newTestContext(t)
+	clay.SetFocus(clay.ID("Row"))
+	layoutTabOrder()
+	if got := clay.GetFocusedElementId(); got.Id != 0 {
+		t.Fatalf("expected focus on an element that isn't focusable to be cleared, got %s", got.StringId)
+	}
+	clay.SetFocus(clay.ID("E"))
+	clay.ClearFocus()
+	if focused := layoutTabOrder(); len(focused) != 0 {
+		t.Fatalf("expected no focus after ClearFocus, got %v", focused)
+	}
+}
+
+// layoutGrid lays out a 3x3 grid of buttons named by their row and column, with a 10 pixel gap.
+// With a dialog, a modal floating element holding buttons Yes and No is opened over the grid.
+func layoutGrid(dialog bool) {
+	focused := map[string]bool{}
+	clay.BeginLayout()
+	clay.UI(clay.ID("Grid"))(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{LayoutDirection: clay.TOP_TO_BOTTOM, ChildGap: 10},
+	}, func() {
+		for row := range 3 {
+			clay.UI()(clay.ElementDeclaration{Layout: clay.LayoutConfig{ChildGap: 10}}, func() {
+				for column := range 3 {
+					button(fmt.Sprint(row, column), 0, focused)
+				}
+			})
+		}
+		if dialog {
+			clay.UI(clay.ID("Dialog"))(clay.ElementDeclaration{
+				Floating: clay.FloatingElementConfig{AttachTo: clay.ATTACH_TO_ROOT, Offset: clay.Vector2{X: 200}, Modal: true},
+			}, func() {
+				button("Yes", 0, focused)
+				button("No", 0, focused)
+			})
+		}
+	})
+	clay.EndLayout()
+}
+
+// expectFocus fails the test if the element with the provided name isn't focused.
+func expectFocus(t *testing.T, name string) {
+	t.Helper()
+	if got := clay.GetFocusedElementId(); got.Id != clay.ID(name).Id {
+		t.Fatalf("expected %s to be focused, got %q", name, got.StringId)
+	}
+}
+
+func TestFocusSpatial(t *testing.T) {
+	newTestContext(t)
+	layoutGrid(false)
+	// Without a focused element, a spatial move focuses the first element in tab order
+	clay.MoveFocus(clay.FOCUS_DIRECTION_DOWN)
+	expectFocus(t, "0 0")
+	moves := []struct {
+		direction clay.FocusDirection
+		want      string
+	}{
+		{clay.FOCUS_DIRECTION_RIGHT, "0 1"},
+		{clay.FOCUS_DIRECTION_DOWN, "1 1"},
+		{clay.FOCUS_DIRECTION_DOWN, "2 1"},
+		{clay.FOCUS_DIRECTION_RIGHT, "2 2"},
+		{clay.FOCUS_DIRECTION_UP, "1 2"},
+		{clay.FOCUS_DIRECTION_LEFT, "1 1"},
+		{clay.FOCUS_DIRECTION_LEFT, "1 0"},
+	}
+	for _, move := range moves {
+		if !clay.MoveFocus(move.direction) {
+			t.Fatalf("expected focus to move to %s", move.want)
+		}
+		expectFocus(t, move.want)
+	}
+	// Nothing is further left, so focus stays where it is
+	if clay.MoveFocus(clay.FOCUS_DIRECTION_LEFT) {
+		t.Fatal("expected no element to the left of 1 0")
+	}
+	expectFocus(t, "1 0")
+	clay.SetKeyboardState([]clay.KeyEvent{{Key: clay.KEY_UP}})
+	expectFocus(t, "0 0")
+}
+
+func TestFocusSpatialModal(t *testing.T) {
+	newTestContext(t)
+	clay.SetFocus(clay.ID("2 2"))
+	layoutGrid(true)
+	// The grid is outside the dialog, so moving focus starts over inside it
+	clay.MoveFocus(clay.FOCUS_DIRECTION_RIGHT)
+	expectFocus(t, "Yes")
+	clay.MoveFocus(clay.FOCUS_DIRECTION_RIGHT)
+	expectFocus(t, "No")
+	if clay.MoveFocus(clay.FOCUS_DIRECTION_DOWN) || clay.MoveFocus(clay.FOCUS_DIRECTION_RIGHT) {
+		t.Fatal("expected focus to stay inside the dialog")
+	}
+	clay.MoveFocus(clay.FOCUS_DIRECTION_NEXT)
+	expectFocus(t, "Yes")
+	clay.MoveFocus(clay.FOCUS_DIRECTION_LEFT)
+	expectFocus(t, "Yes")
+}
+
+// layoutFocusList lays out a 100 pixel tall scroll container holding a column of five buttons named A to E.
+func layoutFocusList() {
+	focused := map[string]bool{}
+	clay.BeginLayout()
+	clay.UI(clay.ID("List"))(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Height: clay.SizingFixed(100)}, LayoutDirection: clay.TOP_TO_BOTTOM},
+		Clip:   clay.ClipElementConfig{Vertical: true, ChildOffset: clay.GetScrollOffset()},
+	}, func() {
+		for _, name := range []string{"A", "B", "C", "D", "E"} {
+			button(name, 0, focused)
+		}
+	})
+	clay.EndLayout()
+}
+
+func TestFocusSpatialScrollsIntoView(t *testing.T) {
+	newTestContext(t)
+	layoutFocusList()
+	clay.SetFocus(clay.ID("A"))
+	for range 3 {
+		clay.MoveFocus(clay.FOCUS_DIRECTION_DOWN)
+	}
+	expectFocus(t, "D")
+	for range 60 {
+		clay.UpdateScrollContainers(false, clay.Vector2{}, 1.0/60)
+		layoutFocusList()
+	}
+	// D spans 150 to 200, so the list scrolls just far enough to show its bottom edge
+	if position := *clay.GetScrollContainerData(clay.ID("List")).ScrollPosition; position != (clay.Vector2{Y: -100}) {
+		t.Fatalf("expected the list to scroll D into view, got %v", position)
+	}
+}
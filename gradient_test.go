@@ -0,0 +1,130 @@
+package clay_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+	"github.com/TotallyGamerJet/clay/renderers/software"
+	"golang.org/x/image/draw"
+)
+
+var (
+	red   = clay.Color{R: 255, A: 255}
+	blue  = clay.Color{B: 255, A: 255}
+	white = clay.Color{R: 255, G: 255, B: 255, A: 255}
+	black = color.RGBA{A: 255}
+)
+
+// renderElement renders a single element of the given size at the origin with the software renderer, over a black background.
+func renderElement(t *testing.T, width, height float32, decl clay.ElementDeclaration) *image.RGBA {
+	t.Helper()
+	decl.Layout.Sizing = clay.Sizing{Width: clay.SizingFixed(width), Height: clay.SizingFixed(height)}
+	return renderLayout(t, int(width), int(height), func() {
+		clay.UI(clay.ID("Element"))(decl, nil)
+	})
+}
+
+// renderLayout renders the elements declared by layout with the software renderer into an image of the given size, over a black background.
+func renderLayout(t *testing.T, width, height int, layout func()) *image.RGBA {
+	t.Helper()
+	newTestContext(t)
+	clay.BeginLayout()
+	layout()
+	cmds := clay.EndLayout()
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(black), image.Point{}, draw.Src)
+	if err := software.ClayRender(img, cmds, nil); err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func expectPixels(t *testing.T, img *image.RGBA, points []image.Point, want []color.RGBA) {
+	t.Helper()
+	for i, p := range points {
+		if got := img.RGBAAt(p.X, p.Y); got != want[i] {
+			t.Errorf("pixel %v: expected %v, got %v", p, want[i], got)
+		}
+	}
+}
+
+func row(y int, xs ...int) []image.Point {
+	points := make([]image.Point, len(xs))
+	for i, x := range xs {
+		points[i] = image.Pt(x, y)
+	}
+	return points
+}
+
+func TestLinearGradient(t *testing.T) {
+	img := renderElement(t, 4, 2, clay.ElementDeclaration{
+		BackgroundGradient: clay.LinearGradient(90, clay.GradientStop{Color: red}, clay.GradientStop{Color: blue, Position: 1}),
+	})
+	// Pixels are colored at their centers, an eighth of the way along for the first one
+	want := []color.RGBA{{223, 0, 32, 255}, {159, 0, 96, 255}, {96, 0, 159, 255}, {32, 0, 223, 255}}
+	expectPixels(t, img, row(0, 0, 1, 2, 3), want)
+	expectPixels(t, img, row(1, 0, 1, 2, 3), want)
+}
+
+func TestLinearGradientAngle(t *testing.T) {
+	img := renderElement(t, 1, 4, clay.ElementDeclaration{
+		BackgroundGradient: clay.LinearGradient(0, clay.GradientStop{Color: red}, clay.GradientStop{Color: blue, Position: 1}),
+	})
+	// 0 degrees runs from the bottom to the top
+	expectPixels(t, img, []image.Point{{0, 0}, {0, 1}, {0, 2}, {0, 3}},
+		[]color.RGBA{{32, 0, 223, 255}, {96, 0, 159, 255}, {159, 0, 96, 255}, {223, 0, 32, 255}})
+}
+
+func TestLinearGradientStops(t *testing.T) {
+	img := renderElement(t, 8, 1, clay.ElementDeclaration{
+		BackgroundGradient: clay.LinearGradient(90,
+			clay.GradientStop{Color: red, Position: 0.25},
+			clay.GradientStop{Color: white, Position: 0.5},
+			clay.GradientStop{Color: white, Position: 0.5},
+			clay.GradientStop{Color: blue, Position: 0.75},
+		),
+	})
+	expectPixels(t, img, row(0, 0, 1, 2, 3, 4, 5, 6, 7), []color.RGBA{
+		{255, 0, 0, 255},
+		{255, 0, 0, 255},
+		{255, 64, 64, 255},
+		{255, 191, 191, 255},
+		{191, 191, 255, 255},
+		{64, 64, 255, 255},
+		{0, 0, 255, 255},
+		{0, 0, 255, 255},
+	})
+}
+
+func TestRadialGradient(t *testing.T) {
+	img := renderElement(t, 5, 5, clay.ElementDeclaration{
+		BackgroundGradient: clay.RadialGradient(clay.Vector2{X: 0.5, Y: 0.5}, 2.5, clay.GradientStop{Color: white}, clay.GradientStop{Color: clay.Color{A: 255}, Position: 1}),
+	})
+	// Distances from the center of 0, 1, 2, sqrt(2) and past the radius
+	expectPixels(t, img, []image.Point{{2, 2}, {3, 2}, {2, 0}, {1, 1}, {0, 0}}, []color.RGBA{
+		{255, 255, 255, 255}, {153, 153, 153, 255}, {51, 51, 51, 255}, {111, 111, 111, 255}, {0, 0, 0, 255},
+	})
+}
+
+func TestGradientCornerRadius(t *testing.T) {
+	img := renderElement(t, 10, 10, clay.ElementDeclaration{
+		BackgroundGradient: clay.LinearGradient(90, clay.GradientStop{Color: red}),
+		CornerRadius:       clay.CornerRadiusAll(5),
+	})
+	expectPixels(t, img, []image.Point{{0, 0}, {9, 9}, {5, 5}, {1, 5}}, []color.RGBA{black, black, {255, 0, 0, 255}, {255, 0, 0, 255}})
+}
+
+func TestBorderGradient(t *testing.T) {
+	img := renderElement(t, 4, 4, clay.ElementDeclaration{
+		Border: clay.BorderElementConfig{
+			Width:    clay.BorderOutside(1),
+			Gradient: clay.LinearGradient(90, clay.GradientStop{Color: red}, clay.GradientStop{Color: blue, Position: 1}),
+		},
+	})
+	edge := []color.RGBA{{223, 0, 32, 255}, {159, 0, 96, 255}, {96, 0, 159, 255}, {32, 0, 223, 255}}
+	expectPixels(t, img, row(0, 0, 1, 2, 3), edge)
+	expectPixels(t, img, row(1, 0, 1, 2, 3), []color.RGBA{edge[0], black, black, edge[3]})
+	expectPixels(t, img, row(3, 0, 1, 2, 3), edge)
+}
@@ -0,0 +1,124 @@
+package clay_test
+
+import (
+	"image"
+	"image/color"
+	"testing"
+	"unsafe"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// pixels returns an image with a row for every string, made of 'w'hite, 'r'ed and 'b'lue pixels.
+func pixels(rows ...string) *image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, len(rows[0]), len(rows)))
+	colors := map[byte]color.RGBA{'w': {255, 255, 255, 255}, 'r': {R: 255, A: 255}, 'b': {B: 255, A: 255}}
+	for y, r := range rows {
+		for x := range len(r) {
+			img.SetRGBA(x, y, colors[r[x]])
+		}
+	}
+	var m image.Image = img
+	return &m
+}
+
+// checkerboard returns a 4x2 image whose left half is red and right half is blue.
+func checkerboard() *image.Image {
+	return pixels("rrbb", "rrbb")
+}
+
+func TestImageIntrinsicSize(t *testing.T) {
+	newTestContext(t)
+	clay.BeginLayout()
+	clay.UI(clay.ID("Column"))(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40)}, LayoutDirection: clay.TOP_TO_BOTTOM},
+	}, func() {
+		clay.UI(clay.ID("Natural"))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Padding: clay.PaddingAll(1)},
+			Image:  clay.ImageElementConfig{ImageData: unsafe.Pointer(checkerboard()), IntrinsicSize: clay.Dimensions{Width: 8, Height: 4}},
+		}, nil)
+		clay.UI(clay.ID("Grown"))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingGrow(0)}},
+			Image:  clay.ImageElementConfig{ImageData: unsafe.Pointer(checkerboard()), IntrinsicSize: clay.Dimensions{Width: 8, Height: 4}},
+		}, nil)
+	})
+	clay.EndLayout()
+	// FIT sized images take their intrinsic size, on top of their padding
+	if natural := clay.GetElementData(clay.ID("Natural")); natural.BoundingBox.Width != 10 || natural.BoundingBox.Height != 6 {
+		t.Errorf("expected a 10x6 element, got %v", natural.BoundingBox)
+	}
+	// Images that grow keep the aspect ratio of their intrinsic size
+	if grown := clay.GetElementData(clay.ID("Grown")); grown.BoundingBox.Width != 40 || grown.BoundingBox.Height != 20 {
+		t.Errorf("expected a 40x20 element, got %v", grown.BoundingBox)
+	}
+}
+
+func TestImageFitSoftwareRenderer(t *testing.T) {
+	redRGBA, blueRGBA := color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	t.Run("contain", func(t *testing.T) {
+		img := renderElement(t, 4, 4, clay.ElementDeclaration{
+			Image: clay.ImageElementConfig{
+				ImageData: unsafe.Pointer(checkerboard()),
+				Fit:       clay.IMAGE_FIT_CONTAIN,
+				Alignment: clay.ChildAlignment{Y: clay.ALIGN_Y_BOTTOM},
+			},
+		})
+		expectPixels(t, img, []image.Point{{0, 0}, {3, 1}, {0, 2}, {3, 3}}, []color.RGBA{black, black, redRGBA, blueRGBA})
+	})
+	t.Run("cover", func(t *testing.T) {
+		img := renderElement(t, 2, 2, clay.ElementDeclaration{
+			Image: clay.ImageElementConfig{
+				ImageData: unsafe.Pointer(checkerboard()),
+				Fit:       clay.IMAGE_FIT_COVER,
+				Alignment: clay.ChildAlignment{X: clay.ALIGN_X_RIGHT},
+			},
+		})
+		// Only the right half of the image is kept
+		expectPixels(t, img, []image.Point{{0, 0}, {1, 1}}, []color.RGBA{blueRGBA, blueRGBA})
+	})
+	t.Run("source rect", func(t *testing.T) {
+		img := renderElement(t, 4, 4, clay.ElementDeclaration{
+			Image: clay.ImageElementConfig{
+				ImageData:  unsafe.Pointer(checkerboard()),
+				SourceRect: clay.BoundingBox{Width: 2, Height: 2},
+			},
+		})
+		expectPixels(t, img, []image.Point{{0, 0}, {3, 3}}, []color.RGBA{redRGBA, redRGBA})
+	})
+	t.Run("none", func(t *testing.T) {
+		img := renderElement(t, 6, 4, clay.ElementDeclaration{
+			Image: clay.ImageElementConfig{
+				ImageData: unsafe.Pointer(checkerboard()),
+				Fit:       clay.IMAGE_FIT_NONE,
+				Alignment: clay.ChildAlignment{X: clay.ALIGN_X_CENTER, Y: clay.ALIGN_Y_CENTER},
+			},
+		})
+		expectPixels(t, img, append(row(1, 0, 1, 4, 5), row(0, 2)...), []color.RGBA{black, redRGBA, blueRGBA, black, black})
+	})
+}
+
+func TestImageNineSliceSoftwareRenderer(t *testing.T) {
+	whiteRGBA, redRGBA, blueRGBA := color.RGBA{255, 255, 255, 255}, color.RGBA{R: 255, A: 255}, color.RGBA{B: 255, A: 255}
+	t.Run("stretched", func(t *testing.T) {
+		img := renderElement(t, 6, 6, clay.ElementDeclaration{
+			Image: clay.ImageElementConfig{
+				ImageData: unsafe.Pointer(pixels("www", "wrw", "www")),
+				NineSlice: clay.NineSlice{Left: 1, Top: 1, Right: 1, Bottom: 1},
+			},
+		})
+		// The corners and edges stay a pixel wide while the center fills the rest
+		expectPixels(t, img, row(0, 0, 3, 5), []color.RGBA{whiteRGBA, whiteRGBA, whiteRGBA})
+		expectPixels(t, img, row(3, 0, 1, 3, 4, 5), []color.RGBA{whiteRGBA, redRGBA, redRGBA, redRGBA, whiteRGBA})
+		expectPixels(t, img, row(5, 0, 3, 5), []color.RGBA{whiteRGBA, whiteRGBA, whiteRGBA})
+	})
+	t.Run("tiled", func(t *testing.T) {
+		img := renderElement(t, 8, 1, clay.ElementDeclaration{
+			Image: clay.ImageElementConfig{
+				ImageData: unsafe.Pointer(pixels("wrbw")),
+				NineSlice: clay.NineSlice{Left: 1, Right: 1, Tiled: true},
+			},
+		})
+		expectPixels(t, img, row(0, 0, 1, 2, 3, 4, 5, 6, 7),
+			[]color.RGBA{whiteRGBA, redRGBA, blueRGBA, redRGBA, blueRGBA, redRGBA, blueRGBA, whiteRGBA})
+	})
+}
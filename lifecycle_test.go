@@ -0,0 +1,148 @@
+package clay_test
+
+import (
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+type lifecycleEvent struct {
+	event      clay.LifecycleEvent
+	visibility clay.ElementVisibility
+}
+
+// layoutItem lays out a 40x20 element named Item at (x, 0) that records its lifecycle events, or leaves it out when it isn't declared.
+func layoutItem(x float32, declared bool, events *[]lifecycleEvent) {
+	clay.BeginLayout()
+	clay.UI()(clay.ElementDeclaration{Layout: clay.LayoutConfig{Padding: clay.Padding{Left: uint16(x)}}}, func() {
+		if declared {
+			clay.UI(clay.ID("Item"))(clay.ElementDeclaration{
+				Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(20)}},
+			}, func() {
+				clay.OnLifecycle(func(_ clay.ElementId, event clay.LifecycleEvent, visibility clay.ElementVisibility, userData any) {
+					events := userData.(*[]lifecycleEvent)
+					*events = append(*events, lifecycleEvent{event, visibility})
+				}, events)
+			})
+		}
+	})
+	clay.EndLayout()
+}
+
+func TestLifecycleEvents(t *testing.T) {
+	newTestContext(t)
+	visible := clay.ElementVisibility{VisibleBoundingBox: clay.BoundingBox{X: 100, Width: 40, Height: 20}, VisibleFraction: 1, Visible: true, Found: true}
+	half := clay.ElementVisibility{VisibleBoundingBox: clay.BoundingBox{X: 380, Width: 20, Height: 20}, VisibleFraction: 0.5, Visible: true, Found: true}
+	steps := []struct {
+		x        float32
+		declared bool
+		want     []lifecycleEvent
+	}{
+		{100, true, []lifecycleEvent{{clay.LIFECYCLE_EVENT_APPEARED, visible}}},
+		// Nothing changed, so nothing is reported
+		{100, true, nil},
+		{380, true, []lifecycleEvent{{clay.LIFECYCLE_EVENT_VISIBILITY_CHANGED, half}}},
+		// Moving offscreen changes the visibility, the element is still declared
+		{420, true, []lifecycleEvent{{clay.LIFECYCLE_EVENT_VISIBILITY_CHANGED, clay.ElementVisibility{Found: true}}}},
+		{100, true, []lifecycleEvent{{clay.LIFECYCLE_EVENT_VISIBILITY_CHANGED, visible}}},
+		// The binding from the last layout the element was declared in is told it disappeared
+		{100, false, []lifecycleEvent{{clay.LIFECYCLE_EVENT_DISAPPEARED, clay.ElementVisibility{}}}},
+		{100, false, nil},
+		{100, true, []lifecycleEvent{{clay.LIFECYCLE_EVENT_APPEARED, visible}}},
+	}
+	// Shared between frames, since a binding from an earlier layout reports the element disappearing
+	var events []lifecycleEvent
+	for i, step := range steps {
+		events = nil
+		layoutItem(step.x, step.declared, &events)
+		if len(events) != len(step.want) {
+			t.Fatalf("frame %d: expected events %v, got %v", i, step.want, events)
+		}
+		for j := range events {
+			if events[j] != step.want[j] {
+				t.Fatalf("frame %d: expected events %v, got %v", i, step.want, events)
+			}
+		}
+		if got := clay.GetElementVisibility(clay.ID("Item")); got.Found != step.declared {
+			t.Fatalf("frame %d: expected found to be %v, got %v", i, step.declared, got)
+		}
+	}
+}
+
+func TestVisibilityNestedClip(t *testing.T) {
+	newTestContext(t)
+	clay.BeginLayout()
+	clay.UI(clay.ID("Outer"))(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(100)}},
+		Clip:   clay.ClipElementConfig{Horizontal: true, Vertical: true},
+	}, func() {
+		clay.UI()(clay.ElementDeclaration{Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(60)}}}, nil)
+		// Inner spans 60 to 160, so only its left 40 pixels are inside Outer
+		clay.UI(clay.ID("Inner"))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(100)}, Padding: clay.Padding{Top: 90}},
+			Clip:   clay.ClipElementConfig{Horizontal: true, Vertical: true},
+		}, func() {
+			for _, name := range []string{"A", "B", "C"} {
+				clay.UI(clay.ID(name))(clay.ElementDeclaration{
+					Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(20), Height: clay.SizingFixed(20)}},
+				}, nil)
+			}
+		})
+	})
+	clay.EndLayout()
+	tests := []struct {
+		name string
+		want clay.ElementVisibility
+	}{
+		{"Inner", clay.ElementVisibility{VisibleBoundingBox: clay.BoundingBox{X: 60, Width: 40, Height: 100}, VisibleFraction: 0.4, Visible: true, Found: true}},
+		// A is clipped by the bottom of Inner
+		{"A", clay.ElementVisibility{VisibleBoundingBox: clay.BoundingBox{X: 60, Y: 90, Width: 20, Height: 10}, VisibleFraction: 0.5, Visible: true, Found: true}},
+		// B is inside Inner, but the right edge of Outer cuts it in half as well
+		{"B", clay.ElementVisibility{VisibleBoundingBox: clay.BoundingBox{X: 80, Y: 90, Width: 20, Height: 10}, VisibleFraction: 0.5, Visible: true, Found: true}},
+		// C is inside Inner, but entirely outside Outer
+		{"C", clay.ElementVisibility{Found: true}},
+		{"Missing", clay.ElementVisibility{}},
+	}
+	for _, test := range tests {
+		if got := clay.GetElementVisibility(clay.ID(test.name)); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
+
+func TestVisibilityFloating(t *testing.T) {
+	newTestContext(t)
+	clay.BeginLayout()
+	clay.UI(clay.ID("Container"))(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(100)}},
+		Clip:   clay.ClipElementConfig{Horizontal: true, Vertical: true},
+	}, func() {
+		// Floating elements that escape their parent's clipping are only clipped by the root
+		clay.UI(clay.ID("Escaped"))(clay.ElementDeclaration{
+			Layout:   clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(20)}},
+			Floating: clay.FloatingElementConfig{AttachTo: clay.ATTACH_TO_PARENT, Offset: clay.Vector2{X: 80}},
+		}, nil)
+		clay.UI(clay.ID("Clipped"))(clay.ElementDeclaration{
+			Layout:   clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(20)}},
+			Floating: clay.FloatingElementConfig{AttachTo: clay.ATTACH_TO_PARENT, Offset: clay.Vector2{X: 80}, ClipTo: clay.CLIP_TO_ATTACHED_PARENT},
+		}, nil)
+	})
+	clay.UI(clay.ID("Root"))(clay.ElementDeclaration{
+		Layout:   clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(20)}},
+		Floating: clay.FloatingElementConfig{AttachTo: clay.ATTACH_TO_ROOT, Offset: clay.Vector2{X: 390, Y: 290}},
+	}, nil)
+	clay.EndLayout()
+	tests := []struct {
+		name string
+		want clay.ElementVisibility
+	}{
+		{"Escaped", clay.ElementVisibility{VisibleBoundingBox: clay.BoundingBox{X: 80, Width: 40, Height: 20}, VisibleFraction: 1, Visible: true, Found: true}},
+		{"Clipped", clay.ElementVisibility{VisibleBoundingBox: clay.BoundingBox{X: 80, Width: 20, Height: 20}, VisibleFraction: 0.5, Visible: true, Found: true}},
+		{"Root", clay.ElementVisibility{VisibleBoundingBox: clay.BoundingBox{X: 390, Y: 290, Width: 10, Height: 10}, VisibleFraction: 0.125, Visible: true, Found: true}},
+	}
+	for _, test := range tests {
+		if got := clay.GetElementVisibility(clay.ID(test.name)); got != test.want {
+			t.Errorf("%s: expected %v, got %v", test.name, test.want, got)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package clay_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+func TestOpacityLayer(t *testing.T) {
+	newTestContext(t)
+	clay.BeginLayout()
+	clay.UI(clay.ID("Faded"))(clay.ElementDeclaration{
+		Opacity:         clay.Opacity(0.5),
+		BackgroundColor: red,
+	}, nil)
+	clay.UI(clay.ID("Opaque"))(clay.ElementDeclaration{
+		Opacity:         clay.Opacity(1),
+		BackgroundColor: red,
+	}, nil)
+	cmds := clay.EndLayout()
+	var types []clay.RenderCommandType
+	for cmd := range cmds.Iter() {
+		types = append(types, cmd.CommandType)
+		if cmd.CommandType == clay.RENDER_COMMAND_TYPE_LAYER_START && cmd.RenderData.Layer.Opacity != 0.5 {
+			t.Errorf("expected layer opacity 0.5, got %v", cmd.RenderData.Layer.Opacity)
+		}
+	}
+	// Fully opaque elements don't need a layer
+	want := []clay.RenderCommandType{
+		clay.RENDER_COMMAND_TYPE_LAYER_START, clay.RENDER_COMMAND_TYPE_RECTANGLE, clay.RENDER_COMMAND_TYPE_LAYER_END,
+		clay.RENDER_COMMAND_TYPE_RECTANGLE,
+	}
+	if len(types) != len(want) {
+		t.Fatalf("expected commands %v, got %v", want, types)
+	}
+	for i := range want {
+		if types[i] != want[i] {
+			t.Fatalf("expected commands %v, got %v", want, types)
+		}
+	}
+}
+
+func TestOpacityOverlappingChildren(t *testing.T) {
+	img := renderLayout(t, 4, 2, func() {
+		clay.UI(clay.ID("Faded"))(clay.ElementDeclaration{
+			Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(4), Height: clay.SizingFixed(2)}},
+			Opacity:         clay.Opacity(0.5),
+			BackgroundColor: red,
+		}, func() {
+			clay.UI()(clay.ElementDeclaration{
+				Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(2), Height: clay.SizingGrow(0)}},
+				BackgroundColor: blue,
+			}, nil)
+		})
+	})
+	// The child covers its parent before the two are faded together, so no red shows through it
+	faded := []color.RGBA{{0, 0, 128, 255}, {0, 0, 128, 255}, {128, 0, 0, 255}, {128, 0, 0, 255}}
+	expectPixels(t, img, row(0, 0, 1, 2, 3), faded)
+	expectPixels(t, img, row(1, 0, 1, 2, 3), faded)
+}
+
+func TestTranslucentRectangleSoftwareRenderer(t *testing.T) {
+	img := renderElement(t, 4, 2, clay.ElementDeclaration{BackgroundColor: clay.Color{R: 255, A: 128}})
+	// Rectangles without rounded corners are blended over what's beneath them too
+	faded := []color.RGBA{{128, 0, 0, 255}, {128, 0, 0, 255}}
+	expectPixels(t, img, row(0, 0, 3), faded)
+	expectPixels(t, img, row(1, 0, 3), faded)
+}
@@ -0,0 +1,239 @@
+package clay
+
+import "time"
+
+const (
+	// Overlays are drawn above ordinary floating elements, starting at this z index and rising by two per stack level.
+	overlayZIndex = 1000
+	// Tooltips are drawn above every overlay.
+	tooltipZIndex = 30000
+
+	defaultTooltipDelay = 500 * time.Millisecond
+)
+
+type OverlayConfig struct {
+	// Draws a backdrop over everything below the overlay that captures the pointer, and confines focus navigation to the overlay.
+	Modal         bool
+	BackdropColor Color
+	// Keeps the overlay open when the pointer is pressed outside of it.
+	IgnoreOutsideClick bool
+	// Keeps the overlay open when CLAY_KEY_ESCAPE is pressed while it is the topmost overlay.
+	IgnoreEscape bool
+}
+
+type TooltipConfig struct {
+	// How long the pointer has to rest on the anchor before the tooltip appears, defaults to 500ms.
+	// The time is measured with the frame times passed to SetDeltaTime.
+	Delay time.Duration
+}
+
+type overlayEntry struct {
+	id ElementId
+	// The element that was focused when the overlay opened, restored when it closes.
+	previousFocus ElementId
+	config        OverlayConfig
+	// The element the overlay is attached to, if any. Pressing it doesn't count as an outside click, so it can toggle the overlay.
+	anchor uint32
+	// The layout generation the overlay was opened in, so the press that opened it doesn't also dismiss it.
+	opened uint32
+}
+
+type overlayContext struct {
+	stack      []overlayEntry
+	generation uint32
+}
+
+type tooltipState struct {
+	hovering bool
+	hovered  time.Duration // frame time since the pointer started resting on the anchor
+}
+
+var overlayContexts = map[*Context]*overlayContext{}
+
+// currentOverlays returns the overlay state of the current context, after dismissing overlays for outside clicks once per layout.
+func currentOverlays() *overlayContext {
+	context := GetCurrentContext()
+	oc, ok := overlayContexts[context]
+	if !ok {
+		oc = &overlayContext{}
+		overlayContexts[context] = oc
+	}
+	if oc.generation != context.generation {
+		oc.generation = context.generation
+		if context.pointerInfo.State == POINTER_DATA_PRESSED_THIS_FRAME {
+			oc.dismissOutside()
+		}
+	}
+	return oc
+}
+
+func overlayBackdropId(id ElementId) ElementId {
+	return __HashString(toString("OverlayBackdrop"), id.Id)
+}
+
+// OpenOverlay opens the overlay with the given id on top of the overlay stack. If it is already open, the overlays above it are closed.
+// The overlay is displayed by declaring it with Overlay.
+func OpenOverlay(id ElementId) {
+	oc := currentOverlays()
+	if i := oc.index(id); i >= 0 {
+		oc.closeFrom(i + 1)
+		return
+	}
+	oc.stack = append(oc.stack, overlayEntry{id: id, previousFocus: GetFocusedElementId(), opened: GetCurrentContext().generation})
+}
+
+// OpenChildOverlay opens the overlay with the given id directly above parent, closing any other overlays above parent first.
+// It is intended for nested submenus, where opening one submenu closes its siblings. If parent isn't open, it behaves like OpenOverlay.
+func OpenChildOverlay(parent, id ElementId) {
+	oc := currentOverlays()
+	if i := oc.index(parent); i >= 0 && oc.index(id) != i+1 {
+		oc.closeFrom(i + 1)
+	}
+	OpenOverlay(id)
+}
+
+// CloseOverlay closes the overlay with the given id along with every overlay above it, restoring the focus from before they were opened.
+func CloseOverlay(id ElementId) {
+	oc := currentOverlays()
+	if i := oc.index(id); i >= 0 {
+		oc.closeFrom(i)
+	}
+}
+
+// CloseAllOverlays closes every open overlay.
+func CloseAllOverlays() {
+	currentOverlays().closeFrom(0)
+}
+
+// OverlayOpen returns true if the overlay with the given id is open.
+func OverlayOpen(id ElementId) bool {
+	return currentOverlays().index(id) >= 0
+}
+
+// Overlay declares the overlay with the given id if it is open, and returns whether it did. The overlay is a floating element laid out
+// according to decl, stacked above the overlays opened before it. decl.Floating positions it, and attaches it to the root when
+// AttachTo is left as ATTACH_TO_NONE. Overlay can be declared anywhere in the layout, e.g. next to the button that opens it.
+func Overlay(id ElementId, config OverlayConfig, decl ElementDeclaration, children func()) bool {
+	oc := currentOverlays()
+	level := oc.index(id)
+	if level < 0 {
+		return false
+	}
+	entry := &oc.stack[level]
+	entry.config = config
+	if decl.Floating.AttachTo == ATTACH_TO_NONE {
+		decl.Floating.AttachTo = ATTACH_TO_ROOT
+	}
+	entry.anchor = 0
+	if decl.Floating.AttachTo == ATTACH_TO_ELEMENT_WITH_ID {
+		entry.anchor = decl.Floating.ParentId
+	}
+	zIndex := int16(overlayZIndex + level*2)
+	if config.Modal {
+		UI(overlayBackdropId(id))(ElementDeclaration{
+			BackgroundColor: config.BackdropColor,
+			Layout:          LayoutConfig{Sizing: Sizing{Width: SizingGrow(0), Height: SizingGrow(0)}},
+			Floating:        FloatingElementConfig{AttachTo: ATTACH_TO_ROOT, ZIndex: zIndex},
+		}, nil)
+	}
+	decl.Floating.ZIndex = zIndex + 1
+	decl.Floating.PointerCaptureMode = POINTER_CAPTURE_MODE_CAPTURE
+	decl.Floating.Modal = decl.Floating.Modal || config.Modal
+	UI(id)(decl, children)
+	return true
+}
+
+// Tooltip declares a tooltip for the element with the given anchor id once the pointer has rested on it for the configured delay,
+// and returns whether it did. The tooltip is a floating element laid out according to decl that doesn't capture the pointer.
+// It is centered below the anchor unless decl.Floating.AttachTo is set. Tooltip must be declared every frame to track the hover.
+func Tooltip(anchor ElementId, config TooltipConfig, decl ElementDeclaration, children func()) bool {
+	if config.Delay == 0 {
+		config.Delay = defaultTooltipDelay
+	}
+	st := State[tooltipState](anchor)
+	pointer := GetCurrentContext().pointerInfo
+	if !PointerOver(anchor) || pointer.State == POINTER_DATA_PRESSED_THIS_FRAME || pointer.State == POINTER_DATA_PRESSED {
+		st.hovering = false
+		return false
+	}
+	if !st.hovering {
+		st.hovering, st.hovered = true, 0
+		return false
+	}
+	st.hovered += frameTime()
+	if st.hovered < config.Delay {
+		return false
+	}
+	if decl.Floating.AttachTo == ATTACH_TO_NONE {
+		decl.Floating.AttachTo = ATTACH_TO_ELEMENT_WITH_ID
+		decl.Floating.ParentId = anchor.Id
+		decl.Floating.AttachPoints = FloatingAttachPoints{Element: ATTACH_POINT_CENTER_TOP, Parent: ATTACH_POINT_CENTER_BOTTOM}
+	}
+	decl.Floating.ZIndex = tooltipZIndex
+	decl.Floating.PointerCaptureMode = POINTER_CAPTURE_MODE_PASSTHROUGH
+	UI(__HashString(toString("Tooltip"), anchor.Id))(decl, children)
+	return true
+}
+
+func (oc *overlayContext) index(id ElementId) int {
+	for i, entry := range oc.stack {
+		if entry.id.Id == id.Id {
+			return i
+		}
+	}
+	return -1
+}
+
+// closeFrom closes the overlays from index i to the top of the stack, topmost first, so the focus ends up where it was before overlay i opened.
+func (oc *overlayContext) closeFrom(i int) {
+	for len(oc.stack) > i {
+		oc.remove(len(oc.stack) - 1)
+	}
+}
+
+// remove closes overlay i. Focus is only restored when it is the topmost overlay, since the overlays above it own the focus.
+func (oc *overlayContext) remove(i int) {
+	entry := oc.stack[i]
+	oc.stack = append(oc.stack[:i], oc.stack[i+1:]...)
+	if i < len(oc.stack) {
+		return
+	}
+	if entry.previousFocus.Id != 0 {
+		SetFocus(entry.previousFocus)
+	} else {
+		ClearFocus()
+	}
+}
+
+// dismissOutside closes the overlays above the topmost one under the pointer, using the hit test from the last layout.
+// Overlays that ignore outside clicks stop the dismissal, along with everything below them. Overlays opened by this press are kept.
+func (oc *overlayContext) dismissOutside() {
+	generation := GetCurrentContext().generation
+	for i := len(oc.stack) - 1; i >= 0; i-- {
+		entry := oc.stack[i]
+		if entry.opened+1 >= generation {
+			continue
+		}
+		if PointerOver(entry.id) || entry.anchor != 0 && PointerOver(ElementId{Id: entry.anchor}) || entry.config.IgnoreOutsideClick {
+			return
+		}
+		oc.remove(i)
+	}
+}
+
+// dismissWithKeys closes the topmost overlay for each CLAY_KEY_ESCAPE press, and returns the remaining key events.
+func dismissWithKeys(keyEvents []KeyEvent) []KeyEvent {
+	oc, ok := overlayContexts[GetCurrentContext()]
+	if !ok || len(oc.stack) == 0 {
+		return keyEvents
+	}
+	remaining := keyEvents[:0:0]
+	for _, keyEvent := range keyEvents {
+		if keyEvent.Key == KEY_ESCAPE && !keyEvent.Repeat && len(oc.stack) > 0 && !oc.stack[len(oc.stack)-1].config.IgnoreEscape {
+			oc.closeFrom(len(oc.stack) - 1)
+			continue
+		}
+		remaining = append(remaining, keyEvent)
+	}
+	return remaining
+}
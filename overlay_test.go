@@ -0,0 +1,130 @@
+package clay_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutOverlays lays out a focusable button at the top left, a menu below it with a submenu to its right, and a modal dialog.
+func layoutOverlays() {
+	clay.BeginLayout()
+	clay.UI(clay.ID("Button"))(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(20)}},
+		Focus:  clay.FocusElementConfig{Focusable: true},
+	}, nil)
+	clay.Overlay(clay.ID("Menu"), clay.OverlayConfig{}, clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(100)}},
+		Floating: clay.FloatingElementConfig{
+			AttachTo:     clay.ATTACH_TO_ELEMENT_WITH_ID,
+			ParentId:     clay.ID("Button").Id,
+			AttachPoints: clay.FloatingAttachPoints{Element: clay.ATTACH_POINT_LEFT_TOP, Parent: clay.ATTACH_POINT_LEFT_BOTTOM},
+		},
+	}, func() {
+		clay.Overlay(clay.ID("Submenu"), clay.OverlayConfig{}, clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(100)}},
+			Floating: clay.FloatingElementConfig{
+				AttachTo:     clay.ATTACH_TO_PARENT,
+				AttachPoints: clay.FloatingAttachPoints{Element: clay.ATTACH_POINT_LEFT_TOP, Parent: clay.ATTACH_POINT_RIGHT_TOP},
+			},
+		}, nil)
+	})
+	clay.Overlay(clay.ID("Dialog"), clay.OverlayConfig{Modal: true}, clay.ElementDeclaration{
+		Layout:   clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(50), Height: clay.SizingFixed(50)}},
+		Floating: clay.FloatingElementConfig{Offset: clay.Vector2{X: 300, Y: 200}},
+	}, nil)
+	clay.EndLayout()
+}
+
+func click(position clay.Vector2) {
+	clay.SetPointerState(position, true)
+	layoutOverlays()
+	clay.SetPointerState(position, false)
+	layoutOverlays()
+}
+
+func TestOverlayOutsideClick(t *testing.T) {
+	newTestContext(t)
+	clay.SetPointerState(clay.Vector2{}, false)
+	layoutOverlays()
+	clay.OpenOverlay(clay.ID("Menu"))
+	layoutOverlays()
+	clay.OpenChildOverlay(clay.ID("Menu"), clay.ID("Submenu"))
+	layoutOverlays()
+	if data := clay.GetElementData(clay.ID("Submenu")); !data.Found || data.BoundingBox.X != 100 || data.BoundingBox.Y != 20 {
+		t.Fatalf("the submenu should open to the right of the menu: got %v", data.BoundingBox)
+	}
+
+	click(clay.Vector2{X: 150, Y: 50})
+	if !clay.OverlayOpen(clay.ID("Menu")) || !clay.OverlayOpen(clay.ID("Submenu")) {
+		t.Fatal("clicking inside the submenu should keep both menus open")
+	}
+	click(clay.Vector2{X: 50, Y: 50})
+	if !clay.OverlayOpen(clay.ID("Menu")) || clay.OverlayOpen(clay.ID("Submenu")) {
+		t.Fatal("clicking the menu should only close the submenu")
+	}
+	click(clay.Vector2{X: 50, Y: 10})
+	if !clay.OverlayOpen(clay.ID("Menu")) {
+		t.Fatal("clicking the anchor shouldn't count as an outside click")
+	}
+	click(clay.Vector2{X: 300, Y: 10})
+	if clay.OverlayOpen(clay.ID("Menu")) {
+		t.Fatal("clicking outside should close the menu")
+	}
+}
+
+func TestOverlayModal(t *testing.T) {
+	newTestContext(t)
+	clay.SetPointerState(clay.Vector2{}, false)
+	layoutOverlays()
+	clay.SetFocus(clay.ID("Button"))
+	clay.OpenOverlay(clay.ID("Dialog"))
+	layoutOverlays()
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 10}, false)
+	if clay.PointerOver(clay.ID("Button")) {
+		t.Fatal("the backdrop should capture the pointer")
+	}
+	clay.ClearFocus()
+	clay.SetKeyboardState([]clay.KeyEvent{{Key: clay.KEY_ESCAPE}})
+	if clay.OverlayOpen(clay.ID("Dialog")) {
+		t.Fatal("escape should close the dialog")
+	}
+	if clay.GetFocusedElementId() != clay.ID("Button") {
+		t.Fatal("closing the dialog should restore the focus from before it opened")
+	}
+}
+
+func TestTooltip(t *testing.T) {
+	newTestContext(t)
+	tooltip := func() bool {
+		shown := false
+		clay.BeginLayout()
+		clay.UI(clay.ID("Anchor"))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(20)}},
+		}, func() {
+			shown = clay.Tooltip(clay.ID("Anchor"), clay.TooltipConfig{Delay: 250 * time.Millisecond}, clay.ElementDeclaration{
+				Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(10)}},
+			}, nil)
+		})
+		clay.EndLayout()
+		return shown
+	}
+	clay.SetDeltaTime(0.1)
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 10}, false)
+	tooltip()
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 10}, false)
+	// The hover starts on the first frame over the anchor, and 250ms takes three more 100ms frames from there
+	for frame := range 3 {
+		if tooltip() {
+			t.Fatalf("the tooltip should wait for the hover delay, but appeared after %d frames", frame+1)
+		}
+	}
+	if !tooltip() {
+		t.Fatal("the tooltip should appear after the hover delay")
+	}
+	clay.SetPointerState(clay.Vector2{X: 200, Y: 10}, false)
+	if tooltip() {
+		t.Fatal("the tooltip should hide when the pointer leaves the anchor")
+	}
+}
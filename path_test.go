@@ -0,0 +1,145 @@
+package clay_test
+
+import (
+	"image"
+	"image/color"
+	"slices"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+func TestPathRenderCommand(t *testing.T) {
+	newTestContext(t)
+	commands := []clay.PathCommand{clay.MoveTo(0, 0), clay.LineTo(10, 10)}
+	clay.BeginLayout()
+	clay.UI(clay.ID("Chart"))(clay.ElementDeclaration{
+		Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+		BackgroundColor: blue,
+		Path:            clay.PathElementConfig{Commands: clay.PathCommands(commands...), StrokeColor: red, StrokeWidth: 2, Join: clay.LINE_JOIN_ROUND},
+	}, nil)
+	cmds := clay.EndLayout()
+	var types []clay.RenderCommandType
+	for cmd := range cmds.Iter() {
+		types = append(types, cmd.CommandType)
+		if cmd.CommandType != clay.RENDER_COMMAND_TYPE_PATH {
+			continue
+		}
+		path := cmd.RenderData.Path
+		if path.Commands.Length != 2 || path.StrokeColor != red || path.StrokeWidth != 2 || path.Join != clay.LINE_JOIN_ROUND || path.Scale != 1 {
+			t.Errorf("unexpected path render data %+v", path)
+		}
+	}
+	// Paths are drawn over the element's background
+	if len(types) != 2 || types[0] != clay.RENDER_COMMAND_TYPE_RECTANGLE || types[1] != clay.RENDER_COMMAND_TYPE_PATH {
+		t.Errorf("expected a RECTANGLE and then a PATH command, got %v", types)
+	}
+}
+
+func renderPath(t *testing.T, width, height float32, path clay.PathElementConfig) *image.RGBA {
+	t.Helper()
+	return renderElement(t, width, height, clay.ElementDeclaration{Path: path})
+}
+
+func TestPathFillSoftwareRenderer(t *testing.T) {
+	redRGBA := color.RGBA{R: 255, A: 255}
+	// A square with a square hole winding the other way
+	img := renderPath(t, 8, 8, clay.PathElementConfig{
+		Commands: clay.PathCommands(
+			clay.MoveTo(1, 1), clay.LineTo(7, 1), clay.LineTo(7, 7), clay.LineTo(1, 7), clay.ClosePath(),
+			clay.MoveTo(3, 3), clay.LineTo(3, 5), clay.LineTo(5, 5), clay.LineTo(5, 3), clay.ClosePath(),
+		),
+		FillColor: red,
+	})
+	expectPixels(t, img, row(2, 0, 1, 3, 6, 7), []color.RGBA{black, redRGBA, redRGBA, redRGBA, black})
+	expectPixels(t, img, row(4, 2, 3, 4, 5), []color.RGBA{redRGBA, black, black, redRGBA})
+}
+
+func TestPathCurvesSoftwareRenderer(t *testing.T) {
+	redRGBA := color.RGBA{R: 255, A: 255}
+	img := renderPath(t, 10, 10, clay.PathElementConfig{
+		Commands:  clay.PathCommands(clay.Arc(5, 5, 4, 0, 360)),
+		FillColor: red,
+	})
+	expectPixels(t, img, []image.Point{{5, 5}, {2, 5}, {5, 7}, {0, 0}, {9, 9}}, []color.RGBA{redRGBA, redRGBA, redRGBA, black, black})
+
+	img = renderPath(t, 10, 10, clay.PathElementConfig{
+		Commands:  clay.PathCommands(clay.MoveTo(0, 10), clay.QuadTo(5, -10, 10, 10), clay.ClosePath()),
+		FillColor: red,
+	})
+	// The curve peaks halfway between its ends and its control point
+	expectPixels(t, img, []image.Point{{5, 1}, {5, 9}, {0, 0}, {9, 0}}, []color.RGBA{redRGBA, redRGBA, black, black})
+}
+
+func TestPathStrokeSoftwareRenderer(t *testing.T) {
+	faded := clay.Color{R: 255, G: 255, B: 255, A: 128}
+	fadedRGBA := color.RGBA{R: 128, G: 128, B: 128, A: 255}
+	img := renderPath(t, 8, 8, clay.PathElementConfig{
+		Commands:    clay.PathCommands(clay.MoveTo(0, 2), clay.LineTo(5, 2), clay.LineTo(5, 8)),
+		StrokeColor: faded,
+		StrokeWidth: 2,
+	})
+	expectPixels(t, img, row(0, 0, 2), []color.RGBA{black, black})
+	expectPixels(t, img, row(1, 0, 2), []color.RGBA{fadedRGBA, fadedRGBA})
+	// Where the segments and the miter overlap, the translucent stroke is still only drawn once
+	expectPixels(t, img, row(1, 4, 5), []color.RGBA{fadedRGBA, fadedRGBA})
+	expectPixels(t, img, row(2, 4, 5), []color.RGBA{fadedRGBA, fadedRGBA})
+	expectPixels(t, img, row(5, 3, 4, 5, 6), []color.RGBA{black, fadedRGBA, fadedRGBA, black})
+}
+
+func TestPathCommandsCopied(t *testing.T) {
+	newTestContext(t)
+	commands := []clay.PathCommand{clay.MoveTo(0, 0), clay.LineTo(10, 10)}
+	layout := func(declared bool) clay.RenderCommandArray {
+		clay.BeginLayout()
+		if declared {
+			clay.UI(clay.ID("Chart"))(clay.ElementDeclaration{
+				Layout:     clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+				Path:       clay.PathElementConfig{Commands: clay.PathCommands(commands...), StrokeColor: red, StrokeWidth: 2},
+				Transition: clay.TransitionElementConfig{Duration: 1, Exit: clay.TransitionEnterExitConfig{Enabled: true}},
+			}, nil)
+			// The commands only need to stay valid during the declaration
+			commands[1] = clay.LineTo(5, 0)
+		}
+		return clay.EndLayout()
+	}
+	clay.SetDeltaTime(0.25)
+	for _, declared := range []bool{true, false, false} {
+		cmds := layout(declared)
+		var drawn bool
+		for cmd := range cmds.Iter() {
+			if cmd.CommandType != clay.RENDER_COMMAND_TYPE_PATH {
+				continue
+			}
+			drawn = true
+			// Exiting elements replay the commands they were last declared with
+			if got := slices.Collect(cmd.RenderData.Path.Commands.Iter()); len(got) != 2 || got[1] != clay.LineTo(10, 10) {
+				t.Fatalf("declared %v: expected the commands as they were declared, got %v", declared, got)
+			}
+		}
+		if !drawn {
+			t.Fatalf("declared %v: expected a PATH command", declared)
+		}
+		commands[1] = clay.LineTo(10, 10)
+	}
+}
+
+func TestPathArcSweep(t *testing.T) {
+	redRGBA := color.RGBA{R: 255, A: 255}
+	// Sweeping around many times draws the same circle as sweeping around once
+	img := renderPath(t, 10, 10, clay.PathElementConfig{
+		Commands:  clay.PathCommands(clay.Arc(5, 5, 4, 0, 1e9)),
+		FillColor: red,
+	})
+	expectPixels(t, img, []image.Point{{5, 5}, {2, 5}, {5, 7}, {0, 0}, {9, 9}}, []color.RGBA{redRGBA, redRGBA, redRGBA, black, black})
+}
+
+func TestPathOffscreenSoftwareRenderer(t *testing.T) {
+	redRGBA := color.RGBA{R: 255, A: 255}
+	// Only the part of the path on the screen is rasterized
+	img := renderPath(t, 10, 10, clay.PathElementConfig{
+		Commands:  clay.PathCommands(clay.MoveTo(0, 0), clay.LineTo(1e6, 0), clay.LineTo(1e6, 1e6), clay.ClosePath()),
+		FillColor: red,
+	})
+	expectPixels(t, img, []image.Point{{9, 1}, {1, 9}}, []color.RGBA{redRGBA, black})
+}
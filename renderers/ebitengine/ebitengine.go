@@ -1,7 +1,6 @@
 package ebitengine
 
 import (
-	"fmt"
 	"image"
 	"image/color"
 	"log/slog"
@@ -10,14 +9,44 @@ import (
 	"unsafe"
 
 	"github.com/TotallyGamerJet/clay"
+	"github.com/TotallyGamerJet/clay/renderers/internal/border"
+	"github.com/TotallyGamerJet/clay/renderers/internal/geometry"
+	"github.com/TotallyGamerJet/clay/renderers/internal/gradient"
+	"github.com/TotallyGamerJet/clay/renderers/internal/imagefit"
+	"github.com/TotallyGamerJet/clay/renderers/internal/transform"
+	"github.com/TotallyGamerJet/clay/renderers/internal/vectorpath"
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
-	"github.com/hajimehoshi/ebiten/v2/vector"
 )
 
 var whiteImage *ebiten.Image
 var solidColorImage *ebiten.Image
 
+// transformImage is the offscreen image transformed render commands are drawn into, reused across commands.
+var transformImage *ebiten.Image
+
+// layerImages are the offscreen images layers are drawn into, one for each level of nesting, reused across frames.
+var layerImages []*ebiten.Image
+
+// clipImages are the offscreen images rounded clipping is drawn into, one for each level of nesting, reused across frames.
+var clipImages []*ebiten.Image
+
+// layer is an offscreen image that render commands are drawn into between LAYER_START and LAYER_END.
+type layer struct {
+	// The images that were drawn into before the layer started
+	screen, fullScreen *ebiten.Image
+	image              *ebiten.Image
+}
+
+// clip is the clipping started by a SCISSOR_START. Rounded clipping draws into an offscreen image until the SCISSOR_END,
+// which has its corners erased before it is drawn onto the screen.
+type clip struct {
+	// The image that was drawn into before the clipping started
+	screen  *ebiten.Image
+	image   *ebiten.Image
+	corners [][]geometry.Point
+}
+
 func init() {
 	// Creating a sub-image to avoid bleeding edges
 	// https://github.com/hajimehoshi/ebiten/blob/1a4237213c92be1b9c16176887d992eb4183751b/vector/util.go#L26-L29
@@ -45,20 +74,48 @@ func MeasureText(txt clay.StringSlice, config *clay.TextElementConfig, userData
 
 func ClayRender(screen *ebiten.Image, scaleFactor float32, renderCommands clay.RenderCommandArray, fonts []text.Face) error {
 	fullScreen := screen
+	var layers []layer
+	var clips []clip
 	for renderCommand := range renderCommands.Iter() {
 		boundingBox := renderCommand.BoundingBox
 		boundingBox.X *= scaleFactor
 		boundingBox.Y *= scaleFactor
 		boundingBox.Width *= scaleFactor
 		boundingBox.Height *= scaleFactor
+		m := renderCommand.Transform
+		m.Tx *= scaleFactor
+		m.Ty *= scaleFactor
+		// Transformed commands are drawn onto a transparent image, which is then drawn onto the screen with the transform
+		target := screen
+		var region image.Rectangle
+		if transform.Transformed(&renderCommand) {
+			extents := transform.Extents(&renderCommand)
+			extents.X *= scaleFactor
+			extents.Y *= scaleFactor
+			extents.Width *= scaleFactor
+			extents.Height *= scaleFactor
+			region = transform.Region(m, extents, screen.Bounds())
+			if region.Empty() {
+				continue
+			}
+			screen = offscreenImage(region.Size())
+			boundingBox.X -= float32(region.Min.X)
+			boundingBox.Y -= float32(region.Min.Y)
+		}
 		switch renderCommand.CommandType {
 		case clay.RENDER_COMMAND_TYPE_RECTANGLE:
 			config := &renderCommand.RenderData.Rectangle
-			if config.CornerRadius.TopLeft > 0 {
-				cornerRadius := config.CornerRadius.TopLeft * scaleFactor
-				if err := renderFillRoundedRect(screen, boundingBox, cornerRadius, config.BackgroundColor); err != nil {
-					return err
-				}
+			cornerRadius := clay.CornerRadius{
+				TopLeft:     config.CornerRadius.TopLeft * scaleFactor,
+				TopRight:    config.CornerRadius.TopRight * scaleFactor,
+				BottomLeft:  config.CornerRadius.BottomLeft * scaleFactor,
+				BottomRight: config.CornerRadius.BottomRight * scaleFactor,
+			}
+			if config.Gradient.Type != clay.GRADIENT_TYPE_NONE {
+				config.Gradient.Radius *= scaleFactor
+				renderGradient(screen, &config.Gradient, boundingBox, [][]geometry.Point{geometry.Outline(boundingBox, cornerRadius)})
+			} else if cornerRadius != (clay.CornerRadius{}) {
+				renderTriangles(screen, geometry.RoundedRect(boundingBox, cornerRadius, config.BackgroundColor))
 			} else {
 				// Workaround for vector.DrawFilledRect bug on macOS/Retina displays
 				rectColor := color.RGBA{
@@ -88,21 +145,66 @@ func ClayRender(screen *ebiten.Image, scaleFactor float32, renderCommands clay.R
 			opts.GeoM.Translate(float64(boundingBox.X), float64(boundingBox.Y))
 			text.Draw(screen, cloned, font, opts)
 		case clay.RENDER_COMMAND_TYPE_SCISSOR_START:
-			screen = screen.SubImage(image.Rect(
-				int(boundingBox.X), int(boundingBox.Y),
-				int(boundingBox.X+boundingBox.Width),
-				int(boundingBox.Y+boundingBox.Height),
-			)).(*ebiten.Image)
+			// Transformed clipping rectangles are clipped to by their bounds
+			bounds := m.Bounds(boundingBox)
+			rect := image.Rect(
+				int(bounds.X), int(bounds.Y),
+				int(bounds.X+bounds.Width),
+				int(bounds.Y+bounds.Height),
+			)
+			c := clip{screen: screen}
+			if radius := renderCommand.RenderData.Clip.CornerRadius; radius != (clay.CornerRadius{}) {
+				radius.TopLeft *= scaleFactor
+				radius.TopRight *= scaleFactor
+				radius.BottomLeft *= scaleFactor
+				radius.BottomRight *= scaleFactor
+				c.corners = geometry.Corners(boundingBox, radius)
+				for _, corner := range c.corners {
+					for i, p := range corner {
+						v := m.Apply(clay.Vector2{X: p.X, Y: p.Y})
+						corner[i] = geometry.Point{X: v.X, Y: v.Y}
+					}
+				}
+				c.image = pooledImage(&clipImages, len(clips), fullScreen.Bounds().Max)
+				screen = c.image.SubImage(screen.Bounds()).(*ebiten.Image)
+			}
+			clips = append(clips, c)
+			// Nested clipping only draws inside of the clipping around it
+			screen = screen.SubImage(rect).(*ebiten.Image)
 		case clay.RENDER_COMMAND_TYPE_SCISSOR_END:
-			screen = fullScreen
+			if len(clips) == 0 {
+				screen = fullScreen
+				break
+			}
+			c := clips[len(clips)-1]
+			clips = clips[:len(clips)-1]
+			if c.image != nil {
+				for _, corner := range c.corners {
+					eraseFan(screen, corner)
+				}
+				opts := &ebiten.DrawImageOptions{}
+				opts.GeoM.Translate(float64(screen.Bounds().Min.X), float64(screen.Bounds().Min.Y))
+				c.screen.DrawImage(screen, opts)
+			}
+			screen = c.screen
 		case clay.RENDER_COMMAND_TYPE_IMAGE:
 			config := &renderCommand.RenderData.Image
 			img := (*ebiten.Image)(config.ImageData.(unsafe.Pointer))
-			opts := &ebiten.DrawImageOptions{}
+			config.IntrinsicSize.Width *= scaleFactor
+			config.IntrinsicSize.Height *= scaleFactor
+			config.NineSliceScale *= scaleFactor
 			bounds := img.Bounds()
-			opts.GeoM.Scale(float64(boundingBox.Width/float32(bounds.Dx())), float64(boundingBox.Height/float32(bounds.Dy())))
-			opts.GeoM.Translate(float64(boundingBox.X), float64(boundingBox.Y))
-			screen.DrawImage(img, opts)
+			for _, part := range imagefit.Parts(boundingBox, config, float32(bounds.Dx()), float32(bounds.Dy())) {
+				src, dst := part.Src, part.Dst
+				srcRect := image.Rect(int(src.X), int(src.Y), int(src.X+src.Width), int(src.Y+src.Height)).Add(bounds.Min)
+				if srcRect.Empty() {
+					continue
+				}
+				opts := &ebiten.DrawImageOptions{}
+				opts.GeoM.Scale(float64(dst.Width/float32(srcRect.Dx())), float64(dst.Height/float32(srcRect.Dy())))
+				opts.GeoM.Translate(float64(dst.X), float64(dst.Y))
+				screen.DrawImage(img.SubImage(srcRect).(*ebiten.Image), opts)
+			}
 		case clay.RENDER_COMMAND_TYPE_BORDER:
 			config := &renderCommand.RenderData.Border
 			config.Width.Top = uint16(float32(config.Width.Top) * scaleFactor)
@@ -114,597 +216,175 @@ func ClayRender(screen *ebiten.Image, scaleFactor float32, renderCommands clay.R
 			config.CornerRadius.BottomLeft *= scaleFactor
 			config.CornerRadius.TopRight *= scaleFactor
 			config.CornerRadius.BottomRight *= scaleFactor
-			if boundingBox.Width > 0 && boundingBox.Height > 0 {
-				maxRadius := min(boundingBox.Width, boundingBox.Height) / 2.0
-
-				if config.Width.Left > 0 {
-					clampedRadiusTop := min(config.CornerRadius.TopLeft, maxRadius)
-					clampedRadiusBottom := min(config.CornerRadius.BottomLeft, maxRadius)
-					vector.DrawFilledRect(
-						screen,
-						boundingBox.X,
-						boundingBox.Y+clampedRadiusTop,
-						float32(config.Width.Left), boundingBox.Height-clampedRadiusTop-clampedRadiusBottom,
-						color.RGBA{
-							R: uint8(config.Color.R),
-							G: uint8(config.Color.G),
-							B: uint8(config.Color.B),
-							A: uint8(config.Color.A),
-						}, true,
-					)
-				}
-
-				if config.Width.Right > 0 {
-					clampedRadiusTop := min(config.CornerRadius.TopRight, maxRadius)
-					clampedRadiusBottom := min(config.CornerRadius.BottomRight, maxRadius)
-					vector.DrawFilledRect(
-						screen,
-						boundingBox.X+boundingBox.Width-float32(config.Width.Right),
-						boundingBox.Y+clampedRadiusTop,
-						float32(config.Width.Right),
-						boundingBox.Height-clampedRadiusTop-clampedRadiusBottom,
-						color.RGBA{
-							R: uint8(config.Color.R),
-							G: uint8(config.Color.G),
-							B: uint8(config.Color.B),
-							A: uint8(config.Color.A),
-						}, true,
-					)
-				}
-
-				if config.Width.Top > 0 {
-					clampedRadiusLeft := min(config.CornerRadius.TopLeft, maxRadius)
-					clampedRadiusRight := min(config.CornerRadius.TopRight, maxRadius)
-					vector.DrawFilledRect(
-						screen,
-						boundingBox.X+clampedRadiusLeft,
-						boundingBox.Y,
-						boundingBox.Width-clampedRadiusLeft-clampedRadiusRight,
-						float32(config.Width.Top),
-						color.RGBA{
-							R: uint8(config.Color.R),
-							G: uint8(config.Color.G),
-							B: uint8(config.Color.B),
-							A: uint8(config.Color.A),
-						}, true,
-					)
-				}
-
-				if config.Width.Bottom > 0 {
-					clampedRadiusLeft := min(config.CornerRadius.BottomLeft, maxRadius)
-					clampedRadiusRight := min(config.CornerRadius.BottomRight, maxRadius)
-					vector.DrawFilledRect(
-						screen,
-						boundingBox.X+clampedRadiusLeft,
-						boundingBox.Y+boundingBox.Height-float32(config.Width.Bottom),
-						boundingBox.Width-clampedRadiusLeft-clampedRadiusRight,
-						float32(config.Width.Bottom),
-						color.RGBA{
-							R: uint8(config.Color.R),
-							G: uint8(config.Color.G),
-							B: uint8(config.Color.B),
-							A: uint8(config.Color.A),
-						}, true,
-					)
-				}
-
-				// corner index: 0->3 topLeft -> CW -> bottonLeft
-				if config.Width.Top > 0 && config.CornerRadius.TopLeft > 0 {
-					renderCornerBorder(screen, &boundingBox, config, 0, config.Color)
-				}
-				if config.Width.Top > 0 && config.CornerRadius.TopRight > 0 {
-					renderCornerBorder(screen, &boundingBox, config, 1, config.Color)
-				}
-				if config.Width.Bottom > 0 && config.CornerRadius.BottomLeft > 0 {
-					renderCornerBorder(screen, &boundingBox, config, 2, config.Color)
-				}
-				if config.Width.Bottom > 0 && config.CornerRadius.BottomLeft > 0 {
-					renderCornerBorder(screen, &boundingBox, config, 3, config.Color)
-				}
+			config.DashLength *= scaleFactor
+			config.GapLength *= scaleFactor
+			if config.Gradient.Type != clay.GRADIENT_TYPE_NONE || config.Style != clay.BORDER_STYLE_SOLID || !border.Uniform(config.Colors) {
+				// Gradients, sides of different colors, dashes and dots are drawn from the outline of every part of the borders
+				config.Gradient.Radius *= scaleFactor
+				renderTriangles(screen, border.Triangles(boundingBox, config))
+				break
 			}
+			// All sides share a color
+			renderTriangles(screen, geometry.Border(boundingBox, config.CornerRadius, config.Width, config.Colors.Top))
+		case clay.RENDER_COMMAND_TYPE_SHADOW:
+			config := renderCommand.RenderData.Shadow
+			config.Offset.X *= scaleFactor
+			config.Offset.Y *= scaleFactor
+			config.BlurRadius *= scaleFactor
+			config.Spread *= scaleFactor
+			config.CornerRadius.TopLeft *= scaleFactor
+			config.CornerRadius.TopRight *= scaleFactor
+			config.CornerRadius.BottomLeft *= scaleFactor
+			config.CornerRadius.BottomRight *= scaleFactor
+			renderShadow(screen, boundingBox, &config)
+		case clay.RENDER_COMMAND_TYPE_PATH:
+			config := renderCommand.RenderData.Path
+			config.StrokeWidth *= scaleFactor
+			config.Scale *= scaleFactor
+			renderTriangles(screen, vectorpath.Triangles(boundingBox, &config))
+		case clay.RENDER_COMMAND_TYPE_LAYER_START:
+			img := pooledImage(&layerImages, len(layers), fullScreen.Bounds().Max)
+			layers = append(layers, layer{screen: screen, fullScreen: fullScreen, image: img})
+			screen, fullScreen = img, img
+		case clay.RENDER_COMMAND_TYPE_LAYER_END:
+			if len(layers) == 0 {
+				break
+			}
+			l := layers[len(layers)-1]
+			layers = layers[:len(layers)-1]
+			screen, fullScreen = l.screen, l.fullScreen
+			// Images are premultiplied, so scaling alpha fades the whole layer
+			opts := &ebiten.DrawImageOptions{}
+			opts.ColorScale.ScaleAlpha(renderCommand.RenderData.Layer.Opacity)
+			screen.DrawImage(l.image, opts)
 		case clay.RENDER_COMMAND_TYPE_NONE:
 		case clay.RENDER_COMMAND_TYPE_CUSTOM:
 		default:
 			slog.Warn("Unknown command type", "type", renderCommand.CommandType)
 		}
+		if !region.Empty() {
+			opts := &ebiten.DrawImageOptions{Filter: ebiten.FilterLinear}
+			opts.GeoM.Translate(float64(region.Min.X), float64(region.Min.Y))
+			var geoM ebiten.GeoM
+			geoM.SetElement(0, 0, float64(m.A))
+			geoM.SetElement(0, 1, float64(m.C))
+			geoM.SetElement(0, 2, float64(m.Tx))
+			geoM.SetElement(1, 0, float64(m.B))
+			geoM.SetElement(1, 1, float64(m.D))
+			geoM.SetElement(1, 2, float64(m.Ty))
+			opts.GeoM.Concat(geoM)
+			target.DrawImage(screen, opts)
+			screen = target
+		}
 	}
 
 	return nil
 }
 
-const numCircleSegments = 16
-
-func renderFillRoundedRect(screen *ebiten.Image, rect clay.BoundingBox, cornerRadius float32, _color clay.Color) error {
-	r := _color.R / 255
-	g := _color.G / 255
-	b := _color.B / 255
-	a := _color.A / 255
-
-	indexCount, vertexCount := 0, uint16(0)
-
-	minRadius := min(rect.Width, rect.Height) / 2.0
-	clampedRadius := min(cornerRadius, minRadius)
-
-	numCircleSegments := max(numCircleSegments, int(clampedRadius*0.5)) // check if it needs to be clamped
-
-	var vertices [512]ebiten.Vertex
-	var indices [512]uint16
-
-	// define center rectangle
-	// 0 Center TL
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + clampedRadius,
-		DstY:   rect.Y + clampedRadius,
-		SrcX:   1,
-		SrcY:   1,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
-	}
-	vertexCount++
-	// 1 Center TR
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + rect.Width - clampedRadius,
-		DstY:   rect.Y + clampedRadius,
-		SrcX:   2,
-		SrcY:   1,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
-	}
-	vertexCount++
-	// 2 Center BR
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + rect.Width - clampedRadius,
-		DstY:   rect.Y + rect.Height - clampedRadius,
-		SrcX:   2,
-		SrcY:   2,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
-	}
-	vertexCount++
-	// 3 Center BL
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + clampedRadius,
-		DstY:   rect.Y + rect.Height - clampedRadius,
-		SrcX:   1,
-		SrcY:   2,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
-	}
-	vertexCount++
-
-	indices[indexCount] = 0
-	indexCount++
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = 3
-	indexCount++
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = 2
-	indexCount++
-	indices[indexCount] = 3
-	indexCount++
-
-	// define rounded corners as triangle fans
-	step := (math.Pi / 2) / float32(numCircleSegments)
-	for i := 0; i < numCircleSegments; i++ {
-		angle1 := float32(i) * step
-		angle2 := (float32(i) + 1) * step
-
-		for j := uint16(0); j < 4; j++ {
-			var cx, cy, signX, signY float32
-
-			switch j {
-			case 0:
-				cx = rect.X + clampedRadius
-				cy = rect.Y + clampedRadius
-				signX = -1
-				signY = -1
-			case 1:
-				cx = rect.X + rect.Width - clampedRadius
-				cy = rect.Y + clampedRadius
-				signX = 1
-				signY = -1
-			case 2:
-				cx = rect.X + rect.Width - clampedRadius
-				cy = rect.Y + rect.Height - clampedRadius
-				signX = 1
-				signY = 1
-			case 3:
-				cx = rect.X + clampedRadius
-				cy = rect.Y + rect.Height - clampedRadius
-				signX = -1
-				signY = 1
-			default:
-				return fmt.Errorf("out of bounds index: %d", j)
-			}
-
-			vertices[vertexCount] = ebiten.Vertex{
-				DstX:   cx + float32(math.Cos(float64(angle1)))*clampedRadius*signX,
-				DstY:   cy + float32(math.Sin(float64(angle1)))*clampedRadius*signY,
-				SrcX:   1,
-				SrcY:   1,
-				ColorR: r,
-				ColorG: g,
-				ColorB: b,
-				ColorA: a,
-			}
-			vertexCount++
-			vertices[vertexCount] = ebiten.Vertex{
-				DstX:   cx + float32(math.Cos(float64(angle2)))*clampedRadius*signX,
-				DstY:   cy + float32(math.Sin(float64(angle2)))*clampedRadius*signY,
-				SrcX:   1,
-				SrcY:   1,
-				ColorR: r,
-				ColorG: g,
-				ColorB: b,
-				ColorA: a,
-			}
-			vertexCount++
-
-			indices[indexCount] = j // Connect to corresponding central rectangle vertex
-			indexCount++
-			indices[indexCount] = vertexCount - 2
-			indexCount++
-			indices[indexCount] = vertexCount - 1
-			indexCount++
-		}
-	}
-	// Define edge rectangles
-	// Top edge
-	// TL
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + clampedRadius,
-		DstY:   rect.Y,
-		SrcX:   0,
-		SrcY:   0,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
-	}
-	vertexCount++
-	// TR
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + rect.Width - clampedRadius,
-		DstY:   rect.Y,
-		SrcX:   1,
-		SrcY:   0,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
+// offscreenImage returns a transparent image of the given size to draw a transformed render command into.
+func offscreenImage(size image.Point) *ebiten.Image {
+	switch {
+	case transformImage == nil:
+		transformImage = ebiten.NewImage(size.X, size.Y)
+	case transformImage.Bounds().Dx() < size.X || transformImage.Bounds().Dy() < size.Y:
+		// Grown to fit the largest command so far, so commands of different sizes don't keep reallocating it
+		bounds := transformImage.Bounds()
+		transformImage.Deallocate()
+		transformImage = ebiten.NewImage(max(size.X, bounds.Dx()), max(size.Y, bounds.Dy()))
+	default:
+		transformImage.Clear()
 	}
-	vertexCount++
+	return transformImage.SubImage(image.Rectangle{Max: size}).(*ebiten.Image)
+}
 
-	indices[indexCount] = 0
-	indexCount++
-	indices[indexCount] = vertexCount - 2 // TL
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // TR
-	indexCount++
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = 0
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // TR
-	indexCount++
-	// Right edge
-	// RT
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + rect.Width,
-		DstY:   rect.Y + clampedRadius,
-		SrcX:   1,
-		SrcY:   0,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
+// pooledImage returns the cleared image of the given size for the given level of nesting from the pool, replacing it if its size changed.
+func pooledImage(pool *[]*ebiten.Image, depth int, size image.Point) *ebiten.Image {
+	if depth == len(*pool) {
+		*pool = append(*pool, nil)
 	}
-	vertexCount++
-	// RB
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + rect.Width,
-		DstY:   rect.Y + rect.Height - clampedRadius,
-		SrcX:   1,
-		SrcY:   1,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
+	img := (*pool)[depth]
+	if img == nil || img.Bounds().Size() != size {
+		if img != nil {
+			img.Deallocate()
+		}
+		img = ebiten.NewImage(size.X, size.Y)
+		(*pool)[depth] = img
+	} else {
+		img.Clear()
 	}
-	vertexCount++
+	return img
+}
 
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = vertexCount - 2 // RT
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // RB
-	indexCount++
-	indices[indexCount] = 2
-	indexCount++
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // RB
-	indexCount++
-	// Bottom edge
-	// BR
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + rect.Width - clampedRadius,
-		DstY:   rect.Y + rect.Height,
-		SrcX:   1,
-		SrcY:   1,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
+// eraseFan clears the screen inside the fan of triangles around the first point.
+func eraseFan(screen *ebiten.Image, fan []geometry.Point) {
+	vertices := make([]ebiten.Vertex, len(fan))
+	for i, p := range fan {
+		vertices[i] = ebiten.Vertex{DstX: p.X, DstY: p.Y, SrcX: 1, SrcY: 1, ColorR: 1, ColorG: 1, ColorB: 1, ColorA: 1}
 	}
-	vertexCount++
-	// BL
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X + clampedRadius,
-		DstY:   rect.Y + rect.Height,
-		SrcX:   0,
-		SrcY:   1,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
+	indices := make([]uint16, 0, (len(fan)-2)*3)
+	for i := 1; i+1 < len(fan); i++ {
+		indices = append(indices, 0, uint16(i), uint16(i+1))
 	}
-	vertexCount++
+	screen.DrawTriangles(vertices, indices, whiteImage, &ebiten.DrawTrianglesOptions{
+		AntiAlias: true,
+		Blend:     ebiten.BlendDestinationOut,
+	})
+}
 
-	indices[indexCount] = 2
-	indexCount++
-	indices[indexCount] = vertexCount - 2 // BR
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // BL
-	indexCount++
-	indices[indexCount] = 3
-	indexCount++
-	indices[indexCount] = 2
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // BL
-	indexCount++
-	// Left edge
-	// LB
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X,
-		DstY:   rect.Y + rect.Height - clampedRadius,
-		SrcX:   0,
-		SrcY:   1,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
-	}
-	vertexCount++
-	// LT
-	vertices[vertexCount] = ebiten.Vertex{
-		DstX:   rect.X,
-		DstY:   rect.Y + clampedRadius,
-		SrcX:   0,
-		SrcY:   0,
-		ColorR: r,
-		ColorG: g,
-		ColorB: b,
-		ColorA: a,
+func renderShadow(screen *ebiten.Image, boundingBox clay.BoundingBox, config *clay.ShadowRenderData) {
+	mesh, indices := geometry.Shadow(boundingBox, config)
+	vertices := make([]ebiten.Vertex, len(mesh))
+	for i, v := range mesh {
+		vertices[i] = ebiten.Vertex{
+			DstX:   v.X,
+			DstY:   v.Y,
+			SrcX:   1,
+			SrcY:   1,
+			ColorR: config.Color.R / 255,
+			ColorG: config.Color.G / 255,
+			ColorB: config.Color.B / 255,
+			ColorA: v.Alpha,
+		}
 	}
-	vertexCount++
-
-	indices[indexCount] = 3
-	indexCount++
-	indices[indexCount] = vertexCount - 2 // LB
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // LT
-	indexCount++
-	indices[indexCount] = 0
-	indexCount++
-	indices[indexCount] = 3
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // LT
-	indexCount++
-
-	// Render everything
-	screen.DrawTriangles(vertices[:vertexCount], indices[:indexCount], whiteImage, &ebiten.DrawTrianglesOptions{
+	screen.DrawTriangles(vertices, indices, whiteImage, &ebiten.DrawTrianglesOptions{
 		AntiAlias: true,
 	})
-
-	return nil
 }
 
-// all rendering is performed by a single ebitengine call, using two sets of arcing triangles, inner and outer, that fit together; along with two triangles to fill the end gaps.
-func renderCornerBorder(screen *ebiten.Image, boundingBox *clay.BoundingBox, config *clay.BorderRenderData, cornerIndex int, _color clay.Color) {
-	/////////////////////////////////
-	//The arc is constructed of outer triangles and inner triangles (if needed).
-	//First three vertices are first outer triangle's vertices
-	//Each two vertices after that are the inner-middle and second-outer vertex of
-	//each outer triangle after the first, because there first-outer vertex is equal to the
-	//second-outer vertex of the previous triangle. Indices set accordingly.
-	//The final two vertices are the missing vertices for the first and last inner triangles (if needed)
-	//Everything is in clockwise order (CW).
-	/////////////////////////////////
-
-	r := _color.R / 255
-	g := _color.G / 255
-	b := _color.B / 255
-	a := _color.A / 255
-
-	var centerX, centerY, outerRadius, startAngle, borderWidth float32
-	maxRadius := min(boundingBox.Width, boundingBox.Height) / 2.0
-
-	var vertices [512]ebiten.Vertex
-	var indices [512]uint16
-	indexCount, vertexCount := uint16(0), uint16(0)
-
-	switch cornerIndex {
-	case 0:
-		startAngle = math.Pi
-		outerRadius = min(config.CornerRadius.TopLeft, maxRadius)
-		centerX = boundingBox.X + outerRadius
-		centerY = boundingBox.Y + outerRadius
-		borderWidth = float32(config.Width.Top)
-	case 1:
-		startAngle = 3 * math.Pi / 2
-		outerRadius = min(config.CornerRadius.TopRight, maxRadius)
-		centerX = boundingBox.X + boundingBox.Width - outerRadius
-		centerY = boundingBox.Y + outerRadius
-		borderWidth = float32(config.Width.Top)
-	case 2:
-		startAngle = 0
-		outerRadius = min(config.CornerRadius.BottomRight, maxRadius)
-		centerX = boundingBox.X + boundingBox.Width - outerRadius
-		centerY = boundingBox.Y + boundingBox.Height - outerRadius
-		borderWidth = float32(config.Width.Bottom)
-	case 3:
-		startAngle = math.Pi / 2
-		outerRadius = min(config.CornerRadius.BottomLeft, maxRadius)
-		centerX = boundingBox.X + outerRadius
-		centerY = boundingBox.Y + boundingBox.Height - outerRadius
-		borderWidth = float32(config.Width.Bottom)
-	default:
-		panic("invalid corner index")
+// renderGradient fills the convex polygons with the gradient filling boundingBox.
+func renderGradient(screen *ebiten.Image, g *clay.Gradient, boundingBox clay.BoundingBox, polygons [][]geometry.Point) {
+	var vertices []geometry.Vertex
+	for _, polygon := range polygons {
+		vertices = append(vertices, gradient.Triangles(g, boundingBox, polygon)...)
 	}
+	renderTriangles(screen, vertices)
+}
 
-	innerRadius := outerRadius - borderWidth
-	minNumOuterTriangles := numCircleSegments
-	numOuterTriangles := max(minNumOuterTriangles, int(math.Ceil(float64(outerRadius*0.5))))
-	angleStep := math.Pi / (2.0 * float32(numOuterTriangles))
-
-	// outer triangles, in CW order
-	for i := 0; i < numOuterTriangles; i++ {
-		angle1 := startAngle + float32(i)*angleStep       // first-outer vertex angle
-		angle2 := startAngle + (float32(i)+0.5)*angleStep // inner-middle vertex angle
-		angle3 := startAngle + float32(i+1)*angleStep     // second-outer vertex angle
-
-		if i == 0 { // first outer triangle
-			vertices[vertexCount] = ebiten.Vertex{
-				DstX:   centerX + float32(math.Cos(float64(angle1)))*outerRadius,
-				DstY:   centerY + float32(math.Sin(float64(angle1)))*outerRadius,
-				SrcX:   0,
-				SrcY:   0,
-				ColorR: r,
-				ColorG: g,
-				ColorB: b,
-				ColorA: a,
-			} // vertex index = 0
-			vertexCount++
-		}
-		indices[indexCount] = vertexCount - 1 // will be second-outer vertex of last outer triangle if not first outer triangle.
-		indexCount++
-		if innerRadius > 0 {
-			vertices[vertexCount] = ebiten.Vertex{
-				DstX:   centerX + float32(math.Cos(float64(angle2)))*(innerRadius),
-				DstY:   centerY + float32(math.Sin(float64(angle2)))*(innerRadius),
-				SrcX:   0,
-				SrcY:   0,
-				ColorR: r,
-				ColorG: g,
-				ColorB: b,
-				ColorA: a,
-			}
-			vertexCount++
-		} else {
-			vertices[vertexCount] = ebiten.Vertex{
-				DstX:   centerX,
-				DstY:   centerY,
-				SrcX:   0,
-				SrcY:   0,
-				ColorR: r,
-				ColorG: g,
-				ColorB: b,
-				ColorA: a,
-			}
-			vertexCount++
-		}
-
-		indices[indexCount] = vertexCount - 1
-		indexCount++
-		vertices[vertexCount] = ebiten.Vertex{
-			DstX:   centerX + float32(math.Cos(float64(angle3)))*outerRadius,
-			DstY:   centerY + float32(math.Sin(float64(angle3)))*outerRadius,
-			SrcX:   0,
-			SrcY:   0,
-			ColorR: r,
-			ColorG: g,
-			ColorB: b,
-			ColorA: a,
+// renderTriangles draws the list of triangles, three vertices each, blending their vertex colors.
+func renderTriangles(screen *ebiten.Image, triangles []geometry.Vertex) {
+	vertices := make([]ebiten.Vertex, len(triangles))
+	for i, v := range triangles {
+		vertices[i] = ebiten.Vertex{
+			DstX:   v.X,
+			DstY:   v.Y,
+			SrcX:   1,
+			SrcY:   1,
+			ColorR: v.Color.R / 255,
+			ColorG: v.Color.G / 255,
+			ColorB: v.Color.B / 255,
+			ColorA: v.Color.A / 255,
 		}
-		vertexCount++
-		indices[indexCount] = vertexCount - 1
-		indexCount++
 	}
-
-	if innerRadius > 0 {
-		// inner triangles in CW order (except the first and last)
-		for i := 0; i < numOuterTriangles-1; i++ { // skip the last outer triangle
-			if i == 0 { // first outer triangle -> second inner triangle
-				indices[indexCount] = 1 // inner-middle vertex of first outer triangle
-				indexCount++
-				indices[indexCount] = 2 // second-outer vertex of first outer triangle
-				indexCount++
-				indices[indexCount] = 3 // innder-middle vertex of second-outer triangle
-				indexCount++
-			} else {
-				baseIndex := 3                                    // skip first outer triangle
-				indices[indexCount] = uint16(baseIndex + (i-1)*2) // inner-middle vertex of current outer triangle
-				indexCount++
-				indices[indexCount] = uint16(baseIndex + (i-1)*2 + 1) // second-outer vertex of current outer triangle
-				indexCount++
-				indices[indexCount] = uint16(baseIndex + (i-1)*2 + 2) // inner-middle vertex of next outer triangle
-				indexCount++
-			}
-		}
-
-		endAngle := startAngle + math.Pi/2.0
-
-		// last inner triangle
-		indices[indexCount] = vertexCount - 2 // inner-middle vertex of last outer triangle
-		indexCount++
-		indices[indexCount] = vertexCount - 1 // second-outer vertex of last outer triangle
-		indexCount++
-		vertices[vertexCount] = ebiten.Vertex{
-			DstX:   centerX + float32(math.Cos(float64(endAngle)))*innerRadius,
-			DstY:   centerY + float32(math.Sin(float64(endAngle)))*innerRadius,
-			SrcX:   0,
-			SrcY:   0,
-			ColorR: r,
-			ColorG: g,
-			ColorB: b,
-			ColorA: a,
-		} // missing vertex
-		vertexCount++
-		indices[indexCount] = vertexCount - 1
-		indexCount++
-
-		// //first inner triangle
-		indices[indexCount] = 0 // first-outer vertex of first outer triangle
-		indexCount++
-		indices[indexCount] = 1 // inner-middle vertex of first outer triangle
-		indexCount++
-		vertices[vertexCount] = ebiten.Vertex{
-			DstX:   centerX + float32(math.Cos(float64(startAngle)))*innerRadius,
-			DstY:   centerY + float32(math.Sin(float64(startAngle)))*innerRadius,
-			SrcX:   0,
-			SrcY:   0,
-			ColorR: r,
-			ColorG: g,
-			ColorB: b,
-			ColorA: a,
-		} // missing vertex
-		vertexCount++
-		indices[indexCount] = vertexCount - 1
-		indexCount++
+	// Indices are 16 bit, so large meshes are drawn in batches of whole triangles
+	const maxVertices = math.MaxUint16 / 3 * 3
+	indices := make([]uint16, min(len(vertices), maxVertices))
+	for i := range indices {
+		indices[i] = uint16(i)
+	}
+	for start := 0; start < len(vertices); start += maxVertices {
+		batch := vertices[start:min(start+maxVertices, len(vertices))]
+		screen.DrawTriangles(batch, indices[:len(batch)], whiteImage, &ebiten.DrawTrianglesOptions{
+			AntiAlias: true,
+		})
 	}
-
-	screen.DrawTriangles(vertices[:vertexCount], indices[:indexCount], whiteImage, &ebiten.DrawTrianglesOptions{
-		AntiAlias: true,
-	})
 }
@@ -0,0 +1,197 @@
+// Package border splits the borders of rounded rectangles into convex parts on a single side, following their style's
+// dashes and dots, for the renderers that draw borders with colored triangles or per pixel.
+package border
+
+import (
+	"math"
+
+	"github.com/TotallyGamerJet/clay"
+	"github.com/TotallyGamerJet/clay/renderers/internal/geometry"
+	"github.com/TotallyGamerJet/clay/renderers/internal/gradient"
+)
+
+// The number of straight segments each dot of a dotted border is drawn with.
+const dotSegments = 12
+
+// Side is one of the four sides of a border.
+type Side int
+
+const (
+	SideLeft Side = iota
+	SideRight
+	SideTop
+	SideBottom
+)
+
+// Color returns the color of the side.
+func (s Side) Color(colors clay.BorderColors) clay.Color {
+	switch s {
+	case SideLeft:
+		return colors.Left
+	case SideRight:
+		return colors.Right
+	case SideTop:
+		return colors.Top
+	default:
+		return colors.Bottom
+	}
+}
+
+// SideAt returns the side of the borders of box the point belongs to. Where two sides meet, the corner is split along the
+// line from the outer corner to the inner corner.
+func SideAt(box clay.BoundingBox, width clay.BorderWidth, x, y float32) Side {
+	side, closest := SideTop, float32(math.Inf(1))
+	for _, s := range [4]struct {
+		side     Side
+		distance float32
+		width    uint16
+	}{
+		{SideLeft, x - box.X, width.Left},
+		{SideRight, box.X + box.Width - x, width.Right},
+		{SideTop, y - box.Y, width.Top},
+		{SideBottom, box.Y + box.Height - y, width.Bottom},
+	} {
+		if s.width == 0 {
+			continue
+		}
+		// Measured in border widths, so the split between two sides runs through both of their inner edges
+		if d := s.distance / float32(s.width); d < closest {
+			side, closest = s.side, d
+		}
+	}
+	return side
+}
+
+// Segment is a convex part of the borders, on a single side.
+type Segment struct {
+	Points []geometry.Point
+	Side   Side
+}
+
+// Segments returns the convex parts of the borders of the rounded rectangle in box that are drawn in the given style.
+// Dashes and dots follow the center of the borders around the corners, and are stretched slightly so they repeat evenly
+// all the way around.
+func Segments(box clay.BoundingBox, radius clay.CornerRadius, width clay.BorderWidth, style clay.BorderStyle, dashLength, gapLength float32) []Segment {
+	outer := geometry.Outline(box, radius)
+	inner := geometry.Outline(geometry.BorderInner(box, radius, width))
+	n := len(outer)
+	center := make([]geometry.Point, n)
+	for i := range outer {
+		center[i] = geometry.Lerp(outer[i], inner[i], 0.5)
+	}
+	// The distance along the center of the borders to the start of every quad
+	distances := make([]float32, n+1)
+	sides := make([]Side, n)
+	for i := range outer {
+		j := (i + 1) % n
+		distances[i+1] = distances[i] + geometry.SegmentLength(center[i], center[j])
+		c := geometry.Lerp(geometry.Lerp(outer[i], outer[j], 0.5), geometry.Lerp(inner[i], inner[j], 0.5), 0.5)
+		sides[i] = SideAt(box, width, c.X, c.Y)
+	}
+	perimeter := distances[n]
+	quad := func(i int, from, to float32) []geometry.Point {
+		j := (i + 1) % n
+		return []geometry.Point{geometry.Lerp(outer[i], outer[j], from), geometry.Lerp(outer[i], outer[j], to), geometry.Lerp(inner[i], inner[j], to), geometry.Lerp(inner[i], inner[j], from)}
+	}
+
+	var segments []Segment
+	if style == clay.BORDER_STYLE_SOLID || perimeter <= 0 {
+		for i := range outer {
+			if q := quad(i, 0, 1); area(q) > 1e-3 {
+				segments = append(segments, Segment{Points: q, Side: sides[i]})
+			}
+		}
+		return segments
+	}
+
+	widest := float32(max(width.Left, width.Right, width.Top, width.Bottom))
+	if gapLength <= 0 {
+		gapLength = widest
+	}
+	if dashLength <= 0 {
+		dashLength = widest * 3
+	}
+	if style == clay.BORDER_STYLE_DOTTED {
+		dashLength = widest
+	}
+	period := dashLength + gapLength
+	if period <= 0 {
+		return nil
+	}
+	count := max(float32(math.Round(float64(perimeter/period))), 1)
+	stretch := perimeter / (count * period)
+	period *= stretch
+	dashLength *= stretch
+
+	if style == clay.BORDER_STYLE_DOTTED {
+		i := 0
+		for dot := float32(0); dot < count; dot++ {
+			at := dot * period
+			for i < n-1 && distances[i+1] <= at {
+				i++
+			}
+			length := distances[i+1] - distances[i]
+			if length <= 0 {
+				continue
+			}
+			t := (at - distances[i]) / length
+			j := (i + 1) % n
+			o, in := geometry.Lerp(outer[i], outer[j], t), geometry.Lerp(inner[i], inner[j], t)
+			r := geometry.SegmentLength(o, in) / 2
+			if r <= 0 {
+				continue
+			}
+			c := geometry.Lerp(o, in, 0.5)
+			points := make([]geometry.Point, dotSegments)
+			for k := range points {
+				angle := 2 * math.Pi * float64(k) / dotSegments
+				points[k] = geometry.Point{X: c.X + r*float32(math.Cos(angle)), Y: c.Y + r*float32(math.Sin(angle))}
+			}
+			segments = append(segments, Segment{Points: points, Side: sides[i]})
+		}
+		return segments
+	}
+
+	for i := range outer {
+		start, end := distances[i], distances[i+1]
+		length := end - start
+		if length <= 0 {
+			continue
+		}
+		for dash := float32(math.Floor(float64(start / period))); dash*period < end; dash++ {
+			from := max(dash*period, start)
+			to := min(dash*period+dashLength, end)
+			if to <= from {
+				continue
+			}
+			if q := quad(i, (from-start)/length, (to-start)/length); area(q) > 1e-3 {
+				segments = append(segments, Segment{Points: q, Side: sides[i]})
+			}
+		}
+	}
+	return segments
+}
+
+// area returns the unsigned area of the polygon.
+func area(polygon []geometry.Point) float32 {
+	return float32(math.Abs(float64(geometry.SignedArea(polygon))))
+}
+
+// Uniform reports whether all sides have the same color.
+func Uniform(colors clay.BorderColors) bool {
+	return colors.Left == colors.Right && colors.Left == colors.Top && colors.Left == colors.Bottom
+}
+
+// Triangles returns a list of triangles, three vertices each, covering the borders of the rounded rectangle in box
+// in the style and colors of config, or its gradient.
+func Triangles(box clay.BoundingBox, config *clay.BorderRenderData) []geometry.Vertex {
+	var vertices []geometry.Vertex
+	for _, segment := range Segments(box, config.CornerRadius, config.Width, config.Style, config.DashLength, config.GapLength) {
+		if config.Gradient.Type != clay.GRADIENT_TYPE_NONE {
+			vertices = append(vertices, gradient.Triangles(&config.Gradient, box, segment.Points)...)
+			continue
+		}
+		vertices = geometry.Fan(vertices, segment.Points, segment.Side.Color(config.Colors))
+	}
+	return vertices
+}
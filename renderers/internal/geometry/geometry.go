@@ -0,0 +1,245 @@
+// Package geometry turns rectangles, borders and shadows with rounded corners into anti-aliased triangles for the renderers
+// that draw with colored triangles, or into the coverage of every pixel for the ones that fill pixels themselves. Every
+// corner's radius is taken on its own, so all renderers round each corner the same way. The points, vertices and outlines
+// it works with are shared by the other renderer helpers.
+package geometry
+
+import (
+	"math"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// Edges fade out over a pixel, from half a pixel inside of them to half a pixel outside.
+const feather = 0.5
+
+// The number of straight segments each rounded corner of a shadow's rings is drawn with.
+const shadowCornerSegments = 8
+
+// Coverage returns how much of a pixel whose center is distance away from a shape's edge is inside the shape, fading over width pixels.
+func Coverage(distance, width float32) float32 {
+	return min(max(0.5-distance/max(width, 1), 0), 1)
+}
+
+// GrowRadius returns the radii of the corners of a rounded rectangle grown by amount on every side, or shrunk when it's negative.
+func GrowRadius(radius clay.CornerRadius, amount float32) clay.CornerRadius {
+	return clay.CornerRadius{
+		TopLeft:     max(radius.TopLeft+amount, 0),
+		TopRight:    max(radius.TopRight+amount, 0),
+		BottomLeft:  max(radius.BottomLeft+amount, 0),
+		BottomRight: max(radius.BottomRight+amount, 0),
+	}
+}
+
+// Distance returns the signed distance from the point to the edge of the rounded rectangle, negative inside it.
+func Distance(x, y float32, box clay.BoundingBox, radius clay.CornerRadius) float32 {
+	halfWidth, halfHeight := box.Width/2, box.Height/2
+	dx, dy := x-(box.X+halfWidth), y-(box.Y+halfHeight)
+	r := radius.TopLeft
+	switch {
+	case dx >= 0 && dy < 0:
+		r = radius.TopRight
+	case dx >= 0 && dy >= 0:
+		r = radius.BottomRight
+	case dx < 0 && dy >= 0:
+		r = radius.BottomLeft
+	}
+	r = min(r, halfWidth, halfHeight)
+	qx, qy := abs(dx)-halfWidth+r, abs(dy)-halfHeight+r
+	outside := float32(math.Hypot(float64(max(qx, 0)), float64(max(qy, 0))))
+	return outside + min(max(qx, qy), 0) - r
+}
+
+// RoundedRect returns a list of triangles, three vertices each, filling the rounded rectangle in box with color. Its edges
+// fade out over a pixel.
+func RoundedRect(box clay.BoundingBox, radius clay.CornerRadius, color clay.Color) []Vertex {
+	if box.Width <= 0 || box.Height <= 0 {
+		return nil
+	}
+	inner := offsetOutline(box, radius, -feather, -feather, -feather, -feather)
+	outer := offsetOutline(box, radius, feather, feather, feather, feather)
+	vertices := Fan(nil, inner, color)
+	return ring(vertices, inner, color, outer, transparent(color))
+}
+
+// Border returns a list of triangles, three vertices each, filling the borders of the rounded rectangle in box with color.
+// Both the outside and the inside edges of the borders fade out over a pixel, along the sides that have a border.
+func Border(box clay.BoundingBox, radius clay.CornerRadius, width clay.BorderWidth, color clay.Color) []Vertex {
+	if box.Width <= 0 || box.Height <= 0 {
+		return nil
+	}
+	inner, innerRadius := BorderInner(box, radius, width)
+	if inner.Width <= 0 || inner.Height <= 0 {
+		// The borders cover the whole rectangle
+		return RoundedRect(box, radius, color)
+	}
+	// Sides without a border don't fade, so all the outlines meet there and nothing is drawn along them
+	side := func(w uint16) float32 {
+		if w == 0 {
+			return 0
+		}
+		return feather
+	}
+	left, top, right, bottom := side(width.Left), side(width.Top), side(width.Right), side(width.Bottom)
+	outerEdge := offsetOutline(box, radius, left, top, right, bottom)
+	outer := offsetOutline(box, radius, -left, -top, -right, -bottom)
+	innerEdge := offsetOutline(inner, innerRadius, -left, -top, -right, -bottom)
+	innerFilled := offsetOutline(inner, innerRadius, left, top, right, bottom)
+	vertices := ring(nil, outer, color, outerEdge, transparent(color))
+	vertices = ring(vertices, innerFilled, color, outer, color)
+	return ring(vertices, innerEdge, transparent(color), innerFilled, color)
+}
+
+// ShadowVertex is a point of a shadow's mesh, with the shadow's opacity at it from 0 to 1.
+type ShadowVertex struct {
+	X, Y, Alpha float32
+}
+
+// Shadow returns the vertices of triangles covering the shadow cast by the rounded rectangle in box, and the indices of
+// every triangle's three vertices. The mesh is made of rings following the shape, with the shadow's opacity computed at
+// every vertex so the blur is interpolated between them. Outer shadows are also drawn underneath the element, which hides
+// them when it is opaque.
+func Shadow(box clay.BoundingBox, config *clay.ShadowRenderData) ([]ShadowVertex, []uint16) {
+	spread := config.Spread
+	if config.Inset {
+		spread = -spread
+	}
+	shadowBox := clay.BoundingBox{
+		X:      box.X + config.Offset.X - spread,
+		Y:      box.Y + config.Offset.Y - spread,
+		Width:  max(box.Width+spread*2, 0),
+		Height: max(box.Height+spread*2, 0),
+	}
+	shadowRadius := GrowRadius(config.CornerRadius, spread)
+	reach := max(config.BlurRadius/2, 0) + 0.5
+	// Rings are placed at these distances from the edge of base, negative inside it
+	base, baseRadius, from, to := shadowBox, shadowRadius, -reach, reach
+	// Inset shadows don't follow the rings when they are offset, so their opacity also changes along the straight edges
+	// near the corners, which are split into edgeSegments steps from either end.
+	edgeSegments, edgeStep := 0, float32(0)
+	if config.Inset {
+		depth := min(max(abs(config.Offset.X), abs(config.Offset.Y))+config.Spread+reach*2, min(box.Width, box.Height)/2)
+		base, baseRadius, from, to = box, config.CornerRadius, -depth, 0
+		edgeSegments = int(min(math.Ceil(float64(depth)/2), 32))
+		edgeStep = depth / float32(max(edgeSegments, 1))
+	}
+	alpha := func(x, y float32) float32 {
+		shadow := Coverage(Distance(x, y, shadowBox, shadowRadius), config.BlurRadius)
+		if config.Inset {
+			shadow = 1 - shadow
+		}
+		return shadow * config.Color.A / 255
+	}
+
+	rings := int(min(max(math.Ceil(float64(to-from)/2), 1), 16))
+	ringSize := 4 * (shadowCornerSegments + 1 + edgeSegments*2)
+	vertices := make([]ShadowVertex, 0, (rings+1)*ringSize+1)
+	indices := make([]uint16, 0, rings*ringSize*6+ringSize*3)
+	for ring := 0; ring <= rings; ring++ {
+		d := from + (to-from)*float32(ring)/float32(rings)
+		box := clay.BoundingBox{X: base.X - d, Y: base.Y - d, Width: max(base.Width+d*2, 0), Height: max(base.Height+d*2, 0)}
+		radius := GrowRadius(baseRadius, d)
+		maxRadius := min(box.Width, box.Height) / 2
+		// The center of every corner's arc lies inward from the bounding box's corner by the radius, in the direction of dx and dy
+		corners := [4]struct{ x, y, dx, dy, r, angle float32 }{
+			{box.X, box.Y, 1, 1, min(radius.TopLeft, maxRadius), math.Pi},
+			{box.X + box.Width, box.Y, -1, 1, min(radius.TopRight, maxRadius), math.Pi * 1.5},
+			{box.X + box.Width, box.Y + box.Height, -1, -1, min(radius.BottomRight, maxRadius), 0},
+			{box.X, box.Y + box.Height, 1, -1, min(radius.BottomLeft, maxRadius), math.Pi / 2},
+		}
+		arcPoint := func(corner int, i int) (float32, float32) {
+			c := corners[corner]
+			angle := float64(c.angle) + math.Pi/2*float64(i)/shadowCornerSegments
+			return c.x + c.dx*c.r + c.r*float32(math.Cos(angle)), c.y + c.dy*c.r + c.r*float32(math.Sin(angle))
+		}
+		for corner := range corners {
+			for i := 0; i <= shadowCornerSegments; i++ {
+				x, y := arcPoint(corner, i)
+				vertices = append(vertices, ShadowVertex{X: x, Y: y, Alpha: alpha(x, y)})
+			}
+			// Step along the edge to the next corner from both ends, stopping in the middle of short edges
+			startX, startY := arcPoint(corner, shadowCornerSegments)
+			endX, endY := arcPoint((corner+1)%4, 0)
+			length := float32(math.Hypot(float64(endX-startX), float64(endY-startY)))
+			for i := range edgeSegments * 2 {
+				distance := min(float32(i+1)*edgeStep, length/2)
+				if i >= edgeSegments {
+					distance = length - min(float32(edgeSegments*2-i)*edgeStep, length/2)
+				}
+				x, y := startX, startY
+				if length > 0 {
+					x, y = startX+(endX-startX)*distance/length, startY+(endY-startY)*distance/length
+				}
+				vertices = append(vertices, ShadowVertex{X: x, Y: y, Alpha: alpha(x, y)})
+			}
+		}
+		if ring > 0 {
+			inner, outer := (ring-1)*ringSize, ring*ringSize
+			for i := 0; i < ringSize; i++ {
+				j := (i + 1) % ringSize
+				indices = append(indices,
+					uint16(inner+i), uint16(outer+i), uint16(outer+j),
+					uint16(inner+i), uint16(outer+j), uint16(inner+j),
+				)
+			}
+		}
+	}
+	// Fill the innermost ring with a fan around the center
+	center := len(vertices)
+	cx, cy := base.X+base.Width/2, base.Y+base.Height/2
+	vertices = append(vertices, ShadowVertex{X: cx, Y: cy, Alpha: alpha(cx, cy)})
+	for i := 0; i < ringSize; i++ {
+		indices = append(indices, uint16(center), uint16(i), uint16((i+1)%ringSize))
+	}
+	return vertices, indices
+}
+
+// outline returns the outline of the rounded rectangle in box with its sides moved outward by the given amounts, inward
+// when they're negative, as [Outline] does. Rounded corners grow and shrink along with the sides next to them,
+// while square ones stay square, so outlines of the same rectangle can be joined point by point.
+func offsetOutline(box clay.BoundingBox, radius clay.CornerRadius, left, top, right, bottom float32) []Point {
+	maxRadius := min(box.Width, box.Height) / 2
+	grow := func(r, a, b float32) float32 {
+		if r <= 0 {
+			return 0
+		}
+		return max(min(r, maxRadius)+(a+b)/2, 0)
+	}
+	return Outline(clay.BoundingBox{
+		X:      box.X - left,
+		Y:      box.Y - top,
+		Width:  max(box.Width+left+right, 0),
+		Height: max(box.Height+top+bottom, 0),
+	}, clay.CornerRadius{
+		TopLeft:     grow(radius.TopLeft, left, top),
+		TopRight:    grow(radius.TopRight, right, top),
+		BottomLeft:  grow(radius.BottomLeft, left, bottom),
+		BottomRight: grow(radius.BottomRight, right, bottom),
+	})
+}
+
+// ring appends triangles filling the space between the outlines inner and outer, which have the same number of points,
+// blending from innerColor to outerColor.
+func ring(vertices []Vertex, inner []Point, innerColor clay.Color, outer []Point, outerColor clay.Color) []Vertex {
+	for i := range inner {
+		j := (i + 1) % len(inner)
+		a := Vertex{X: inner[i].X, Y: inner[i].Y, Color: innerColor}
+		b := Vertex{X: outer[i].X, Y: outer[i].Y, Color: outerColor}
+		c := Vertex{X: outer[j].X, Y: outer[j].Y, Color: outerColor}
+		d := Vertex{X: inner[j].X, Y: inner[j].Y, Color: innerColor}
+		vertices = append(vertices, a, b, c, a, c, d)
+	}
+	return vertices
+}
+
+func transparent(c clay.Color) clay.Color {
+	c.A = 0
+	return c
+}
+
+func abs(v float32) float32 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
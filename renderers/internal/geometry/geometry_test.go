@@ -0,0 +1,216 @@
+package geometry
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+var red = clay.Color{R: 255, A: 255}
+
+// area returns the total area of the triangles, three vertices each.
+func area(vertices []Vertex) float32 {
+	var total float32
+	for i := 0; i+2 < len(vertices); i += 3 {
+		a, b, c := vertices[i], vertices[i+1], vertices[i+2]
+		total += abs((b.X-a.X)*(c.Y-a.Y)-(c.X-a.X)*(b.Y-a.Y)) / 2
+	}
+	return total
+}
+
+// covered reports whether the point is inside any of the triangles with a non-zero area.
+func covered(vertices []Vertex, x, y float32) bool {
+	for i := 0; i+2 < len(vertices); i += 3 {
+		a, b, c := vertices[i], vertices[i+1], vertices[i+2]
+		d1 := (b.X-a.X)*(y-a.Y) - (b.Y-a.Y)*(x-a.X)
+		d2 := (c.X-b.X)*(y-b.Y) - (c.Y-b.Y)*(x-b.X)
+		d3 := (a.X-c.X)*(y-c.Y) - (a.Y-c.Y)*(x-c.X)
+		if (d1 > 0 && d2 > 0 && d3 > 0) || (d1 < 0 && d2 < 0 && d3 < 0) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestRoundedRect(t *testing.T) {
+	if vertices := RoundedRect(clay.BoundingBox{Width: 0, Height: 10}, clay.CornerRadius{}, red); vertices != nil {
+		t.Fatalf("expected no triangles for an empty box, got %d vertices", len(vertices))
+	}
+	box := clay.BoundingBox{X: 10, Y: 20, Width: 40, Height: 30}
+	radius := clay.CornerRadius{TopLeft: 10, BottomRight: 5}
+	vertices := RoundedRect(box, radius, red)
+	if len(vertices) == 0 || len(vertices)%3 != 0 {
+		t.Fatalf("expected whole triangles, got %d vertices", len(vertices))
+	}
+	// Opaque vertices lie half a pixel inside the edge and transparent ones half a pixel outside of it
+	for _, v := range vertices {
+		want := float32(-feather)
+		if v.Color == transparent(red) {
+			want = feather
+		} else if v.Color != red {
+			t.Fatalf("expected vertices to be red or transparent, got %v", v.Color)
+		}
+		// Square corners stay square, so the distance is measured to the outline moved by the feather instead
+		grown := clay.BoundingBox{X: box.X - want, Y: box.Y - want, Width: box.Width + want*2, Height: box.Height + want*2}
+		grownRadius := clay.CornerRadius{TopLeft: radius.TopLeft + want, BottomRight: radius.BottomRight + want}
+		if d := Distance(v.X, v.Y, grown, grownRadius); abs(d) > 0.01 {
+			t.Errorf("vertex at (%v, %v): expected to be %v from the edge, got %v", v.X, v.Y, want, d+want)
+		}
+	}
+	// A square rectangle is covered up to half a pixel outside of its edges without any overlap
+	if got := area(RoundedRect(box, clay.CornerRadius{}, red)); got != 41*31 {
+		t.Errorf("expected the triangles to cover %v, got %v", 41*31, got)
+	}
+}
+
+func TestOutline(t *testing.T) {
+	box := clay.BoundingBox{X: 10, Y: 20, Width: 40, Height: 30}
+	radius := clay.CornerRadius{TopLeft: 10, BottomRight: 50}
+	// Outlines always have the same number of points, and a radius larger than the box is limited to half of its height
+	points := Outline(box, radius)
+	if len(points) != len(Outline(box, clay.CornerRadius{})) {
+		t.Fatalf("expected outlines of the same length, got %d points", len(points))
+	}
+	for _, p := range points {
+		if d := Distance(p.X, p.Y, box, clay.CornerRadius{TopLeft: 10, BottomRight: 15}); abs(d) > 1e-3 {
+			t.Fatalf("expected (%v, %v) to be on the edge, got %v away", p.X, p.Y, d)
+		}
+	}
+	if SignedArea(points) <= 0 {
+		t.Error("expected the outline to go clockwise")
+	}
+	// Only the rounded corners are cut off, each fanning out from the box's corner
+	corners := Corners(box, radius)
+	if len(corners) != 2 || corners[0][0] != (Point{10, 20}) || corners[1][0] != (Point{50, 50}) {
+		t.Fatalf("expected the top left and bottom right corners to be cut off, got %v", corners)
+	}
+}
+
+func TestBorder(t *testing.T) {
+	box := clay.BoundingBox{Width: 40, Height: 30}
+	radius := clay.CornerRadius{TopLeft: 8}
+	vertices := Border(box, radius, clay.BorderWidth{Left: 2, Top: 2, Right: 2, Bottom: 2}, red)
+	if len(vertices) == 0 || len(vertices)%3 != 0 {
+		t.Fatalf("expected whole triangles, got %d vertices", len(vertices))
+	}
+	tests := []struct {
+		x, y    float32
+		covered bool
+	}{
+		{1, 15, true},
+		{39, 15, true},
+		{20, 1, true},
+		{20, 29, true},
+		// Inside the borders
+		{20, 15, false},
+		{4, 15, false},
+		// Outside the rounded corner
+		{0.5, 0.5, false},
+	}
+	for _, test := range tests {
+		if got := covered(vertices, test.x, test.y); got != test.covered {
+			t.Errorf("(%v, %v): expected covered to be %v, got %v", test.x, test.y, test.covered, got)
+		}
+	}
+
+	// Sides without a border don't fade, so nothing is drawn beyond them
+	vertices = Border(box, clay.CornerRadius{}, clay.BorderWidth{Top: 2}, red)
+	for _, v := range vertices {
+		if v.X < box.X || v.X > box.X+box.Width || v.Y > box.Y+box.Height {
+			t.Fatalf("expected the vertex at (%v, %v) not to be outside the sides without a border", v.X, v.Y)
+		}
+	}
+	if covered(vertices, 20, 15) || !covered(vertices, 21, 1) {
+		t.Error("expected only the top border to be covered")
+	}
+
+	// Borders covering the whole rectangle fill it
+	full := Border(box, radius, clay.BorderWidth{Left: 20, Top: 20, Right: 20, Bottom: 20}, red)
+	want := RoundedRect(box, radius, red)
+	if len(full) != len(want) {
+		t.Fatalf("expected borders covering the rectangle to fill it, got %d vertices instead of %d", len(full), len(want))
+	}
+	for i := range want {
+		if full[i] != want[i] {
+			t.Fatalf("expected borders covering the rectangle to fill it, got %v instead of %v", full[i], want[i])
+		}
+	}
+}
+
+func TestShadow(t *testing.T) {
+	box := clay.BoundingBox{X: 100, Y: 100, Width: 40, Height: 40}
+	tests := []struct {
+		name   string
+		config clay.ShadowRenderData
+		// The shadow's alpha at the center of the element
+		center float32
+	}{
+		// Outer shadows are drawn underneath the element too
+		{"outer", clay.ShadowRenderData{Color: clay.Color{A: 255}, Offset: clay.Vector2{X: 4, Y: 4}, BlurRadius: 8, Spread: 2}, 1},
+		{"inset", clay.ShadowRenderData{Color: clay.Color{A: 255}, Offset: clay.Vector2{X: 4, Y: 4}, BlurRadius: 8, Spread: 2, Inset: true}, 0},
+		{"rounded", clay.ShadowRenderData{Color: clay.Color{A: 128}, BlurRadius: 4, CornerRadius: clay.CornerRadius{TopLeft: 10, BottomRight: 10}}, 128.0 / 255},
+	}
+	for _, test := range tests {
+		vertices, indices := Shadow(box, &test.config)
+		if len(indices) == 0 || len(indices)%3 != 0 {
+			t.Fatalf("%s: expected whole triangles, got %d indices", test.name, len(indices))
+		}
+		for _, i := range indices {
+			if int(i) >= len(vertices) {
+				t.Fatalf("%s: index %d is out of range of %d vertices", test.name, i, len(vertices))
+			}
+		}
+		// The opacity at every vertex is the coverage the software renderer computes for a pixel there
+		spread := test.config.Spread
+		if test.config.Inset {
+			spread = -spread
+		}
+		shadowBox := clay.BoundingBox{
+			X:      box.X + test.config.Offset.X - spread,
+			Y:      box.Y + test.config.Offset.Y - spread,
+			Width:  box.Width + spread*2,
+			Height: box.Height + spread*2,
+		}
+		for _, v := range vertices {
+			want := Coverage(Distance(v.X, v.Y, shadowBox, GrowRadius(test.config.CornerRadius, spread)), test.config.BlurRadius)
+			if test.config.Inset {
+				want = 1 - want
+			}
+			if want *= test.config.Color.A / 255; abs(v.Alpha-want) > 1e-5 {
+				t.Fatalf("%s: vertex at (%v, %v): expected alpha %v, got %v", test.name, v.X, v.Y, want, v.Alpha)
+			}
+		}
+		center := vertices[len(vertices)-1]
+		if abs(center.Alpha-test.center) > 1e-5 {
+			t.Errorf("%s: expected alpha %v at the center, got %v", test.name, test.center, center.Alpha)
+		}
+		// Outer shadows fade out completely by their outermost ring
+		if !test.config.Inset {
+			for _, v := range vertices[len(vertices)-1-4*(shadowCornerSegments+1) : len(vertices)-1] {
+				if v.Alpha != 0 {
+					t.Fatalf("%s: expected the outermost ring to be transparent, got %v at (%v, %v)", test.name, v.Alpha, v.X, v.Y)
+				}
+			}
+		}
+	}
+}
+
+func TestDistance(t *testing.T) {
+	box := clay.BoundingBox{Width: 20, Height: 20}
+	radius := clay.CornerRadius{TopLeft: 10}
+	tests := []struct {
+		x, y, want float32
+	}{
+		{10, 10, -10},
+		{10, -5, 5},
+		// The rounded corner is measured from its arc, the square one from its corner
+		{0, 0, float32(10*math.Sqrt2 - 10)},
+		{25, 25, float32(5 * math.Sqrt2)},
+	}
+	for _, test := range tests {
+		if got := Distance(test.x, test.y, box, radius); abs(got-test.want) > 1e-4 {
+			t.Errorf("(%v, %v): expected %v, got %v", test.x, test.y, test.want, got)
+		}
+	}
+}
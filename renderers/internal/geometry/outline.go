@@ -0,0 +1,107 @@
+package geometry
+
+import (
+	"math"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// The number of straight segments each rounded corner of an outline is drawn with.
+const cornerSegments = 16
+
+type Point struct {
+	X, Y float32
+}
+
+type Vertex struct {
+	X, Y  float32
+	Color clay.Color
+}
+
+// Outline returns the outline of the rounded rectangle in box, clockwise from the top left corner. The outline always has
+// the same number of points, so outlines of different rectangles can be joined point by point.
+func Outline(box clay.BoundingBox, radius clay.CornerRadius) []Point {
+	maxRadius := min(box.Width, box.Height) / 2
+	corners := [4]struct{ x, y, r, angle float32 }{
+		{box.X + min(radius.TopLeft, maxRadius), box.Y + min(radius.TopLeft, maxRadius), min(radius.TopLeft, maxRadius), math.Pi},
+		{box.X + box.Width - min(radius.TopRight, maxRadius), box.Y + min(radius.TopRight, maxRadius), min(radius.TopRight, maxRadius), math.Pi * 1.5},
+		{box.X + box.Width - min(radius.BottomRight, maxRadius), box.Y + box.Height - min(radius.BottomRight, maxRadius), min(radius.BottomRight, maxRadius), 0},
+		{box.X + min(radius.BottomLeft, maxRadius), box.Y + box.Height - min(radius.BottomLeft, maxRadius), min(radius.BottomLeft, maxRadius), math.Pi / 2},
+	}
+	points := make([]Point, 0, 4*(cornerSegments+1))
+	for _, corner := range corners {
+		for i := 0; i <= cornerSegments; i++ {
+			angle := float64(corner.angle) + math.Pi/2*float64(i)/cornerSegments
+			points = append(points, Point{
+				X: corner.x + corner.r*float32(math.Cos(angle)),
+				Y: corner.y + corner.r*float32(math.Sin(angle)),
+			})
+		}
+	}
+	return points
+}
+
+// Corners returns the areas that rounding the corners of box cuts off, each as a fan of points around the corner of box.
+// Corners without a radius are left out.
+func Corners(box clay.BoundingBox, radius clay.CornerRadius) [][]Point {
+	outline := Outline(box, radius)
+	corners := [4]Point{{box.X, box.Y}, {box.X + box.Width, box.Y}, {box.X + box.Width, box.Y + box.Height}, {box.X, box.Y + box.Height}}
+	radii := [4]float32{radius.TopLeft, radius.TopRight, radius.BottomRight, radius.BottomLeft}
+	var fans [][]Point
+	for i, corner := range corners {
+		if radii[i] <= 0 {
+			continue
+		}
+		arc := outline[i*(cornerSegments+1) : (i+1)*(cornerSegments+1)]
+		fans = append(fans, append([]Point{corner}, arc...))
+	}
+	return fans
+}
+
+// BorderInner returns the rounded rectangle left inside the borders of the rounded rectangle in box.
+func BorderInner(box clay.BoundingBox, radius clay.CornerRadius, width clay.BorderWidth) (clay.BoundingBox, clay.CornerRadius) {
+	left, right, top, bottom := float32(width.Left), float32(width.Right), float32(width.Top), float32(width.Bottom)
+	inner := clay.BoundingBox{
+		X:      box.X + left,
+		Y:      box.Y + top,
+		Width:  max(box.Width-left-right, 0),
+		Height: max(box.Height-top-bottom, 0),
+	}
+	innerRadius := clay.CornerRadius{
+		TopLeft:     max(radius.TopLeft-max(left, top), 0),
+		TopRight:    max(radius.TopRight-max(right, top), 0),
+		BottomLeft:  max(radius.BottomLeft-max(left, bottom), 0),
+		BottomRight: max(radius.BottomRight-max(right, bottom), 0),
+	}
+	return inner, innerRadius
+}
+
+// Fan appends triangles, three vertices each, covering the convex polygon in a single color.
+func Fan(vertices []Vertex, polygon []Point, color clay.Color) []Vertex {
+	for i := 1; i+1 < len(polygon); i++ {
+		for _, p := range [3]Point{polygon[0], polygon[i], polygon[i+1]} {
+			vertices = append(vertices, Vertex{X: p.X, Y: p.Y, Color: color})
+		}
+	}
+	return vertices
+}
+
+// Lerp returns the point t of the way from a to b.
+func Lerp(a, b Point, t float32) Point {
+	return Point{X: a.X + (b.X-a.X)*t, Y: a.Y + (b.Y-a.Y)*t}
+}
+
+// SegmentLength returns the distance between a and b.
+func SegmentLength(a, b Point) float32 {
+	return float32(math.Hypot(float64(b.X-a.X), float64(b.Y-a.Y)))
+}
+
+// SignedArea returns the area of the polygon, positive when its points go clockwise on the screen.
+func SignedArea(polygon []Point) float32 {
+	var sum float32
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		sum += p.X*q.Y - q.X*p.Y
+	}
+	return sum / 2
+}
@@ -0,0 +1,150 @@
+// Package gradient splits shapes into triangles whose vertex colors reproduce a [clay.Gradient] when they are interpolated,
+// for the renderers that draw with colored triangles.
+package gradient
+
+import (
+	"math"
+	"slices"
+
+	"github.com/TotallyGamerJet/clay"
+	"github.com/TotallyGamerJet/clay/renderers/internal/geometry"
+)
+
+// Radial gradients are split into cells of this many rings and angles, on top of the rings at every stop.
+const (
+	radialRings    = 16
+	radialSegments = 48
+)
+
+// Triangles returns a list of triangles, three vertices each, covering the convex polygon with the gradient filling box.
+// Linear gradients are split at every stop so they are reproduced exactly, radial ones are approximated by small cells.
+func Triangles(g *clay.Gradient, box clay.BoundingBox, polygon []geometry.Point) []geometry.Vertex {
+	var vertices []geometry.Vertex
+	fan := func(polygon []geometry.Point) {
+		for i := 1; i+1 < len(polygon); i++ {
+			for _, p := range [3]geometry.Point{polygon[0], polygon[i], polygon[i+1]} {
+				vertices = append(vertices, geometry.Vertex{X: p.X, Y: p.Y, Color: g.ColorAt(box, p.X, p.Y)})
+			}
+		}
+	}
+	stops := g.Stops[:min(max(g.StopCount, 0), clay.GRADIENT_MAX_STOPS)]
+	if g.Type != clay.GRADIENT_TYPE_RADIAL {
+		// Colors change linearly between stops, so every band between two stops can be drawn as is
+		position := func(p geometry.Point) float32 { return g.Position(box, p.X, p.Y) }
+		from := float32(math.Inf(-1))
+		for i := 0; i <= len(stops); i++ {
+			to := float32(math.Inf(1))
+			if i < len(stops) {
+				to = stops[i].Position
+			}
+			band := clip(polygon, func(p geometry.Point) float32 { return position(p) - from })
+			band = clip(band, func(p geometry.Point) float32 { return to - position(p) })
+			fan(band)
+			from = to
+		}
+		return vertices
+	}
+
+	cx, cy := box.X+box.Width*g.Center.X, box.Y+box.Height*g.Center.Y
+	var maxDistance float32
+	minX, minY, maxX, maxY := float32(math.Inf(1)), float32(math.Inf(1)), float32(math.Inf(-1)), float32(math.Inf(-1))
+	for _, p := range polygon {
+		maxDistance = max(maxDistance, float32(math.Hypot(float64(p.X-cx), float64(p.Y-cy))))
+		minX, minY, maxX, maxY = min(minX, p.X), min(minY, p.Y), max(maxX, p.X), max(maxY, p.Y)
+	}
+	// The outermost ring is grown so its straight segments still enclose the polygon
+	maxDistance /= float32(math.Cos(math.Pi / radialSegments))
+	radii := make([]float32, 0, radialRings+len(stops)+1)
+	for i := 0; i <= radialRings; i++ {
+		radii = append(radii, maxDistance*float32(i)/radialRings)
+	}
+	for _, stop := range stops {
+		// Position is the distance from the center divided by the gradient's radius
+		if unit := g.Position(box, cx+1, cy); unit > 0 {
+			if r := stop.Position / unit; r > 0 && r < maxDistance {
+				radii = append(radii, r)
+			}
+		}
+	}
+	slices.Sort(radii)
+	inside := edges(polygon)
+	for ring := 1; ring < len(radii); ring++ {
+		r0, r1 := radii[ring-1], radii[ring]
+		if r1-r0 < 1e-3 {
+			continue
+		}
+		for segment := 0; segment < radialSegments; segment++ {
+			a0 := 2 * math.Pi * float64(segment) / radialSegments
+			a1 := 2 * math.Pi * float64(segment+1) / radialSegments
+			point := func(r float32, a float64) geometry.Point {
+				return geometry.Point{X: cx + r*float32(math.Cos(a)), Y: cy + r*float32(math.Sin(a))}
+			}
+			cell := []geometry.Point{point(r0, a0), point(r1, a0), point(r1, a1), point(r0, a1)}
+			if r0 == 0 {
+				cell = cell[1:]
+			}
+			if outside(cell, minX, minY, maxX, maxY) {
+				continue
+			}
+			for _, edge := range inside {
+				cell = clip(cell, edge)
+			}
+			fan(cell)
+		}
+	}
+	return vertices
+}
+
+// clip returns the part of the convex polygon where inside returns a value >= 0. inside must change linearly across the plane.
+func clip(polygon []geometry.Point, inside func(geometry.Point) float32) []geometry.Point {
+	if len(polygon) == 0 {
+		return nil
+	}
+	clipped := make([]geometry.Point, 0, len(polygon)+1)
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		dp, dq := inside(p), inside(q)
+		if dp >= 0 {
+			clipped = append(clipped, p)
+		}
+		if (dp >= 0) != (dq >= 0) {
+			t := dp / (dp - dq)
+			clipped = append(clipped, geometry.Point{X: p.X + (q.X-p.X)*t, Y: p.Y + (q.Y-p.Y)*t})
+		}
+	}
+	if len(clipped) < 3 {
+		return nil
+	}
+	return clipped
+}
+
+// edges returns a function for every edge of the convex polygon that is >= 0 on the polygon's side of it.
+func edges(polygon []geometry.Point) []func(geometry.Point) float32 {
+	orientation := float32(1)
+	if geometry.SignedArea(polygon) < 0 {
+		orientation = -1
+	}
+	sides := make([]func(geometry.Point) float32, 0, len(polygon))
+	for i, p := range polygon {
+		q := polygon[(i+1)%len(polygon)]
+		if p == q {
+			continue
+		}
+		sides = append(sides, func(v geometry.Point) float32 {
+			return orientation * ((q.X-p.X)*(v.Y-p.Y) - (q.Y-p.Y)*(v.X-p.X))
+		})
+	}
+	return sides
+}
+
+// outside reports whether the polygon lies entirely outside of the given bounds.
+func outside(polygon []geometry.Point, minX, minY, maxX, maxY float32) bool {
+	left, top, right, bottom := true, true, true, true
+	for _, p := range polygon {
+		left = left && p.X < minX
+		top = top && p.Y < minY
+		right = right && p.X > maxX
+		bottom = bottom && p.Y > maxY
+	}
+	return left || top || right || bottom
+}
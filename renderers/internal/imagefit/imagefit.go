@@ -0,0 +1,170 @@
+// Package imagefit works out which parts of an image the renderers draw for an image render command, and where, following
+// its fit mode, alignment, source rectangle and nine-slice.
+package imagefit
+
+import (
+	"github.com/TotallyGamerJet/clay"
+)
+
+// place returns the part of the image to draw, in the image's own pixels, and where to draw it, cropped to box. The image
+// is imageWidth by imageHeight pixels. Nothing should be drawn when either rectangle is empty.
+func place(box clay.BoundingBox, config *clay.ImageRenderData, imageWidth, imageHeight float32) (src, dst clay.BoundingBox) {
+	src = config.SourceRect
+	if src.Width <= 0 || src.Height <= 0 {
+		src = clay.BoundingBox{Width: imageWidth, Height: imageHeight}
+	}
+	if src.Width <= 0 || src.Height <= 0 || box.Width <= 0 || box.Height <= 0 {
+		return clay.BoundingBox{}, clay.BoundingBox{}
+	}
+	natural := config.IntrinsicSize
+	if natural.Width <= 0 || natural.Height <= 0 {
+		natural = clay.Dimensions{Width: src.Width, Height: src.Height}
+	}
+
+	width, height := box.Width, box.Height
+	switch config.Fit {
+	case clay.IMAGE_FIT_CONTAIN:
+		scale := min(box.Width/natural.Width, box.Height/natural.Height)
+		width, height = natural.Width*scale, natural.Height*scale
+	case clay.IMAGE_FIT_COVER:
+		scale := max(box.Width/natural.Width, box.Height/natural.Height)
+		width, height = natural.Width*scale, natural.Height*scale
+	case clay.IMAGE_FIT_NONE:
+		width, height = natural.Width, natural.Height
+	case clay.IMAGE_FIT_SCALE_DOWN:
+		scale := min(box.Width/natural.Width, box.Height/natural.Height, 1)
+		width, height = natural.Width*scale, natural.Height*scale
+	}
+	dst = clay.BoundingBox{
+		X:      box.X + (box.Width-width)*alignX(config.Alignment.X),
+		Y:      box.Y + (box.Height-height)*alignY(config.Alignment.Y),
+		Width:  width,
+		Height: height,
+	}
+
+	// Parts of the image outside of box are cut off of both rectangles
+	left, top := max(box.X-dst.X, 0), max(box.Y-dst.Y, 0)
+	right := max(dst.X+dst.Width-(box.X+box.Width), 0)
+	bottom := max(dst.Y+dst.Height-(box.Y+box.Height), 0)
+	scaleX, scaleY := src.Width/dst.Width, src.Height/dst.Height
+	src = clay.BoundingBox{
+		X:      src.X + left*scaleX,
+		Y:      src.Y + top*scaleY,
+		Width:  src.Width - (left+right)*scaleX,
+		Height: src.Height - (top+bottom)*scaleY,
+	}
+	dst = clay.BoundingBox{
+		X:      dst.X + left,
+		Y:      dst.Y + top,
+		Width:  dst.Width - left - right,
+		Height: dst.Height - top - bottom,
+	}
+	return src, dst
+}
+
+func alignX(a clay.LayoutAlignmentX) float32 {
+	switch a {
+	case clay.ALIGN_X_CENTER:
+		return 0.5
+	case clay.ALIGN_X_RIGHT:
+		return 1
+	}
+	return 0
+}
+
+func alignY(a clay.LayoutAlignmentY) float32 {
+	switch a {
+	case clay.ALIGN_Y_CENTER:
+		return 0.5
+	case clay.ALIGN_Y_BOTTOM:
+		return 1
+	}
+	return 0
+}
+
+// Part is a piece of an image drawn from Src, in the image's own pixels, into Dst.
+type Part struct {
+	Src, Dst clay.BoundingBox
+}
+
+// Parts returns the pieces to draw for the image render command, which is a single one unless the image is drawn as a
+// nine-slice. The image is imageWidth by imageHeight pixels.
+func Parts(box clay.BoundingBox, config *clay.ImageRenderData, imageWidth, imageHeight float32) []Part {
+	slice := config.NineSlice
+	if slice.Left <= 0 && slice.Top <= 0 && slice.Right <= 0 && slice.Bottom <= 0 {
+		src, dst := place(box, config, imageWidth, imageHeight)
+		if src.Width <= 0 || src.Height <= 0 || dst.Width <= 0 || dst.Height <= 0 {
+			return nil
+		}
+		return []Part{{Src: src, Dst: dst}}
+	}
+	src := config.SourceRect
+	if src.Width <= 0 || src.Height <= 0 {
+		src = clay.BoundingBox{Width: imageWidth, Height: imageHeight}
+	}
+	if src.Width <= 0 || src.Height <= 0 || box.Width <= 0 || box.Height <= 0 {
+		return nil
+	}
+	scale := config.NineSliceScale
+	if scale <= 0 {
+		scale = 1
+	}
+	left, top := clamp(slice.Left, src.Width), clamp(slice.Top, src.Height)
+	right, bottom := clamp(slice.Right, src.Width-left), clamp(slice.Bottom, src.Height-top)
+	// Corners shrink to fit into elements smaller than them
+	drawnLeft, drawnRight := shrink(left*scale, right*scale, box.Width)
+	drawnTop, drawnBottom := shrink(top*scale, bottom*scale, box.Height)
+
+	type span struct{ start, size float32 }
+	srcColumns := [3]span{{src.X, left}, {src.X + left, src.Width - left - right}, {src.X + src.Width - right, right}}
+	srcRows := [3]span{{src.Y, top}, {src.Y + top, src.Height - top - bottom}, {src.Y + src.Height - bottom, bottom}}
+	dstColumns := [3]span{{box.X, drawnLeft}, {box.X + drawnLeft, box.Width - drawnLeft - drawnRight}, {box.X + box.Width - drawnRight, drawnRight}}
+	dstRows := [3]span{{box.Y, drawnTop}, {box.Y + drawnTop, box.Height - drawnTop - drawnBottom}, {box.Y + box.Height - drawnBottom, drawnBottom}}
+	parts := make([]Part, 0, 9)
+	for row := range 3 {
+		for column := range 3 {
+			src := clay.BoundingBox{X: srcColumns[column].start, Y: srcRows[row].start, Width: srcColumns[column].size, Height: srcRows[row].size}
+			dst := clay.BoundingBox{X: dstColumns[column].start, Y: dstRows[row].start, Width: dstColumns[column].size, Height: dstRows[row].size}
+			if src.Width <= 0 || src.Height <= 0 || dst.Width <= 0 || dst.Height <= 0 {
+				continue
+			}
+			parts = tile(parts, src, dst, slice.Tiled && column == 1, slice.Tiled && row == 1, scale)
+		}
+	}
+	return parts
+}
+
+// tile appends the pieces that repeat src at the given scale across dst along the tiled directions, and stretch it along the others.
+// The last tile in each direction is cut off where dst ends.
+func tile(parts []Part, src, dst clay.BoundingBox, tileX, tileY bool, scale float32) []Part {
+	width, height := dst.Width, dst.Height
+	// Tiles smaller than a pixel are stretched instead
+	if tileX && src.Width*scale >= 1 {
+		width = src.Width * scale
+	}
+	if tileY && src.Height*scale >= 1 {
+		height = src.Height * scale
+	}
+	for y := float32(0); y < dst.Height; y += height {
+		for x := float32(0); x < dst.Width; x += width {
+			w, h := min(width, dst.Width-x), min(height, dst.Height-y)
+			parts = append(parts, Part{
+				Src: clay.BoundingBox{X: src.X, Y: src.Y, Width: src.Width * w / width, Height: src.Height * h / height},
+				Dst: clay.BoundingBox{X: dst.X + x, Y: dst.Y + y, Width: w, Height: h},
+			})
+		}
+	}
+	return parts
+}
+
+func clamp(v, limit float32) float32 {
+	return min(max(v, 0), max(limit, 0))
+}
+
+// shrink scales a and b down so together they fit into size.
+func shrink(a, b, size float32) (float32, float32) {
+	if a+b > size {
+		return a * size / (a + b), b * size / (a + b)
+	}
+	return a, b
+}
@@ -0,0 +1,75 @@
+// Package transform helps renderers draw render commands with a [clay.Matrix2D], by drawing them untransformed into an
+// offscreen image first, which is then drawn onto the screen with the transform.
+package transform
+
+import (
+	"image"
+	"math"
+
+	"github.com/TotallyGamerJet/clay"
+	"github.com/TotallyGamerJet/clay/renderers/internal/vectorpath"
+)
+
+// Transformed reports whether the render command needs to be drawn with its transform. Clipping and layers are handled as if they
+// had none, and custom commands are left to the application.
+func Transformed(cmd *clay.RenderCommand) bool {
+	switch cmd.CommandType {
+	case clay.RENDER_COMMAND_TYPE_RECTANGLE, clay.RENDER_COMMAND_TYPE_BORDER, clay.RENDER_COMMAND_TYPE_TEXT,
+		clay.RENDER_COMMAND_TYPE_IMAGE, clay.RENDER_COMMAND_TYPE_SHADOW, clay.RENDER_COMMAND_TYPE_PATH:
+		return !cmd.Transform.IsIdentity()
+	}
+	return false
+}
+
+// Extents returns the area the render command draws into before it's transformed, which is larger than its bounding box for outer shadows
+// and paths reaching outside of it.
+func Extents(cmd *clay.RenderCommand) clay.BoundingBox {
+	box := cmd.BoundingBox
+	if shadow := &cmd.RenderData.Shadow; cmd.CommandType == clay.RENDER_COMMAND_TYPE_SHADOW && !shadow.Inset {
+		extent := max(shadow.BlurRadius+shadow.Spread, 0)
+		minX, minY := min(box.X, box.X+shadow.Offset.X-extent), min(box.Y, box.Y+shadow.Offset.Y-extent)
+		maxX := max(box.X+box.Width, box.X+box.Width+shadow.Offset.X+extent)
+		maxY := max(box.Y+box.Height, box.Y+box.Height+shadow.Offset.Y+extent)
+		box = clay.BoundingBox{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+	}
+	if cmd.CommandType == clay.RENDER_COMMAND_TYPE_PATH {
+		path := vectorpath.Bounds(append(vectorpath.Fill(box, &cmd.RenderData.Path), vectorpath.Stroke(box, &cmd.RenderData.Path)...))
+		if path.Width > 0 && path.Height > 0 {
+			minX, minY := min(box.X, path.X), min(box.Y, path.Y)
+			maxX, maxY := max(box.X+box.Width, path.X+path.Width), max(box.Y+box.Height, path.Y+path.Height)
+			box = clay.BoundingBox{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+		}
+	}
+	return box
+}
+
+// Region returns the pixels of extents that end up inside clip once they're transformed by m, with a pixel of margin for filtering.
+// It's empty if none of them do.
+func Region(m clay.Matrix2D, extents clay.BoundingBox, clip image.Rectangle) image.Rectangle {
+	inverse, ok := m.Invert()
+	if !ok {
+		return image.Rectangle{}
+	}
+	visible := inverse.Bounds(clay.BoundingBox{
+		X: float32(clip.Min.X), Y: float32(clip.Min.Y), Width: float32(clip.Dx()), Height: float32(clip.Dy()),
+	})
+	return pixels(extents).Inset(-1).Intersect(pixels(visible).Inset(-1))
+}
+
+// Points returns the corners of the rectangle r once transformed by m, clockwise from the top left.
+func Points(m clay.Matrix2D, r image.Rectangle) [4]clay.Vector2 {
+	return [4]clay.Vector2{
+		m.Apply(clay.Vector2{X: float32(r.Min.X), Y: float32(r.Min.Y)}),
+		m.Apply(clay.Vector2{X: float32(r.Max.X), Y: float32(r.Min.Y)}),
+		m.Apply(clay.Vector2{X: float32(r.Max.X), Y: float32(r.Max.Y)}),
+		m.Apply(clay.Vector2{X: float32(r.Min.X), Y: float32(r.Max.Y)}),
+	}
+}
+
+// pixels returns the smallest rectangle of whole pixels that contains box.
+func pixels(box clay.BoundingBox) image.Rectangle {
+	return image.Rect(
+		int(math.Floor(float64(box.X))), int(math.Floor(float64(box.Y))),
+		int(math.Ceil(float64(box.X+box.Width))), int(math.Ceil(float64(box.Y+box.Height))),
+	)
+}
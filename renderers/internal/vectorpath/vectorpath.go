@@ -0,0 +1,335 @@
+// Package vectorpath splits the paths of PATH render commands into triangles, so every renderer fills and strokes them
+// identically.
+package vectorpath
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/TotallyGamerJet/clay"
+	"github.com/TotallyGamerJet/clay/renderers/internal/geometry"
+)
+
+// The number of straight segments a full circle is drawn with. Arcs and round joins use their share of it.
+const circleSegments = 64
+
+// Curves are split into straight segments about this many pixels long, but no more than maxCurveSegments of them.
+const (
+	curveSegmentLength = 4
+	maxCurveSegments   = 64
+)
+
+// Miters more than this many times as long as the stroke is wide are beveled instead, like SVG's default stroke-miterlimit.
+const miterLimit = 4
+
+// polyline is a subpath made of straight segments.
+type polyline struct {
+	points []geometry.Point
+	closed bool
+}
+
+// Triangles returns a list of triangles, three vertices each, filling and then stroking the path of config, which is
+// relative to box.
+func Triangles(box clay.BoundingBox, config *clay.PathRenderData) []geometry.Vertex {
+	var vertices []geometry.Vertex
+	if config.FillColor.A > 0 {
+		vertices = append(vertices, colored(Fill(box, config), config.FillColor)...)
+	}
+	if config.StrokeColor.A > 0 {
+		vertices = append(vertices, colored(Stroke(box, config), config.StrokeColor)...)
+	}
+	return vertices
+}
+
+// Fill returns a list of triangles, three points each, covering the inside of the path of config using the non zero
+// winding rule. The triangles don't overlap.
+func Fill(box clay.BoundingBox, config *clay.PathRenderData) []geometry.Point {
+	var polygons [][]geometry.Point
+	for _, line := range flatten(box, config) {
+		if len(line.points) >= 3 {
+			polygons = append(polygons, line.points)
+		}
+	}
+	return tessellate(polygons)
+}
+
+// Stroke returns a list of triangles, three points each, covering the outline of the path of config. The triangles don't
+// overlap, so translucent strokes are drawn evenly where segments and joins meet.
+func Stroke(box clay.BoundingBox, config *clay.PathRenderData) []geometry.Point {
+	halfWidth := config.StrokeWidth / 2
+	if halfWidth <= 0 {
+		return nil
+	}
+	var polygons [][]geometry.Point
+	for _, line := range flatten(box, config) {
+		points := line.points
+		if len(points) < 2 {
+			continue
+		}
+		segments := len(points) - 1
+		if line.closed {
+			segments++
+		}
+		normals := make([]geometry.Point, segments)
+		for i := range segments {
+			a, b := points[i], points[(i+1)%len(points)]
+			normals[i] = normal(a, b)
+			n := scale(normals[i], halfWidth)
+			polygons = append(polygons, []geometry.Point{add(a, n), add(b, n), sub(b, n), sub(a, n)})
+		}
+		for i := range segments {
+			// The join at the end of segment i, with the segment after it
+			if i+1 == segments && !line.closed {
+				break
+			}
+			p := points[(i+1)%len(points)]
+			polygons = append(polygons, join(p, normals[i], normals[(i+1)%segments], halfWidth, config.Join)...)
+		}
+	}
+	for _, polygon := range polygons {
+		// The polygons all wind the same way, so they add up rather than cancel out where they overlap
+		if geometry.SignedArea(polygon) < 0 {
+			slices.Reverse(polygon)
+		}
+	}
+	return tessellate(polygons)
+}
+
+// Bounds returns the smallest box containing all the points.
+func Bounds(points []geometry.Point) clay.BoundingBox {
+	if len(points) == 0 {
+		return clay.BoundingBox{}
+	}
+	minX, minY, maxX, maxY := points[0].X, points[0].Y, points[0].X, points[0].Y
+	for _, p := range points[1:] {
+		minX, minY, maxX, maxY = min(minX, p.X), min(minY, p.Y), max(maxX, p.X), max(maxY, p.Y)
+	}
+	return clay.BoundingBox{X: minX, Y: minY, Width: maxX - minX, Height: maxY - minY}
+}
+
+// flatten returns the subpaths of the path of config on screen, with curves and arcs split into straight segments.
+func flatten(box clay.BoundingBox, config *clay.PathRenderData) []polyline {
+	scaleFactor := config.Scale
+	if scaleFactor <= 0 {
+		scaleFactor = 1
+	}
+	at := func(v clay.Vector2) geometry.Point {
+		return geometry.Point{X: box.X + v.X*scaleFactor, Y: box.Y + v.Y*scaleFactor}
+	}
+	var lines []polyline
+	var current polyline
+	finish := func(closed bool) {
+		current.closed = closed
+		if len(current.points) >= 2 {
+			lines = append(lines, current)
+		}
+		// Commands after a close continue from the start of the closed subpath
+		var start []geometry.Point
+		if closed && len(current.points) > 0 {
+			start = []geometry.Point{current.points[0]}
+		}
+		current = polyline{points: start}
+	}
+	lineTo := func(p geometry.Point) {
+		if n := len(current.points); n > 0 && current.points[n-1] == p {
+			return
+		}
+		current.points = append(current.points, p)
+	}
+	for command := range config.Commands.Iter() {
+		switch command.Type {
+		case clay.PATH_COMMAND_MOVE_TO:
+			finish(false)
+			lineTo(at(command.Points[0]))
+		case clay.PATH_COMMAND_LINE_TO:
+			lineTo(at(command.Points[0]))
+		case clay.PATH_COMMAND_QUAD_TO:
+			if len(current.points) == 0 {
+				lineTo(at(command.Points[0]))
+			}
+			p0, p1, p2 := current.points[len(current.points)-1], at(command.Points[0]), at(command.Points[1])
+			n := curveSegments(geometry.SegmentLength(p0, p1) + geometry.SegmentLength(p1, p2))
+			for i := 1; i <= n; i++ {
+				t := float32(i) / float32(n)
+				lineTo(geometry.Lerp(geometry.Lerp(p0, p1, t), geometry.Lerp(p1, p2, t), t))
+			}
+		case clay.PATH_COMMAND_CUBIC_TO:
+			if len(current.points) == 0 {
+				lineTo(at(command.Points[0]))
+			}
+			p0, p1, p2, p3 := current.points[len(current.points)-1], at(command.Points[0]), at(command.Points[1]), at(command.Points[2])
+			n := curveSegments(geometry.SegmentLength(p0, p1) + geometry.SegmentLength(p1, p2) + geometry.SegmentLength(p2, p3))
+			for i := 1; i <= n; i++ {
+				t := float32(i) / float32(n)
+				a, b, c := geometry.Lerp(p0, p1, t), geometry.Lerp(p1, p2, t), geometry.Lerp(p2, p3, t)
+				lineTo(geometry.Lerp(geometry.Lerp(a, b, t), geometry.Lerp(b, c, t), t))
+			}
+		case clay.PATH_COMMAND_ARC:
+			center, radius := at(command.Points[0]), command.Radius*scaleFactor
+			start := float64(command.StartAngle) * math.Pi / 180
+			// Sweeping further than a full circle would only draw over it again
+			sweep := min(max(float64(command.EndAngle-command.StartAngle)*math.Pi/180, -2*math.Pi), 2*math.Pi)
+			n := min(max(int(math.Ceil(math.Abs(sweep)/(2*math.Pi)*circleSegments)), 1), circleSegments)
+			for i := 0; i <= n; i++ {
+				angle := start + sweep*float64(i)/float64(n)
+				lineTo(geometry.Point{X: center.X + radius*float32(math.Cos(angle)), Y: center.Y + radius*float32(math.Sin(angle))})
+			}
+		case clay.PATH_COMMAND_CLOSE:
+			// The closing segment is implied, so it isn't doubled up when the subpath already ends at its start
+			if n := len(current.points); n > 1 && current.points[n-1] == current.points[0] {
+				current.points = current.points[:n-1]
+			}
+			finish(true)
+		}
+	}
+	finish(false)
+	return lines
+}
+
+func curveSegments(length float32) int {
+	return min(max(int(math.Ceil(float64(length/curveSegmentLength))), 1), maxCurveSegments)
+}
+
+// join returns the polygons filling the gap between two segments of a stroke meeting at p, with the given normals.
+func join(p, n1, n2 geometry.Point, halfWidth float32, style clay.LineJoin) [][]geometry.Point {
+	if style == clay.LINE_JOIN_ROUND {
+		circle := make([]geometry.Point, circleSegments)
+		for i := range circle {
+			angle := 2 * math.Pi * float64(i) / circleSegments
+			circle[i] = geometry.Point{X: p.X + halfWidth*float32(math.Cos(angle)), Y: p.Y + halfWidth*float32(math.Sin(angle))}
+		}
+		return [][]geometry.Point{circle}
+	}
+	// Only the outside of the turn has a gap, the inside is covered by the segments overlapping
+	cross := n1.X*n2.Y - n1.Y*n2.X
+	if cross == 0 {
+		return nil
+	}
+	side := float32(1)
+	if cross > 0 {
+		side = -1
+	}
+	a, b := add(p, scale(n1, side*halfWidth)), add(p, scale(n2, side*halfWidth))
+	if style == clay.LINE_JOIN_MITER {
+		// The tip is where the outer edges of both segments meet
+		if d := 1 + n1.X*n2.X + n1.Y*n2.Y; d > 1e-6 {
+			miter := scale(add(n1, n2), side*halfWidth/d)
+			if geometry.SegmentLength(geometry.Point{}, miter) <= miterLimit*halfWidth {
+				return [][]geometry.Point{{p, a, add(p, miter), b}}
+			}
+		}
+	}
+	return [][]geometry.Point{{p, a, b}}
+}
+
+type edge struct {
+	x0, y0, x1, y1 float32
+	// +1 for edges going down the screen, -1 for edges going up
+	winding int
+}
+
+func (e edge) xAt(y float32) float32 {
+	return e.x0 + (e.x1-e.x0)*(y-e.y0)/(e.y1-e.y0)
+}
+
+// tessellate returns a list of non overlapping triangles, three points each, covering where the polygons wind around a
+// point a non zero number of times. The polygons are cut into horizontal slabs at every vertex and every crossing of their
+// edges, so the edges inside each slab can be paired up into trapezoids from left to right.
+func tessellate(polygons [][]geometry.Point) []geometry.Point {
+	var edges []edge
+	var ys []float32
+	for _, polygon := range polygons {
+		for i, p := range polygon {
+			q := polygon[(i+1)%len(polygon)]
+			ys = append(ys, p.Y)
+			switch {
+			case p.Y < q.Y:
+				edges = append(edges, edge{p.X, p.Y, q.X, q.Y, 1})
+			case p.Y > q.Y:
+				edges = append(edges, edge{q.X, q.Y, p.X, p.Y, -1})
+			}
+		}
+	}
+	slices.SortFunc(edges, func(a, b edge) int { return cmp.Compare(a.y0, b.y0) })
+	for i, a := range edges {
+		for _, b := range edges[i+1:] {
+			if b.y0 >= a.y1 {
+				break
+			}
+			top, bottom := b.y0, min(a.y1, b.y1)
+			d0, d1 := a.xAt(top)-b.xAt(top), a.xAt(bottom)-b.xAt(bottom)
+			if (d0 < 0 && d1 > 0) || (d0 > 0 && d1 < 0) {
+				ys = append(ys, top+(bottom-top)*d0/(d0-d1))
+			}
+		}
+	}
+	slices.Sort(ys)
+	ys = slices.Compact(ys)
+
+	type crossing struct {
+		top, bottom float32
+		winding     int
+	}
+	var triangles []geometry.Point
+	var active []crossing
+	for k := 0; k+1 < len(ys); k++ {
+		top, bottom := ys[k], ys[k+1]
+		active = active[:0]
+		for _, e := range edges {
+			if e.y0 > top {
+				break
+			}
+			if e.y1 >= bottom {
+				active = append(active, crossing{e.xAt(top), e.xAt(bottom), e.winding})
+			}
+		}
+		slices.SortFunc(active, func(a, b crossing) int { return cmp.Compare(a.top+a.bottom, b.top+b.bottom) })
+		winding := 0
+		var left crossing
+		for _, c := range active {
+			if winding == 0 {
+				left = c
+			}
+			winding += c.winding
+			if winding != 0 {
+				continue
+			}
+			tl, tr := geometry.Point{X: left.top, Y: top}, geometry.Point{X: c.top, Y: top}
+			br, bl := geometry.Point{X: c.bottom, Y: bottom}, geometry.Point{X: left.bottom, Y: bottom}
+			if tr.X > tl.X {
+				triangles = append(triangles, tl, tr, br)
+			}
+			if br.X > bl.X {
+				triangles = append(triangles, tl, br, bl)
+			}
+		}
+	}
+	return triangles
+}
+
+func colored(triangles []geometry.Point, c clay.Color) []geometry.Vertex {
+	vertices := make([]geometry.Vertex, len(triangles))
+	for i, p := range triangles {
+		vertices[i] = geometry.Vertex{X: p.X, Y: p.Y, Color: c}
+	}
+	return vertices
+}
+
+// normal returns the unit vector perpendicular to the segment from a to b.
+func normal(a, b geometry.Point) geometry.Point {
+	length := geometry.SegmentLength(a, b)
+	return geometry.Point{X: -(b.Y - a.Y) / length, Y: (b.X - a.X) / length}
+}
+
+func add(a, b geometry.Point) geometry.Point {
+	return geometry.Point{X: a.X + b.X, Y: a.Y + b.Y}
+}
+
+func sub(a, b geometry.Point) geometry.Point {
+	return geometry.Point{X: a.X - b.X, Y: a.Y - b.Y}
+}
+
+func scale(p geometry.Point, s float32) geometry.Point {
+	return geometry.Point{X: p.X * s, Y: p.Y * s}
+}
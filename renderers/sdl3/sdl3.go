@@ -2,12 +2,18 @@ package sdl3
 
 import (
 	"fmt"
+	"image"
 	"log/slog"
-	"math"
 	"strings"
 	"unsafe"
 
 	"github.com/TotallyGamerJet/clay"
+	"github.com/TotallyGamerJet/clay/renderers/internal/border"
+	"github.com/TotallyGamerJet/clay/renderers/internal/geometry"
+	"github.com/TotallyGamerJet/clay/renderers/internal/gradient"
+	"github.com/TotallyGamerJet/clay/renderers/internal/imagefit"
+	"github.com/TotallyGamerJet/clay/renderers/internal/transform"
+	"github.com/TotallyGamerJet/clay/renderers/internal/vectorpath"
 	"github.com/Zyko0/go-sdl3/sdl"
 	"github.com/Zyko0/go-sdl3/ttf"
 )
@@ -16,6 +22,31 @@ type RendererData struct {
 	Renderer   *sdl.Renderer
 	TextEngine *ttf.TextEngine
 	Fonts      []*ttf.Font
+
+	// The render targets layers are drawn into, one for each level of nesting, reused across frames
+	layerTextures []*sdl.Texture
+	// The render targets rounded clipping is drawn into, one for each level of nesting, reused across frames
+	clipTextures []*sdl.Texture
+	// The render target transformed render commands are drawn into, reused across commands
+	transformTexture *sdl.Texture
+}
+
+// layer is a render target that render commands are drawn into between LAYER_START and LAYER_END,
+// or that a single transformed render command is drawn into.
+type layer struct {
+	texture *sdl.Texture
+	// The render target from before the layer started
+	target *sdl.Texture
+}
+
+// clip is the clipping started by a SCISSOR_START. Rounded clipping draws into a texture until the SCISSOR_END,
+// which has its corners erased before it is drawn onto the render target.
+type clip struct {
+	rect sdl.Rect
+	// The clipping rectangle from before the clipping started
+	clipRect  *sdl.Rect
+	offscreen layer
+	corners   [][]geometry.Point
 }
 
 func MeasureText(text clay.StringSlice, config *clay.TextElementConfig, userData unsafe.Pointer) clay.Dimensions {
@@ -37,8 +68,28 @@ func ClayRender(rendererData *RendererData, renderCommands clay.RenderCommandArr
 	renderer := rendererData.Renderer
 	fonts := rendererData.Fonts
 	textEngine := rendererData.TextEngine
+	var layers []layer
+	var clips []clip
 	for renderCommand := range renderCommands.Iter() {
 		boundingBox := renderCommand.BoundingBox
+		// Transformed commands are drawn onto a transparent texture, which is then drawn onto the screen with the transform
+		var region image.Rectangle
+		var offscreen layer
+		if transform.Transformed(&renderCommand) {
+			visible, err := visibleBounds(renderer)
+			if err != nil {
+				return err
+			}
+			region = transform.Region(renderCommand.Transform, transform.Extents(&renderCommand), visible)
+			if region.Empty() {
+				continue
+			}
+			if offscreen, err = startTransform(rendererData, region.Size()); err != nil {
+				return err
+			}
+			boundingBox.X -= float32(region.Min.X)
+			boundingBox.Y -= float32(region.Min.Y)
+		}
 		rect := sdl.FRect{
 			X: boundingBox.X,
 			Y: boundingBox.Y,
@@ -55,9 +106,12 @@ func ClayRender(rendererData *RendererData, renderCommands clay.RenderCommandArr
 				uint8(config.BackgroundColor.B),
 				uint8(config.BackgroundColor.A),
 			)
-			if config.CornerRadius.TopLeft > 0 {
-				err := renderFillRoundedRect(renderer, rect, config.CornerRadius.TopLeft, config.BackgroundColor)
-				if err != nil {
+			if config.Gradient.Type != clay.GRADIENT_TYPE_NONE {
+				if err := renderGradient(renderer, &config.Gradient, boundingBox, [][]geometry.Point{geometry.Outline(boundingBox, config.CornerRadius)}); err != nil {
+					return err
+				}
+			} else if config.CornerRadius != (clay.CornerRadius{}) {
+				if err := renderTriangles(renderer, geometry.RoundedRect(boundingBox, config.CornerRadius, config.BackgroundColor)); err != nil {
 					return err
 				}
 			} else {
@@ -80,17 +134,64 @@ func ClayRender(rendererData *RendererData, renderCommands clay.RenderCommandArr
 			text.DrawRenderer(rect.X, rect.Y)
 			text.Destroy()
 		case clay.RENDER_COMMAND_TYPE_SCISSOR_START:
-			currentClippingRectangle := sdl.Rect{
-				X: int32(boundingBox.X),
-				Y: int32(boundingBox.Y),
-				W: int32(boundingBox.Width),
-				H: int32(boundingBox.Height),
+			// Transformed clipping rectangles are clipped to by their bounds
+			bounds := renderCommand.Transform.Bounds(boundingBox)
+			c := clip{rect: sdl.Rect{
+				X: int32(bounds.X),
+				Y: int32(bounds.Y),
+				W: int32(bounds.Width),
+				H: int32(bounds.Height),
+			}}
+			// Nested clipping only draws inside of the clipping around it
+			if renderer.ClipEnabled() == nil {
+				clipRect, err := renderer.ClipRect()
+				if err != nil {
+					return err
+				}
+				c.clipRect = &clipRect
+				if intersection := c.rect.Intersection(&clipRect); intersection != nil {
+					c.rect = *intersection
+				} else {
+					c.rect.W, c.rect.H = 0, 0
+				}
 			}
-			if err := renderer.SetClipRect(&currentClippingRectangle); err != nil {
+			if radius := renderCommand.RenderData.Clip.CornerRadius; radius != (clay.CornerRadius{}) {
+				texture, err := pooledTexture(renderer, &rendererData.clipTextures, len(clips))
+				if err != nil {
+					return err
+				}
+				if c.offscreen, err = startOffscreen(renderer, texture); err != nil {
+					return err
+				}
+				c.corners = geometry.Corners(boundingBox, radius)
+				for _, corner := range c.corners {
+					for i, p := range corner {
+						v := renderCommand.Transform.Apply(clay.Vector2{X: p.X, Y: p.Y})
+						corner[i] = geometry.Point{X: v.X, Y: v.Y}
+					}
+				}
+			}
+			clips = append(clips, c)
+			if err := renderer.SetClipRect(&c.rect); err != nil {
 				return err
 			}
 		case clay.RENDER_COMMAND_TYPE_SCISSOR_END:
-			if err := renderer.SetClipRect(nil); err != nil {
+			if len(clips) == 0 {
+				if err := renderer.SetClipRect(nil); err != nil {
+					return err
+				}
+				break
+			}
+			c := clips[len(clips)-1]
+			clips = clips[:len(clips)-1]
+			if c.offscreen.texture != nil {
+				// The render target from before the clipping started keeps its own clipping rectangle
+				if err := endClip(renderer, c); err != nil {
+					return err
+				}
+				break
+			}
+			if err := renderer.SetClipRect(c.clipRect); err != nil {
 				return err
 			}
 		case clay.RENDER_COMMAND_TYPE_IMAGE:
@@ -99,406 +200,303 @@ func ClayRender(rendererData *RendererData, renderCommands clay.RenderCommandArr
 			if err != nil {
 				return err
 			}
-			destination := sdl.FRect{
-				X: rect.X,
-				Y: rect.Y,
-				W: rect.W,
-				H: rect.H,
-			}
-			if err := renderer.RenderTexture(texture, nil, &destination); err != nil {
-				return err
+			for _, part := range imagefit.Parts(boundingBox, config, float32(texture.W), float32(texture.H)) {
+				source := sdl.FRect{
+					X: part.Src.X,
+					Y: part.Src.Y,
+					W: part.Src.Width,
+					H: part.Src.Height,
+				}
+				destination := sdl.FRect{
+					X: part.Dst.X,
+					Y: part.Dst.Y,
+					W: part.Dst.Width,
+					H: part.Dst.Height,
+				}
+				if err := renderer.RenderTexture(texture, &source, &destination); err != nil {
+					return err
+				}
 			}
 			texture.Destroy()
 		case clay.RENDER_COMMAND_TYPE_BORDER:
 			config := &renderCommand.RenderData.Border
-			if err := renderer.SetDrawColor(uint8(config.Color.R), uint8(config.Color.G), uint8(config.Color.B), uint8(config.Color.A)); err != nil {
+			if config.Gradient.Type != clay.GRADIENT_TYPE_NONE || config.Style != clay.BORDER_STYLE_SOLID || !border.Uniform(config.Colors) {
+				// Gradients, sides of different colors, dashes and dots are drawn from the outline of every part of the borders
+				if err := renderTriangles(renderer, border.Triangles(boundingBox, config)); err != nil {
+					return err
+				}
+				break
+			}
+			// All sides share a color
+			renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+			if err := renderTriangles(renderer, geometry.Border(boundingBox, config.CornerRadius, config.Width, config.Colors.Top)); err != nil {
 				return err
 			}
-
-			if boundingBox.Width > 0 && boundingBox.Height > 0 {
-				maxRadius := min(boundingBox.Width, boundingBox.Height) / 2.0
-
-				if config.Width.Left > 0 {
-					clampedRadiusTop := min(config.CornerRadius.TopLeft, maxRadius)
-					clampedRadiusBottom := min(config.CornerRadius.BottomLeft, maxRadius)
-					rect := sdl.FRect{
-						X: boundingBox.X,
-						Y: boundingBox.Y + clampedRadiusTop,
-						W: float32(config.Width.Left),
-						H: boundingBox.Height - clampedRadiusTop - clampedRadiusBottom,
-					}
-					if err := renderer.RenderFillRect(&rect); err != nil {
-						return err
-					}
-				}
-
-				if config.Width.Right > 0 {
-					clampedRadiusTop := min(config.CornerRadius.TopRight, maxRadius)
-					clampedRadiusBottom := min(config.CornerRadius.BottomRight, maxRadius)
-					rect := sdl.FRect{
-						X: boundingBox.X + boundingBox.Width - float32(config.Width.Right),
-						Y: boundingBox.Y + clampedRadiusTop,
-						W: float32(config.Width.Right),
-						H: boundingBox.Height - clampedRadiusTop - clampedRadiusBottom,
-					}
-					if err := renderer.RenderFillRect(&rect); err != nil {
-						return err
-					}
-				}
-
-				if config.Width.Top > 0 {
-					clampedRadiusLeft := min(config.CornerRadius.TopLeft, maxRadius)
-					clampedRadiusRight := min(config.CornerRadius.TopRight, maxRadius)
-					rect := sdl.FRect{
-						X: boundingBox.X + clampedRadiusLeft,
-						Y: boundingBox.Y,
-						W: boundingBox.Width - clampedRadiusLeft - clampedRadiusRight,
-						H: float32(config.Width.Top),
-					}
-					if err := renderer.RenderFillRect(&rect); err != nil {
-						return err
-					}
-				}
-
-				if config.Width.Bottom > 0 {
-					clampedRadiusLeft := min(config.CornerRadius.BottomLeft, maxRadius)
-					clampedRadiusRight := min(config.CornerRadius.BottomRight, maxRadius)
-					rect := sdl.FRect{
-						X: boundingBox.X + clampedRadiusLeft,
-						Y: boundingBox.Y + boundingBox.Height - float32(config.Width.Bottom),
-						W: boundingBox.Width - clampedRadiusLeft - clampedRadiusRight,
-						H: float32(config.Width.Bottom),
-					}
-					if err := renderer.RenderFillRect(&rect); err != nil {
-						return err
-					}
-				}
-
-				// corner index: 0->3 topLeft -> CW -> bottonLeft
-				if config.Width.Top > 0 && config.CornerRadius.TopLeft > 0 {
-					renderCornerBorder(renderer, &boundingBox, config, 0, config.Color)
-				}
-				if config.Width.Top > 0 && config.CornerRadius.TopRight > 0 {
-					renderCornerBorder(renderer, &boundingBox, config, 1, config.Color)
-				}
-				if config.Width.Bottom > 0 && config.CornerRadius.BottomLeft > 0 {
-					renderCornerBorder(renderer, &boundingBox, config, 2, config.Color)
-				}
-				if config.Width.Bottom > 0 && config.CornerRadius.BottomLeft > 0 {
-					renderCornerBorder(renderer, &boundingBox, config, 3, config.Color)
-				}
+		case clay.RENDER_COMMAND_TYPE_SHADOW:
+			renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+			if err := renderShadow(renderer, boundingBox, &renderCommand.RenderData.Shadow); err != nil {
+				return err
+			}
+		case clay.RENDER_COMMAND_TYPE_PATH:
+			renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+			if err := renderTriangles(renderer, vectorpath.Triangles(boundingBox, &renderCommand.RenderData.Path)); err != nil {
+				return err
+			}
+		case clay.RENDER_COMMAND_TYPE_LAYER_START:
+			l, err := startLayer(rendererData, len(layers))
+			if err != nil {
+				return err
+			}
+			layers = append(layers, l)
+		case clay.RENDER_COMMAND_TYPE_LAYER_END:
+			if len(layers) == 0 {
+				break
+			}
+			l := layers[len(layers)-1]
+			layers = layers[:len(layers)-1]
+			if err := endLayer(renderer, l, renderCommand.RenderData.Layer.Opacity); err != nil {
+				return err
 			}
 		case clay.RENDER_COMMAND_TYPE_NONE:
 		case clay.RENDER_COMMAND_TYPE_CUSTOM:
 		default:
 			slog.Warn("Unknown command type", "type", renderCommand.CommandType)
 		}
+		if !region.Empty() {
+			if err := endTransform(renderer, offscreen, renderCommand.Transform, region); err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
 
-const numCircleSegments = 16
-
-func renderFillRoundedRect(renderer *sdl.Renderer, rect sdl.FRect, cornerRadius float32, _color clay.Color) error {
-	color := sdl.FColor{
-		R: _color.R / 255,
-		G: _color.G / 255,
-		B: _color.B / 255,
-		A: _color.A / 255,
+func renderShadow(renderer *sdl.Renderer, boundingBox clay.BoundingBox, config *clay.ShadowRenderData) error {
+	mesh, meshIndices := geometry.Shadow(boundingBox, config)
+	vertices := make([]sdl.Vertex, len(mesh))
+	for i, v := range mesh {
+		color := sdl.FColor{R: config.Color.R / 255, G: config.Color.G / 255, B: config.Color.B / 255, A: v.Alpha}
+		vertices[i] = sdl.Vertex{Position: sdl.FPoint{X: v.X, Y: v.Y}, Color: color}
 	}
+	indices := make([]int32, len(meshIndices))
+	for i, index := range meshIndices {
+		indices[i] = int32(index)
+	}
+	return renderer.RenderGeometry(nil, vertices, indices)
+}
 
-	indexCount, vertexCount := 0, int32(0)
-
-	minRadius := min(rect.W, rect.H) / 2.0
-	clampedRadius := min(cornerRadius, minRadius)
-
-	numCircleSegments := max(numCircleSegments, int(clampedRadius*0.5)) // check if needs to be clamped
-
-	var vertices [512]sdl.Vertex
-	var indices [512]int32
-
-	// define center rectangle
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + clampedRadius, Y: rect.Y + clampedRadius}, Color: color, TexCoord: sdl.FPoint{}} // 0 center TL
-	vertexCount++
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + rect.W - clampedRadius, Y: rect.Y + clampedRadius}, Color: color, TexCoord: sdl.FPoint{X: 1}} // 1 center TR
-	vertexCount++
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + rect.W - clampedRadius, Y: rect.Y + rect.H - clampedRadius}, Color: color, TexCoord: sdl.FPoint{X: 1, Y: 1}} // 2 center BR
-	vertexCount++
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + clampedRadius, Y: rect.Y + rect.H - clampedRadius}, Color: color, TexCoord: sdl.FPoint{Y: 1}} // 3 center BL
-	vertexCount++
-
-	indices[indexCount] = 0
-	indexCount++
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = 3
-	indexCount++
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = 2
-	indexCount++
-	indices[indexCount] = 3
-	indexCount++
-
-	// define rounded corners as triangle fans
-	step := (math.Pi / 2) / float32(numCircleSegments)
-	for i := 0; i < numCircleSegments; i++ {
-		angle1 := float32(i) * step
-		angle2 := (float32(i) + 1) * step
+// renderGradient fills the convex polygons with the gradient filling boundingBox.
+func renderGradient(renderer *sdl.Renderer, g *clay.Gradient, boundingBox clay.BoundingBox, polygons [][]geometry.Point) error {
+	var vertices []geometry.Vertex
+	for _, polygon := range polygons {
+		vertices = append(vertices, gradient.Triangles(g, boundingBox, polygon)...)
+	}
+	return renderTriangles(renderer, vertices)
+}
 
-		for j := int32(0); j < 4; j++ {
-			var cx, cy, signX, signY float32
+// renderTriangles draws the list of triangles, three vertices each, blending their vertex colors.
+func renderTriangles(renderer *sdl.Renderer, triangles []geometry.Vertex) error {
+	if len(triangles) == 0 {
+		return nil
+	}
+	vertices := make([]sdl.Vertex, len(triangles))
+	for i, v := range triangles {
+		vertices[i] = sdl.Vertex{Position: sdl.FPoint{X: v.X, Y: v.Y}, Color: sdl.FColor{R: v.Color.R / 255, G: v.Color.G / 255, B: v.Color.B / 255, A: v.Color.A / 255}}
+	}
+	return renderer.RenderGeometry(nil, vertices, nil)
+}
 
-			switch j {
-			case 0:
-				cx = rect.X + clampedRadius
-				cy = rect.Y + clampedRadius
-				signX = -1
-				signY = -1
-			case 1:
-				cx = rect.X + rect.W - clampedRadius
-				cy = rect.Y + clampedRadius
-				signX = 1
-				signY = -1
-			case 2:
-				cx = rect.X + rect.W - clampedRadius
-				cy = rect.Y + rect.H - clampedRadius
-				signX = 1
-				signY = 1
-			case 3:
-				cx = rect.X + clampedRadius
-				cy = rect.Y + rect.H - clampedRadius
-				signX = -1
-				signY = 1
-			default:
-				return fmt.Errorf("out of bounds index: %d", j)
-			}
+// startLayer clears the layer texture for the given level of nesting and makes it the render target.
+func startLayer(rendererData *RendererData, depth int) (layer, error) {
+	renderer := rendererData.Renderer
+	texture, err := pooledTexture(renderer, &rendererData.layerTextures, depth)
+	if err != nil {
+		return layer{}, err
+	}
+	return startOffscreen(renderer, texture)
+}
 
-			vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: cx + float32(math.Cos(float64(angle1)))*clampedRadius*signX, Y: cy + float32(math.Sin(float64(angle1)))*clampedRadius*signY}, Color: color, TexCoord: sdl.FPoint{}}
-			vertexCount++
-			vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: cx + float32(math.Cos(float64(angle2)))*clampedRadius*signX, Y: cy + float32(math.Sin(float64(angle2)))*clampedRadius*signY}, Color: color, TexCoord: sdl.FPoint{}}
-			vertexCount++
+// endLayer restores the render target from before the layer started and draws the layer onto it with the given opacity.
+func endLayer(renderer *sdl.Renderer, l layer, opacity float32) error {
+	if err := renderer.SetRenderTarget(l.target); err != nil {
+		return err
+	}
+	// The texture is premultiplied, so its colors fade along with its alpha
+	alpha := uint8(min(max(opacity, 0), 1)*255 + 0.5)
+	if err := l.texture.SetColorMod(alpha, alpha, alpha); err != nil {
+		return err
+	}
+	if err := l.texture.SetAlphaMod(alpha); err != nil {
+		return err
+	}
+	return renderer.RenderTexture(l.texture, nil, nil)
+}
 
-			indices[indexCount] = j // Connect to corresponding central rectangle vertex
-			indexCount++
-			indices[indexCount] = vertexCount - 2
-			indexCount++
-			indices[indexCount] = vertexCount - 1
-			indexCount++
+// endClip erases the corners of rounded clipping, then restores the render target from before the clipping started and draws
+// the clipped area onto it.
+func endClip(renderer *sdl.Renderer, c clip) error {
+	// Clears whatever is drawn over by the alpha of what is drawn
+	erase := sdl.ComposeCustomBlendMode(
+		sdl.BLENDFACTOR_ZERO, sdl.BLENDFACTOR_ONE_MINUS_SRC_ALPHA, sdl.BLENDOPERATION_ADD,
+		sdl.BLENDFACTOR_ZERO, sdl.BLENDFACTOR_ONE_MINUS_SRC_ALPHA, sdl.BLENDOPERATION_ADD,
+	)
+	if err := renderer.SetDrawBlendMode(erase); err != nil {
+		return err
+	}
+	for _, corner := range c.corners {
+		vertices := make([]sdl.Vertex, len(corner))
+		for i, p := range corner {
+			vertices[i] = sdl.Vertex{Position: sdl.FPoint{X: p.X, Y: p.Y}, Color: sdl.FColor{R: 1, G: 1, B: 1, A: 1}}
+		}
+		indices := make([]int32, 0, (len(corner)-2)*3)
+		for i := 1; i+1 < len(corner); i++ {
+			indices = append(indices, 0, int32(i), int32(i+1))
+		}
+		if err := renderer.RenderGeometry(nil, vertices, indices); err != nil {
+			return err
 		}
 	}
-	// Define edge rectangles
-	// Top edge
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + clampedRadius, Y: rect.Y}, Color: color, TexCoord: sdl.FPoint{}} // TL
-	vertexCount++
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + rect.W - clampedRadius, Y: rect.Y}, Color: color, TexCoord: sdl.FPoint{X: 1}} // TR
-	vertexCount++
-
-	indices[indexCount] = 0
-	indexCount++
-	indices[indexCount] = vertexCount - 2 // TL
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // TR
-	indexCount++
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = 0
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // TR
-	indexCount++
-	// Right edge
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + rect.W, Y: rect.Y + clampedRadius}, Color: color, TexCoord: sdl.FPoint{X: 1}} // RT
-	vertexCount++
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + rect.W, Y: rect.Y + rect.H - clampedRadius}, Color: color, TexCoord: sdl.FPoint{X: 1, Y: 1}} // RB
-	vertexCount++
-
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = vertexCount - 2 // RT
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // RB
-	indexCount++
-	indices[indexCount] = 2
-	indexCount++
-	indices[indexCount] = 1
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // RB
-	indexCount++
-	// Bottom edge
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + rect.W - clampedRadius, Y: rect.Y + rect.H}, Color: color, TexCoord: sdl.FPoint{X: 1, Y: 1}} // BR
-	vertexCount++
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X + clampedRadius, Y: rect.Y + rect.H}, Color: color, TexCoord: sdl.FPoint{Y: 1}} // BL
-	vertexCount++
-
-	indices[indexCount] = 2
-	indexCount++
-	indices[indexCount] = vertexCount - 2 // BR
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // BL
-	indexCount++
-	indices[indexCount] = 3
-	indexCount++
-	indices[indexCount] = 2
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // BL
-	indexCount++
-	// Left edge
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X, Y: rect.Y + rect.H - clampedRadius}, Color: color, TexCoord: sdl.FPoint{Y: 1}} // LB
-	vertexCount++
-	vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: rect.X, Y: rect.Y + clampedRadius}, Color: color, TexCoord: sdl.FPoint{}} // LT
-	vertexCount++
-
-	indices[indexCount] = 3
-	indexCount++
-	indices[indexCount] = vertexCount - 2 // LB
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // LT
-	indexCount++
-	indices[indexCount] = 0
-	indexCount++
-	indices[indexCount] = 3
-	indexCount++
-	indices[indexCount] = vertexCount - 1 // LT
-	indexCount++
-
-	// Render everything
-	return renderer.RenderGeometry(nil, vertices[:vertexCount], indices[:indexCount])
+	if err := renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND); err != nil {
+		return err
+	}
+	if err := renderer.SetRenderTarget(c.offscreen.target); err != nil {
+		return err
+	}
+	rect := sdl.FRect{X: float32(c.rect.X), Y: float32(c.rect.Y), W: float32(c.rect.W), H: float32(c.rect.H)}
+	return renderer.RenderTexture(c.offscreen.texture, &rect, &rect)
 }
 
-// all rendering is performed by a single SDL call, using twi sets of arcing triangles, inner and outer, that fit together; along with two tringles to fill the end gaps.
-func renderCornerBorder(renderer *sdl.Renderer, boundingBox *clay.BoundingBox, config *clay.BorderRenderData, cornerIndex int, _color clay.Color) {
-	/////////////////////////////////
-	//The arc is constructed of outer triangles and inner triangles (if needed).
-	//First three vertices are first outer triangle's vertices
-	//Each two vertices after that are the inner-middle and second-outer vertex of
-	//each outer triangle after the first, because there first-outer vertex is equal to the
-	//second-outer vertex of the previous triangle. Indices set accordingly.
-	//The final two vertices are the missing vertices for the first and last inner triangles (if needed)
-	//Everything is in clockwise order (CW).
-	/////////////////////////////////
-
-	color := sdl.FColor{
-		R: _color.R / 255,
-		G: _color.G / 255,
-		B: _color.B / 255,
-		A: _color.A / 255,
+// pooledTexture returns the render target the size of the output for the given level of nesting from the pool,
+// replacing it if the size of the output changed.
+func pooledTexture(renderer *sdl.Renderer, pool *[]*sdl.Texture, depth int) (*sdl.Texture, error) {
+	width, height, err := renderer.CurrentOutputSize()
+	if err != nil {
+		return nil, err
 	}
-
-	var centerX, centerY, outerRadius, startAngle, borderWidth float32
-	maxRadius := min(boundingBox.Width, boundingBox.Height) / 2.0
-
-	var vertices [512]sdl.Vertex
-	var indices [512]int32
-	indexCount, vertexCount := int32(0), int32(0)
-
-	switch cornerIndex {
-	case 0:
-		startAngle = math.Pi
-		outerRadius = min(config.CornerRadius.TopLeft, maxRadius)
-		centerX = boundingBox.X + outerRadius
-		centerY = boundingBox.Y + outerRadius
-		borderWidth = float32(config.Width.Top)
-	case 1:
-		startAngle = 3 * math.Pi / 2
-		outerRadius = min(config.CornerRadius.TopRight, maxRadius)
-		centerX = boundingBox.X + boundingBox.Width - outerRadius
-		centerY = boundingBox.Y + outerRadius
-		borderWidth = float32(config.Width.Top)
-	case 2:
-		startAngle = 0
-		outerRadius = min(config.CornerRadius.BottomRight, maxRadius)
-		centerX = boundingBox.X + boundingBox.Width - outerRadius
-		centerY = boundingBox.Y + boundingBox.Height - outerRadius
-		borderWidth = float32(config.Width.Bottom)
-	case 3:
-		startAngle = math.Pi / 2
-		outerRadius = min(config.CornerRadius.BottomLeft, maxRadius)
-		centerX = boundingBox.X + outerRadius
-		centerY = boundingBox.Y + boundingBox.Height - outerRadius
-		borderWidth = float32(config.Width.Bottom)
-	default:
-		panic("invalid corner index")
+	if depth == len(*pool) {
+		*pool = append(*pool, nil)
 	}
-
-	innerRadius := outerRadius - borderWidth
-	minNumOuterTriangles := numCircleSegments
-	numOuterTriangles := max(minNumOuterTriangles, int(math.Ceil(float64(outerRadius*0.5))))
-	angleStep := math.Pi / (2.0 * float32(numOuterTriangles))
-
-	// outer triangles, in CW order
-	for i := 0; i < numOuterTriangles; i++ {
-		angle1 := startAngle + float32(i)*angleStep       // first-outer vertex angle
-		angle2 := startAngle + (float32(i)+0.5)*angleStep // inner-middle vertex angle
-		angle3 := startAngle + float32(i+1)*angleStep     // second-outer vertex angle
-
-		if i == 0 { // first outer triangle
-			vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: centerX + float32(math.Cos(float64(angle1)))*outerRadius, Y: centerY + float32(math.Sin(float64(angle1)))*outerRadius}, Color: color} // vertex index = 0
-			vertexCount++
+	textures := *pool
+	if texture := textures[depth]; texture != nil {
+		if w, h, err := texture.Size(); err != nil || int32(w) != width || int32(h) != height {
+			texture.Destroy()
+			textures[depth] = nil
 		}
-		indices[indexCount] = vertexCount - 1 // will be second-outer vertex of last outer triangle if not first outer triangle.
-		indexCount++
-		if innerRadius > 0 {
-			vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: centerX + float32(math.Cos(float64(angle2)))*(innerRadius), Y: centerY + float32(math.Sin(float64(angle2)))*(innerRadius)}, Color: color}
-			vertexCount++
-		} else {
-			vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: centerX, Y: centerY}, Color: color}
-			vertexCount++
+	}
+	if textures[depth] == nil {
+		if textures[depth], err = createTargetTexture(renderer, int(width), int(height)); err != nil {
+			return nil, err
 		}
-
-		indices[indexCount] = vertexCount - 1
-		indexCount++
-		vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: centerX + float32(math.Cos(float64(angle3)))*outerRadius, Y: centerY + float32(math.Sin(float64(angle3)))*outerRadius}, Color: color}
-		vertexCount++
-		indices[indexCount] = vertexCount - 1
-		indexCount++
 	}
+	return textures[depth], nil
+}
 
-	if innerRadius > 0 {
-		// inner triangles in CW order (except the first and last)
-		for i := 0; i < numOuterTriangles-1; i++ { // skip the last outer triangle
-			if i == 0 { // first outer triangle -> second inner triangle
-				indices[indexCount] = 1 // inner-middle vertex of first outer triangle
-				indexCount++
-				indices[indexCount] = 2 // second-outer vertex of first outer triangle
-				indexCount++
-				indices[indexCount] = 3 // innder-middle vertex of second-outer triangle
-				indexCount++
-			} else {
-				baseIndex := 3                                   // skip first outer triangle
-				indices[indexCount] = int32(baseIndex + (i-1)*2) // inner-middle vertex of current outer triangle
-				indexCount++
-				indices[indexCount] = int32(baseIndex + (i-1)*2 + 1) // second-outer vertex of current outer triangle
-				indexCount++
-				indices[indexCount] = int32(baseIndex + (i-1)*2 + 2) // inner-middle vertex of next outer triangle
-				indexCount++
+// Release destroys the render targets pooled for drawing layers, rounded clipping and transformed render commands.
+// Call it before destroying the renderer. Drawing with rendererData again creates them anew.
+func (rendererData *RendererData) Release() {
+	for _, pool := range [][]*sdl.Texture{rendererData.layerTextures, rendererData.clipTextures} {
+		for _, texture := range pool {
+			if texture != nil {
+				texture.Destroy()
 			}
 		}
+	}
+	if rendererData.transformTexture != nil {
+		rendererData.transformTexture.Destroy()
+	}
+	rendererData.layerTextures, rendererData.clipTextures, rendererData.transformTexture = nil, nil, nil
+}
+
+// startTransform makes a cleared texture of at least the given size the render target, to draw a transformed render command into.
+func startTransform(rendererData *RendererData, size image.Point) (layer, error) {
+	renderer := rendererData.Renderer
+	texture := rendererData.transformTexture
+	if texture != nil {
+		if w, h, err := texture.Size(); err != nil || int(w) < size.X || int(h) < size.Y {
+			// Grown to fit the largest command so far, so commands of different sizes don't keep recreating it
+			size = image.Pt(max(size.X, int(w)), max(size.Y, int(h)))
+			texture.Destroy()
+			texture = nil
+		}
+	}
+	if texture == nil {
+		var err error
+		if texture, err = createTargetTexture(renderer, size.X, size.Y); err != nil {
+			return layer{}, err
+		}
+		rendererData.transformTexture = texture
+	}
+	return startOffscreen(renderer, texture)
+}
 
-		endAngle := startAngle + math.Pi/2.0
+// endTransform restores the render target from before the transformed render command was drawn, and draws the region of
+// the texture it was drawn into onto it with the transform.
+func endTransform(renderer *sdl.Renderer, l layer, m clay.Matrix2D, region image.Rectangle) error {
+	if err := renderer.SetRenderTarget(l.target); err != nil {
+		return err
+	}
+	w, h, err := l.texture.Size()
+	if err != nil {
+		return err
+	}
+	u, v := float32(region.Dx())/w, float32(region.Dy())/h
+	corners := transform.Points(m, region)
+	texCoords := [4]sdl.FPoint{{X: 0, Y: 0}, {X: u, Y: 0}, {X: u, Y: v}, {X: 0, Y: v}}
+	vertices := make([]sdl.Vertex, 4)
+	for i, corner := range corners {
+		vertices[i] = sdl.Vertex{Position: sdl.FPoint{X: corner.X, Y: corner.Y}, Color: sdl.FColor{R: 1, G: 1, B: 1, A: 1}, TexCoord: texCoords[i]}
+	}
+	if err := l.texture.SetColorMod(255, 255, 255); err != nil {
+		return err
+	}
+	if err := l.texture.SetAlphaMod(255); err != nil {
+		return err
+	}
+	return renderer.RenderGeometry(l.texture, vertices, []int32{0, 1, 2, 0, 2, 3})
+}
 
-		// last inner triangle
-		indices[indexCount] = vertexCount - 2 // inner-middle vertex of last outer triangle
-		indexCount++
-		indices[indexCount] = vertexCount - 1 // second-outer vertex of last outer triangle
-		indexCount++
-		vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: centerX + float32(math.Cos(float64(endAngle)))*innerRadius, Y: centerY + float32(math.Sin(float64(endAngle)))*innerRadius}, Color: color} // missing vertex
-		vertexCount++
-		indices[indexCount] = vertexCount - 1
-		indexCount++
+// createTargetTexture creates a texture that can be drawn into, and drawn with premultiplied alpha.
+func createTargetTexture(renderer *sdl.Renderer, width, height int) (*sdl.Texture, error) {
+	texture, err := renderer.CreateTexture(sdl.PIXELFORMAT_ARGB8888, sdl.TEXTUREACCESS_TARGET, width, height)
+	if err != nil {
+		return nil, err
+	}
+	// Blending into a cleared texture leaves its colors premultiplied by alpha
+	if err := texture.SetBlendMode(sdl.BLENDMODE_BLEND_PREMULTIPLIED); err != nil {
+		return nil, err
+	}
+	return texture, nil
+}
 
-		// //first inner triangle
-		indices[indexCount] = 0 // first-outer vertex of first outer triangle
-		indexCount++
-		indices[indexCount] = 1 // inner-middle vertex of first outer triangle
-		indexCount++
-		vertices[vertexCount] = sdl.Vertex{Position: sdl.FPoint{X: centerX + float32(math.Cos(float64(startAngle)))*innerRadius, Y: centerY + float32(math.Sin(float64(startAngle)))*innerRadius}, Color: color} // missing vertex
-		vertexCount++
-		indices[indexCount] = vertexCount - 1
-		indexCount++
+// startOffscreen makes the texture the render target after clearing it, saving the render target to restore.
+func startOffscreen(renderer *sdl.Renderer, texture *sdl.Texture) (layer, error) {
+	l := layer{texture: texture, target: renderer.RenderTarget()}
+	// Every render target keeps its own clipping rectangle, restored along with it
+	if err := renderer.SetRenderTarget(texture); err != nil {
+		return layer{}, err
+	}
+	if err := renderer.SetClipRect(nil); err != nil {
+		return layer{}, err
 	}
+	if err := renderer.SetDrawColor(0, 0, 0, 0); err != nil {
+		return layer{}, err
+	}
+	return l, renderer.Clear()
+}
 
-	err := renderer.RenderGeometry(nil, vertices[:vertexCount], indices[:indexCount])
+// visibleBounds returns the part of the render target that isn't clipped.
+func visibleBounds(renderer *sdl.Renderer) (image.Rectangle, error) {
+	width, height, err := renderer.CurrentOutputSize()
 	if err != nil {
-		return
+		return image.Rectangle{}, err
+	}
+	bounds := image.Rect(0, 0, int(width), int(height))
+	if renderer.ClipEnabled() == nil {
+		r, err := renderer.ClipRect()
+		if err != nil {
+			return image.Rectangle{}, err
+		}
+		bounds = bounds.Intersect(image.Rect(int(r.X), int(r.Y), int(r.X+r.W), int(r.Y+r.H)))
 	}
+	return bounds, nil
 }
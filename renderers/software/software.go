@@ -13,9 +13,16 @@ import (
 	"unsafe"
 
 	"github.com/TotallyGamerJet/clay"
+	"github.com/TotallyGamerJet/clay/renderers/internal/border"
+	"github.com/TotallyGamerJet/clay/renderers/internal/geometry"
+	"github.com/TotallyGamerJet/clay/renderers/internal/imagefit"
+	"github.com/TotallyGamerJet/clay/renderers/internal/transform"
+	"github.com/TotallyGamerJet/clay/renderers/internal/vectorpath"
 	"golang.org/x/image/draw"
 	"golang.org/x/image/font"
+	"golang.org/x/image/math/f64"
 	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
 )
 
 // PrintPlaygroundImage prints the image so that it displays in the Go playground. It may have to shrink the image
@@ -49,25 +56,49 @@ func MeasureText(txt clay.StringSlice, config *clay.TextElementConfig, userData
 	}
 }
 
+// layer is an offscreen image that render commands are drawn into between LAYER_START and LAYER_END.
+type layer struct {
+	// The images that were drawn into before the layer started
+	screen, fullScreen draw.Image
+	image              *image.RGBA
+}
+
+// clip is the clipping started by a SCISSOR_START. Rounded clipping draws into an offscreen image until the SCISSOR_END,
+// which is then drawn through the mask.
+type clip struct {
+	// The image that was drawn into before the clipping started
+	screen draw.Image
+	image  *image.RGBA
+	mask   *image.Alpha
+}
+
 func ClayRender(screen draw.Image, renderCommands clay.RenderCommandArray, fonts []font.Face) error {
 	fullScreen := screen
+	var layers []layer
+	var clips []clip
 	for renderCommand := range renderCommands.Iter() {
 		boundingBox := renderCommand.BoundingBox
+		// Transformed commands are drawn in place onto a transparent image, which is then drawn onto the screen with the transform
+		target := screen
+		var offscreen *image.RGBA
+		if transform.Transformed(&renderCommand) {
+			region := transform.Region(renderCommand.Transform, transform.Extents(&renderCommand), screen.Bounds())
+			if region.Empty() {
+				continue
+			}
+			offscreen = image.NewRGBA(region)
+			screen = offscreen
+		}
 		switch renderCommand.CommandType {
 		case clay.RENDER_COMMAND_TYPE_RECTANGLE:
 			config := &renderCommand.RenderData.Rectangle
-			c := color.RGBA{
-				R: uint8(config.BackgroundColor.R),
-				G: uint8(config.BackgroundColor.G),
-				B: uint8(config.BackgroundColor.B),
-				A: uint8(config.BackgroundColor.A),
-			}
-			rect := image.Rect(int(boundingBox.X), int(boundingBox.Y), int(boundingBox.X+boundingBox.Width), int(boundingBox.Y+boundingBox.Height))
-			if config.CornerRadius.TopLeft > 0 {
-				draw.Draw(screen, rect, &image.Uniform{C: c}, image.Point{}, draw.Src)
-			} else {
-				draw.Draw(screen, rect, &image.Uniform{C: c}, image.Point{}, draw.Src)
+			colorAt := func(x, y float32) clay.Color { return config.BackgroundColor }
+			if config.Gradient.Type != clay.GRADIENT_TYPE_NONE {
+				colorAt = gradientColor(&config.Gradient, boundingBox)
 			}
+			fillShape(screen, boundingBox, func(x, y float32) float32 {
+				return geometry.Coverage(geometry.Distance(x, y, boundingBox, config.CornerRadius), 1)
+			}, colorAt)
 		case clay.RENDER_COMMAND_TYPE_TEXT:
 			config := &renderCommand.RenderData.Text
 			cloned := strings.Clone(config.StringContents.String())
@@ -87,28 +118,248 @@ func ClayRender(screen draw.Image, renderCommands clay.RenderCommandArray, fonts
 			}
 			d.DrawString(cloned)
 		case clay.RENDER_COMMAND_TYPE_SCISSOR_START:
+			// Transformed clipping rectangles are clipped to by their bounds
+			boundingBox = renderCommand.Transform.Bounds(boundingBox)
 			rect := image.Rect(int(boundingBox.X), int(boundingBox.Y), int(boundingBox.X+boundingBox.Width), int(boundingBox.Y+boundingBox.Height))
+			c := clip{screen: screen}
+			// Nested clipping only draws inside of the clipping around it
 			screen = screen.(interface {
 				SubImage(r image.Rectangle) image.Image
 			}).SubImage(rect).(draw.Image)
+			if radius := renderCommand.RenderData.Clip.CornerRadius; radius != (clay.CornerRadius{}) {
+				c.image = image.NewRGBA(screen.Bounds())
+				c.mask = clipMask(screen.Bounds(), renderCommand.BoundingBox, radius, renderCommand.Transform)
+				screen = c.image
+			}
+			clips = append(clips, c)
 		case clay.RENDER_COMMAND_TYPE_SCISSOR_END:
-			screen = fullScreen
+			if len(clips) == 0 {
+				screen = fullScreen
+				break
+			}
+			c := clips[len(clips)-1]
+			clips = clips[:len(clips)-1]
+			screen = c.screen
+			if c.image != nil {
+				draw.DrawMask(screen, c.image.Bounds(), c.image, c.image.Bounds().Min, c.mask, c.mask.Bounds().Min, draw.Over)
+			}
 		case clay.RENDER_COMMAND_TYPE_IMAGE:
 			config := &renderCommand.RenderData.Image
 			img := (*image.Image)(config.ImageData.(unsafe.Pointer))
 			if img == nil {
-				continue
+				break
+			}
+			bounds := (*img).Bounds()
+			for _, part := range imagefit.Parts(boundingBox, config, float32(bounds.Dx()), float32(bounds.Dy())) {
+				src, dst := part.Src, part.Dst
+				srcRect := image.Rect(int(src.X), int(src.Y), int(src.X+src.Width), int(src.Y+src.Height)).Add(bounds.Min)
+				destRect := image.Rect(int(dst.X), int(dst.Y), int(dst.X+dst.Width), int(dst.Y+dst.Height))
+				if srcRect.Empty() || destRect.Empty() {
+					continue
+				}
+				draw.ApproxBiLinear.Scale(screen, destRect, *img, srcRect, draw.Over, nil)
 			}
-			destRect := image.Rect(int(boundingBox.X), int(boundingBox.Y), int(boundingBox.X+boundingBox.Width), int(boundingBox.Y+boundingBox.Height))
-			draw.ApproxBiLinear.Scale(screen, destRect, *img, (*img).Bounds(), draw.Over, nil)
 		case clay.RENDER_COMMAND_TYPE_BORDER:
-			panic("not implemented")
+			renderBorder(screen, boundingBox, &renderCommand.RenderData.Border)
+		case clay.RENDER_COMMAND_TYPE_SHADOW:
+			renderShadow(screen, boundingBox, &renderCommand.RenderData.Shadow)
+		case clay.RENDER_COMMAND_TYPE_PATH:
+			renderPath(screen, boundingBox, &renderCommand.RenderData.Path)
+		case clay.RENDER_COMMAND_TYPE_LAYER_START:
+			img := image.NewRGBA(fullScreen.Bounds())
+			layers = append(layers, layer{screen: screen, fullScreen: fullScreen, image: img})
+			screen, fullScreen = img, img
+		case clay.RENDER_COMMAND_TYPE_LAYER_END:
+			if len(layers) == 0 {
+				break
+			}
+			l := layers[len(layers)-1]
+			layers = layers[:len(layers)-1]
+			screen, fullScreen = l.screen, l.fullScreen
+			// Clipping that was active when the layer started applies to all of it
+			opacity := image.NewUniform(color.Alpha{A: channel(renderCommand.RenderData.Layer.Opacity * 255)})
+			draw.DrawMask(screen, screen.Bounds(), l.image, screen.Bounds().Min, opacity, image.Point{}, draw.Over)
 		case clay.RENDER_COMMAND_TYPE_NONE:
 		case clay.RENDER_COMMAND_TYPE_CUSTOM:
 		default:
 			slog.Warn("Unknown command type", "type", renderCommand.CommandType)
 		}
+		if offscreen != nil {
+			screen = target
+			m := renderCommand.Transform
+			s2d := f64.Aff3{float64(m.A), float64(m.C), float64(m.Tx), float64(m.B), float64(m.D), float64(m.Ty)}
+			draw.BiLinear.Transform(screen, s2d, offscreen, offscreen.Bounds(), draw.Over, nil)
+		}
 	}
 
 	return nil
 }
+
+// renderBorder draws the part of the rounded rectangle in boundingBox that lies outside of the rectangle left inside its borders.
+func renderBorder(screen draw.Image, boundingBox clay.BoundingBox, config *clay.BorderRenderData) {
+	inner, innerRadius := geometry.BorderInner(boundingBox, config.CornerRadius, config.Width)
+	colorAt := func(x, y float32) clay.Color {
+		return border.SideAt(boundingBox, config.Width, x, y).Color(config.Colors)
+	}
+	if config.Gradient.Type != clay.GRADIENT_TYPE_NONE {
+		colorAt = gradientColor(&config.Gradient, boundingBox)
+	}
+	if config.Style != clay.BORDER_STYLE_SOLID {
+		// Dashes and dots are rasterized from their outlines
+		segments := border.Segments(boundingBox, config.CornerRadius, config.Width, config.Style, config.DashLength, config.GapLength)
+		polygons := make([][]geometry.Point, len(segments))
+		for i, segment := range segments {
+			polygons[i] = segment.Points
+		}
+		mask := polygonMask(boundingBox, polygons, screen.Bounds())
+		fillShape(screen, boundingBox, func(x, y float32) float32 {
+			return float32(mask.AlphaAt(int(x), int(y)).A) / 255
+		}, colorAt)
+		return
+	}
+	fillShape(screen, boundingBox, func(x, y float32) float32 {
+		outer := geometry.Coverage(geometry.Distance(x, y, boundingBox, config.CornerRadius), 1)
+		return outer * (1 - geometry.Coverage(geometry.Distance(x, y, inner, innerRadius), 1))
+	}, colorAt)
+}
+
+// renderPath fills and then strokes the path, rasterizing the triangles they're split into.
+func renderPath(screen draw.Image, boundingBox clay.BoundingBox, config *clay.PathRenderData) {
+	for _, shape := range [2]struct {
+		color     clay.Color
+		triangles func(clay.BoundingBox, *clay.PathRenderData) []geometry.Point
+	}{
+		{config.FillColor, vectorpath.Fill},
+		{config.StrokeColor, vectorpath.Stroke},
+	} {
+		if shape.color.A <= 0 {
+			continue
+		}
+		triangles := shape.triangles(boundingBox, config)
+		if len(triangles) == 0 {
+			continue
+		}
+		polygons := make([][]geometry.Point, 0, len(triangles)/3)
+		for i := 0; i+2 < len(triangles); i += 3 {
+			polygons = append(polygons, triangles[i:i+3])
+		}
+		area := vectorpath.Bounds(triangles)
+		mask := polygonMask(area, polygons, screen.Bounds())
+		fillShape(screen, area, func(x, y float32) float32 {
+			return float32(mask.AlphaAt(int(x), int(y)).A) / 255
+		}, func(x, y float32) clay.Color {
+			return shape.color
+		})
+	}
+}
+
+// polygonMask returns how much of every pixel around boundingBox, and inside bounds, is covered by the polygons, which
+// mustn't overlap.
+func polygonMask(boundingBox clay.BoundingBox, polygons [][]geometry.Point, bounds image.Rectangle) *image.Alpha {
+	rect := image.Rect(int(math.Floor(float64(boundingBox.X))), int(math.Floor(float64(boundingBox.Y))), int(math.Ceil(float64(boundingBox.X+boundingBox.Width))), int(math.Ceil(float64(boundingBox.Y+boundingBox.Height)))).Intersect(bounds)
+	mask := image.NewAlpha(rect)
+	if rect.Empty() {
+		return mask
+	}
+	origin := geometry.Point{X: float32(rect.Min.X), Y: float32(rect.Min.Y)}
+	z := vector.NewRasterizer(rect.Dx(), rect.Dy())
+	for _, polygon := range polygons {
+		z.MoveTo(polygon[0].X-origin.X, polygon[0].Y-origin.Y)
+		for _, p := range polygon[1:] {
+			z.LineTo(p.X-origin.X, p.Y-origin.Y)
+		}
+		z.ClosePath()
+	}
+	z.Draw(mask, rect, image.Opaque, image.Point{})
+	return mask
+}
+
+func gradientColor(g *clay.Gradient, boundingBox clay.BoundingBox) func(x, y float32) clay.Color {
+	return func(x, y float32) clay.Color {
+		return g.ColorAt(boundingBox, x, y)
+	}
+}
+
+// fillShape draws every pixel of boundingBox in the color colorAt returns for its center, blended over the screen by the
+// coverage coverageAt returns for it. Pixels are fully covered when their center is at least half a pixel inside a shape's edge.
+func fillShape(screen draw.Image, boundingBox clay.BoundingBox, coverageAt func(x, y float32) float32, colorAt func(x, y float32) clay.Color) {
+	rect := image.Rect(int(math.Floor(float64(boundingBox.X))), int(math.Floor(float64(boundingBox.Y))), int(math.Ceil(float64(boundingBox.X+boundingBox.Width))), int(math.Ceil(float64(boundingBox.Y+boundingBox.Height)))).Intersect(screen.Bounds())
+	if rect.Empty() {
+		return
+	}
+	src := image.NewNRGBA(rect)
+	mask := image.NewAlpha(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			px, py := float32(x)+0.5, float32(y)+0.5
+			a := coverageAt(px, py)
+			if a <= 0 {
+				continue
+			}
+			c := colorAt(px, py)
+			src.SetNRGBA(x, y, color.NRGBA{R: channel(c.R), G: channel(c.G), B: channel(c.B), A: channel(c.A)})
+			mask.SetAlpha(x, y, color.Alpha{A: channel(a * 255)})
+		}
+	}
+	draw.DrawMask(screen, rect, src, rect.Min, mask, rect.Min, draw.Over)
+}
+
+// channel rounds a color channel represented as 0-255 to the nearest byte.
+func channel(v float32) uint8 {
+	return uint8(min(max(v, 0), 255) + 0.5)
+}
+
+// renderShadow draws the shadow cast by the rounded rectangle in boundingBox. Every pixel's coverage is computed from its distance
+// to the shadow's edge, fading out linearly over the blur radius. Outer shadows are also drawn underneath the element, like the meshes of
+// [geometry.Shadow], which hides them when it is opaque.
+func renderShadow(screen draw.Image, boundingBox clay.BoundingBox, config *clay.ShadowRenderData) {
+	spread := config.Spread
+	if config.Inset {
+		spread = -spread
+	}
+	shadowBox := clay.BoundingBox{
+		X:      boundingBox.X + config.Offset.X - spread,
+		Y:      boundingBox.Y + config.Offset.Y - spread,
+		Width:  max(boundingBox.Width+spread*2, 0),
+		Height: max(boundingBox.Height+spread*2, 0),
+	}
+	shadowRadius := geometry.GrowRadius(config.CornerRadius, spread)
+	area := boundingBox
+	if !config.Inset {
+		extent := max(config.BlurRadius/2, 0) + 1
+		area = clay.BoundingBox{X: shadowBox.X - extent, Y: shadowBox.Y - extent, Width: shadowBox.Width + extent*2, Height: shadowBox.Height + extent*2}
+	}
+	rect := image.Rect(int(math.Floor(float64(area.X))), int(math.Floor(float64(area.Y))), int(math.Ceil(float64(area.X+area.Width))), int(math.Ceil(float64(area.Y+area.Height)))).Intersect(screen.Bounds())
+	if rect.Empty() {
+		return
+	}
+	mask := image.NewAlpha(rect)
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			px, py := float32(x)+0.5, float32(y)+0.5
+			shadow := geometry.Coverage(geometry.Distance(px, py, shadowBox, shadowRadius), config.BlurRadius)
+			if config.Inset {
+				shadow = (1 - shadow) * geometry.Coverage(geometry.Distance(px, py, boundingBox, config.CornerRadius), 1)
+			}
+			mask.SetAlpha(x, y, color.Alpha{A: uint8(shadow*config.Color.A + 0.5)})
+		}
+	}
+	c := color.RGBA{R: uint8(config.Color.R), G: uint8(config.Color.G), B: uint8(config.Color.B), A: 255}
+	draw.DrawMask(screen, rect, &image.Uniform{C: c}, image.Point{}, mask, rect.Min, draw.Over)
+}
+
+// clipMask returns how much of every pixel in bounds is inside the rounded rectangle in box, drawn with the transform.
+func clipMask(bounds image.Rectangle, box clay.BoundingBox, radius clay.CornerRadius, m clay.Matrix2D) *image.Alpha {
+	mask := image.NewAlpha(bounds)
+	inverse, ok := m.Invert()
+	if !ok {
+		return mask
+	}
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			p := inverse.Apply(clay.Vector2{X: float32(x) + 0.5, Y: float32(y) + 0.5})
+			mask.SetAlpha(x, y, color.Alpha{A: channel(geometry.Coverage(geometry.Distance(p.X, p.Y, box, radius), 1) * 255)})
+		}
+	}
+	return mask
+}
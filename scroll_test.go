@@ -0,0 +1,364 @@
+package clay_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutScrollList lays out a 100x100 scroll container named List, holding a column of 50 pixel tall rows named Row 0 and up.
+func layoutScrollList(rows int, clip clay.ClipElementConfig) clay.RenderCommandArray {
+	clip.Vertical = true
+	clay.BeginLayout()
+	open := clay.UI(clay.ID("List"))
+	clip.ChildOffset = clay.GetScrollOffset()
+	open(clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(100)}, LayoutDirection: clay.TOP_TO_BOTTOM},
+		Clip:   clip,
+	}, func() {
+		for row := range rows {
+			clay.UI(clay.ID(fmt.Sprint("Row ", row)))(clay.ElementDeclaration{
+				Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingGrow(0), Height: clay.SizingFixed(50)}},
+			}, nil)
+		}
+	})
+	return clay.EndLayout()
+}
+
+// scrollFrames runs the provided number of 60 FPS frames without any user scrolling, and returns the scroll position of List.
+func scrollFrames(frames, rows int, clip clay.ClipElementConfig) clay.Vector2 {
+	for range frames {
+		clay.UpdateScrollContainers(false, clay.Vector2{}, 1.0/60)
+		layoutScrollList(rows, clip)
+	}
+	return *clay.GetScrollContainerData(clay.ID("List")).ScrollPosition
+}
+
+func TestScrollIntoView(t *testing.T) {
+	tests := []struct {
+		alignment clay.ScrollAlignment
+		row       int
+		want      float32
+	}{
+		{clay.SCROLL_ALIGN_NEAREST, 4, -150},
+		{clay.SCROLL_ALIGN_START, 4, -200},
+		{clay.SCROLL_ALIGN_CENTER, 4, -175},
+		{clay.SCROLL_ALIGN_END, 4, -150},
+		// Targets are clamped to the content
+		{clay.SCROLL_ALIGN_START, 9, -400},
+		{clay.SCROLL_ALIGN_END, 0, 0},
+	}
+	for _, test := range tests {
+		newTestContext(t)
+		layoutScrollList(10, clay.ClipElementConfig{})
+		clay.ScrollIntoView(clay.ID("List"), clay.ID(fmt.Sprint("Row ", test.row)), test.alignment)
+		// The scroll is animated, so it hasn't arrived after a single frame
+		if position := scrollFrames(1, 10, clay.ClipElementConfig{}); test.want != 0 && position.Y <= test.want {
+			t.Errorf("alignment %d row %d: expected the first frame to be part of the way there, got %v", test.alignment, test.row, position.Y)
+		}
+		if position := scrollFrames(60, 10, clay.ClipElementConfig{}); position.Y != test.want {
+			t.Errorf("alignment %d row %d: got %v, want %v", test.alignment, test.row, position.Y, test.want)
+		}
+	}
+}
+
+func TestScrollIntoViewNearestVisible(t *testing.T) {
+	newTestContext(t)
+	layoutScrollList(10, clay.ClipElementConfig{})
+	clay.ScrollTo(clay.ID("List"), clay.Vector2{Y: -100}, false)
+	scrollFrames(1, 10, clay.ClipElementConfig{})
+	// Row 2 is already fully visible, so the nearest alignment leaves the list where it is
+	clay.ScrollIntoView(clay.ID("List"), clay.ID("Row 2"), clay.SCROLL_ALIGN_NEAREST)
+	if position := scrollFrames(60, 10, clay.ClipElementConfig{}); position.Y != -100 {
+		t.Fatalf("expected a visible target not to scroll, got %v", position.Y)
+	}
+	clay.ScrollIntoView(clay.ID("List"), clay.ID("Row 0"), clay.SCROLL_ALIGN_NEAREST)
+	if position := scrollFrames(60, 10, clay.ClipElementConfig{}); position.Y != 0 {
+		t.Fatalf("expected a target above the viewport to align with the top, got %v", position.Y)
+	}
+}
+
+func TestScrollTo(t *testing.T) {
+	newTestContext(t)
+	layoutScrollList(10, clay.ClipElementConfig{})
+	clay.ScrollTo(clay.ID("List"), clay.Vector2{Y: -120}, false)
+	if position := scrollFrames(1, 10, clay.ClipElementConfig{}); position.Y != -120 {
+		t.Fatalf("expected a jump to -120, got %v", position.Y)
+	}
+	clay.ScrollTo(clay.ID("List"), clay.Vector2{Y: -1000}, false)
+	if position := scrollFrames(1, 10, clay.ClipElementConfig{}); position.Y != -400 {
+		t.Fatalf("expected the jump to be clamped to the content, got %v", position.Y)
+	}
+
+	clay.ScrollTo(clay.ID("List"), clay.Vector2{}, true)
+	position := scrollFrames(5, 10, clay.ClipElementConfig{})
+	if position.Y <= -400 || position.Y >= 0 {
+		t.Fatalf("expected the animation to be part of the way there, got %v", position.Y)
+	}
+	// Wheel input cancels the animation, and the list stays wherever the wheel left it
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 50}, false)
+	clay.UpdateScrollContainers(false, clay.Vector2{Y: -1}, 1.0/60)
+	layoutScrollList(10, clay.ClipElementConfig{})
+	position = *clay.GetScrollContainerData(clay.ID("List")).ScrollPosition
+	if cancelled := scrollFrames(60, 10, clay.ClipElementConfig{}); cancelled.Y != position.Y || cancelled.Y == 0 {
+		t.Fatalf("expected the wheel to cancel the animation at %v, got %v", position.Y, cancelled.Y)
+	}
+}
+
+func TestScrollToFollowsGrowingContent(t *testing.T) {
+	newTestContext(t)
+	layoutScrollList(4, clay.ClipElementConfig{})
+	clay.ScrollTo(clay.ID("List"), clay.Vector2{Y: -math.MaxFloat32}, true)
+	// Rows keep being added while the animation runs, and the target keeps following the bottom
+	rows := 4
+	for range 30 {
+		rows++
+		scrollFrames(1, rows, clay.ClipElementConfig{})
+	}
+	if position := scrollFrames(60, rows, clay.ClipElementConfig{}); position.Y != float32(-(rows*50 - 100)) {
+		t.Fatalf("expected the list to end up at the bottom, got %v", position.Y)
+	}
+}
+
+// dragList drags List with the pointer from y to y+distance over the provided number of frames, and releases it.
+func dragList(y, distance float32, frames int, clip clay.ClipElementConfig) {
+	clay.SetPointerState(clay.Vector2{X: 50, Y: y}, false)
+	scrollFrames(1, 10, clip)
+	for frame := range frames + 1 {
+		clay.SetPointerState(clay.Vector2{X: 50, Y: y + distance*float32(frame)/float32(frames)}, true)
+		clay.UpdateScrollContainers(true, clay.Vector2{}, 1.0/60)
+		layoutScrollList(10, clip)
+	}
+	clay.SetPointerState(clay.Vector2{X: 50, Y: y + distance}, false)
+	clay.UpdateScrollContainers(true, clay.Vector2{}, 1.0/60)
+	layoutScrollList(10, clip)
+}
+
+func TestScrollSnapInterval(t *testing.T) {
+	newTestContext(t)
+	clip := clay.ClipElementConfig{Snap: clay.ScrollSnapConfig{Type: clay.SCROLL_SNAP_INTERVAL, Interval: 50}}
+	layoutScrollList(10, clip)
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 50}, false)
+	clay.UpdateScrollContainers(false, clay.Vector2{Y: -3}, 1.0/60)
+	layoutScrollList(10, clip)
+	// The wheel has to be idle for a moment before the list snaps
+	if position := scrollFrames(5, 10, clip); position.Y != -30 {
+		t.Fatalf("expected the list not to snap while the wheel may still be turning, got %v", position.Y)
+	}
+	if position := scrollFrames(60, 10, clip); position.Y != -50 {
+		t.Fatalf("expected the list to snap to -50, got %v", position.Y)
+	}
+}
+
+func TestScrollSnapChildren(t *testing.T) {
+	newTestContext(t)
+	clip := clay.ClipElementConfig{
+		Snap:    clay.ScrollSnapConfig{Type: clay.SCROLL_SNAP_CHILDREN, Alignment: clay.SCROLL_ALIGN_CENTER},
+		Physics: clay.ScrollPhysicsConfig{Friction: 0.5},
+	}
+	layoutScrollList(10, clip)
+	dragList(80, -60, 6, clip)
+	// The flick would come to rest around -108, and centering a row in the list puts the closest snap point at -125
+	if position := scrollFrames(120, 10, clip); position.Y != -125 {
+		t.Fatalf("expected the list to come to rest with Row 3 centered, got %v", position.Y)
+	}
+}
+
+func TestScrollMomentum(t *testing.T) {
+	travel := func(friction float32) float32 {
+		newTestContext(t)
+		clip := clay.ClipElementConfig{Physics: clay.ScrollPhysicsConfig{Friction: friction}}
+		layoutScrollList(10, clip)
+		dragList(90, -30, 3, clip)
+		released := *clay.GetScrollContainerData(clay.ID("List")).ScrollPosition
+		return released.Y - scrollFrames(120, 10, clip).Y
+	}
+	// A quick flick keeps the list moving after release, and more friction stops it sooner
+	loose, tight := travel(0.05), travel(0.5)
+	if loose <= tight || tight <= 0 {
+		t.Fatalf("expected momentum to carry the list further with less friction, got %v and %v", loose, tight)
+	}
+
+	newTestContext(t)
+	clip := clay.ClipElementConfig{Physics: clay.ScrollPhysicsConfig{DragThreshold: 40}}
+	layoutScrollList(10, clip)
+	dragList(90, -30, 3, clip)
+	// The drag didn't cover the threshold, so the list stops where it was released
+	if position := scrollFrames(60, 10, clip); position.Y != -30 {
+		t.Fatalf("expected no momentum below the drag threshold, got %v", position.Y)
+	}
+}
+
+func TestScrollOverscroll(t *testing.T) {
+	newTestContext(t)
+	clip := clay.ClipElementConfig{Physics: clay.ScrollPhysicsConfig{Overscroll: 40}}
+	layoutScrollList(10, clip)
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 10}, false)
+	scrollFrames(1, 10, clip)
+	for _, y := range []float32{10, 50, 90} {
+		clay.SetPointerState(clay.Vector2{X: 50, Y: y}, true)
+		clay.UpdateScrollContainers(true, clay.Vector2{}, 1.0/60)
+		layoutScrollList(10, clip)
+	}
+	// Dragging 80 pixels past the top only pulls the content part of the way, with resistance
+	pulled := *clay.GetScrollContainerData(clay.ID("List")).ScrollPosition
+	if pulled.Y <= 0 || pulled.Y >= 40 {
+		t.Fatalf("expected the content to be pulled less than 40 pixels past the top, got %v", pulled.Y)
+	}
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 90}, false)
+	if position := scrollFrames(60, 10, clip); position.Y != 0 {
+		t.Fatalf("expected the content to spring back to the top, got %v", position.Y)
+	}
+}
+
+// scrollbarCommands returns the render commands of List's scrollbar, told apart by their colors. ok is false if the thumb wasn't drawn.
+func scrollbarCommands(cmds clay.RenderCommandArray) (track, thumb clay.RenderCommand, ok bool) {
+	for cmd := range cmds.Iter() {
+		if cmd.CommandType != clay.RENDER_COMMAND_TYPE_RECTANGLE {
+			continue
+		}
+		switch cmd.RenderData.Rectangle.BackgroundColor {
+		case blue:
+			track = cmd
+		case white:
+			thumb, ok = cmd, true
+		}
+	}
+	return track, thumb, ok
+}
+
+// scrollbarFrame runs a 60 FPS frame with the pointer at the provided position and lays out List with 10 rows.
+func scrollbarFrame(pointer clay.Vector2, down bool, clip clay.ClipElementConfig) clay.RenderCommandArray {
+	clay.SetPointerState(pointer, down)
+	clay.UpdateScrollContainers(true, clay.Vector2{}, 1.0/60)
+	return layoutScrollList(10, clip)
+}
+
+func scrollPosition() float32 {
+	return clay.GetScrollContainerData(clay.ID("List")).ScrollPosition.Y
+}
+
+func TestScrollbarGeometry(t *testing.T) {
+	newTestContext(t)
+	clip := clay.ClipElementConfig{Scrollbar: clay.ScrollbarConfig{Width: 10, ThumbColor: white, TrackColor: blue}}
+	track, thumb, ok := scrollbarCommands(layoutScrollList(10, clip))
+	// The track runs along the right edge, and the thumb is as long relative to it as the viewport is to the content
+	if !ok || track.BoundingBox != (clay.BoundingBox{X: 90, Width: 10, Height: 100}) || thumb.BoundingBox != (clay.BoundingBox{X: 90, Width: 10, Height: 20}) {
+		t.Fatalf("expected the track at %v and the thumb at %v, got %v and %v",
+			clay.BoundingBox{X: 90, Width: 10, Height: 100}, clay.BoundingBox{X: 90, Width: 10, Height: 20}, track.BoundingBox, thumb.BoundingBox)
+	}
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 50}, false)
+	clay.UpdateScrollContainers(false, clay.Vector2{Y: -20}, 1.0/60)
+	if _, thumb, _ := scrollbarCommands(layoutScrollList(10, clip)); thumb.BoundingBox.Y != 40 {
+		t.Fatalf("expected the thumb halfway down the track at y 40, got %v", thumb.BoundingBox.Y)
+	}
+
+	// The track and thumb keep their ids when the children change
+	_, more, _ := scrollbarCommands(layoutScrollList(11, clip))
+	if thumb.Id == track.Id || more.Id != thumb.Id {
+		t.Fatalf("expected the thumb id %v to differ from the track's %v and stay the same, got %v", thumb.Id, track.Id, more.Id)
+	}
+
+	tests := []struct {
+		name      string
+		scrollbar clay.ScrollbarConfig
+		height    float32
+	}{
+		{"min thumb length", clay.ScrollbarConfig{Width: 10, MinThumbLength: 30, ThumbColor: white}, 30},
+		// The thumb is never shorter than it is wide
+		{"width", clay.ScrollbarConfig{Width: 25, ThumbColor: white}, 25},
+	}
+	for _, test := range tests {
+		if _, thumb, _ := scrollbarCommands(layoutScrollList(10, clay.ClipElementConfig{Scrollbar: test.scrollbar})); thumb.BoundingBox.Height != test.height {
+			t.Errorf("%s: expected a thumb %v long, got %v", test.name, test.height, thumb.BoundingBox.Height)
+		}
+	}
+
+	// Nothing is drawn when the content fits
+	if _, _, ok := scrollbarCommands(layoutScrollList(2, clip)); ok {
+		t.Fatal("expected no scrollbar when the content fits")
+	}
+}
+
+func TestScrollbarDrag(t *testing.T) {
+	newTestContext(t)
+	clip := clay.ClipElementConfig{Scrollbar: clay.ScrollbarConfig{Width: 10, ThumbColor: white}}
+	scrollbarFrame(clay.Vector2{X: 95, Y: 10}, false, clip)
+	scrollbarFrame(clay.Vector2{X: 95, Y: 10}, true, clip)
+	// Moving the thumb 40 pixels along the 80 pixels it can travel scrolls halfway through the content, without dragging the content itself
+	scrollbarFrame(clay.Vector2{X: 95, Y: 50}, true, clip)
+	if position := scrollPosition(); position != -200 {
+		t.Fatalf("expected dragging the thumb to scroll to -200, got %v", position)
+	}
+	// The drag continues when the pointer leaves the track, and is clamped to the content
+	scrollbarFrame(clay.Vector2{X: 200, Y: 150}, true, clip)
+	if position := scrollPosition(); position != -400 {
+		t.Fatalf("expected dragging past the end of the track to scroll to -400, got %v", position)
+	}
+	scrollbarFrame(clay.Vector2{X: 200, Y: 150}, false, clip)
+	if position := scrollFrames(60, 10, clip); position.Y != -400 {
+		t.Fatalf("expected the list to stay where the thumb was released, got %v", position.Y)
+	}
+}
+
+func TestScrollbarTrackPaging(t *testing.T) {
+	newTestContext(t)
+	clip := clay.ClipElementConfig{Scrollbar: clay.ScrollbarConfig{Width: 10, ThumbColor: white, TrackColor: blue}}
+	scrollbarFrame(clay.Vector2{X: 95, Y: 90}, false, clip)
+	steps := []struct {
+		y    float32
+		want float32
+	}{
+		// Clicking the track below the thumb pages down by the height of the viewport, and above it pages up
+		{90, -100},
+		{90, -200},
+		{5, -100},
+	}
+	for i, step := range steps {
+		scrollbarFrame(clay.Vector2{X: 95, Y: step.y}, true, clip)
+		// The page is animated, so the list hasn't arrived after a single frame
+		if position := scrollPosition(); position == step.want {
+			t.Fatalf("step %d: expected paging to be animated, got %v", i, position)
+		}
+		scrollbarFrame(clay.Vector2{X: 95, Y: step.y}, false, clip)
+		if position := scrollFrames(60, 10, clip); position.Y != step.want {
+			t.Fatalf("step %d: expected the list to page to %v, got %v", i, step.want, position.Y)
+		}
+	}
+}
+
+func TestScrollbarAutoHide(t *testing.T) {
+	newTestContext(t)
+	clip := clay.ClipElementConfig{Scrollbar: clay.ScrollbarConfig{Width: 10, ThumbColor: white, AutoHideDelay: 0.5}}
+	layoutScrollList(10, clip)
+	visible := func(frames int, pointer clay.Vector2) bool {
+		var cmds clay.RenderCommandArray
+		for range frames {
+			cmds = scrollbarFrame(pointer, false, clip)
+		}
+		_, _, ok := scrollbarCommands(cmds)
+		return ok
+	}
+	away := clay.Vector2{X: 200, Y: 200}
+	if !visible(20, away) {
+		t.Fatal("expected the scrollbar to be visible before the delay")
+	}
+	if visible(20, away) {
+		t.Fatal("expected the scrollbar to hide after half a second without scrolling")
+	}
+	// Scrolling shows it again
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 50}, false)
+	clay.UpdateScrollContainers(false, clay.Vector2{Y: -1}, 1.0/60)
+	if _, _, ok := scrollbarCommands(layoutScrollList(10, clip)); !ok {
+		t.Fatal("expected scrolling to show the scrollbar")
+	}
+	// Hovering the track keeps it visible
+	if !visible(60, clay.Vector2{X: 95, Y: 50}) {
+		t.Fatal("expected the scrollbar to stay visible while it is hovered")
+	}
+	if visible(40, away) {
+		t.Fatal("expected the scrollbar to hide once the pointer left it")
+	}
+}
@@ -0,0 +1,116 @@
+package clay_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// commandTypes returns the types of the render commands in order.
+func commandTypes(cmds clay.RenderCommandArray) []clay.RenderCommandType {
+	var types []clay.RenderCommandType
+	for cmd := range cmds.Iter() {
+		types = append(types, cmd.CommandType)
+	}
+	return types
+}
+
+// layoutShadowed lays out a 40x40 clipped element named Card at the provided position, with a background, a border,
+// a child and the provided shadow.
+func layoutShadowed(position clay.Vector2, shadow clay.ShadowElementConfig) clay.RenderCommandArray {
+	clay.BeginLayout()
+	clay.UI(clay.ID("Card"))(clay.ElementDeclaration{
+		Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(40)}},
+		BackgroundColor: white,
+		Border:          clay.BorderElementConfig{Color: blue, Width: clay.BorderOutside(1)},
+		Clip:            clay.ClipElementConfig{Horizontal: true, Vertical: true},
+		Shadow:          shadow,
+		Floating:        clay.FloatingElementConfig{AttachTo: clay.ATTACH_TO_ROOT, Offset: position},
+	}, func() {
+		clay.UI()(clay.ElementDeclaration{
+			Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+			BackgroundColor: red,
+		}, nil)
+	})
+	return clay.EndLayout()
+}
+
+// expectCommandTypes fails the test if the render commands don't have the provided types in order.
+func expectCommandTypes(t *testing.T, cmds clay.RenderCommandArray, want ...clay.RenderCommandType) {
+	t.Helper()
+	got := commandTypes(cmds)
+	if len(got) != len(want) {
+		t.Fatalf("expected commands %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected commands %v, got %v", want, got)
+		}
+	}
+}
+
+func TestShadowOrder(t *testing.T) {
+	newTestContext(t)
+	shadow := clay.ShadowElementConfig{Color: clay.Color{A: 128}, Offset: clay.Vector2{Y: 4}, BlurRadius: 4, Spread: 2}
+	cmds := layoutShadowed(clay.Vector2{X: 100, Y: 100}, shadow)
+	// Outer shadows are drawn behind everything else, outside of the element's own clipping
+	expectCommandTypes(t, cmds,
+		clay.RENDER_COMMAND_TYPE_SHADOW, clay.RENDER_COMMAND_TYPE_SCISSOR_START, clay.RENDER_COMMAND_TYPE_RECTANGLE,
+		clay.RENDER_COMMAND_TYPE_RECTANGLE, clay.RENDER_COMMAND_TYPE_BORDER, clay.RENDER_COMMAND_TYPE_SCISSOR_END)
+	cmd := clay.RenderCommandArray_Get(&cmds, 0)
+	want := clay.ShadowRenderData{Color: clay.Color{A: 128}, Offset: clay.Vector2{Y: 4}, BlurRadius: 4, Spread: 2}
+	if cmd.RenderData.Shadow != want || cmd.BoundingBox != (clay.BoundingBox{X: 100, Y: 100, Width: 40, Height: 40}) {
+		t.Fatalf("expected the shadow %v around the element's box, got %v at %v", want, cmd.RenderData.Shadow, cmd.BoundingBox)
+	}
+
+	// Inset shadows are drawn over the background, beneath the children and border
+	shadow.Inset = true
+	expectCommandTypes(t, layoutShadowed(clay.Vector2{X: 100, Y: 100}, shadow),
+		clay.RENDER_COMMAND_TYPE_SCISSOR_START, clay.RENDER_COMMAND_TYPE_RECTANGLE, clay.RENDER_COMMAND_TYPE_SHADOW,
+		clay.RENDER_COMMAND_TYPE_RECTANGLE, clay.RENDER_COMMAND_TYPE_BORDER, clay.RENDER_COMMAND_TYPE_SCISSOR_END)
+
+	// Transparent shadows aren't drawn at all
+	expectCommandTypes(t, layoutShadowed(clay.Vector2{X: 100, Y: 100}, clay.ShadowElementConfig{BlurRadius: 4}),
+		clay.RENDER_COMMAND_TYPE_SCISSOR_START, clay.RENDER_COMMAND_TYPE_RECTANGLE,
+		clay.RENDER_COMMAND_TYPE_RECTANGLE, clay.RENDER_COMMAND_TYPE_BORDER, clay.RENDER_COMMAND_TYPE_SCISSOR_END)
+}
+
+func TestShadowCulling(t *testing.T) {
+	newTestContext(t)
+	tests := []struct {
+		name     string
+		position clay.Vector2
+		shadow   clay.ShadowElementConfig
+		drawn    bool
+	}{
+		// The element is just off the right edge, but its shadow is offset and blurred back onto the screen
+		{"outer", clay.Vector2{X: 420, Y: 100}, clay.ShadowElementConfig{Color: clay.Color{A: 128}, Offset: clay.Vector2{X: -20}, BlurRadius: 10}, true},
+		{"spread", clay.Vector2{X: 410, Y: 100}, clay.ShadowElementConfig{Color: clay.Color{A: 128}, Spread: 20}, true},
+		{"too far", clay.Vector2{X: 440, Y: 100}, clay.ShadowElementConfig{Color: clay.Color{A: 128}, Offset: clay.Vector2{X: -20}, BlurRadius: 10}, false},
+		// Inset shadows are culled with the element, however far they are offset
+		{"inset", clay.Vector2{X: 420, Y: 100}, clay.ShadowElementConfig{Color: clay.Color{A: 128}, Offset: clay.Vector2{X: -40}, BlurRadius: 10, Inset: true}, false},
+		{"inset visible", clay.Vector2{X: 390, Y: 100}, clay.ShadowElementConfig{Color: clay.Color{A: 128}, Inset: true}, true},
+	}
+	for _, test := range tests {
+		cmds := layoutShadowed(test.position, test.shadow)
+		var drawn bool
+		for cmd := range cmds.Iter() {
+			drawn = drawn || cmd.CommandType == clay.RENDER_COMMAND_TYPE_SHADOW
+		}
+		if drawn != test.drawn {
+			t.Errorf("%s: expected drawn to be %v, got %v", test.name, test.drawn, drawn)
+		}
+	}
+}
+
+func TestShadowSoftwareRenderer(t *testing.T) {
+	img := renderElement(t, 4, 2, clay.ElementDeclaration{
+		BackgroundColor: clay.Color{R: 255, A: 128},
+		Shadow:          clay.ShadowElementConfig{Color: white},
+	})
+	// Outer shadows are drawn underneath the element as well, so they show through its translucent background
+	blended := []color.RGBA{{255, 127, 127, 255}, {255, 127, 127, 255}}
+	expectPixels(t, img, row(0, 0, 3), blended)
+	expectPixels(t, img, row(1, 0, 3), blended)
+}
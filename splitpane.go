@@ -0,0 +1,278 @@
+package clay
+
+import "time"
+
+const splitPaneDoubleClickTime = 500 * time.Millisecond
+
+type SplitPaneConfig struct {
+	// LEFT_TO_RIGHT places the panes side by side with vertical dividers, TOP_TO_BOTTOM stacks them with horizontal dividers.
+	Direction LayoutDirection
+	// Thickness of the dividers between panes in pixels, defaults to 4.
+	DividerSize       float32
+	DividerColor      Color
+	DividerHoverColor Color
+}
+
+type SplitPane struct {
+	// Initial share of the container taken by this pane. Panes with a Ratio of 0 split the unclaimed share equally.
+	Ratio float32
+	// Limits for the size of the pane along the split direction in pixels, applied when dividers are dragged and when the
+	// container is resized. A MaxSize of 0 means no limit.
+	MinSize, MaxSize float32
+	// Double-clicking a divider next to a collapsible pane collapses it, and double-clicking it again restores it.
+	// Clicks are timed with the frame times passed to SetDeltaTime.
+	Collapsible bool
+	// Declares the contents of the pane.
+	Content func()
+}
+
+type splitPaneState struct {
+	ratios    []float32
+	collapsed []float32 // ratio before collapsing, or 0 when expanded
+	available float32   // space shared by the panes in the last layout
+	panes     []SplitPane
+
+	dragging      int // index of the divider being dragged, or -1
+	pointerOrigin float32
+	sizesOrigin   [2]float32
+	sinceClick    time.Duration // frame time since the last press on a divider
+	lastClicked   int           // index of the divider pressed last, or -1
+}
+
+func splitPaneStateFor(id ElementId) *splitPaneState {
+	st, created := elementState[splitPaneState](id, true)
+	if created {
+		st.dragging = -1
+		st.lastClicked = -1
+	}
+	return st
+}
+
+func splitPaneId(id ElementId, index int) ElementId {
+	return __HashStringWithOffset(toString("SplitPane"), uint32(index), id.Id)
+}
+
+func splitDividerId(id ElementId, index int) ElementId {
+	return __HashStringWithOffset(toString("SplitDivider"), uint32(index), id.Id)
+}
+
+// SplitPaneRatios returns a copy of the current ratios of the split container with the given id, e.g. to persist them
+// between sessions. It returns nil if the container hasn't been declared yet.
+func SplitPaneRatios(id ElementId) []float32 {
+	st, _ := elementState[splitPaneState](id, false)
+	if st == nil {
+		return nil
+	}
+	return append([]float32(nil), st.ratios...)
+}
+
+// SetSplitPaneRatios replaces the ratios of the split container with the given id, e.g. to restore them from a previous session.
+// The ratios are applied when the container is next declared with the same number of panes.
+func SetSplitPaneRatios(id ElementId, ratios []float32) {
+	st := splitPaneStateFor(id)
+	st.ratios = append(st.ratios[:0], ratios...)
+	st.collapsed = make([]float32, len(ratios))
+}
+
+// SplitPanes declares a container with the given id that divides its space between panes, separated by dividers that can be
+// dragged with the pointer. The ratios between the panes are kept across frames. The container is laid out according to decl,
+// except for its layout direction and child gap, which come from config.
+func SplitPanes(id ElementId, config SplitPaneConfig, decl ElementDeclaration, panes ...SplitPane) {
+	if config.DividerSize == 0 {
+		config.DividerSize = 4
+	}
+	st := splitPaneStateFor(id)
+	st.panes = panes
+	if len(st.ratios) != len(panes) {
+		st.reset()
+	}
+	horizontal := config.Direction == LEFT_TO_RIGHT
+	if data := GetElementData(id); data.Found {
+		size, padding := data.BoundingBox.Height, float32(decl.Layout.Padding.Top)+float32(decl.Layout.Padding.Bottom)
+		if horizontal {
+			size, padding = data.BoundingBox.Width, float32(decl.Layout.Padding.Left)+float32(decl.Layout.Padding.Right)
+		}
+		st.available = max(size-padding-config.DividerSize*float32(len(panes)-1), 0)
+		if st.dragging < 0 {
+			st.constrain()
+		}
+	}
+	st.handlePointer(id, horizontal)
+
+	decl.Layout.LayoutDirection = config.Direction
+	decl.Layout.ChildGap = uint16(config.DividerSize)
+	UI(id)(decl, func() {
+		for i, pane := range panes {
+			sizing := Sizing{Width: SizingPercent(st.ratios[i]), Height: SizingGrow(0)}
+			if !horizontal {
+				sizing = Sizing{Width: SizingGrow(0), Height: SizingPercent(st.ratios[i])}
+			}
+			UI(splitPaneId(id, i))(ElementDeclaration{
+				Layout: LayoutConfig{Sizing: sizing},
+				Clip:   ClipElementConfig{Horizontal: true, Vertical: true},
+			}, func() {
+				if pane.Content != nil {
+					pane.Content()
+				}
+				if i < len(panes)-1 {
+					st.declareDivider(id, i, config, horizontal)
+				}
+			})
+		}
+	})
+}
+
+// declareDivider declares the divider after pane i as a floating element over the child gap, so it doesn't take space
+// from the panes' percentages.
+func (st *splitPaneState) declareDivider(id ElementId, i int, config SplitPaneConfig, horizontal bool) {
+	dividerId := splitDividerId(id, i)
+	color := config.DividerColor
+	if st.dragging == i || PointerOver(dividerId) {
+		color = config.DividerHoverColor
+	}
+	decl := ElementDeclaration{
+		BackgroundColor: color,
+		Layout:          LayoutConfig{Sizing: Sizing{Width: SizingFixed(config.DividerSize), Height: SizingGrow(0)}},
+		Floating: FloatingElementConfig{
+			AttachTo:     ATTACH_TO_PARENT,
+			AttachPoints: FloatingAttachPoints{Element: ATTACH_POINT_LEFT_TOP, Parent: ATTACH_POINT_RIGHT_TOP},
+		},
+	}
+	if !horizontal {
+		decl.Layout.Sizing = Sizing{Width: SizingGrow(0), Height: SizingFixed(config.DividerSize)}
+		decl.Floating.AttachPoints.Parent = ATTACH_POINT_LEFT_BOTTOM
+	}
+	UI(dividerId)(decl, nil)
+}
+
+func (st *splitPaneState) handlePointer(id ElementId, horizontal bool) {
+	pointer := GetCurrentContext().pointerInfo
+	st.sinceClick += frameTime()
+	position := pointer.Position.Y
+	if horizontal {
+		position = pointer.Position.X
+	}
+	switch {
+	case pointer.State == POINTER_DATA_PRESSED_THIS_FRAME:
+		for i := range len(st.ratios) - 1 {
+			if !PointerOver(splitDividerId(id, i)) {
+				continue
+			}
+			if st.lastClicked == i && st.sinceClick < splitPaneDoubleClickTime {
+				st.toggleCollapsed(i)
+				st.lastClicked = -1
+				return
+			}
+			st.sinceClick, st.lastClicked = 0, i
+			st.dragging = i
+			st.pointerOrigin = position
+			st.sizesOrigin = [2]float32{st.ratios[i] * st.available, st.ratios[i+1] * st.available}
+			return
+		}
+	case pointer.State == POINTER_DATA_PRESSED && st.dragging >= 0 && st.available > 0:
+		i := st.dragging
+		before, after := st.panes[i], st.panes[i+1]
+		total := st.sizesOrigin[0] + st.sizesOrigin[1]
+		// The divider only moves space between its two neighbours, within both of their limits
+		low := max(before.MinSize, total-maxSize(after, total))
+		high := min(maxSize(before, total), total-after.MinSize)
+		size := min(max(st.sizesOrigin[0]+position-st.pointerOrigin, low), high)
+		st.ratios[i], st.ratios[i+1] = size/st.available, (total-size)/st.available
+		st.collapsed[i], st.collapsed[i+1] = 0, 0
+	case pointer.State == POINTER_DATA_RELEASED_THIS_FRAME || pointer.State == POINTER_DATA_RELEASED:
+		st.dragging = -1
+	}
+}
+
+// toggleCollapsed collapses the pane before divider i if it is collapsible, otherwise the pane after it, giving its
+// space to the other neighbour. If that pane is already collapsed, it is restored instead.
+func (st *splitPaneState) toggleCollapsed(i int) {
+	pane, neighbour := i, i+1
+	if !st.panes[i].Collapsible || st.collapsed[i+1] > 0 {
+		pane, neighbour = i+1, i
+	}
+	if !st.panes[pane].Collapsible {
+		return
+	}
+	if restored := st.collapsed[pane]; restored > 0 {
+		restored = min(restored, st.ratios[neighbour])
+		st.ratios[pane], st.ratios[neighbour] = restored, st.ratios[neighbour]-restored
+		st.collapsed[pane] = 0
+		return
+	}
+	st.collapsed[pane] = st.ratios[pane]
+	st.ratios[neighbour] += st.ratios[pane]
+	st.ratios[pane] = 0
+}
+
+// reset gives every pane its configured Ratio, splitting whatever is left equally between the panes without one.
+func (st *splitPaneState) reset() {
+	st.ratios = make([]float32, len(st.panes))
+	st.collapsed = make([]float32, len(st.panes))
+	st.dragging = -1
+	claimed, unset := float32(0), 0
+	for _, pane := range st.panes {
+		claimed += pane.Ratio
+		if pane.Ratio <= 0 {
+			unset++
+		}
+	}
+	for i, pane := range st.panes {
+		st.ratios[i] = pane.Ratio
+		if pane.Ratio <= 0 {
+			st.ratios[i] = max(1-claimed, 0) / float32(unset)
+		}
+	}
+}
+
+// constrain moves space between panes until every expanded pane is within its limits, as far as the available space allows.
+func (st *splitPaneState) constrain() {
+	if st.available <= 0 {
+		return
+	}
+	sizes := make([]float32, len(st.ratios))
+	total := float32(0)
+	for i, ratio := range st.ratios {
+		sizes[i] = ratio * st.available
+		total += sizes[i]
+	}
+	for range len(sizes) {
+		excess := float32(0)
+		for i, pane := range st.panes {
+			if st.collapsed[i] > 0 {
+				continue
+			}
+			clamped := min(max(sizes[i], pane.MinSize), maxSize(pane, total))
+			excess += sizes[i] - clamped
+			sizes[i] = clamped
+		}
+		if abs32(excess) < 0.5 {
+			break
+		}
+		// Give the excess to, or take the deficit from, the panes that still have room, in proportion to their size
+		room := float32(0)
+		for i, pane := range st.panes {
+			if st.collapsed[i] == 0 && (excess > 0 && sizes[i] < maxSize(pane, total) || excess < 0 && sizes[i] > pane.MinSize) {
+				room += max(sizes[i], 1)
+			}
+		}
+		if room == 0 {
+			break
+		}
+		for i, pane := range st.panes {
+			if st.collapsed[i] == 0 && (excess > 0 && sizes[i] < maxSize(pane, total) || excess < 0 && sizes[i] > pane.MinSize) {
+				sizes[i] += excess * max(sizes[i], 1) / room
+			}
+		}
+	}
+	for i := range sizes {
+		st.ratios[i] = sizes[i] / st.available
+	}
+}
+
+func maxSize(pane SplitPane, total float32) float32 {
+	if pane.MaxSize > 0 {
+		return pane.MaxSize
+	}
+	return total
+}
@@ -0,0 +1,138 @@
+package clay_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutSplitPanes lays out a 400 pixel wide split container with a 4 pixel divider, 100 pixels from the left edge.
+func layoutSplitPanes(panes ...clay.SplitPane) {
+	clay.BeginLayout()
+	clay.UI()(clay.ElementDeclaration{Layout: clay.LayoutConfig{Padding: clay.Padding{Left: 100}}}, func() {
+		clay.SplitPanes(clay.ID("Split"), clay.SplitPaneConfig{Direction: clay.LEFT_TO_RIGHT}, clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(404), Height: clay.SizingFixed(100)}},
+		}, panes...)
+	})
+	clay.EndLayout()
+}
+
+func paneWidth(index int) float32 {
+	return clay.GetElementData(clay.IDI("SplitPaneTest", uint32(index))).BoundingBox.Width
+}
+
+func splitTestPane(index int, pane clay.SplitPane) clay.SplitPane {
+	pane.Content = func() {
+		clay.UI(clay.IDI("SplitPaneTest", uint32(index)))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingGrow(0), Height: clay.SizingGrow(0)}},
+		}, nil)
+	}
+	return pane
+}
+
+func TestSplitPanesDrag(t *testing.T) {
+	newTestContext(t)
+	clay.SetPointerState(clay.Vector2{}, false)
+	panes := []clay.SplitPane{
+		splitTestPane(0, clay.SplitPane{Ratio: 0.25, MinSize: 50}),
+		splitTestPane(1, clay.SplitPane{MaxSize: 350}),
+	}
+	layoutSplitPanes(panes...)
+	if paneWidth(0) != 100 || paneWidth(1) != 300 {
+		t.Fatalf("expected the configured ratio: got %v and %v", paneWidth(0), paneWidth(1))
+	}
+
+	// The divider sits in the gap after the first pane
+	clay.SetPointerState(clay.Vector2{X: 202, Y: 50}, true)
+	layoutSplitPanes(panes...)
+	clay.SetPointerState(clay.Vector2{X: 172, Y: 50}, true)
+	layoutSplitPanes(panes...)
+	layoutSplitPanes(panes...)
+	if paneWidth(0) != 70 || paneWidth(1) != 330 {
+		t.Fatalf("dragging should move the divider by 30 pixels: got %v and %v", paneWidth(0), paneWidth(1))
+	}
+	clay.SetPointerState(clay.Vector2{X: 100, Y: 50}, true)
+	layoutSplitPanes(panes...)
+	layoutSplitPanes(panes...)
+	if paneWidth(0) != 50 || paneWidth(1) != 350 {
+		t.Fatalf("dragging should stop at the MinSize: got %v and %v", paneWidth(0), paneWidth(1))
+	}
+	clay.SetPointerState(clay.Vector2{X: 100, Y: 50}, false)
+	if ratios := clay.SplitPaneRatios(clay.ID("Split")); math.Abs(float64(ratios[0]-0.125)) > 1e-4 {
+		t.Fatalf("expected the first pane to take 12.5%%, got %v", ratios)
+	}
+
+	// Lowering a limit moves space to the other panes on the next layout
+	panes[1] = splitTestPane(1, clay.SplitPane{MaxSize: 250})
+	layoutSplitPanes(panes...)
+	layoutSplitPanes(panes...)
+	if paneWidth(0) != 150 || paneWidth(1) != 250 {
+		t.Fatalf("the second pane should be clamped to its MaxSize: got %v and %v", paneWidth(0), paneWidth(1))
+	}
+}
+
+func TestSplitPanesCollapse(t *testing.T) {
+	newTestContext(t)
+	panes := []clay.SplitPane{
+		splitTestPane(0, clay.SplitPane{Ratio: 0.25, Collapsible: true}),
+		splitTestPane(1, clay.SplitPane{}),
+	}
+	clay.SetPointerState(clay.Vector2{}, false)
+	layoutSplitPanes(panes...)
+	click := func() {
+		clay.SetPointerState(clay.Vector2{X: 202, Y: 50}, true)
+		layoutSplitPanes(panes...)
+		clay.SetPointerState(clay.Vector2{X: 202, Y: 50}, false)
+		layoutSplitPanes(panes...)
+	}
+	click()
+	click()
+	if paneWidth(0) != 0 || paneWidth(1) != 400 {
+		t.Fatalf("double-clicking the divider should collapse the first pane: got %v and %v", paneWidth(0), paneWidth(1))
+	}
+	// The collapsed pane leaves the divider at the left edge
+	clay.SetPointerState(clay.Vector2{X: 102, Y: 50}, false)
+	click = func() {
+		clay.SetPointerState(clay.Vector2{X: 102, Y: 50}, true)
+		layoutSplitPanes(panes...)
+		clay.SetPointerState(clay.Vector2{X: 102, Y: 50}, false)
+		layoutSplitPanes(panes...)
+	}
+	click()
+	click()
+	layoutSplitPanes(panes...)
+	if paneWidth(0) != 100 || paneWidth(1) != 300 {
+		t.Fatalf("double-clicking again should restore the first pane: got %v and %v", paneWidth(0), paneWidth(1))
+	}
+}
+
+func TestSplitPanesDoubleClickTime(t *testing.T) {
+	newTestContext(t)
+	panes := []clay.SplitPane{
+		splitTestPane(0, clay.SplitPane{Ratio: 0.25, Collapsible: true}),
+		splitTestPane(1, clay.SplitPane{}),
+	}
+	clay.SetPointerState(clay.Vector2{}, false)
+	layoutSplitPanes(panes...)
+	click := func() {
+		clay.SetPointerState(clay.Vector2{X: 202, Y: 50}, true)
+		layoutSplitPanes(panes...)
+		clay.SetPointerState(clay.Vector2{X: 202, Y: 50}, false)
+		layoutSplitPanes(panes...)
+	}
+	// Clicks are timed in frames, so two 300ms frames between them is too slow for a double-click
+	clay.SetDeltaTime(0.3)
+	click()
+	click()
+	if paneWidth(0) != 100 {
+		t.Fatalf("two slow clicks shouldn't collapse the first pane: got %v", paneWidth(0))
+	}
+	clay.SetDeltaTime(0.2)
+	click()
+	click()
+	layoutSplitPanes(panes...)
+	if paneWidth(0) != 0 {
+		t.Fatalf("two clicks 400ms apart should collapse the first pane: got %v", paneWidth(0))
+	}
+}
@@ -0,0 +1,95 @@
+package clay
+
+import "reflect"
+
+// Element state is kept while its element is declared, and for this many layouts after it was last declared,
+// the same number of generations the measure text cache keeps unused entries for.
+const defaultStateGenerations = 2
+
+type stateKey struct {
+	id  uint32
+	typ reflect.Type
+}
+
+type stateEntry struct {
+	value any
+	// The layout generation State was last called in for this entry.
+	touched uint32
+}
+
+type stateStore struct {
+	entries     map[stateKey]*stateEntry
+	generations uint32
+	collected   uint32 // generation of the last collection
+}
+
+var stateContexts = map[*Context]*stateStore{}
+
+// currentStates returns the element state of the current context, after collecting state that has expired once per layout.
+func currentStates() *stateStore {
+	context := GetCurrentContext()
+	ss, ok := stateContexts[context]
+	if !ok {
+		ss = &stateStore{entries: map[stateKey]*stateEntry{}, generations: defaultStateGenerations}
+		stateContexts[context] = ss
+	}
+	if ss.collected != context.generation {
+		ss.collected = context.generation
+		ss.collect(context.generation)
+	}
+	return ss
+}
+
+// State returns the state of type T attached to the element with the given id, creating a zero value the first time it is requested.
+// The state persists across frames until the element hasn't been declared, and State hasn't been called for it, for
+// a number of layouts set by SetStateGenerations, after which it is discarded. Elements can hold state of several types at once.
+func State[T any](id ElementId) *T {
+	st, _ := elementState[T](id, true)
+	return st
+}
+
+// DeleteState discards the state of type T attached to the element with the given id, so the next call to State returns a zero value.
+func DeleteState[T any](id ElementId) {
+	delete(currentStates().entries, stateKey{id: id.Id, typ: reflect.TypeFor[T]()})
+}
+
+// SetStateGenerations sets the number of layouts element state is kept for after its element was last declared. Defaults to 2.
+func SetStateGenerations(generations uint32) {
+	currentStates().generations = generations
+}
+
+// elementState returns the state of type T attached to the element with the given id, and whether it was created by this call.
+// If create is false and there is no state, it returns nil.
+func elementState[T any](id ElementId, create bool) (*T, bool) {
+	ss := currentStates()
+	key := stateKey{id: id.Id, typ: reflect.TypeFor[T]()}
+	entry, ok := ss.entries[key]
+	if !ok {
+		if !create {
+			return nil, false
+		}
+		entry = &stateEntry{value: new(T)}
+		ss.entries[key] = entry
+	}
+	entry.touched = GetCurrentContext().generation
+	return entry.value.(*T), !ok
+}
+
+// collect discards the state of elements that haven't been declared or requested within the configured number of generations.
+func (ss *stateStore) collect(generation uint32) {
+	for key, entry := range ss.entries {
+		lastSeen := entry.touched
+		// Declared elements are stamped with the generation after the one they were declared in. Collection runs during
+		// declaration, so elements that have already been declared in this layout only tell whether they were in the last one.
+		if item := __GetHashMapItem(key.id); item != &LayoutElementHashMapItem_DEFAULT && item.Generation > 0 {
+			if item.Generation <= generation {
+				lastSeen = max(lastSeen, item.Generation-1)
+			} else if !item.Appeared {
+				lastSeen = max(lastSeen, generation-1)
+			}
+		}
+		if generation-lastSeen > ss.generations {
+			delete(ss.entries, key)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package clay_test
+
+import (
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+type counterState struct {
+	count int
+}
+
+// layoutCounter declares the element with the given id if declared is true, and returns its counter after incrementing it.
+func layoutCounter(id clay.ElementId, declared bool) int {
+	clay.BeginLayout()
+	count := 0
+	if declared {
+		clay.UI(id)(clay.ElementDeclaration{}, func() {
+			st := clay.State[counterState](id)
+			st.count++
+			count = st.count
+		})
+	}
+	clay.EndLayout()
+	return count
+}
+
+func TestStatePersists(t *testing.T) {
+	newTestContext(t)
+	id := clay.ID("Counter")
+	for i := 1; i <= 3; i++ {
+		if got := layoutCounter(id, true); got != i {
+			t.Fatalf("frame %d: expected count %d, got %d", i, i, got)
+		}
+	}
+	if got := *clay.State[int](id); got != 0 {
+		t.Errorf("state of a different type should be separate, got %d", got)
+	}
+	clay.DeleteState[counterState](id)
+	if got := layoutCounter(id, true); got != 1 {
+		t.Errorf("expected deleted state to restart at 1, got %d", got)
+	}
+}
+
+func TestStateCollected(t *testing.T) {
+	newTestContext(t)
+	id := clay.ID("Counter")
+	layoutCounter(id, true)
+	layoutCounter(id, true)
+	// Skipping fewer layouts than the default of 2 keeps the state
+	layoutCounter(id, false)
+	if got := layoutCounter(id, true); got != 3 {
+		t.Fatalf("expected state to survive a short gap, got count %d", got)
+	}
+	for range 3 {
+		layoutCounter(id, false)
+	}
+	if got := layoutCounter(id, true); got != 1 {
+		t.Errorf("expected state to be collected after a long gap, got count %d", got)
+	}
+}
+
+func TestStateInitialize(t *testing.T) {
+	memory := make([]byte, clay.MinMemorySize())
+	id := clay.ID("Counter")
+	for range 2 {
+		// Initializing a context in the same memory again starts it without the state and overlays of the previous one
+		clay.Initialize(clay.CreateArenaWithCapacityAndMemory(memory), clay.Dimensions{Width: 400, Height: 300}, clay.ErrorHandler{ErrorHandlerFunction: handleClayError})
+		if clay.OverlayOpen(clay.ID("Menu")) {
+			t.Fatal("expected no overlays to be open")
+		}
+		layoutCounter(id, true)
+		if got := layoutCounter(id, true); got != 2 {
+			t.Fatalf("expected the count to start over, got %d", got)
+		}
+		clay.OpenOverlay(clay.ID("Menu"))
+	}
+}
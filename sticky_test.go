@@ -0,0 +1,111 @@
+package clay_test
+
+import (
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutStickyList lays out a 100 pixel tall scroll container scrolled to scrollY, holding a red 20 pixel sticky header and three
+// blue 30 pixel rows for each of the sections A and B. With sections, each header and its rows are wrapped in a section element.
+func layoutStickyList(scrollY float32, sticky clay.StickyElementConfig, sections bool) clay.RenderCommandArray {
+	var cmds clay.RenderCommandArray
+	// Laid out twice, because a scroll container only exists to take the scroll position once it has been declared
+	for range 2 {
+		clay.ScrollTo(clay.ID("List"), clay.Vector2{Y: scrollY}, false)
+		clay.UpdateScrollContainers(false, clay.Vector2{}, 1.0/60)
+		clay.BeginLayout()
+		clay.UI(clay.ID("List"))(clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(100)}, LayoutDirection: clay.TOP_TO_BOTTOM},
+			Clip:   clay.ClipElementConfig{Vertical: true, ChildOffset: clay.GetScrollOffset()},
+		}, func() {
+			for _, section := range []string{"A", "B"} {
+				content := func() {
+					clay.UI(clay.ID("Header "+section))(clay.ElementDeclaration{
+						Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingGrow(0), Height: clay.SizingFixed(20)}},
+						BackgroundColor: red,
+						Sticky:          sticky,
+					}, nil)
+					for range 3 {
+						clay.UI()(clay.ElementDeclaration{
+							Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingGrow(0), Height: clay.SizingFixed(30)}},
+							BackgroundColor: blue,
+						}, nil)
+					}
+				}
+				if sections {
+					clay.UI(clay.ID("Section "+section))(clay.ElementDeclaration{
+						Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingGrow(0)}, LayoutDirection: clay.TOP_TO_BOTTOM},
+					}, content)
+				} else {
+					content()
+				}
+			}
+		})
+		cmds = clay.EndLayout()
+	}
+	return cmds
+}
+
+// headerY returns the top edge of the header of the provided section.
+func headerY(section string) float32 {
+	return clay.GetElementData(clay.ID("Header " + section)).BoundingBox.Y
+}
+
+func TestStickyHeaders(t *testing.T) {
+	newTestContext(t)
+	sticky := clay.StickyElementConfig{Vertical: true}
+	tests := []struct {
+		scrollY, a, b float32
+	}{
+		{0, 0, 110},
+		// Header A sticks to the top while its rows scroll underneath it
+		{-50, 0, 60},
+		// Header B pushes header A out of the way as it arrives
+		{-100, -10, 10},
+		{-120, -30, 0},
+	}
+	for _, test := range tests {
+		layoutStickyList(test.scrollY, sticky, false)
+		if a, b := headerY("A"), headerY("B"); a != test.a || b != test.b {
+			t.Errorf("scrolled to %v: expected headers at %v and %v, got %v and %v", test.scrollY, test.a, test.b, a, b)
+		}
+	}
+}
+
+func TestStickyOffset(t *testing.T) {
+	newTestContext(t)
+	layoutStickyList(-50, clay.StickyElementConfig{Vertical: true, Offset: clay.Vector2{Y: 5}}, false)
+	if a := headerY("A"); a != 5 {
+		t.Fatalf("expected header A to keep 5 pixels from the top, got %v", a)
+	}
+	// Without vertical, the header scrolls with the content
+	layoutStickyList(-50, clay.StickyElementConfig{Horizontal: true}, false)
+	if a := headerY("A"); a != -50 {
+		t.Fatalf("expected a horizontal sticky header not to stick vertically, got %v", a)
+	}
+}
+
+func TestStickySections(t *testing.T) {
+	newTestContext(t)
+	// Section A spans 0 to 110, so its header can't go below 90 even though nothing pushes it
+	layoutStickyList(-100, clay.StickyElementConfig{Vertical: true}, true)
+	if a, b := headerY("A"), headerY("B"); a != -10 || b != 10 {
+		t.Fatalf("expected header A to stay inside its section, got %v and %v", a, b)
+	}
+}
+
+func TestStickyDrawOrder(t *testing.T) {
+	newTestContext(t)
+	cmds := layoutStickyList(-50, clay.StickyElementConfig{Vertical: true}, false)
+	// Header A is drawn after the rows of section A that scroll underneath it
+	var colors []clay.Color
+	for cmd := range cmds.Iter() {
+		if cmd.CommandType == clay.RENDER_COMMAND_TYPE_RECTANGLE {
+			colors = append(colors, cmd.RenderData.Rectangle.BackgroundColor)
+		}
+	}
+	if len(colors) != 8 || colors[0] != blue || colors[6] != red || colors[7] != red {
+		t.Fatalf("expected the rows to be drawn before the headers, got %v", colors)
+	}
+}
@@ -0,0 +1,629 @@
+package clay
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+)
+
+const maxTextInputUndo = 100
+
+type TextInputConfig struct {
+	// The font, size and color of the text. Wrapping is always disabled, lines are only broken at newlines.
+	TextConfig TextElementConfig
+	// Allows newlines to be entered with CLAY_KEY_ENTER. Single-line inputs report Enter as TextInputResult.Submitted instead.
+	Multiline bool
+	// Shown when the input is empty.
+	Placeholder      string
+	PlaceholderColor Color
+	CaretColor       Color
+	// Width of the caret in pixels, defaults to 1.
+	CaretWidth     float32
+	SelectionColor Color
+	// Maximum number of runes the input accepts, or 0 for no limit.
+	MaxLength int
+}
+
+type TextInputResult struct {
+	// The value was edited this frame.
+	Changed bool
+	// Enter was pressed in a single-line input since the last frame.
+	Submitted bool
+}
+
+type textInputSnapshot struct {
+	value         string
+	caret, anchor int
+}
+
+type textInputState struct {
+	value         string
+	synced        string
+	caret, anchor int // byte offsets into value
+	preferredX    float32
+	undo, redo    []textInputSnapshot
+	typing        bool
+	submitted     bool
+	dragging      bool
+	scroll        Vector2
+	config        TextInputConfig
+	lineHeight    float32
+	// Strings handed to Text are only referenced by arena memory, so they are kept alive here until they have been rendered.
+	retained []string
+}
+
+type textInputContext struct {
+	text           string
+	textGeneration uint32 // generation of the layout text was provided for
+	composition    string
+	clipboard      Clipboard
+}
+
+var textInputContexts = map[*Context]*textInputContext{}
+
+func currentTextInputContext() *textInputContext {
+	context := GetCurrentContext()
+	tc, ok := textInputContexts[context]
+	if !ok {
+		tc = &textInputContext{clipboard: &MemoryClipboard{}}
+		textInputContexts[context] = tc
+	}
+	// Text that no focused text input consumed during the layout it was provided for is discarded, instead of being
+	// inserted into whichever input is focused next
+	if tc.text != "" && tc.textGeneration != context.generation {
+		tc.text = ""
+	}
+	return tc
+}
+
+// SetTextInput provides the text typed since the last frame. Call it once per frame before BeginLayout, next to SetKeyboardState.
+// The text is inserted at the caret of the focused text input, and is dropped if no text input is focused during the next layout.
+func SetTextInput(text string) {
+	tc := currentTextInputContext()
+	tc.text = text
+	tc.textGeneration = GetCurrentContext().generation + 1
+}
+
+// SetCompositionText provides the text an input method editor is currently composing. It is displayed underlined at the caret
+// of the focused text input, but isn't part of its value until the IME commits it through SetTextInput. Pass "" when composition ends.
+func SetCompositionText(text string) {
+	currentTextInputContext().composition = text
+}
+
+// TextInputCaret returns the bounding box of the caret of the text input with the given id from the last layout.
+// It is intended for positioning IME candidate windows.
+func TextInputCaret(id ElementId) (BoundingBox, bool) {
+	data := GetElementData(textInputCaretId(id))
+	return data.BoundingBox, data.Found
+}
+
+func textInputCaretId(id ElementId) ElementId {
+	return __HashString(toString("TextInputCaret"), id.Id)
+}
+
+func textInputLineId(id ElementId, line int) ElementId {
+	return __HashStringWithOffset(toString("TextInputLine"), uint32(line), id.Id)
+}
+
+// TextInput declares an editable text field with the given id. The element is focusable and is laid out according to decl,
+// except that it always clips its contents and stacks lines top to bottom.
+// value is read every frame and updated when the user edits it, so the application can also replace it at any time.
+func TextInput(id ElementId, value *string, config TextInputConfig, decl ElementDeclaration) TextInputResult {
+	tc := currentTextInputContext()
+	st, created := elementState[textInputState](id, true)
+	if created {
+		*st = textInputState{value: *value, synced: *value, caret: len(*value), anchor: len(*value), preferredX: -1}
+	}
+	if *value != st.synced {
+		st.reset(*value)
+	}
+	if config.CaretWidth == 0 {
+		config.CaretWidth = 1
+	}
+	config.TextConfig.WrapMode = TEXT_WRAP_NONE
+	st.config = config
+	st.lineHeight = float32(config.TextConfig.LineHeight)
+	if st.lineHeight == 0 {
+		st.lineHeight = measureString("Mg", &config.TextConfig).Height
+	}
+
+	focused := GetFocusedElementId().Id == id.Id
+	if focused && tc.text != "" {
+		st.insert(tc.text, true)
+		tc.text = ""
+	}
+	st.handlePointer(id)
+
+	var result TextInputResult
+	if st.submitted {
+		result.Submitted = true
+		st.submitted = false
+	}
+	if st.value != st.synced {
+		*value = st.value
+		st.synced = st.value
+		result.Changed = true
+	}
+	if focused {
+		st.scrollToCaret(id, decl.Layout.Padding)
+	}
+
+	composition := ""
+	if focused {
+		composition = strings.NewReplacer("\r", "", "\n", "").Replace(tc.composition)
+	}
+	decl.Layout.LayoutDirection = TOP_TO_BOTTOM
+	decl.Focus.Focusable = true
+	decl.Clip = ClipElementConfig{Horizontal: true, Vertical: config.Multiline, ChildOffset: st.scroll}
+	st.retained = nil
+	UI(id)(decl, func() {
+		OnKey(textInputOnKey, nil)
+		st.declareLines(id, focused, composition)
+	})
+	return result
+}
+
+func (st *textInputState) declareLines(id ElementId, focused bool, composition string) {
+	textConfig := TextConfig(st.config.TextConfig)
+	if st.value == "" && composition == "" {
+		st.declareLine(id, 0, func() {
+			if focused {
+				st.declareCaret(id)
+			}
+			if st.config.Placeholder != "" {
+				placeholderConfig := st.config.TextConfig
+				placeholderConfig.TextColor = st.config.PlaceholderColor
+				st.text(st.config.Placeholder, TextConfig(placeholderConfig))
+			}
+		})
+		return
+	}
+	selectionStart, selectionEnd := st.selection()
+	if composition != "" {
+		selectionStart, selectionEnd = st.caret, st.caret
+	}
+	lineStart := 0
+	for line := 0; ; line++ {
+		lineEnd := len(st.value)
+		if i := strings.IndexByte(st.value[lineStart:], '\n'); i >= 0 {
+			lineEnd = lineStart + i
+		}
+		st.declareLine(id, line, func() {
+			// Split the line into runs before, inside and after the selection. The caret sits on one of the run boundaries.
+			bounds := [4]int{lineStart, min(max(selectionStart, lineStart), lineEnd), min(max(selectionEnd, lineStart), lineEnd), lineEnd}
+			caretDeclared := !focused
+			for i, bound := range bounds {
+				if !caretDeclared && bound == st.caret {
+					if composition != "" {
+						UI()(ElementDeclaration{
+							Border: BorderElementConfig{Color: st.config.TextConfig.TextColor, Width: BorderWidth{Bottom: 1}},
+						}, func() {
+							st.text(composition, textConfig)
+						})
+					}
+					st.declareCaret(id)
+					caretDeclared = true
+				}
+				if i == len(bounds)-1 || bound == bounds[i+1] {
+					continue
+				}
+				run := st.value[bound:bounds[i+1]]
+				if i == 1 {
+					UI()(ElementDeclaration{BackgroundColor: st.config.SelectionColor}, func() {
+						st.text(run, textConfig)
+					})
+				} else {
+					st.text(run, textConfig)
+				}
+			}
+		})
+		if lineEnd == len(st.value) {
+			break
+		}
+		lineStart = lineEnd + 1
+	}
+}
+
+func (st *textInputState) declareLine(id ElementId, line int, children func()) {
+	UI(textInputLineId(id, line))(ElementDeclaration{
+		Layout: LayoutConfig{Sizing: Sizing{Height: SizingFixed(st.lineHeight)}},
+	}, children)
+}
+
+// declareCaret places the caret inside a zero width element, so that it overflows instead of pushing the following text aside.
+func (st *textInputState) declareCaret(id ElementId) {
+	UI()(ElementDeclaration{
+		Layout: LayoutConfig{Sizing: Sizing{Width: SizingFixed(0), Height: SizingFixed(st.lineHeight)}},
+	}, func() {
+		UI(textInputCaretId(id))(ElementDeclaration{
+			Layout:          LayoutConfig{Sizing: Sizing{Width: SizingFixed(st.config.CaretWidth), Height: SizingFixed(st.lineHeight)}},
+			BackgroundColor: st.config.CaretColor,
+		}, nil)
+	})
+}
+
+func (st *textInputState) text(s string, config *TextElementConfig) {
+	st.retained = append(st.retained, s)
+	Text(s, config)
+}
+
+func textInputOnKey(id ElementId, keyEvent KeyEvent, _ any) bool {
+	st, _ := elementState[textInputState](id, false)
+	if st == nil {
+		return false
+	}
+	return st.handleKey(keyEvent)
+}
+
+func (st *textInputState) handleKey(keyEvent KeyEvent) bool {
+	shortcut := keyEvent.Modifiers&(KEY_MODIFIER_CTRL|KEY_MODIFIER_SUPER) != 0
+	word := keyEvent.Modifiers&(KEY_MODIFIER_CTRL|KEY_MODIFIER_ALT) != 0
+	extend := keyEvent.Modifiers&KEY_MODIFIER_SHIFT != 0
+	start, end := st.selection()
+	switch keyEvent.Key {
+	case KEY_LEFT:
+		switch {
+		case start != end && !extend:
+			st.moveCaret(start, false)
+		case word:
+			st.moveCaret(previousWordBoundary(st.value, st.caret), extend)
+		default:
+			st.moveCaret(previousRuneBoundary(st.value, st.caret), extend)
+		}
+	case KEY_RIGHT:
+		switch {
+		case start != end && !extend:
+			st.moveCaret(end, false)
+		case word:
+			st.moveCaret(nextWordBoundary(st.value, st.caret), extend)
+		default:
+			st.moveCaret(nextRuneBoundary(st.value, st.caret), extend)
+		}
+	case KEY_HOME:
+		if shortcut {
+			st.moveCaret(0, extend)
+		} else {
+			st.moveCaret(lineStart(st.value, st.caret), extend)
+		}
+	case KEY_END:
+		if shortcut {
+			st.moveCaret(len(st.value), extend)
+		} else {
+			st.moveCaret(lineEnd(st.value, st.caret), extend)
+		}
+	case KEY_UP, KEY_DOWN:
+		if !st.config.Multiline {
+			return false
+		}
+		st.moveLine(keyEvent.Key == KEY_DOWN, extend)
+	case KEY_BACKSPACE:
+		if start == end {
+			if word {
+				st.anchor = previousWordBoundary(st.value, st.caret)
+			} else {
+				st.anchor = previousRuneBoundary(st.value, st.caret)
+			}
+		}
+		st.insert("", false)
+	case KEY_DELETE:
+		if start == end {
+			if word {
+				st.anchor = nextWordBoundary(st.value, st.caret)
+			} else {
+				st.anchor = nextRuneBoundary(st.value, st.caret)
+			}
+		}
+		st.insert("", false)
+	case KEY_ENTER:
+		if st.config.Multiline {
+			st.insert("\n", false)
+		} else {
+			st.submitted = true
+		}
+	case KEY_A:
+		if !shortcut {
+			return false
+		}
+		st.anchor, st.caret = 0, len(st.value)
+		st.typing = false
+	case KEY_C, KEY_X:
+		if !shortcut {
+			return false
+		}
+		if start != end {
+			currentTextInputContext().clipboard.WriteText(st.value[start:end])
+			if keyEvent.Key == KEY_X {
+				st.insert("", false)
+			}
+		}
+	case KEY_V:
+		if !shortcut {
+			return false
+		}
+		st.insert(currentTextInputContext().clipboard.ReadText(), false)
+	case KEY_Z:
+		if !shortcut {
+			return false
+		}
+		if extend {
+			st.redoEdit()
+		} else {
+			st.undoEdit()
+		}
+	case KEY_Y:
+		if !shortcut {
+			return false
+		}
+		st.redoEdit()
+	default:
+		return false
+	}
+	return true
+}
+
+func (st *textInputState) reset(value string) {
+	st.value, st.synced = value, value
+	st.caret = min(st.caret, len(value))
+	st.anchor = min(st.anchor, len(value))
+	st.undo, st.redo = nil, nil
+	st.typing = false
+}
+
+func (st *textInputState) selection() (start, end int) {
+	return min(st.caret, st.anchor), max(st.caret, st.anchor)
+}
+
+func (st *textInputState) moveCaret(position int, extend bool) {
+	st.caret = position
+	if !extend {
+		st.anchor = position
+	}
+	st.preferredX = -1
+	st.typing = false
+}
+
+// insert replaces the selection with text. Consecutive typing is merged into a single undo step.
+func (st *textInputState) insert(text string, typing bool) {
+	text = st.sanitize(text)
+	start, end := st.selection()
+	if st.config.MaxLength > 0 {
+		available := st.config.MaxLength - utf8.RuneCountInString(st.value) + utf8.RuneCountInString(st.value[start:end])
+		for utf8.RuneCountInString(text) > max(available, 0) {
+			_, size := utf8.DecodeLastRuneInString(text)
+			text = text[:len(text)-size]
+		}
+	}
+	if text == "" && start == end {
+		return
+	}
+	if !typing || !st.typing {
+		st.pushUndo()
+	}
+	st.redo = nil
+	st.value = st.value[:start] + text + st.value[end:]
+	st.caret = start + len(text)
+	st.anchor = st.caret
+	st.preferredX = -1
+	st.typing = typing
+}
+
+func (st *textInputState) sanitize(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '\n' && st.config.Multiline, r == '\t':
+			return r
+		case r == '\n' || r == '\r':
+			if st.config.Multiline {
+				return '\n'
+			}
+			return ' '
+		case unicode.IsControl(r):
+			return -1
+		}
+		return r
+	}, text)
+}
+
+func (st *textInputState) snapshot() textInputSnapshot {
+	return textInputSnapshot{value: st.value, caret: st.caret, anchor: st.anchor}
+}
+
+func (st *textInputState) restore(snapshot textInputSnapshot) {
+	st.value, st.caret, st.anchor = snapshot.value, snapshot.caret, snapshot.anchor
+	st.preferredX = -1
+	st.typing = false
+}
+
+func (st *textInputState) pushUndo() {
+	st.undo = append(st.undo, st.snapshot())
+	if len(st.undo) > maxTextInputUndo {
+		st.undo = st.undo[1:]
+	}
+}
+
+func (st *textInputState) undoEdit() {
+	if len(st.undo) == 0 {
+		return
+	}
+	st.redo = append(st.redo, st.snapshot())
+	st.restore(st.undo[len(st.undo)-1])
+	st.undo = st.undo[:len(st.undo)-1]
+}
+
+func (st *textInputState) redoEdit() {
+	if len(st.redo) == 0 {
+		return
+	}
+	st.undo = append(st.undo, st.snapshot())
+	st.restore(st.redo[len(st.redo)-1])
+	st.redo = st.redo[:len(st.redo)-1]
+}
+
+// moveLine moves the caret to the previous or next line, keeping it as close as possible to the column it started in.
+func (st *textInputState) moveLine(down, extend bool) {
+	start := lineStart(st.value, st.caret)
+	if st.preferredX < 0 {
+		st.preferredX = st.measure(st.value[start:st.caret])
+	}
+	preferredX := st.preferredX
+	var target int
+	switch {
+	case down && lineEnd(st.value, st.caret) == len(st.value):
+		target = len(st.value)
+	case down:
+		next := lineEnd(st.value, st.caret) + 1
+		target = next + st.offsetAtX(st.value[next:lineEnd(st.value, next)], preferredX)
+	case start == 0:
+		target = 0
+	default:
+		previous := lineStart(st.value, start-1)
+		target = previous + st.offsetAtX(st.value[previous:start-1], preferredX)
+	}
+	st.moveCaret(target, extend)
+	st.preferredX = preferredX
+}
+
+func (st *textInputState) handlePointer(id ElementId) {
+	pointer := GetCurrentContext().pointerInfo
+	switch {
+	case pointer.State == POINTER_DATA_PRESSED_THIS_FRAME && PointerOver(id):
+		st.dragging = true
+		st.moveCaret(st.offsetAtPoint(id, pointer.Position), false)
+	case pointer.State == POINTER_DATA_PRESSED && st.dragging:
+		st.moveCaret(st.offsetAtPoint(id, pointer.Position), true)
+	case pointer.State == POINTER_DATA_RELEASED_THIS_FRAME || pointer.State == POINTER_DATA_RELEASED:
+		st.dragging = false
+	}
+}
+
+// offsetAtPoint returns the byte offset closest to a point, using the line positions from the last layout.
+func (st *textInputState) offsetAtPoint(id ElementId, point Vector2) int {
+	start := 0
+	for line := 0; ; line++ {
+		end := lineEnd(st.value, start)
+		data := GetElementData(textInputLineId(id, line))
+		if !data.Found || point.Y < data.BoundingBox.Y+data.BoundingBox.Height || end == len(st.value) {
+			return start + st.offsetAtX(st.value[start:end], point.X-data.BoundingBox.X)
+		}
+		start = end + 1
+	}
+}
+
+func (st *textInputState) offsetAtX(line string, x float32) int {
+	best, bestDistance := 0, abs32(x)
+	for i := range line {
+		if i == 0 {
+			continue
+		}
+		if distance := abs32(st.measure(line[:i]) - x); distance < bestDistance {
+			best, bestDistance = i, distance
+		}
+	}
+	if distance := abs32(st.measure(line) - x); distance < bestDistance {
+		best = len(line)
+	}
+	return best
+}
+
+func (st *textInputState) scrollToCaret(id ElementId, padding Padding) {
+	data := GetElementData(id)
+	if !data.Found {
+		return
+	}
+	start := lineStart(st.value, st.caret)
+	caretX := st.measure(st.value[start:st.caret])
+	caretY := float32(strings.Count(st.value[:start], "\n")) * st.lineHeight
+	width := data.BoundingBox.Width - float32(padding.Left) - float32(padding.Right) - st.config.CaretWidth
+	height := data.BoundingBox.Height - float32(padding.Top) - float32(padding.Bottom) - st.lineHeight
+	st.scroll.X = min(st.scroll.X, width-caretX)
+	st.scroll.X = min(max(st.scroll.X, -caretX), 0)
+	if st.config.Multiline {
+		st.scroll.Y = min(st.scroll.Y, height-caretY)
+		st.scroll.Y = min(max(st.scroll.Y, -caretY), 0)
+	}
+}
+
+func (st *textInputState) measure(s string) float32 {
+	if s == "" {
+		return 0
+	}
+	return measureString(s, &st.config.TextConfig).Width
+}
+
+func measureString(s string, config *TextElementConfig) Dimensions {
+	if __MeasureText == nil {
+		return Dimensions{}
+	}
+	userData, _ := GetCurrentContext().measureTextUserData.(unsafe.Pointer)
+	return __MeasureText(StringSlice{Length: int32(len(s)), Chars: unsafe.StringData(s), BaseChars: unsafe.StringData(s)}, config, userData)
+}
+
+func abs32(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func lineStart(s string, position int) int {
+	return strings.LastIndexByte(s[:position], '\n') + 1
+}
+
+func lineEnd(s string, position int) int {
+	if i := strings.IndexByte(s[position:], '\n'); i >= 0 {
+		return position + i
+	}
+	return len(s)
+}
+
+func previousRuneBoundary(s string, position int) int {
+	_, size := utf8.DecodeLastRuneInString(s[:position])
+	return position - size
+}
+
+func nextRuneBoundary(s string, position int) int {
+	_, size := utf8.DecodeRuneInString(s[position:])
+	return position + size
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func previousWordBoundary(s string, position int) int {
+	for position > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:position])
+		if isWordRune(r) {
+			break
+		}
+		position -= size
+	}
+	for position > 0 {
+		r, size := utf8.DecodeLastRuneInString(s[:position])
+		if !isWordRune(r) {
+			break
+		}
+		position -= size
+	}
+	return position
+}
+
+func nextWordBoundary(s string, position int) int {
+	for position < len(s) {
+		r, size := utf8.DecodeRuneInString(s[position:])
+		if isWordRune(r) {
+			break
+		}
+		position += size
+	}
+	for position < len(s) {
+		r, size := utf8.DecodeRuneInString(s[position:])
+		if !isWordRune(r) {
+			break
+		}
+		position += size
+	}
+	return position
+}
@@ -0,0 +1,171 @@
+package clay_test
+
+import (
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// newTextInputTest sets up a test context with an in-memory clipboard.
+func newTextInputTest(t *testing.T) *clay.MemoryClipboard {
+	t.Helper()
+	newTestContext(t)
+	clipboard := &clay.MemoryClipboard{}
+	clay.SetClipboard(clipboard)
+	return clipboard
+}
+
+func layoutTextInput(value *string, multiline bool) clay.TextInputResult {
+	var result clay.TextInputResult
+	clay.BeginLayout()
+	clay.UI(clay.ID("Root"))(clay.ElementDeclaration{}, func() {
+		result = clay.TextInput(clay.ID("Input"), value, clay.TextInputConfig{Multiline: multiline}, clay.ElementDeclaration{
+			Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(200), Height: clay.SizingFixed(100)}},
+		})
+	})
+	clay.EndLayout()
+	return result
+}
+
+func pressKeys(value *string, multiline bool, keyEvents ...clay.KeyEvent) clay.TextInputResult {
+	clay.SetKeyboardState(keyEvents)
+	return layoutTextInput(value, multiline)
+}
+
+func typeText(value *string, multiline bool, text string) clay.TextInputResult {
+	clay.SetTextInput(text)
+	return layoutTextInput(value, multiline)
+}
+
+func TestTextInputEditing(t *testing.T) {
+	newTextInputTest(t)
+	value := ""
+	layoutTextInput(&value, false)
+	clay.SetFocus(clay.ID("Input"))
+
+	if result := typeText(&value, false, "hello world"); !result.Changed || value != "hello world" {
+		t.Fatalf("typing: got %q, changed %v", value, result.Changed)
+	}
+	pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_LEFT, Modifiers: clay.KEY_MODIFIER_CTRL}, clay.KeyEvent{Key: clay.KEY_BACKSPACE})
+	if value != "helloworld" {
+		t.Fatalf("word left and backspace: got %q", value)
+	}
+	pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_HOME}, clay.KeyEvent{Key: clay.KEY_DELETE, Modifiers: clay.KEY_MODIFIER_CTRL})
+	if value != "" {
+		t.Fatalf("delete word: got %q", value)
+	}
+	pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_Z, Modifiers: clay.KEY_MODIFIER_CTRL})
+	if value != "helloworld" {
+		t.Fatalf("undo: got %q", value)
+	}
+	pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_Z, Modifiers: clay.KEY_MODIFIER_CTRL}, clay.KeyEvent{Key: clay.KEY_Z, Modifiers: clay.KEY_MODIFIER_CTRL})
+	if value != "" {
+		t.Fatalf("typing should undo as one step: got %q", value)
+	}
+	pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_Y, Modifiers: clay.KEY_MODIFIER_CTRL}, clay.KeyEvent{Key: clay.KEY_Z, Modifiers: clay.KEY_MODIFIER_CTRL | clay.KEY_MODIFIER_SHIFT})
+	if value != "helloworld" {
+		t.Fatalf("redo: got %q", value)
+	}
+	if result := pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_ENTER}); !result.Submitted || value != "helloworld" {
+		t.Fatalf("enter in a single-line input should submit: got %q, submitted %v", value, result.Submitted)
+	}
+}
+
+func TestTextInputClipboard(t *testing.T) {
+	clipboard := newTextInputTest(t)
+	value := "copy paste"
+	layoutTextInput(&value, false)
+	clay.SetFocus(clay.ID("Input"))
+
+	pressKeys(&value, false,
+		clay.KeyEvent{Key: clay.KEY_HOME},
+		clay.KeyEvent{Key: clay.KEY_RIGHT, Modifiers: clay.KEY_MODIFIER_CTRL | clay.KEY_MODIFIER_SHIFT},
+		clay.KeyEvent{Key: clay.KEY_X, Modifiers: clay.KEY_MODIFIER_CTRL},
+	)
+	if value != " paste" || clipboard.Text != "copy" {
+		t.Fatalf("cut: got %q, clipboard %q", value, clipboard.Text)
+	}
+	clipboard.Text = "line\r\nbreak"
+	pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_END}, clay.KeyEvent{Key: clay.KEY_V, Modifiers: clay.KEY_MODIFIER_CTRL})
+	if value != " pasteline break" {
+		t.Fatalf("paste into a single-line input: got %q", value)
+	}
+	pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_A, Modifiers: clay.KEY_MODIFIER_CTRL}, clay.KeyEvent{Key: clay.KEY_C, Modifiers: clay.KEY_MODIFIER_CTRL})
+	if clipboard.Text != value {
+		t.Fatalf("copy all: got %q", clipboard.Text)
+	}
+}
+
+func TestTextInputMultiline(t *testing.T) {
+	newTextInputTest(t)
+	value := "first line\nab\nthird line"
+	layoutTextInput(&value, true)
+	clay.SetFocus(clay.ID("Input"))
+
+	// Moving down from column 5 clamps to the end of the short line, then returns to column 5 on the line after it
+	pressKeys(&value, true,
+		clay.KeyEvent{Key: clay.KEY_HOME, Modifiers: clay.KEY_MODIFIER_CTRL},
+		clay.KeyEvent{Key: clay.KEY_RIGHT}, clay.KeyEvent{Key: clay.KEY_RIGHT}, clay.KeyEvent{Key: clay.KEY_RIGHT},
+		clay.KeyEvent{Key: clay.KEY_RIGHT}, clay.KeyEvent{Key: clay.KEY_RIGHT},
+		clay.KeyEvent{Key: clay.KEY_DOWN}, clay.KeyEvent{Key: clay.KEY_DOWN},
+	)
+	typeText(&value, true, "!")
+	if value != "first line\nab\nthird! line" {
+		t.Fatalf("vertical navigation: got %q", value)
+	}
+	pressKeys(&value, true, clay.KeyEvent{Key: clay.KEY_ENTER})
+	if value != "first line\nab\nthird!\n line" {
+		t.Fatalf("enter in a multiline input: got %q", value)
+	}
+}
+
+func TestTextInputComposition(t *testing.T) {
+	newTextInputTest(t)
+	value := "ab"
+	layoutTextInput(&value, false)
+	clay.SetFocus(clay.ID("Input"))
+	pressKeys(&value, false, clay.KeyEvent{Key: clay.KEY_LEFT})
+
+	clay.SetCompositionText("xy")
+	layoutTextInput(&value, false)
+	if value != "ab" {
+		t.Fatalf("composition text must not be part of the value: got %q", value)
+	}
+	caret, found := clay.TextInputCaret(clay.ID("Input"))
+	input := clay.GetElementData(clay.ID("Input"))
+	if !found || caret.X-input.BoundingBox.X != 30 {
+		t.Fatalf("caret should be drawn after the composition text: got %v", caret.X-input.BoundingBox.X)
+	}
+
+	clay.SetCompositionText("")
+	typeText(&value, false, "xy")
+	if value != "axyb" {
+		t.Fatalf("committed composition: got %q", value)
+	}
+}
+
+func TestTextInputUnfocused(t *testing.T) {
+	newTextInputTest(t)
+	value := ""
+	layoutTextInput(&value, false)
+	// Text typed while no text input is focused is dropped after the layout it was provided for
+	typeText(&value, false, "lost")
+	clay.SetFocus(clay.ID("Input"))
+	layoutTextInput(&value, false)
+	if value != "" {
+		t.Fatalf("text typed before the input was focused must not be inserted: got %q", value)
+	}
+	// The same goes for the focused input not being declared in that layout
+	clay.SetTextInput("lost")
+	clay.BeginLayout()
+	clay.EndLayout()
+	layoutTextInput(&value, false)
+	clay.SetFocus(clay.ID("Input"))
+	layoutTextInput(&value, false)
+	if value != "" {
+		t.Fatalf("text typed while the input wasn't declared must not be inserted: got %q", value)
+	}
+	if typeText(&value, false, "kept"); value != "kept" {
+		t.Fatalf("typing into the focused input: got %q", value)
+	}
+}
@@ -0,0 +1,137 @@
+package clay_test
+
+import (
+	"image/color"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutRotated lays out a 100x20 bar rotated by a quarter turn around its center, with a 20x20 child at its left end
+// and a floating badge attached to its right end.
+func layoutRotated() clay.RenderCommandArray {
+	clay.BeginLayout()
+	clay.UI(clay.ID("Bar"))(clay.ElementDeclaration{
+		Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(20)}},
+		BackgroundColor: red,
+		Transform:       clay.Rotate(90),
+	}, func() {
+		clay.UI(clay.ID("Knob"))(clay.ElementDeclaration{
+			Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(20), Height: clay.SizingFixed(20)}},
+			BackgroundColor: blue,
+			Transform:       clay.Translate(10, 0),
+		}, nil)
+		clay.UI(clay.ID("Badge"))(clay.ElementDeclaration{
+			Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+			BackgroundColor: white,
+			Floating: clay.FloatingElementConfig{
+				AttachTo:     clay.ATTACH_TO_PARENT,
+				AttachPoints: clay.FloatingAttachPoints{Element: clay.ATTACH_POINT_LEFT_TOP, Parent: clay.ATTACH_POINT_RIGHT_TOP},
+			},
+		}, nil)
+	})
+	clay.UI(clay.ID("Plain"))(clay.ElementDeclaration{
+		Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+		BackgroundColor: red,
+	}, nil)
+	return clay.EndLayout()
+}
+
+func commandTransform(t *testing.T, cmds clay.RenderCommandArray, id clay.ElementId) (clay.BoundingBox, clay.Matrix2D) {
+	t.Helper()
+	for cmd := range cmds.Iter() {
+		if cmd.Id == id.Id {
+			return cmd.BoundingBox, cmd.Transform
+		}
+	}
+	t.Fatalf("no render command for %v", id)
+	return clay.BoundingBox{}, clay.Matrix2D{}
+}
+
+func TestTransformRenderCommands(t *testing.T) {
+	newTestContext(t)
+	cmds := layoutRotated()
+
+	box, bar := commandTransform(t, cmds, clay.ID("Bar"))
+	if box != (clay.BoundingBox{Width: 100, Height: 20}) {
+		t.Errorf("layout should be unaffected by the transform, got %v", box)
+	}
+	// A quarter turn clockwise around the center at (50, 10)
+	if want := (clay.Matrix2D{A: 0, B: 1, C: -1, D: 0, Tx: 60, Ty: -40}); bar != want {
+		t.Errorf("expected bar transform %v, got %v", want, bar)
+	}
+	// The knob is moved 10 pixels along the rotated bar, which points down
+	_, knob := commandTransform(t, cmds, clay.ID("Knob"))
+	if got := knob.Apply(clay.Vector2{}); got != (clay.Vector2{X: 60, Y: -30}) {
+		t.Errorf("expected the knob's corner at {60 -30}, got %v", got)
+	}
+	// Floating elements follow the element they're attached to
+	_, badge := commandTransform(t, cmds, clay.ID("Badge"))
+	if badge != bar {
+		t.Errorf("expected the badge to share the bar's transform %v, got %v", bar, badge)
+	}
+	if _, plain := commandTransform(t, cmds, clay.ID("Plain")); !plain.IsIdentity() {
+		t.Errorf("expected an untransformed element to have the identity transform, got %v", plain)
+	}
+}
+
+func TestTransformHitTesting(t *testing.T) {
+	newTestContext(t)
+	layoutRotated()
+	// The rotated bar covers x from 40 to 60 and y from -40 to 60
+	clay.SetPointerState(clay.Vector2{X: 50, Y: 50}, false)
+	layoutRotated()
+	if !clay.PointerOver(clay.ID("Bar")) {
+		t.Error("expected the pointer to be over the rotated bar")
+	}
+	clay.SetPointerState(clay.Vector2{X: 90, Y: 10}, false)
+	layoutRotated()
+	if clay.PointerOver(clay.ID("Bar")) {
+		t.Error("expected the pointer outside of the rotated bar not to hit its laid out box")
+	}
+	// The knob is drawn from y = -30 to -10 after the rotation
+	clay.SetPointerState(clay.Vector2{X: 50, Y: -20}, false)
+	layoutRotated()
+	if !clay.PointerOver(clay.ID("Knob")) {
+		t.Error("expected the pointer to be over the knob")
+	}
+}
+
+func TestTransformMatrix(t *testing.T) {
+	m := clay.Matrix2D{A: 0, B: 2, C: -2, D: 0, Tx: 5, Ty: 7}
+	inverse, ok := m.Invert()
+	if !ok {
+		t.Fatal("expected the matrix to be invertible")
+	}
+	p := clay.Vector2{X: 3, Y: -4}
+	if got := inverse.Apply(m.Apply(p)); got != p {
+		t.Errorf("expected the inverse to map back to %v, got %v", p, got)
+	}
+	if got := m.Bounds(clay.BoundingBox{Width: 2, Height: 1}); got != (clay.BoundingBox{X: 3, Y: 7, Width: 2, Height: 4}) {
+		t.Errorf("unexpected transformed bounds %v", got)
+	}
+	if _, ok := (clay.Matrix2D{A: 1}).Invert(); ok {
+		t.Error("expected a collapsed matrix not to be invertible")
+	}
+}
+
+func TestTransformSoftwareRenderer(t *testing.T) {
+	img := renderLayout(t, 6, 4, func() {
+		clay.UI(clay.ID("Moved"))(clay.ElementDeclaration{
+			Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(2), Height: clay.SizingFixed(1)}},
+			BackgroundColor: red,
+			Transform:       clay.Translate(3, 0),
+		}, nil)
+		clay.UI(clay.ID("Turned"))(clay.ElementDeclaration{
+			Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(4), Height: clay.SizingFixed(2)}},
+			BackgroundColor: blue,
+			Floating:        clay.FloatingElementConfig{AttachTo: clay.ATTACH_TO_ROOT, Offset: clay.Vector2{X: 0, Y: 1}},
+			Transform:       clay.Rotate(90),
+		}, nil)
+	})
+	blueRGBA := color.RGBA{B: 255, A: 255}
+	expectPixels(t, img, row(0, 0, 1, 2, 3, 4, 5), []color.RGBA{black, blueRGBA, blueRGBA, {255, 0, 0, 255}, {255, 0, 0, 255}, black})
+	// The 4x2 box centered at (2, 2) stands upright once it's turned a quarter
+	expectPixels(t, img, row(2, 0, 1, 2, 3), []color.RGBA{black, blueRGBA, blueRGBA, black})
+	expectPixels(t, img, row(3, 0, 1, 2, 3), []color.RGBA{black, blueRGBA, blueRGBA, black})
+}
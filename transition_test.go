@@ -0,0 +1,229 @@
+package clay_test
+
+import (
+	"fmt"
+	"image/color"
+	"testing"
+	"unsafe"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutBox lays out a red 40x20 element named Box with the provided transition at (x, 20), or leaves it out when it isn't declared.
+func layoutBox(x float32, declared bool, transition clay.TransitionElementConfig) clay.RenderCommandArray {
+	clay.BeginLayout()
+	clay.UI()(clay.ElementDeclaration{Layout: clay.LayoutConfig{Padding: clay.Padding{Left: uint16(x), Top: 20}}}, func() {
+		if declared {
+			clay.UI(clay.ID("Box"))(clay.ElementDeclaration{
+				Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(20)}},
+				BackgroundColor: red,
+				Transition:      transition,
+			}, nil)
+		}
+	})
+	return clay.EndLayout()
+}
+
+// drawnBox returns where Box was drawn and the opacity it was drawn with, including that of any layers around it.
+// ok is false if Box wasn't drawn.
+func drawnBox(cmds clay.RenderCommandArray) (box clay.BoundingBox, opacity float32, ok bool) {
+	layers := []float32{1}
+	for cmd := range cmds.Iter() {
+		switch cmd.CommandType {
+		case clay.RENDER_COMMAND_TYPE_LAYER_START:
+			layers = append(layers, layers[len(layers)-1]*cmd.RenderData.Layer.Opacity)
+		case clay.RENDER_COMMAND_TYPE_LAYER_END:
+			layers = layers[:len(layers)-1]
+		case clay.RENDER_COMMAND_TYPE_RECTANGLE:
+			if cmd.Id == clay.ID("Box").Id {
+				box, opacity, ok = cmd.BoundingBox, layers[len(layers)-1]*cmd.RenderData.Rectangle.BackgroundColor.A/255, true
+			}
+		}
+	}
+	return box, opacity, ok
+}
+
+func TestTransitionDefaultOpacity(t *testing.T) {
+	newTestContext(t)
+	// Animating every property doesn't make the element transparent when no opacity is set
+	transition := clay.TransitionElementConfig{Properties: clay.TRANSITION_PROPERTY_ALL, Duration: 1}
+	for range 3 {
+		clay.SetDeltaTime(0.5)
+		if _, opacity, _ := drawnBox(layoutBox(0, true, transition)); opacity != 1 {
+			t.Fatalf("expected Box to be opaque, got %v", opacity)
+		}
+	}
+}
+
+func TestTransitionRetarget(t *testing.T) {
+	newTestContext(t)
+	transition := clay.TransitionElementConfig{Properties: clay.TRANSITION_PROPERTY_POSITION, Duration: 1}
+	clay.SetDeltaTime(0.25)
+	steps := []struct {
+		x    float32
+		want float32
+	}{
+		// The first frame is drawn where the element was laid out
+		{0, 0},
+		// Moving starts from where the element is drawn, and the frame the move is noticed doesn't advance it
+		{100, 0},
+		{100, 25},
+		{100, 50},
+		// Moving back mid-animation continues from the animated position, the frame's time included, instead of jumping
+		{0, 75},
+		{0, 56.25},
+		{0, 37.5},
+		{0, 18.75},
+		{0, 0},
+		{0, 0},
+	}
+	for i, step := range steps {
+		if box, _, _ := drawnBox(layoutBox(step.x, true, transition)); box.X != step.want {
+			t.Fatalf("frame %d: expected Box at x %v, got %v", i, step.want, box.X)
+		}
+	}
+}
+
+func TestTransitionEnter(t *testing.T) {
+	newTestContext(t)
+	transition := clay.TransitionElementConfig{Duration: 1, Enter: clay.TransitionEnterExitConfig{Enabled: true, Offset: clay.Vector2{X: 20}}}
+	clay.SetDeltaTime(0.5)
+	steps := []struct {
+		x       float32
+		opacity float32
+	}{
+		// Fades in from transparent while sliding in from the offset
+		{120, 0},
+		{110, 0.5},
+		{100, 1},
+		{100, 1},
+	}
+	for i, step := range steps {
+		box, opacity, _ := drawnBox(layoutBox(100, true, transition))
+		if box.X != step.x || opacity != step.opacity {
+			t.Fatalf("frame %d: expected Box at x %v with opacity %v, got %v with opacity %v", i, step.x, step.opacity, box.X, opacity)
+		}
+	}
+}
+
+func TestTransitionDeltaTime(t *testing.T) {
+	newTestContext(t)
+	transition := clay.TransitionElementConfig{Duration: 1, Enter: clay.TransitionEnterExitConfig{Enabled: true, Opacity: 1, Offset: clay.Vector2{X: 20}}}
+	// Without any time passing, the element stays where the enter animation starts
+	clay.SetDeltaTime(0)
+	for range 3 {
+		if box, _, _ := drawnBox(layoutBox(100, true, transition)); box.X != 120 {
+			t.Fatalf("expected Box to stay at x 120 without any time passing, got %v", box.X)
+		}
+	}
+	clay.SetDeltaTime(0.75)
+	if box, _, _ := drawnBox(layoutBox(100, true, transition)); box.X != 105 {
+		t.Fatalf("expected Box to advance by the delta time to x 105, got %v", box.X)
+	}
+	clay.SetDeltaTime(10)
+	if box, _, _ := drawnBox(layoutBox(100, true, transition)); box.X != 100 {
+		t.Fatalf("expected Box to finish at x 100, got %v", box.X)
+	}
+}
+
+func TestTransitionExit(t *testing.T) {
+	newTestContext(t)
+	transition := clay.TransitionElementConfig{Duration: 1, Exit: clay.TransitionEnterExitConfig{Enabled: true, Offset: clay.Vector2{Y: 20}}}
+	clay.SetDeltaTime(0.5)
+	layoutBox(100, true, transition)
+	steps := []struct {
+		y       float32
+		opacity float32
+	}{
+		// The last frame the element was drawn in is replayed, fading out while it slides to the offset
+		{20, 1},
+		{30, 0.5},
+	}
+	for i, step := range steps {
+		box, opacity, ok := drawnBox(layoutBox(100, false, transition))
+		if !ok || box.Y != step.y || opacity != step.opacity {
+			t.Fatalf("frame %d: expected Box at y %v with opacity %v, got %v with opacity %v", i, step.y, step.opacity, box.Y, opacity)
+		}
+	}
+	if _, _, ok := drawnBox(layoutBox(100, false, transition)); ok {
+		t.Fatal("expected Box to be gone once the exit animation finished")
+	}
+}
+
+func TestTransitionExitRedeclared(t *testing.T) {
+	newTestContext(t)
+	transition := clay.TransitionElementConfig{
+		Properties: clay.TRANSITION_PROPERTY_POSITION | clay.TRANSITION_PROPERTY_OPACITY,
+		Duration:   1,
+		Exit:       clay.TransitionEnterExitConfig{Enabled: true, Offset: clay.Vector2{Y: 20}},
+	}
+	clay.SetDeltaTime(0.5)
+	layoutBox(100, true, transition)
+	layoutBox(100, false, transition)
+	layoutBox(100, false, transition)
+	steps := []struct {
+		y       float32
+		opacity float32
+	}{
+		// Declaring the element halfway through its exit animates it back from there
+		{30, 0.5},
+		{25, 0.75},
+		{20, 1},
+	}
+	for i, step := range steps {
+		box, opacity, _ := drawnBox(layoutBox(100, true, transition))
+		if box.Y != step.y || opacity != step.opacity {
+			t.Fatalf("frame %d: expected Box at y %v with opacity %v, got %v with opacity %v", i, step.y, step.opacity, box.Y, opacity)
+		}
+	}
+}
+
+func TestTransitionExitCapacity(t *testing.T) {
+	clay.SetMaxElementCount(40)
+	newTestContext(t)
+	t.Cleanup(func() { clay.SetMaxElementCount(8192) })
+	// Exiting elements share room for a quarter of the max element count in render commands, and those that don't fit disappear
+	layout := func(declared bool) clay.RenderCommandArray {
+		clay.BeginLayout()
+		clay.UI()(clay.ElementDeclaration{}, func() {
+			for i := range 15 {
+				if declared {
+					clay.UI(clay.ID(fmt.Sprint("Box ", i)))(clay.ElementDeclaration{
+						Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(10), Height: clay.SizingFixed(10)}},
+						BackgroundColor: red,
+						Transition:      clay.TransitionElementConfig{Duration: 1, Exit: clay.TransitionEnterExitConfig{Enabled: true}},
+					}, nil)
+				}
+			}
+		})
+		return clay.EndLayout()
+	}
+	clay.SetDeltaTime(0.25)
+	layout(true)
+	for i := range 2 {
+		var rectangles int
+		cmds := layout(false)
+		for cmd := range cmds.Iter() {
+			if cmd.CommandType == clay.RENDER_COMMAND_TYPE_RECTANGLE {
+				rectangles++
+			}
+		}
+		if rectangles != 10 {
+			t.Fatalf("frame %d: expected 10 exiting elements to be drawn, got %d", i, rectangles)
+		}
+	}
+}
+
+func TestTransitionOpacityImage(t *testing.T) {
+	img := renderLayout(t, 4, 2, func() {
+		clay.UI(clay.ID("Faded"))(clay.ElementDeclaration{
+			Layout:     clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(4), Height: clay.SizingFixed(2)}},
+			Image:      clay.ImageElementConfig{ImageData: unsafe.Pointer(checkerboard())},
+			Transition: clay.TransitionElementConfig{Properties: clay.TRANSITION_PROPERTY_OPACITY, Duration: 1, Opacity: 0.5},
+		}, nil)
+	})
+	// Images are faded by the layer around the element, like everything else it renders
+	faded := []color.RGBA{{128, 0, 0, 255}, {128, 0, 0, 255}, {0, 0, 128, 255}, {0, 0, 128, 255}}
+	expectPixels(t, img, row(0, 0, 1, 2, 3), faded)
+	expectPixels(t, img, row(1, 0, 1, 2, 3), faded)
+}
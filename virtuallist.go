@@ -0,0 +1,119 @@
+package clay
+
+import "sort"
+
+type VirtualListConfig struct {
+	// Number of rows in the list.
+	ItemCount int
+	// Height of every row in pixels. When 0, rows are sized by their contents and EstimateItemHeight is used for rows
+	// that haven't been laid out yet.
+	ItemHeight float32
+	// Returns the expected height of a row before it has been laid out. Once a row has been laid out, its measured height is used instead.
+	EstimateItemHeight func(index int) float32
+	// Number of extra rows declared above and below the viewport, so that fast scrolling doesn't reveal rows a frame late.
+	Overscan int
+}
+
+// The height rows are estimated to be when a VirtualList is declared without an ItemHeight or an EstimateItemHeight.
+const defaultEstimatedItemHeight = 20
+
+type virtualListState struct {
+	// heights and offsets are only used when rows are estimated. offsets[i] is the top of row i, and offsets[len(heights)] the total height.
+	heights     []float32
+	offsets     []float32
+	dirty       bool
+	itemHeight  float32
+	first, last int // rows declared in the last layout
+}
+
+func virtualListRowId(id ElementId, index int) ElementId {
+	return __HashNumber(uint32(index), id.Id)
+}
+
+// VirtualList declares a vertically scrolling clip container with the given id that only declares the rows inside its viewport.
+// row is called for every visible row and should declare that row's elements. Rows above and below the viewport are replaced
+// by spacers, so ScrollContainerData.ContentDimensions and scrollbars behave as if every row existed.
+// The container is laid out according to decl, except that it always stacks rows top to bottom without a child gap.
+func VirtualList(id ElementId, config VirtualListConfig, decl ElementDeclaration, row func(index int)) {
+	if config.ItemHeight <= 0 && config.EstimateItemHeight == nil {
+		reportError(ERROR_TYPE_INVALID_CONFIG, "A VirtualList was declared without an ItemHeight or an EstimateItemHeight, so rows are estimated to be 20 pixels tall until they are laid out.")
+		config.EstimateItemHeight = func(int) float32 { return defaultEstimatedItemHeight }
+	}
+	st := State[virtualListState](id)
+	st.sync(id, config)
+
+	// The visible range comes from the last layout, the same one GetScrollOffset reads from
+	viewportTop, viewportHeight := float32(0), GetCurrentContext().layoutDimensions.Height
+	scroll := GetScrollContainerData(id)
+	if scroll.Found {
+		viewportTop = -scroll.ScrollPosition.Y - float32(decl.Layout.Padding.Top)
+		viewportHeight = scroll.ScrollContainerDimensions.Height
+		decl.Clip.ChildOffset = *scroll.ScrollPosition
+	}
+	count := config.ItemCount
+	first := sort.Search(count, func(i int) bool { return st.offset(i+1) > viewportTop })
+	last := sort.Search(count, func(i int) bool { return st.offset(i) >= viewportTop+viewportHeight })
+	st.first, st.last = max(first-config.Overscan, 0), min(last+config.Overscan, count)
+
+	decl.Layout.LayoutDirection = TOP_TO_BOTTOM
+	decl.Layout.ChildGap = 0
+	decl.Clip.Vertical = true
+	UI(id)(decl, func() {
+		if top := st.offset(st.first); top > 0 {
+			UI()(ElementDeclaration{Layout: LayoutConfig{Sizing: Sizing{Height: SizingFixed(top)}}}, nil)
+		}
+		rowHeight := SizingFit(0, 0)
+		if st.itemHeight > 0 {
+			rowHeight = SizingFixed(st.itemHeight)
+		}
+		for i := st.first; i < st.last; i++ {
+			UI(virtualListRowId(id, i))(ElementDeclaration{Layout: LayoutConfig{Sizing: Sizing{Width: SizingGrow(0), Height: rowHeight}}}, func() {
+				row(i)
+			})
+		}
+		if bottom := st.offset(count) - st.offset(st.last); bottom > 0 {
+			UI()(ElementDeclaration{Layout: LayoutConfig{Sizing: Sizing{Height: SizingFixed(bottom)}}}, nil)
+		}
+	})
+}
+
+// sync brings the row heights up to date with config, replacing estimates with the heights measured in the last layout.
+func (st *virtualListState) sync(id ElementId, config VirtualListConfig) {
+	st.itemHeight = config.ItemHeight
+	if st.itemHeight > 0 {
+		st.heights, st.offsets = nil, nil
+		return
+	}
+	if len(st.heights) != config.ItemCount {
+		// Rows are assumed to be added and removed at the end, as in a log, so known heights are kept
+		previous := len(st.heights)
+		st.heights = append(st.heights[:min(previous, config.ItemCount)], make([]float32, max(config.ItemCount-previous, 0))...)
+		for i := previous; i < config.ItemCount; i++ {
+			st.heights[i] = max(config.EstimateItemHeight(i), 1)
+		}
+		st.first, st.last = min(st.first, config.ItemCount), min(st.last, config.ItemCount)
+		st.dirty = true
+	}
+	for i := st.first; i < st.last; i++ {
+		data := GetElementData(virtualListRowId(id, i))
+		if data.Found && data.BoundingBox.Height > 0 && data.BoundingBox.Height != st.heights[i] {
+			st.heights[i] = data.BoundingBox.Height
+			st.dirty = true
+		}
+	}
+	if st.dirty || st.offsets == nil {
+		st.offsets = append(st.offsets[:0], 0)
+		for _, height := range st.heights {
+			st.offsets = append(st.offsets, st.offsets[len(st.offsets)-1]+height)
+		}
+		st.dirty = false
+	}
+}
+
+// offset returns the distance from the top of the content to the top of row i.
+func (st *virtualListState) offset(i int) float32 {
+	if st.itemHeight > 0 {
+		return float32(i) * st.itemHeight
+	}
+	return st.offsets[i]
+}
@@ -0,0 +1,82 @@
+package clay_test
+
+import (
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutVirtualList lays out a 200 pixel tall list and returns the rows that were declared.
+func layoutVirtualList(config clay.VirtualListConfig, rowHeight float32) []int {
+	var rows []int
+	clay.UpdateScrollContainers(false, clay.Vector2{}, 1.0/60)
+	clay.BeginLayout()
+	clay.VirtualList(clay.ID("List"), config, clay.ElementDeclaration{
+		Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(200)}},
+	}, func(index int) {
+		rows = append(rows, index)
+		clay.UI()(clay.ElementDeclaration{Layout: clay.LayoutConfig{Sizing: clay.Sizing{Height: clay.SizingFixed(rowHeight)}}}, nil)
+	})
+	clay.EndLayout()
+	return rows
+}
+
+func TestVirtualListFixedHeight(t *testing.T) {
+	newTestContext(t)
+	config := clay.VirtualListConfig{ItemCount: 200_000, ItemHeight: 20, Overscan: 2}
+	layoutVirtualList(config, 20)
+	rows := layoutVirtualList(config, 20)
+	if len(rows) != 12 || rows[0] != 0 || rows[11] != 11 {
+		t.Fatalf("expected rows 0 to 11, got %v", rows)
+	}
+	scroll := clay.GetScrollContainerData(clay.ID("List"))
+	if scroll.ContentDimensions.Height != 4_000_000 {
+		t.Fatalf("content height should cover every row: got %v", scroll.ContentDimensions.Height)
+	}
+
+	clay.ScrollTo(clay.ID("List"), clay.Vector2{Y: -20_010}, false)
+	layoutVirtualList(config, 20)
+	rows = layoutVirtualList(config, 20)
+	if len(rows) != 15 || rows[0] != 998 || rows[14] != 1012 {
+		t.Fatalf("expected rows 998 to 1012, got %v", rows)
+	}
+	scroll = clay.GetScrollContainerData(clay.ID("List"))
+	if scroll.ContentDimensions.Height != 4_000_000 || *scroll.ScrollPosition != (clay.Vector2{Y: -20_010}) {
+		t.Fatalf("scrolling should not change the content height: got %v at %v", scroll.ContentDimensions.Height, *scroll.ScrollPosition)
+	}
+}
+
+func TestVirtualListEstimatedHeight(t *testing.T) {
+	newTestContext(t)
+	config := clay.VirtualListConfig{ItemCount: 1000, EstimateItemHeight: func(int) float32 { return 10 }}
+	layoutVirtualList(config, 40)
+	layoutVirtualList(config, 40)
+	rows := layoutVirtualList(config, 40)
+	// Measured rows replace their estimates, so only the five 40 pixel rows that fit the viewport are declared
+	if len(rows) != 5 || rows[0] != 0 {
+		t.Fatalf("expected rows 0 to 4, got %v", rows)
+	}
+	// The first layout declared 30 rows for the 300 pixel fallback viewport, and all of them have been measured since
+	scroll := clay.GetScrollContainerData(clay.ID("List"))
+	if want := float32(30*40 + 970*10); scroll.ContentDimensions.Height != want {
+		t.Fatalf("content height: got %v, want %v", scroll.ContentDimensions.Height, want)
+	}
+}
+
+func TestVirtualListMissingHeight(t *testing.T) {
+	var errors []clay.ErrorType
+	memory := make([]byte, clay.MinMemorySize())
+	clay.Initialize(clay.CreateArenaWithCapacityAndMemory(memory), clay.Dimensions{Width: 400, Height: 300}, clay.ErrorHandler{ErrorHandlerFunction: func(errorData clay.ErrorData) {
+		errors = append(errors, errorData.ErrorType)
+	}})
+	config := clay.VirtualListConfig{ItemCount: 1000}
+	layoutVirtualList(config, 20)
+	rows := layoutVirtualList(config, 20)
+	if len(errors) != 2 || errors[0] != clay.ERROR_TYPE_INVALID_CONFIG {
+		t.Fatalf("expected an invalid config error every frame, got %v", errors)
+	}
+	// Rows fall back to a 20 pixel estimate, so the list still fills its viewport
+	if len(rows) != 10 || rows[0] != 0 {
+		t.Fatalf("expected rows 0 to 9, got %v", rows)
+	}
+}
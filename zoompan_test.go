@@ -0,0 +1,142 @@
+package clay_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/TotallyGamerJet/clay"
+)
+
+// layoutCanvas lays out a 200x200 zoom-pan container named Canvas at (50, 50). It holds a red 40x20 box named Box with a
+// 2 pixel border at (10, 10) in canvas space, the text Hi, and a 100x100 scroll container named List with ten 50 pixel rows.
+func layoutCanvas() clay.RenderCommandArray {
+	clay.BeginLayout()
+	clay.UI()(clay.ElementDeclaration{Layout: clay.LayoutConfig{Padding: clay.PaddingAll(50)}}, func() {
+		clay.UI(clay.ID("Canvas"))(clay.ElementDeclaration{
+			Layout:  clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(200), Height: clay.SizingFixed(200)}, Padding: clay.PaddingAll(10), LayoutDirection: clay.TOP_TO_BOTTOM},
+			ZoomPan: clay.ZoomPanElementConfig{Enabled: true, MaxZoom: 5},
+		}, func() {
+			clay.UI(clay.ID("Box"))(clay.ElementDeclaration{
+				Layout:          clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(40), Height: clay.SizingFixed(20)}},
+				BackgroundColor: red,
+				Border:          clay.BorderElementConfig{Color: blue, Width: clay.BorderAll(2)},
+			}, nil)
+			clay.Text("Hi", clay.TextConfig(clay.TextElementConfig{FontSize: 16, LineHeight: 20}))
+			clay.UI(clay.ID("List"))(clay.ElementDeclaration{
+				Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingFixed(100), Height: clay.SizingFixed(100)}, LayoutDirection: clay.TOP_TO_BOTTOM},
+				Clip:   clay.ClipElementConfig{Vertical: true, ChildOffset: clay.GetScrollOffset()},
+			}, func() {
+				for row := range 10 {
+					clay.UI(clay.ID(fmt.Sprint("Row ", row)))(clay.ElementDeclaration{
+						Layout: clay.LayoutConfig{Sizing: clay.Sizing{Width: clay.SizingGrow(0), Height: clay.SizingFixed(50)}},
+					}, nil)
+				}
+			})
+		})
+	})
+	return clay.EndLayout()
+}
+
+// zoomCanvas lays out the canvas once so that it exists, then zooms it by 2 and pans it 5 pixels right.
+func zoomCanvas(t *testing.T) clay.RenderCommandArray {
+	newTestContext(t)
+	layoutCanvas()
+	data := clay.GetZoomPanData(clay.ID("Canvas"))
+	*data.Zoom = 2
+	*data.Pan = clay.Vector2{X: 5}
+	return layoutCanvas()
+}
+
+func TestZoomPanLayout(t *testing.T) {
+	cmds := zoomCanvas(t)
+	// Box is laid out at (60, 60), 10 pixels from the container origin, which lands at 50 + 5 + 10 * 2 on screen
+	if box := clay.GetElementData(clay.ID("Box")).BoundingBox; box != (clay.BoundingBox{X: 75, Y: 70, Width: 80, Height: 40}) {
+		t.Fatalf("expected Box to be zoomed onto the screen, got %v", box)
+	}
+	if canvas := clay.GetElementData(clay.ID("Canvas")).BoundingBox; canvas != (clay.BoundingBox{X: 50, Y: 50, Width: 200, Height: 200}) {
+		t.Fatalf("expected the container itself not to be zoomed, got %v", canvas)
+	}
+	var text, border bool
+	for cmd := range cmds.Iter() {
+		switch cmd.CommandType {
+		case clay.RENDER_COMMAND_TYPE_TEXT:
+			text = true
+			data := cmd.RenderData.Text
+			if data.FontSize != 32 || data.LineHeight != 40 || cmd.BoundingBox.Width != 40 || cmd.BoundingBox.Height != 40 {
+				t.Errorf("expected text to be scaled by the zoom, got size %v, line height %v and box %v", data.FontSize, data.LineHeight, cmd.BoundingBox)
+			}
+		case clay.RENDER_COMMAND_TYPE_BORDER:
+			border = true
+			if width := cmd.RenderData.Border.Width; width != clay.BorderAll(4) {
+				t.Errorf("expected borders to be scaled by the zoom, got %v", width)
+			}
+		}
+	}
+	if !text || !border {
+		t.Fatal("expected text and border commands")
+	}
+}
+
+func TestZoomPanScreenToCanvas(t *testing.T) {
+	zoomCanvas(t)
+	if point := clay.ZoomPanScreenToCanvas(clay.ID("Canvas"), clay.Vector2{X: 75, Y: 70}); point != (clay.Vector2{X: 10, Y: 10}) {
+		t.Fatalf("expected the top left of Box to map back to (10, 10), got %v", point)
+	}
+	// The pointer is tested against the zoomed boxes, not the laid out ones
+	clay.SetPointerState(clay.Vector2{X: 150, Y: 105}, false)
+	if !clay.PointerOver(clay.ID("Box")) {
+		t.Fatal("expected the pointer to be over the zoomed Box")
+	}
+	clay.SetPointerState(clay.Vector2{X: 65, Y: 65}, false)
+	if clay.PointerOver(clay.ID("Box")) {
+		t.Fatal("expected the pointer not to be over where Box was laid out")
+	}
+}
+
+func TestZoomPanZoomAroundPointer(t *testing.T) {
+	zoomCanvas(t)
+	clay.SetPointerState(clay.Vector2{X: 75, Y: 70}, false)
+	clay.UpdateZoomPanContainers(false, 1)
+	layoutCanvas()
+	// The canvas point under the pointer stays in place
+	if data := clay.GetZoomPanData(clay.ID("Canvas")); *data.Zoom != 4 {
+		t.Fatalf("expected the zoom to double, got %v", *data.Zoom)
+	}
+	if box := clay.GetElementData(clay.ID("Box")).BoundingBox; box != (clay.BoundingBox{X: 75, Y: 70, Width: 160, Height: 80}) {
+		t.Fatalf("expected Box to grow around the pointer, got %v", box)
+	}
+	clay.UpdateZoomPanContainers(false, 1)
+	if data := clay.GetZoomPanData(clay.ID("Canvas")); *data.Zoom != 5 {
+		t.Fatalf("expected the zoom to be clamped to MaxZoom, got %v", *data.Zoom)
+	}
+}
+
+func TestZoomPanScroll(t *testing.T) {
+	zoomCanvas(t)
+	// Scroll data stays in canvas space, however far the container is zoomed
+	scroll := clay.GetScrollContainerData(clay.ID("List"))
+	if scroll.ScrollContainerDimensions != (clay.Dimensions{Width: 100, Height: 100}) || scroll.ContentDimensions != (clay.Dimensions{Width: 100, Height: 500}) {
+		t.Fatalf("expected a 100x100 viewport onto 100x500 of content, got %v and %v", scroll.ScrollContainerDimensions, scroll.ContentDimensions)
+	}
+	clay.ScrollIntoView(clay.ID("List"), clay.ID("Row 4"), clay.SCROLL_ALIGN_START)
+	for range 60 {
+		clay.UpdateScrollContainers(false, clay.Vector2{}, 1.0/60)
+		layoutCanvas()
+	}
+	if position := *scroll.ScrollPosition; position != (clay.Vector2{Y: -200}) {
+		t.Fatalf("expected Row 4 to be scrolled to the top, got %v", position)
+	}
+
+	// List starts at (60, 100) in the canvas, so (75, 150) on screen. Dragging 40 screen pixels moves 20 canvas pixels.
+	clay.SetPointerState(clay.Vector2{X: 100, Y: 200}, false)
+	clay.UpdateScrollContainers(true, clay.Vector2{}, 1.0/60)
+	layoutCanvas()
+	for _, y := range []float32{200, 240} {
+		clay.SetPointerState(clay.Vector2{X: 100, Y: y}, true)
+		clay.UpdateScrollContainers(true, clay.Vector2{}, 1.0/60)
+		layoutCanvas()
+	}
+	if position := *scroll.ScrollPosition; position != (clay.Vector2{Y: -180}) {
+		t.Fatalf("expected the content to follow the pointer, got %v", position)
+	}
+}